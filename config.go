@@ -0,0 +1,293 @@
+package gochat
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/shenghui0779/gochat/corp"
+	"github.com/shenghui0779/gochat/mch"
+	"github.com/shenghui0779/gochat/mchv3"
+	"github.com/shenghui0779/gochat/minip"
+	"github.com/shenghui0779/gochat/offia"
+	"github.com/shenghui0779/gochat/oplatform"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// EnvPrefix 环境变量前缀，如：GOCHAT_OFFIA_APPID、GOCHAT_MCHV3_PRIVATE_KEY_FILE
+const EnvPrefix = "GOCHAT_"
+
+// ErrConfigMissing 未配置对应产品
+var ErrConfigMissing = errors.New("config is missing")
+
+// AppConfig 公众号/小程序/开放平台配置
+type AppConfig struct {
+	AppID     string `json:"appid" yaml:"appid" env:"APPID"`
+	AppSecret string `json:"appsecret" yaml:"appsecret" env:"APPSECRET"`
+	Token     string `json:"token" yaml:"token" env:"TOKEN"`          // 服务器配置 - 令牌
+	AESKey    string `json:"aeskey" yaml:"aeskey" env:"AESKEY"`       // 服务器配置 - 消息加解密密钥
+	OriginID  string `json:"originid" yaml:"originid" env:"ORIGINID"` // 原始ID（仅公众号）
+}
+
+// CorpConfig 企业微信配置
+type CorpConfig struct {
+	CorpID string            `json:"corpid" yaml:"corpid" env:"CORPID"`
+	Token  string            `json:"token" yaml:"token" env:"TOKEN"`    // 回调配置 - Token
+	AESKey string            `json:"aeskey" yaml:"aeskey" env:"AESKEY"` // 回调配置 - EncodingAESKey
+	Agents map[string]string `json:"agents" yaml:"agents" env:"-"`      // 应用 AgentID => Secret（仅支持文件配置），用于 cp.AgentToken
+}
+
+// Secret 返回应用的 secret
+func (c *CorpConfig) Secret(agentID string) (string, error) {
+	secret, ok := c.Agents[agentID]
+
+	if !ok {
+		return "", fmt.Errorf("%w: corp agent %s", ErrConfigMissing, agentID)
+	}
+
+	return secret, nil
+}
+
+// DefaultTokenKeyPrefix 令牌存储 key 的默认前缀
+const DefaultTokenKeyPrefix = "gochat:"
+
+// TokenConfig 令牌存储配置，用于企业微信应用 access_token 与第三方平台 component_access_token、authorizer_access_token；
+// Store 需在代码中设置（如：基于 Redis 的 wx.TokenStore 实现），未设置时令牌仅缓存在内存
+type TokenConfig struct {
+	KeyPrefix string        `json:"key_prefix" yaml:"key_prefix" env:"KEY_PREFIX"` // 存储 key 前缀（默认：gochat:）
+	Store     wx.TokenStore `json:"-" yaml:"-" env:"-"`
+}
+
+func (c *TokenConfig) key(parts ...string) string {
+	prefix := c.KeyPrefix
+
+	if len(prefix) == 0 {
+		prefix = DefaultTokenKeyPrefix
+	}
+
+	return prefix + strings.Join(parts, ":")
+}
+
+// MchConfig 微信商户（支付v2）配置
+type MchConfig struct {
+	MchID    string `json:"mchid" yaml:"mchid" env:"MCHID"`
+	APIKey   string `json:"apikey" yaml:"apikey" env:"APIKEY"`
+	CertFile string `json:"cert_file" yaml:"cert_file" env:"CERT_FILE"` // API证书（p12）路径，退款等接口需要
+}
+
+// MchV3Config 微信商户（支付v3）配置，私钥及平台公钥均为 PKCS#8 PEM 文件
+type MchV3Config struct {
+	MchID              string `json:"mchid" yaml:"mchid" env:"MCHID"`
+	APIKey             string `json:"apikey" yaml:"apikey" env:"APIKEY"`
+	SerialNO           string `json:"serial_no" yaml:"serial_no" env:"SERIAL_NO"`                                  // 商户API证书序列号
+	PrivateKeyFile     string `json:"private_key_file" yaml:"private_key_file" env:"PRIVATE_KEY_FILE"`             // 商户API私钥路径
+	PlatformSerialNO   string `json:"platform_serial_no" yaml:"platform_serial_no" env:"PLATFORM_SERIAL_NO"`       // 平台证书序列号（或公钥ID）
+	PlatformPubKeyFile string `json:"platform_pubkey_file" yaml:"platform_pubkey_file" env:"PLATFORM_PUBKEY_FILE"` // 平台公钥路径
+}
+
+// Config 统一配置，可由结构体、JSON/YAML 文件及环境变量（优先级最高）加载
+type Config struct {
+	Offia     *AppConfig   `json:"offia,omitempty" yaml:"offia,omitempty" env:"OFFIA"`
+	Minip     *AppConfig   `json:"minip,omitempty" yaml:"minip,omitempty" env:"MINIP"`
+	Oplatform *AppConfig   `json:"oplatform,omitempty" yaml:"oplatform,omitempty" env:"OPLATFORM"`
+	Corp      *CorpConfig  `json:"corp,omitempty" yaml:"corp,omitempty" env:"CORP"`
+	Mch       *MchConfig   `json:"mch,omitempty" yaml:"mch,omitempty" env:"MCH"`
+	MchV3     *MchV3Config `json:"mchv3,omitempty" yaml:"mchv3,omitempty" env:"MCHV3"`
+	Token     *TokenConfig `json:"token,omitempty" yaml:"token,omitempty" env:"TOKEN"`
+}
+
+// LoadConfig 加载配置文件（根据扩展名解析：.json、.yaml、.yml），并使用环境变量覆盖
+func LoadConfig(filename string) (*Config, error) {
+	b, err := os.ReadFile(filename)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := new(Config)
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".json":
+		err = json.Unmarshal(b, cfg)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(b, cfg)
+	default:
+		return nil, fmt.Errorf("unsupported config file: %s", filename)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.LoadEnv()
+
+	return cfg, nil
+}
+
+// LoadEnv 使用环境变量覆盖配置项（变量名：EnvPrefix + 产品 + "_" + 配置项，如：GOCHAT_OFFIA_APPID）；
+// 产品未配置但存在对应环境变量时，自动创建该产品配置；env 标签为 "-" 的配置项不支持环境变量
+func (c *Config) LoadEnv() {
+	v := reflect.ValueOf(c).Elem()
+
+	for i := 0; i < v.NumField(); i++ {
+		section := v.Field(i)
+		prefix := EnvPrefix + v.Type().Field(i).Tag.Get("env") + "_"
+
+		elem := reflect.New(section.Type().Elem())
+
+		if !section.IsNil() {
+			elem = section
+		}
+
+		found := false
+
+		for j := 0; j < elem.Elem().NumField(); j++ {
+			name := elem.Elem().Type().Field(j).Tag.Get("env")
+
+			if name == "-" {
+				continue
+			}
+
+			if s, ok := os.LookupEnv(prefix + name); ok {
+				elem.Elem().Field(j).SetString(s)
+				found = true
+			}
+		}
+
+		if found && section.IsNil() {
+			section.Set(elem)
+		}
+	}
+}
+
+// NewOffia 根据配置创建公众号实例（options 在配置项之后生效）
+func (c *Config) NewOffia(options ...offia.Option) (*offia.Offia, error) {
+	if c.Offia == nil {
+		return nil, fmt.Errorf("%w: offia", ErrConfigMissing)
+	}
+
+	opts := []offia.Option{offia.WithServerConfig(c.Offia.Token, c.Offia.AESKey)}
+
+	if len(c.Offia.OriginID) != 0 {
+		opts = append(opts, offia.WithOriginID(c.Offia.OriginID))
+	}
+
+	return offia.New(c.Offia.AppID, c.Offia.AppSecret, append(opts, options...)...), nil
+}
+
+// NewMinip 根据配置创建小程序实例（options 在配置项之后生效）
+func (c *Config) NewMinip(options ...minip.Option) (*minip.Minip, error) {
+	if c.Minip == nil {
+		return nil, fmt.Errorf("%w: minip", ErrConfigMissing)
+	}
+
+	opts := []minip.Option{minip.WithServerConfig(c.Minip.Token, c.Minip.AESKey)}
+
+	return minip.New(c.Minip.AppID, c.Minip.AppSecret, append(opts, options...)...), nil
+}
+
+// NewOplatform 根据配置创建开放平台（第三方平台）实例（options 在配置项之后生效）
+func (c *Config) NewOplatform(options ...oplatform.Option) (*oplatform.Oplatform, error) {
+	if c.Oplatform == nil {
+		return nil, fmt.Errorf("%w: oplatform", ErrConfigMissing)
+	}
+
+	opts := []oplatform.Option{oplatform.WithServerConfig(c.Oplatform.Token, c.Oplatform.AESKey)}
+
+	if c.Token != nil && c.Token.Store != nil {
+		appid := c.Oplatform.AppID
+
+		opts = append(opts,
+			oplatform.WithComponentTokenOptions(wx.WithTokenStore(c.Token.Store, c.Token.key("oplatform", appid, "component_access_token"))),
+			oplatform.WithAuthorizerTokenOptions(func(authorizerAppID string) []wx.TokenOption {
+				return []wx.TokenOption{wx.WithTokenStore(c.Token.Store, c.Token.key("oplatform", appid, "authorizer_access_token", authorizerAppID))}
+			}),
+		)
+	}
+
+	return oplatform.New(c.Oplatform.AppID, c.Oplatform.AppSecret, append(opts, options...)...), nil
+}
+
+// NewCorp 根据配置创建企业微信实例，应用 secret 通过 c.Corp.Secret(agentID) 获取（options 在配置项之后生效）
+func (c *Config) NewCorp(options ...corp.Option) (*corp.Corp, error) {
+	if c.Corp == nil {
+		return nil, fmt.Errorf("%w: corp", ErrConfigMissing)
+	}
+
+	opts := []corp.Option{corp.WithServerConfig(c.Corp.Token, c.Corp.AESKey)}
+
+	if c.Token != nil && c.Token.Store != nil {
+		// 按 AgentID 区分存储 key，避免 secret 出现在存储中；未配置的 secret 使用其摘要
+		agents := make(map[string]string, len(c.Corp.Agents))
+
+		for agentID, secret := range c.Corp.Agents {
+			agents[secret] = agentID
+		}
+
+		opts = append(opts, corp.WithTokenOptions(func(secret string) []wx.TokenOption {
+			agentID, ok := agents[secret]
+
+			if !ok {
+				agentID = wx.MD5(secret)
+			}
+
+			return []wx.TokenOption{wx.WithTokenStore(c.Token.Store, c.Token.key("corp", c.Corp.CorpID, agentID))}
+		}))
+	}
+
+	return corp.New(c.Corp.CorpID, append(opts, options...)...), nil
+}
+
+// NewMch 根据配置创建微信商户（支付v2）实例，配置了证书路径时加载TLS证书（options 在配置项之后生效）
+func (c *Config) NewMch(options ...mch.Option) (*mch.Mch, error) {
+	if c.Mch == nil {
+		return nil, fmt.Errorf("%w: mch", ErrConfigMissing)
+	}
+
+	opts := make([]mch.Option, 0, len(options)+1)
+
+	if len(c.Mch.CertFile) != 0 {
+		cert, err := wx.LoadCertFromPfxFile(c.Mch.CertFile, c.Mch.MchID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, mch.WithTLSCert(cert))
+	}
+
+	return mch.New(c.Mch.MchID, c.Mch.APIKey, append(opts, options...)...), nil
+}
+
+// NewMchV3 根据配置创建微信商户（支付v3）实例，配置了平台公钥路径时加载平台公钥（options 在配置项之后生效）
+func (c *Config) NewMchV3(options ...mchv3.Option) (*mchv3.Mch, error) {
+	if c.MchV3 == nil {
+		return nil, fmt.Errorf("%w: mchv3", ErrConfigMissing)
+	}
+
+	prvkey, err := wx.NewPrivateKeyFromPemFile(wx.RSA_PKCS8, c.MchV3.PrivateKeyFile)
+
+	if err != nil {
+		return nil, err
+	}
+
+	opts := make([]mchv3.Option, 0, len(options)+1)
+
+	if len(c.MchV3.PlatformPubKeyFile) != 0 {
+		pubkey, err := wx.NewPublicKeyFromPemFile(wx.RSA_PKCS8, c.MchV3.PlatformPubKeyFile)
+
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, mchv3.WithPlatformCert(c.MchV3.PlatformSerialNO, pubkey))
+	}
+
+	return mchv3.New(c.MchV3.MchID, c.MchV3.APIKey, c.MchV3.SerialNO, prvkey, append(opts, options...)...), nil
+}
@@ -0,0 +1,141 @@
+package gochat
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/corp"
+	"github.com/shenghui0779/gochat/mock"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+func TestLoadConfigYAML(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "gochat.yaml")
+
+	assert.Nil(t, os.WriteFile(filename, []byte(`
+offia:
+  appid: OFFIA_APPID
+  appsecret: OFFIA_APPSECRET
+  token: TOKEN
+mch:
+  mchid: "10000100"
+  apikey: APIKEY
+  cert_file: mock/p12test.p12
+`), 0o644))
+
+	t.Setenv("GOCHAT_OFFIA_APPSECRET", "ENV_APPSECRET")
+	t.Setenv("GOCHAT_CORP_CORPID", "CORPID")
+
+	cfg, err := LoadConfig(filename)
+
+	assert.Nil(t, err)
+	assert.Equal(t, &AppConfig{
+		AppID:     "OFFIA_APPID",
+		AppSecret: "ENV_APPSECRET",
+		Token:     "TOKEN",
+	}, cfg.Offia)
+	assert.Equal(t, &CorpConfig{CorpID: "CORPID"}, cfg.Corp)
+	assert.Nil(t, cfg.Minip)
+
+	oa, err := cfg.NewOffia()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "OFFIA_APPID", oa.AppID())
+	assert.Equal(t, "ENV_APPSECRET", oa.AppSecret())
+
+	mch, err := cfg.NewMch()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "10000100", mch.MchID())
+
+	_, err = cfg.NewMinip()
+
+	assert.True(t, errors.Is(err, ErrConfigMissing))
+}
+
+func TestLoadConfigJSON(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "gochat.json")
+
+	assert.Nil(t, os.WriteFile(filename, []byte(`{"minip":{"appid":"MINIP_APPID","appsecret":"MINIP_APPSECRET"}}`), 0o644))
+
+	cfg, err := LoadConfig(filename)
+
+	assert.Nil(t, err)
+	assert.Equal(t, &AppConfig{
+		AppID:     "MINIP_APPID",
+		AppSecret: "MINIP_APPSECRET",
+	}, cfg.Minip)
+
+	mp, err := cfg.NewMinip()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "MINIP_APPID", mp.AppID())
+}
+
+func TestLoadConfigUnsupported(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "gochat.toml")
+
+	assert.Nil(t, os.WriteFile(filename, []byte(``), 0o644))
+
+	_, err := LoadConfig(filename)
+
+	assert.NotNil(t, err)
+}
+
+func TestConfigCorpTokenStore(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "gochat.yaml")
+
+	assert.Nil(t, os.WriteFile(filename, []byte(`
+corp:
+  corpid: CORPID
+  agents:
+    "1000002": SECRET
+`), 0o644))
+
+	t.Setenv("GOCHAT_TOKEN_KEY_PREFIX", "app:")
+
+	cfg, err := LoadConfig(filename)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "app:", cfg.Token.KeyPrefix)
+
+	store := wx.NewMemTokenStore()
+	cfg.Token.Store = store
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://qyapi.weixin.qq.com/cgi-bin/gettoken?corpid=CORPID&corpsecret=SECRET", nil).Return([]byte(`{"errcode":0,"errmsg":"ok","access_token":"ACCESS_TOKEN","expires_in":7200}`), nil)
+
+	cp, err := cfg.NewCorp(corp.WithMockClient(client))
+
+	assert.Nil(t, err)
+
+	secret, err := cfg.Corp.Secret("1000002")
+
+	assert.Nil(t, err)
+
+	accessToken, err := cp.AgentToken(context.TODO(), secret)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "ACCESS_TOKEN", accessToken)
+
+	// 按 AgentID 保存，不含 secret
+	snapshot, err := store.Get(context.TODO(), "app:corp:CORPID:1000002")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "ACCESS_TOKEN", snapshot.Token)
+
+	_, err = cfg.Corp.Secret("1000003")
+
+	assert.True(t, errors.Is(err, ErrConfigMissing))
+}
@@ -0,0 +1,278 @@
+package corp
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/shenghui0779/gochat/event"
+)
+
+// Message 回调消息
+type Message interface {
+	Header() *MessageHeader
+}
+
+// MessageHeader 回调消息公共字段
+type MessageHeader struct {
+	XMLName      xml.Name      `xml:"xml"`
+	ToUserName   string        `xml:"ToUserName"`   // 企业微信CorpID
+	FromUserName string        `xml:"FromUserName"` // 成员UserID
+	CreateTime   int64         `xml:"CreateTime"`   // 消息创建时间
+	MsgType      event.MsgType `xml:"MsgType"`      // 消息类型
+	AgentID      int64         `xml:"AgentID"`      // 企业应用的id
+}
+
+// Header returns the message header
+func (h *MessageHeader) Header() *MessageHeader {
+	return h
+}
+
+// TextMessage 文本消息
+type TextMessage struct {
+	MessageHeader
+	MsgID   int64  `xml:"MsgId"`
+	Content string `xml:"Content"`
+}
+
+// ImageMessage 图片消息
+type ImageMessage struct {
+	MessageHeader
+	MsgID   int64  `xml:"MsgId"`
+	PicURL  string `xml:"PicUrl"`
+	MediaID string `xml:"MediaId"`
+}
+
+// VoiceMessage 语音消息
+type VoiceMessage struct {
+	MessageHeader
+	MsgID   int64  `xml:"MsgId"`
+	MediaID string `xml:"MediaId"`
+	Format  string `xml:"Format"`
+}
+
+// VideoMessage 视频消息
+type VideoMessage struct {
+	MessageHeader
+	MsgID        int64  `xml:"MsgId"`
+	MediaID      string `xml:"MediaId"`
+	ThumbMediaID string `xml:"ThumbMediaId"`
+}
+
+// LocationMessage 位置消息
+type LocationMessage struct {
+	MessageHeader
+	MsgID     int64   `xml:"MsgId"`
+	LocationX float64 `xml:"Location_X"`
+	LocationY float64 `xml:"Location_Y"`
+	Scale     int     `xml:"Scale"`
+	Label     string  `xml:"Label"`
+	AppType   string  `xml:"AppType"`
+}
+
+// LinkMessage 链接消息
+type LinkMessage struct {
+	MessageHeader
+	MsgID       int64  `xml:"MsgId"`
+	Title       string `xml:"Title"`
+	Description string `xml:"Description"`
+	URL         string `xml:"Url"`
+	PicURL      string `xml:"PicUrl"`
+}
+
+// EventMessage 事件消息（成员关注、进入应用、菜单、通讯录变更等）
+type EventMessage struct {
+	MessageHeader
+	Event      string  `xml:"Event"`      // 事件类型（原始大小写）
+	EventKey   string  `xml:"EventKey"`   // 事件KEY值
+	ChangeType string  `xml:"ChangeType"` // 通讯录变更类型：create_user、update_user、delete_user、create_party 等
+	UserID     string  `xml:"UserID"`     // 通讯录变更的成员UserID
+	TaskID     string  `xml:"TaskId"`     // 异步任务/模板卡片的任务id
+	Latitude   float64 `xml:"Latitude"`   // 地理位置纬度
+	Longitude  float64 `xml:"Longitude"`  // 地理位置经度
+	Precision  float64 `xml:"Precision"`  // 地理位置精度
+}
+
+// EventType returns the lower-cased event type
+func (e *EventMessage) EventType() event.EventType {
+	return event.EventType(strings.ToLower(e.Event))
+}
+
+// UnknownMessage 未定义类型的消息，保留原始报文
+type UnknownMessage struct {
+	MessageHeader
+	Raw []byte `xml:"-"`
+}
+
+// ParseMessage 将解密后的回调消息解析为具体类型，返回值为以下类型之一：
+// *TextMessage、*ImageMessage、*VoiceMessage、*VideoMessage、*LocationMessage、*LinkMessage、*EventMessage、*UnknownMessage
+func ParseMessage(b []byte) (Message, error) {
+	header := new(MessageHeader)
+
+	if err := xml.Unmarshal(b, header); err != nil {
+		return nil, err
+	}
+
+	var msg Message
+
+	switch header.MsgType {
+	case event.MsgText:
+		msg = new(TextMessage)
+	case event.MsgImage:
+		msg = new(ImageMessage)
+	case event.MsgVoice:
+		msg = new(VoiceMessage)
+	case event.MsgVideo:
+		msg = new(VideoMessage)
+	case event.MsgLocation:
+		msg = new(LocationMessage)
+	case event.MsgLink:
+		msg = new(LinkMessage)
+	case event.MsgEvent:
+		msg = new(EventMessage)
+	default:
+		return &UnknownMessage{
+			MessageHeader: *header,
+			Raw:           b,
+		}, nil
+	}
+
+	if err := xml.Unmarshal(b, msg); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// VerifyURL 验证回调URL（GET请求），验证成功返回解密后的 echostr 明文
+// [参考](https://developer.work.weixin.qq.com/document/path/90930)
+func (corp *Corp) VerifyURL(signature, timestamp, nonce, echostr string) (string, error) {
+	if !corp.VerifyEventSign(signature, timestamp, nonce, echostr) {
+		return "", errors.New("callback signature verified fail")
+	}
+
+	b, err := event.Decrypt(corp.corpid, corp.aeskey, echostr)
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// DecryptMessage 验证签名并解密回调消息，解析为具体类型（参考 ParseMessage）
+func (corp *Corp) DecryptMessage(signature, timestamp, nonce string, body []byte) (Message, error) {
+	msg := new(event.EventMessage)
+
+	if err := xml.Unmarshal(body, msg); err != nil {
+		return nil, err
+	}
+
+	if !corp.VerifyEventSign(signature, timestamp, nonce, msg.Encrypt) {
+		return nil, errors.New("callback signature verified fail")
+	}
+
+	b, err := event.Decrypt(corp.corpid, corp.aeskey, msg.Encrypt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseMessage(b)
+}
+
+// Reply 被动回复消息（加密）
+// [参考](https://developer.work.weixin.qq.com/document/path/90241)
+func (corp *Corp) Reply(userID string, reply event.Reply) (*event.ReplyMessage, error) {
+	body, err := reply.Bytes(corp.corpid, userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// 消息加密
+	cipherText, err := event.Encrypt(corp.corpid, corp.aeskey, corp.nonce(), body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return event.BuildReply(corp.token, corp.nonce(), base64.StdEncoding.EncodeToString(cipherText)), nil
+}
+
+// CallbackHandler 处理回调消息，返回的 reply 不为 nil 时将被动回复给成员
+type CallbackHandler func(ctx context.Context, msg Message) (event.Reply, error)
+
+// CallbackServer returns an http.Handler for the 接收消息服务器URL
+// GET 请求用于验证URL有效性，POST 请求用于接收消息与事件
+func (corp *Corp) CallbackServer(handler CallbackHandler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		if r.Method == http.MethodGet {
+			echo, err := corp.VerifyURL(query.Get("msg_signature"), query.Get("timestamp"), query.Get("nonce"), query.Get("echostr"))
+
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+
+				return
+			}
+
+			w.Write([]byte(echo))
+
+			return
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		msg, err := corp.DecryptMessage(query.Get("msg_signature"), query.Get("timestamp"), query.Get("nonce"), body)
+
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		reply, err := handler(r.Context(), msg)
+
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		// 无需回复时返回空串
+		if reply == nil {
+			return
+		}
+
+		replyMsg, err := corp.Reply(msg.Header().FromUserName, reply)
+
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		b, err := xml.Marshal(replyMsg)
+
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+		w.Write(b)
+	})
+}
@@ -0,0 +1,116 @@
+package corp
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/corp/message"
+	"github.com/shenghui0779/gochat/event"
+)
+
+const (
+	testCallbackToken  = "2faf43d6343a802b6073aae5b3f2f109"
+	testCallbackAESKey = "jxAko083VoJ3lcPXJWzcGJ0M1tFVLgdD6qAq57GJY1U"
+)
+
+func mockEncrypt(t *testing.T, plain string) (string, string) {
+	cipher, err := event.Encrypt("CORPID", testCallbackAESKey, "1234567890123456", []byte(plain))
+
+	assert.Nil(t, err)
+
+	encrypt := base64.StdEncoding.EncodeToString(cipher)
+
+	return encrypt, event.SignWithSHA1(testCallbackToken, "1606902086", "1246833592", encrypt)
+}
+
+func TestVerifyURL(t *testing.T) {
+	cp := New("CORPID", WithServerConfig(testCallbackToken, testCallbackAESKey))
+
+	echostr, signature := mockEncrypt(t, "1616140317555161061")
+
+	echo, err := cp.VerifyURL(signature, "1606902086", "1246833592", echostr)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "1616140317555161061", echo)
+
+	_, err = cp.VerifyURL("SIGNATURE", "1606902086", "1246833592", echostr)
+
+	assert.NotNil(t, err)
+}
+
+func TestParseMessage(t *testing.T) {
+	msg, err := ParseMessage([]byte(`<xml><ToUserName><![CDATA[CORPID]]></ToUserName><FromUserName><![CDATA[USERID]]></FromUserName><CreateTime>1348831860</CreateTime><MsgType><![CDATA[text]]></MsgType><Content><![CDATA[this is a test]]></Content><MsgId>1234567890123456</MsgId><AgentID>1</AgentID></xml>`))
+
+	assert.Nil(t, err)
+
+	text, ok := msg.(*TextMessage)
+
+	assert.True(t, ok)
+	assert.Equal(t, "USERID", text.FromUserName)
+	assert.Equal(t, int64(1), text.AgentID)
+	assert.Equal(t, "this is a test", text.Content)
+
+	msg, err = ParseMessage([]byte(`<xml><ToUserName><![CDATA[CORPID]]></ToUserName><FromUserName><![CDATA[sys]]></FromUserName><CreateTime>1403610513</CreateTime><MsgType><![CDATA[event]]></MsgType><Event><![CDATA[change_contact]]></Event><ChangeType>create_user</ChangeType><UserID><![CDATA[zhangsan]]></UserID></xml>`))
+
+	assert.Nil(t, err)
+
+	e, ok := msg.(*EventMessage)
+
+	assert.True(t, ok)
+	assert.Equal(t, event.EventType("change_contact"), e.EventType())
+	assert.Equal(t, "create_user", e.ChangeType)
+	assert.Equal(t, "zhangsan", e.UserID)
+}
+
+func TestCallbackServer(t *testing.T) {
+	cp := New("CORPID", WithServerConfig(testCallbackToken, testCallbackAESKey))
+
+	srv := cp.CallbackServer(func(ctx context.Context, msg Message) (event.Reply, error) {
+		if text, ok := msg.(*TextMessage); ok {
+			return message.ReplyText("echo: " + text.Content), nil
+		}
+
+		return nil, nil
+	})
+
+	// 验证URL
+	echostr, signature := mockEncrypt(t, "ECHO")
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/callback?msg_signature=%s&timestamp=1606902086&nonce=1246833592&echostr=%s", signature, url.QueryEscape(echostr)), nil)
+
+	srv.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "ECHO", w.Body.String())
+
+	// 接收消息并被动回复
+	encrypt, signature := mockEncrypt(t, `<xml><ToUserName><![CDATA[CORPID]]></ToUserName><FromUserName><![CDATA[USERID]]></FromUserName><CreateTime>1348831860</CreateTime><MsgType><![CDATA[text]]></MsgType><Content><![CDATA[hello]]></Content><MsgId>1234567890123456</MsgId><AgentID>1</AgentID></xml>`)
+
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodPost, fmt.Sprintf("/callback?msg_signature=%s&timestamp=1606902086&nonce=1246833592", signature), strings.NewReader(fmt.Sprintf("<xml><ToUserName><![CDATA[CORPID]]></ToUserName><AgentID><![CDATA[1]]></AgentID><Encrypt><![CDATA[%s]]></Encrypt></xml>", encrypt)))
+
+	srv.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	replyMsg := new(event.ReplyMessage)
+
+	assert.Nil(t, xml.Unmarshal(w.Body.Bytes(), replyMsg))
+
+	m, err := cp.DecryptEventMessage(string(replyMsg.Encrypt))
+
+	assert.Nil(t, err)
+	assert.Equal(t, "CORPID", m["FromUserName"])
+	assert.Equal(t, "USERID", m["ToUserName"])
+	assert.Equal(t, "echo: hello", m["Content"])
+}
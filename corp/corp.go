@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sync"
 
 	"github.com/tidwall/gjson"
 
@@ -14,11 +15,18 @@ import (
 )
 
 type Corp struct {
-	corpid string
-	token  string
-	aeskey string
-	nonce  func() string
-	client wx.HTTPClient
+	corpid    string
+	token     string
+	aeskey    string
+	nonce     func() string
+	client    wx.HTTPClient
+	baseURL   string
+	dials     []wx.DialOption
+	headers   map[string]string
+	tokenmgrs map[string]*wx.TokenManager
+	tokenopts func(secret string) []wx.TokenOption
+	mutex     sync.Mutex
+	lenient   bool
 }
 
 func (corp *Corp) CorpID() string {
@@ -38,7 +46,9 @@ func (corp *Corp) QRCodeAuthURL(agentID, redirectURL, state string) string {
 }
 
 func (corp *Corp) AccessToken(ctx context.Context, secret string, options ...wx.HTTPOption) (*AccessToken, error) {
-	resp, err := corp.client.Do(ctx, http.MethodGet, fmt.Sprintf("%s?corpid=%s&corpsecret=%s", urls.CorpCgiBinAccessToken, corp.corpid, secret), nil, options...)
+	options = wx.PrependHTTPHeaders(options, corp.headers)
+
+	resp, err := corp.client.Do(ctx, http.MethodGet, corp.endpoint(fmt.Sprintf("%s?corpid=%s&corpsecret=%s", urls.CorpCgiBinAccessToken, corp.corpid, secret)), nil, options...)
 
 	if err != nil {
 		return nil, err
@@ -47,7 +57,7 @@ func (corp *Corp) AccessToken(ctx context.Context, secret string, options ...wx.
 	r := gjson.ParseBytes(resp)
 
 	if code := r.Get("errcode").Int(); code != 0 {
-		return nil, fmt.Errorf("%d|%s", code, r.Get("errmsg").String())
+		return nil, wx.NewAPIError(code, r.Get("errmsg").String())
 	}
 
 	token := new(AccessToken)
@@ -59,13 +69,58 @@ func (corp *Corp) AccessToken(ctx context.Context, secret string, options ...wx.
 	return token, nil
 }
 
+// AgentToken 获取应用的 access_token（按 secret 缓存，过期前自动刷新）
+// 每个应用有独立的 secret，获取到的 access_token 只能本应用使用
+func (corp *Corp) AgentToken(ctx context.Context, secret string) (string, error) {
+	return corp.TokenManager(secret).Token(ctx)
+}
+
+// TokenManager returns the access_token manager of the agent（配置项通过 WithTokenOptions 设置）
+func (corp *Corp) TokenManager(secret string) *wx.TokenManager {
+	corp.mutex.Lock()
+	defer corp.mutex.Unlock()
+
+	if mgr, ok := corp.tokenmgrs[secret]; ok {
+		return mgr
+	}
+
+	var options []wx.TokenOption
+
+	if corp.tokenopts != nil {
+		options = corp.tokenopts(secret)
+	}
+
+	mgr := wx.NewTokenManager(func(ctx context.Context) (string, int64, error) {
+		token, err := corp.AccessToken(ctx, secret)
+
+		if err != nil {
+			return "", 0, err
+		}
+
+		return token.Token, token.ExpiresIn, nil
+	}, options...)
+
+	corp.tokenmgrs[secret] = mgr
+
+	return mgr
+}
+
+// endpoint returns the request url with base url applied
+func (corp *Corp) endpoint(reqURL string) string {
+	return wx.JoinURL(corp.baseURL, reqURL)
+}
+
 // Do exec action
 func (corp *Corp) Do(ctx context.Context, accessToken string, action wx.Action, options ...wx.HTTPOption) error {
+	options = wx.PrependHTTPHeaders(options, corp.headers, wx.ActionHeaders(action))
+
 	var (
 		resp []byte
 		err  error
 	)
 
+	reqURL := corp.endpoint(action.URL(accessToken))
+
 	if action.IsUpload() {
 		form, ferr := action.UploadForm()
 
@@ -73,7 +128,11 @@ func (corp *Corp) Do(ctx context.Context, accessToken string, action wx.Action,
 			return ferr
 		}
 
-		resp, err = corp.client.Upload(ctx, action.URL(accessToken), form, options...)
+		if reqURL, options, err = wx.SignAction(action, reqURL, nil, options); err != nil {
+			return err
+		}
+
+		resp, err = corp.client.Upload(ctx, reqURL, form, options...)
 	} else {
 		body, berr := action.Body()
 
@@ -81,7 +140,11 @@ func (corp *Corp) Do(ctx context.Context, accessToken string, action wx.Action,
 			return berr
 		}
 
-		resp, err = corp.client.Do(ctx, action.Method(), action.URL(accessToken), body, options...)
+		if reqURL, options, err = wx.SignAction(action, reqURL, body, options); err != nil {
+			return err
+		}
+
+		resp, err = corp.client.Do(ctx, action.Method(), reqURL, body, options...)
 
 		if err != nil {
 			return err
@@ -95,7 +158,11 @@ func (corp *Corp) Do(ctx context.Context, accessToken string, action wx.Action,
 	r := gjson.ParseBytes(resp)
 
 	if code := r.Get("errcode").Int(); code != 0 {
-		return fmt.Errorf("%d|%s", code, r.Get("errmsg").String())
+		return wx.NewAPIError(code, r.Get("errmsg").String())
+	}
+
+	if corp.lenient {
+		return wx.DecodeLenient(resp, action.Decode)
 	}
 
 	return action.Decode(resp)
@@ -113,7 +180,7 @@ func (corp *Corp) VerifyEventSign(signature string, items ...string) bool {
 
 // DecryptEventMessage 事件消息解密
 func (corp *Corp) DecryptEventMessage(encrypt string) (wx.WXML, error) {
-	b, err := event.Decrypt(corp.corpid, corp.aeskey, encrypt)
+	b, err := corp.DecryptEvent(encrypt)
 
 	if err != nil {
 		return nil, err
@@ -122,6 +189,11 @@ func (corp *Corp) DecryptEventMessage(encrypt string) (wx.WXML, error) {
 	return wx.ParseXML2Map(b)
 }
 
+// DecryptEvent 事件消息解密，返回解密后的原始报文（XML），可用于消息存档或重新解析
+func (corp *Corp) DecryptEvent(encrypt string) ([]byte, error) {
+	return event.Decrypt(corp.corpid, corp.aeskey, encrypt)
+}
+
 // Option 企微配置项
 type Option func(corp *Corp)
 
@@ -148,6 +220,43 @@ func WithClient(c *http.Client) Option {
 	}
 }
 
+// WithHeader 设置请求 header（如：User-Agent、链路追踪 header），作用于所有请求
+func WithHeader(key, value string) Option {
+	return func(corp *Corp) {
+		corp.headers[key] = value
+	}
+}
+
+// WithDialOptions 设置 HTTP Client 的连接配置（如：强制 IPv4、自定义 DNS 解析），
+// 在所有配置项之后作用于默认或 WithClient 设置的 Client，与配置顺序无关；Client 的 Transport 不是 *http.Transport 时不生效
+func WithDialOptions(options ...wx.DialOption) Option {
+	return func(corp *Corp) {
+		corp.dials = options
+	}
+}
+
+// WithLenientDecode 宽松解析返回结果，兼容数字与数字字符串等类型不一致的字段
+func WithLenientDecode() Option {
+	return func(corp *Corp) {
+		corp.lenient = true
+	}
+}
+
+// WithTokenOptions 设置应用 access_token 管理的配置项，按应用 secret 返回
+// （如：wx.WithTokenStore 需为每个应用指定不同的 key）
+func WithTokenOptions(f func(secret string) []wx.TokenOption) Option {
+	return func(corp *Corp) {
+		corp.tokenopts = f
+	}
+}
+
+// WithBaseURL 设置接口域名（默认：https://qyapi.weixin.qq.com），可用于代理或私有化部署
+func WithBaseURL(base string) Option {
+	return func(corp *Corp) {
+		corp.baseURL = base
+	}
+}
+
 // WithMockClient 设置 Mock Client
 func WithMockClient(c wx.HTTPClient) Option {
 	return func(corp *Corp) {
@@ -161,12 +270,17 @@ func New(corpid string, options ...Option) *Corp {
 		nonce: func() string {
 			return wx.Nonce(16)
 		},
-		client: wx.NewDefaultClient(),
+		client:    wx.NewDefaultClient(),
+		baseURL:   wx.BaseURLQYAPI,
+		headers:   make(map[string]string),
+		tokenmgrs: make(map[string]*wx.TokenManager),
 	}
 
 	for _, f := range options {
 		f(corp)
 	}
 
+	corp.client = wx.ApplyDialOptions(corp.client, corp.dials...)
+
 	return corp
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/shenghui0779/gochat/mock"
+	"github.com/shenghui0779/gochat/wx"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -51,3 +52,87 @@ func TestAccessToken(t *testing.T) {
 		ExpiresIn: 7200,
 	}, accessToken)
 }
+
+func TestAccessTokenAPIError(t *testing.T) {
+	resp := []byte(`{
+	"errcode": 40001,
+	"errmsg": "invalid credential"
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://qyapi.weixin.qq.com/cgi-bin/gettoken?corpid=CORPID&corpsecret=SECRET", nil).Return(resp, nil)
+
+	cp := New("CORPID", WithMockClient(client))
+
+	_, err := cp.AccessToken(context.TODO(), "SECRET")
+
+	assert.True(t, wx.IsAPIError(err, 40001))
+}
+
+func TestAgentToken(t *testing.T) {
+	resp := []byte(`{
+	"errcode": 0,
+	"errmsg": "ok",
+	"access_token": "accesstoken000001",
+	"expires_in": 7200
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	// 缓存有效期内仅请求一次
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://qyapi.weixin.qq.com/cgi-bin/gettoken?corpid=CORPID&corpsecret=SECRET", nil).Return(resp, nil).Times(1)
+
+	cp := New("CORPID", WithMockClient(client))
+
+	for i := 0; i < 2; i++ {
+		accessToken, err := cp.AgentToken(context.TODO(), "SECRET")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "accesstoken000001", accessToken)
+	}
+}
+
+func TestTokenManagerWithStore(t *testing.T) {
+	resp := []byte(`{
+	"errcode": 0,
+	"errmsg": "ok",
+	"access_token": "accesstoken000001",
+	"expires_in": 7200
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://qyapi.weixin.qq.com/cgi-bin/gettoken?corpid=CORPID&corpsecret=SECRET", nil).Return(resp, nil)
+
+	store := wx.NewMemTokenStore()
+
+	cp := New("CORPID", WithMockClient(client), WithTokenOptions(func(secret string) []wx.TokenOption {
+		return []wx.TokenOption{wx.WithTokenStore(store, "CORPID:"+secret)}
+	}))
+
+	// AgentToken 与 TokenManager 共用同一管理器及配置项
+	accessToken, err := cp.AgentToken(context.TODO(), "SECRET")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "accesstoken000001", accessToken)
+
+	snapshot, err := store.Get(context.TODO(), "CORPID:SECRET")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "accesstoken000001", snapshot.Token)
+
+	accessToken, err = cp.TokenManager("SECRET").Token(context.TODO())
+
+	assert.Nil(t, err)
+	assert.Equal(t, "accesstoken000001", accessToken)
+}
@@ -0,0 +1,161 @@
+package externalcontact
+
+import (
+	"encoding/json"
+
+	"github.com/shenghui0779/gochat/urls"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+type ParamsAcquisitionLinkList struct {
+	Limit  int    `json:"limit,omitempty"`
+	Cursor string `json:"cursor,omitempty"`
+}
+
+type ResultAcquisitionLinkList struct {
+	LinkIDList []string `json:"link_id_list"`
+	NextCursor string   `json:"next_cursor"`
+}
+
+// ListAcquisitionLink 获取获客链接列表
+func ListAcquisitionLink(params *ParamsAcquisitionLinkList, result *ResultAcquisitionLinkList) wx.Action {
+	return wx.NewPostAction(urls.CorpExternalContactCustomerAcquisitionListLink,
+		wx.WithBody(func() ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+type AcquisitionLink struct {
+	LinkID     string `json:"link_id,omitempty"`
+	LinkName   string `json:"link_name"`
+	URL        string `json:"url,omitempty"`
+	CreateTime int64  `json:"create_time,omitempty"`
+	SkipVerify bool   `json:"skip_verify"`
+}
+
+type AcquisitionRange struct {
+	UserList       []string `json:"user_list,omitempty"`
+	DepartmentList []int64  `json:"department_list,omitempty"`
+}
+
+type ResultAcquisitionLinkGet struct {
+	Link  *AcquisitionLink  `json:"link"`
+	Range *AcquisitionRange `json:"range"`
+}
+
+// GetAcquisitionLink 获取获客链接详情
+func GetAcquisitionLink(linkID string, result *ResultAcquisitionLinkGet) wx.Action {
+	return wx.NewPostAction(urls.CorpExternalContactCustomerAcquisitionGet,
+		wx.WithBody(func() ([]byte, error) {
+			return json.Marshal(map[string]string{
+				"link_id": linkID,
+			})
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+type ParamsAcquisitionLinkCreate struct {
+	LinkName   string            `json:"link_name"`
+	Range      *AcquisitionRange `json:"range"`
+	SkipVerify bool              `json:"skip_verify"`
+}
+
+type ResultAcquisitionLinkCreate struct {
+	Link *AcquisitionLink `json:"link"`
+}
+
+// CreateAcquisitionLink 创建获客链接
+func CreateAcquisitionLink(params *ParamsAcquisitionLinkCreate, result *ResultAcquisitionLinkCreate) wx.Action {
+	return wx.NewPostAction(urls.CorpExternalContactCustomerAcquisitionCreateLink,
+		wx.WithBody(func() ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+type ParamsAcquisitionLinkUpdate struct {
+	LinkID     string            `json:"link_id"`
+	LinkName   string            `json:"link_name,omitempty"`
+	Range      *AcquisitionRange `json:"range,omitempty"`
+	SkipVerify bool              `json:"skip_verify"`
+}
+
+// UpdateAcquisitionLink 编辑获客链接
+func UpdateAcquisitionLink(params *ParamsAcquisitionLinkUpdate) wx.Action {
+	return wx.NewPostAction(urls.CorpExternalContactCustomerAcquisitionUpdateLink,
+		wx.WithBody(func() ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+	)
+}
+
+// DeleteAcquisitionLink 删除获客链接
+func DeleteAcquisitionLink(linkID string) wx.Action {
+	return wx.NewPostAction(urls.CorpExternalContactCustomerAcquisitionDeleteLink,
+		wx.WithBody(func() ([]byte, error) {
+			return json.Marshal(map[string]string{
+				"link_id": linkID,
+			})
+		}),
+	)
+}
+
+type ParamsAcquisitionCustomer struct {
+	LinkID string `json:"link_id"`
+	Limit  int    `json:"limit,omitempty"`
+	Cursor string `json:"cursor,omitempty"`
+}
+
+type AcquisitionCustomer struct {
+	ExternalUserID string `json:"external_userid"`
+	UserID         string `json:"userid"`
+	ChatStatus     int    `json:"chat_status"` // 0-未发消息；1-已发消息；2-已回复消息
+	State          string `json:"state"`
+}
+
+type ResultAcquisitionCustomer struct {
+	CustomerList []*AcquisitionCustomer `json:"customer_list"`
+	NextCursor   string                 `json:"next_cursor"`
+}
+
+// GetAcquisitionCustomer 获取由获客链接添加的客户信息
+func GetAcquisitionCustomer(params *ParamsAcquisitionCustomer, result *ResultAcquisitionCustomer) wx.Action {
+	return wx.NewPostAction(urls.CorpExternalContactCustomerAcquisitionCustomer,
+		wx.WithBody(func() ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+type AcquisitionQuota struct {
+	ExpireDate int64 `json:"expire_date"`
+	Balance    int   `json:"balance"`
+}
+
+type ResultAcquisitionQuota struct {
+	Total     int                 `json:"total"`
+	Balance   int                 `json:"balance"`
+	QuotaList []*AcquisitionQuota `json:"quota_list"`
+}
+
+// GetAcquisitionQuota 查询获客助手剩余使用量
+func GetAcquisitionQuota(result *ResultAcquisitionQuota) wx.Action {
+	return wx.NewGetAction(urls.CorpExternalContactCustomerAcquisitionQuota,
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
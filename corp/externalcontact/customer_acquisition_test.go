@@ -0,0 +1,281 @@
+package externalcontact
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/corp"
+	"github.com/shenghui0779/gochat/mock"
+)
+
+func TestListAcquisitionLink(t *testing.T) {
+	body := []byte(`{"limit":100,"cursor":"CURSOR"}`)
+	resp := []byte(`{
+	"errcode": 0,
+	"errmsg": "ok",
+	"link_id_list": ["LINK_ID_AAA", "LINK_ID_BBB"],
+	"next_cursor": "CURSOR"
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/customer_acquisition/list_link?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	cp := corp.New("CORPID", corp.WithMockClient(client))
+
+	params := &ParamsAcquisitionLinkList{
+		Limit:  100,
+		Cursor: "CURSOR",
+	}
+
+	result := new(ResultAcquisitionLinkList)
+
+	err := cp.Do(context.TODO(), "ACCESS_TOKEN", ListAcquisitionLink(params, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultAcquisitionLinkList{
+		LinkIDList: []string{"LINK_ID_AAA", "LINK_ID_BBB"},
+		NextCursor: "CURSOR",
+	}, result)
+}
+
+func TestGetAcquisitionLink(t *testing.T) {
+	body := []byte(`{"link_id":"LINK_ID"}`)
+	resp := []byte(`{
+	"errcode": 0,
+	"errmsg": "ok",
+	"link": {
+		"link_name": "LINK_NAME",
+		"url": "work.weixin.qq.com/ca/xxxxxx",
+		"create_time": 1672502400,
+		"skip_verify": true
+	},
+	"range": {
+		"user_list": ["zhangsan", "lisi"],
+		"department_list": [2, 3]
+	}
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/customer_acquisition/get?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	cp := corp.New("CORPID", corp.WithMockClient(client))
+
+	result := new(ResultAcquisitionLinkGet)
+
+	err := cp.Do(context.TODO(), "ACCESS_TOKEN", GetAcquisitionLink("LINK_ID", result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultAcquisitionLinkGet{
+		Link: &AcquisitionLink{
+			LinkName:   "LINK_NAME",
+			URL:        "work.weixin.qq.com/ca/xxxxxx",
+			CreateTime: 1672502400,
+			SkipVerify: true,
+		},
+		Range: &AcquisitionRange{
+			UserList:       []string{"zhangsan", "lisi"},
+			DepartmentList: []int64{2, 3},
+		},
+	}, result)
+}
+
+func TestCreateAcquisitionLink(t *testing.T) {
+	body := []byte(`{"link_name":"获客链接1号","range":{"user_list":["zhangsan","lisi"],"department_list":[2,3]},"skip_verify":true}`)
+	resp := []byte(`{
+	"errcode": 0,
+	"errmsg": "ok",
+	"link": {
+		"link_id": "LINK_ID",
+		"link_name": "获客链接1号",
+		"url": "work.weixin.qq.com/ca/xxxxxx",
+		"create_time": 1672502400
+	}
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/customer_acquisition/create_link?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	cp := corp.New("CORPID", corp.WithMockClient(client))
+
+	params := &ParamsAcquisitionLinkCreate{
+		LinkName: "获客链接1号",
+		Range: &AcquisitionRange{
+			UserList:       []string{"zhangsan", "lisi"},
+			DepartmentList: []int64{2, 3},
+		},
+		SkipVerify: true,
+	}
+
+	result := new(ResultAcquisitionLinkCreate)
+
+	err := cp.Do(context.TODO(), "ACCESS_TOKEN", CreateAcquisitionLink(params, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultAcquisitionLinkCreate{
+		Link: &AcquisitionLink{
+			LinkID:     "LINK_ID",
+			LinkName:   "获客链接1号",
+			URL:        "work.weixin.qq.com/ca/xxxxxx",
+			CreateTime: 1672502400,
+		},
+	}, result)
+}
+
+func TestUpdateAcquisitionLink(t *testing.T) {
+	body := []byte(`{"link_id":"LINK_ID","link_name":"获客链接1号","range":{"user_list":["zhangsan","lisi"],"department_list":[2,3]},"skip_verify":true}`)
+	resp := []byte(`{
+	"errcode": 0,
+	"errmsg": "ok"
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/customer_acquisition/update_link?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	cp := corp.New("CORPID", corp.WithMockClient(client))
+
+	params := &ParamsAcquisitionLinkUpdate{
+		LinkID:   "LINK_ID",
+		LinkName: "获客链接1号",
+		Range: &AcquisitionRange{
+			UserList:       []string{"zhangsan", "lisi"},
+			DepartmentList: []int64{2, 3},
+		},
+		SkipVerify: true,
+	}
+
+	err := cp.Do(context.TODO(), "ACCESS_TOKEN", UpdateAcquisitionLink(params))
+
+	assert.Nil(t, err)
+}
+
+func TestDeleteAcquisitionLink(t *testing.T) {
+	body := []byte(`{"link_id":"LINK_ID"}`)
+	resp := []byte(`{
+	"errcode": 0,
+	"errmsg": "ok"
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/customer_acquisition/delete_link?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	cp := corp.New("CORPID", corp.WithMockClient(client))
+
+	err := cp.Do(context.TODO(), "ACCESS_TOKEN", DeleteAcquisitionLink("LINK_ID"))
+
+	assert.Nil(t, err)
+}
+
+func TestGetAcquisitionCustomer(t *testing.T) {
+	body := []byte(`{"link_id":"LINK_ID","limit":1000,"cursor":"CURSOR"}`)
+	resp := []byte(`{
+	"errcode": 0,
+	"errmsg": "ok",
+	"customer_list": [
+		{
+			"external_userid": "woAJ2GCAAAXtWyujaWJHDDGi0mACCCC",
+			"userid": "zhangsan",
+			"chat_status": 0,
+			"state": "CHANNEL_A"
+		}
+	],
+	"next_cursor": "CURSOR"
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/customer_acquisition/customer?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	cp := corp.New("CORPID", corp.WithMockClient(client))
+
+	params := &ParamsAcquisitionCustomer{
+		LinkID: "LINK_ID",
+		Limit:  1000,
+		Cursor: "CURSOR",
+	}
+
+	result := new(ResultAcquisitionCustomer)
+
+	err := cp.Do(context.TODO(), "ACCESS_TOKEN", GetAcquisitionCustomer(params, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultAcquisitionCustomer{
+		CustomerList: []*AcquisitionCustomer{
+			{
+				ExternalUserID: "woAJ2GCAAAXtWyujaWJHDDGi0mACCCC",
+				UserID:         "zhangsan",
+				ChatStatus:     0,
+				State:          "CHANNEL_A",
+			},
+		},
+		NextCursor: "CURSOR",
+	}, result)
+}
+
+func TestGetAcquisitionQuota(t *testing.T) {
+	resp := []byte(`{
+	"errcode": 0,
+	"errmsg": "ok",
+	"total": 1000,
+	"balance": 500,
+	"quota_list": [
+		{
+			"expire_date": 1689749730,
+			"balance": 200
+		},
+		{
+			"expire_date": 1697879730,
+			"balance": 300
+		}
+	]
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/customer_acquisition_quota?access_token=ACCESS_TOKEN", nil).Return(resp, nil)
+
+	cp := corp.New("CORPID", corp.WithMockClient(client))
+
+	result := new(ResultAcquisitionQuota)
+
+	err := cp.Do(context.TODO(), "ACCESS_TOKEN", GetAcquisitionQuota(result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultAcquisitionQuota{
+		Total:   1000,
+		Balance: 500,
+		QuotaList: []*AcquisitionQuota{
+			{ExpireDate: 1689749730, Balance: 200},
+			{ExpireDate: 1697879730, Balance: 300},
+		},
+	}, result)
+}
@@ -14,13 +14,30 @@ type ResultSign struct {
 }
 
 func Sign(ticket, url string) *ResultSign {
-	ret := &ResultSign{
-		NonceStr:  wx.Nonce(16),
-		Timestamp: time.Now().Unix(),
+	return sign(ticket, wx.Nonce(16), time.Now().Unix(), url)
+}
+
+// ResultDualSign wx.config 与 wx.agentConfig 签名
+type ResultDualSign struct {
+	Config      *ResultSign `json:"config"`       // 使用企业的 jsapi_ticket 签名，用于 wx.config
+	AgentConfig *ResultSign `json:"agent_config"` // 使用应用的 jsapi_ticket 签名，用于 wx.agentConfig
+}
+
+// DualSign 生成企业微信网页所需的 wx.config 与 wx.agentConfig 签名
+// [参考](https://developer.work.weixin.qq.com/document/path/90506)
+func DualSign(qyTicket, agentTicket, url string) *ResultDualSign {
+	return &ResultDualSign{
+		Config:      Sign(qyTicket, url),
+		AgentConfig: Sign(agentTicket, url),
 	}
+}
 
-	signStr := fmt.Sprintf("jsapi_ticket=%s&noncestr=%s&timestamp=%d&url=%s", ticket, ret.NonceStr, ret.Timestamp, url)
-	ret.Signature = wx.SHA1(signStr)
+func sign(ticket, nonce string, timestamp int64, url string) *ResultSign {
+	signStr := fmt.Sprintf("jsapi_ticket=%s&noncestr=%s&timestamp=%d&url=%s", ticket, nonce, timestamp, url)
 
-	return ret
+	return &ResultSign{
+		NonceStr:  nonce,
+		Timestamp: timestamp,
+		Signature: wx.SHA1(signStr),
+	}
 }
@@ -0,0 +1,24 @@
+package jsapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSign(t *testing.T) {
+	ret := sign("sM4AOVdWfPE4DxkXGEs8VMCPGGVi4C3VM0P37wVUCFvkVAy_90u5h9nbSlYy3-Sl-HhTdfl2fzFy1AOcHKP7qg", "Wm3WZYTPz0wzccnW", 1414587457, "http://mp.weixin.qq.com?params=value")
+
+	assert.Equal(t, &ResultSign{
+		NonceStr:  "Wm3WZYTPz0wzccnW",
+		Timestamp: 1414587457,
+		Signature: "0f9de62fce790f9a083d5c99e95740ceb90c27ed",
+	}, ret)
+}
+
+func TestDualSign(t *testing.T) {
+	ret := DualSign("QY_TICKET", "AGENT_TICKET", "http://mp.weixin.qq.com?params=value")
+
+	assert.Equal(t, sign("QY_TICKET", ret.Config.NonceStr, ret.Config.Timestamp, "http://mp.weixin.qq.com?params=value"), ret.Config)
+	assert.Equal(t, sign("AGENT_TICKET", ret.AgentConfig.NonceStr, ret.AgentConfig.Timestamp, "http://mp.weixin.qq.com?params=value"), ret.AgentConfig)
+}
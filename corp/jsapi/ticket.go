@@ -1,8 +1,10 @@
 package jsapi
 
 import (
+	"context"
 	"encoding/json"
 
+	"github.com/shenghui0779/gochat/corp"
 	"github.com/shenghui0779/gochat/urls"
 	"github.com/shenghui0779/gochat/wx"
 )
@@ -28,3 +30,34 @@ func GetAgentTicket(result *ResultTicket) wx.Action {
 		}),
 	)
 }
+
+// NewQYTicketManager returns a cached 企业的 jsapi_ticket manager (使用 cp.AgentToken(secret) 获取 access_token)；
+// options 如：wx.WithTokenStore（需与 access_token 使用不同的 key）
+func NewQYTicketManager(cp *corp.Corp, secret string, options ...wx.TokenOption) *wx.TokenManager {
+	return wx.NewTokenManager(func(ctx context.Context) (string, int64, error) {
+		return fetchTicket(ctx, cp, secret, GetQYTicket)
+	}, options...)
+}
+
+// NewAgentTicketManager returns a cached 应用的 jsapi_ticket manager（用于 wx.agentConfig）
+func NewAgentTicketManager(cp *corp.Corp, secret string, options ...wx.TokenOption) *wx.TokenManager {
+	return wx.NewTokenManager(func(ctx context.Context) (string, int64, error) {
+		return fetchTicket(ctx, cp, secret, GetAgentTicket)
+	}, options...)
+}
+
+func fetchTicket(ctx context.Context, cp *corp.Corp, secret string, f func(result *ResultTicket) wx.Action) (string, int64, error) {
+	accessToken, err := cp.AgentToken(ctx, secret)
+
+	if err != nil {
+		return "", 0, err
+	}
+
+	result := new(ResultTicket)
+
+	if err = cp.Do(ctx, accessToken, f(result)); err != nil {
+		return "", 0, err
+	}
+
+	return result.Ticket, int64(result.ExpiresIn), nil
+}
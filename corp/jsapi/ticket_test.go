@@ -8,6 +8,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/shenghui0779/gochat/corp"
 	"github.com/shenghui0779/gochat/mock"
+	"github.com/shenghui0779/gochat/wx"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -66,3 +67,56 @@ func TestGetAgentTicket(t *testing.T) {
 		ExpiresIn: 7200,
 	}, result)
 }
+
+func TestTicketManager(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://qyapi.weixin.qq.com/cgi-bin/gettoken?corpid=CORPID&corpsecret=SECRET", nil).Return([]byte(`{"errcode":0,"errmsg":"ok","access_token":"ACCESS_TOKEN","expires_in":7200}`), nil).Times(1)
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://qyapi.weixin.qq.com/cgi-bin/get_jsapi_ticket?access_token=ACCESS_TOKEN", nil).Return([]byte(`{"errcode":0,"errmsg":"ok","ticket":"QY_TICKET","expires_in":7200}`), nil).Times(1)
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://qyapi.weixin.qq.com/cgi-bin/ticket/get?access_token=ACCESS_TOKEN&type=agent_config", nil).Return([]byte(`{"errcode":0,"errmsg":"ok","ticket":"AGENT_TICKET","expires_in":7200}`), nil).Times(1)
+
+	cp := corp.New("CORPID", corp.WithMockClient(client))
+
+	qymgr := NewQYTicketManager(cp, "SECRET")
+	agentmgr := NewAgentTicketManager(cp, "SECRET")
+
+	for i := 0; i < 2; i++ {
+		ticket, err := qymgr.Token(context.TODO())
+
+		assert.Nil(t, err)
+		assert.Equal(t, "QY_TICKET", ticket)
+
+		ticket, err = agentmgr.Token(context.TODO())
+
+		assert.Nil(t, err)
+		assert.Equal(t, "AGENT_TICKET", ticket)
+	}
+}
+
+func TestTicketManagerWithStore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://qyapi.weixin.qq.com/cgi-bin/gettoken?corpid=CORPID&corpsecret=SECRET", nil).Return([]byte(`{"errcode":0,"errmsg":"ok","access_token":"ACCESS_TOKEN","expires_in":7200}`), nil).Times(1)
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://qyapi.weixin.qq.com/cgi-bin/get_jsapi_ticket?access_token=ACCESS_TOKEN", nil).Return([]byte(`{"errcode":0,"errmsg":"ok","ticket":"QY_TICKET","expires_in":7200}`), nil).Times(1)
+
+	cp := corp.New("CORPID", corp.WithMockClient(client))
+
+	store := wx.NewMemTokenStore()
+
+	ticket, err := NewQYTicketManager(cp, "SECRET", wx.WithTokenStore(store, "CORPID:jsapi_ticket")).Token(context.TODO())
+
+	assert.Nil(t, err)
+	assert.Equal(t, "QY_TICKET", ticket)
+
+	// 新的管理器（如：其他实例）从存储中加载，不再请求
+	ticket, err = NewQYTicketManager(cp, "SECRET", wx.WithTokenStore(store, "CORPID:jsapi_ticket")).Token(context.TODO())
+
+	assert.Nil(t, err)
+	assert.Equal(t, "QY_TICKET", ticket)
+}
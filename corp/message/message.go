@@ -77,14 +77,15 @@ type MsgKV struct {
 	Value string `json:"value"`
 }
 
+// MsgExtra 应用消息的接收对象与发送选项
 type MsgExtra struct {
-	ToUser                 string
-	ToParty                string
-	ToTag                  string
-	Safe                   int
-	EnableIDTrans          int
-	EnableDuplicateCheck   int
-	DuplicateCheckInterval int
+	ToUser                 string // 指定接收消息的成员，多个用 | 分隔；为 @all 时向该企业应用的全部成员发送
+	ToParty                string // 指定接收消息的部门，多个用 | 分隔
+	ToTag                  string // 指定接收消息的标签，多个用 | 分隔
+	Safe                   int    // 是否是保密消息：0-可对外分享，1-不能分享且内容显示水印
+	EnableIDTrans          int    // 是否开启id转译：0-否，1-是
+	EnableDuplicateCheck   int    // 是否开启重复消息检查：0-否，1-是
+	DuplicateCheckInterval int    // 重复消息检查的时间间隔（秒），默认1800s，最大不超过4小时
 }
 
 type Messasge struct {
@@ -0,0 +1,252 @@
+package msgaudit
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// SDK 会话内容存档 C SDK（WeWorkFinanceSdk）的接口，由使用方基于 cgo 封装后注入，本包不依赖 cgo
+type SDK interface {
+	// GetChatData 拉取会话存档（对应 SDK 的 GetChatData），返回原始 JSON 数据
+	GetChatData(ctx context.Context, seq uint64, limit int) ([]byte, error)
+
+	// DecryptData 使用 encrypt_random_key 解密后的密钥解密消息（对应 SDK 的 DecryptData），返回明文 JSON 数据
+	DecryptData(randomKey, encryptMsg string) ([]byte, error)
+
+	// GetMediaData 分片拉取媒体文件（对应 SDK 的 GetMediaData），首次拉取时 indexBuf 为空
+	GetMediaData(ctx context.Context, indexBuf, sdkFileID string) (*MediaData, error)
+}
+
+// MediaData 媒体文件分片
+type MediaData struct {
+	OutIndexBuf string // 下一次拉取需携带的 indexbuf
+	Data        []byte // 分片数据
+	IsFinish    bool   // 是否已拉取完毕
+}
+
+// ChatData 会话存档加密数据
+type ChatData struct {
+	Seq              uint64 `json:"seq"`                // 消息的seq值，标识消息的序号
+	MsgID            string `json:"msgid"`              // 消息id，消息的唯一标识
+	PublicKeyVer     int    `json:"publickey_ver"`      // 加密此条消息使用的公钥版本号
+	EncryptRandomKey string `json:"encrypt_random_key"` // 使用公钥加密的随机密钥
+	EncryptChatMsg   string `json:"encrypt_chat_msg"`   // 加密的消息内容
+}
+
+// ResultChatData 会话存档拉取结果
+type ResultChatData struct {
+	ErrCode  int64       `json:"errcode"`
+	ErrMsg   string      `json:"errmsg"`
+	ChatData []*ChatData `json:"chatdata"`
+}
+
+// 消息动作
+const (
+	ActionSend   = "send"   // 发送消息
+	ActionRecall = "recall" // 撤回消息
+	ActionSwitch = "switch" // 切换企业日志
+)
+
+// TextContent 文本消息
+type TextContent struct {
+	Content string `json:"content"`
+}
+
+// ImageContent 图片消息
+type ImageContent struct {
+	MD5Sum    string `json:"md5sum"`
+	FileSize  int64  `json:"filesize"`
+	SDKFileID string `json:"sdkfileid"` // 媒体资源的id信息，用于 DownloadMedia
+}
+
+// RevokeContent 撤回消息
+type RevokeContent struct {
+	PreMsgID string `json:"pre_msgid"` // 被撤回的原消息id
+}
+
+// VoiceContent 语音消息
+type VoiceContent struct {
+	MD5Sum     string `json:"md5sum"`
+	VoiceSize  int64  `json:"voice_size"`
+	PlayLength int    `json:"play_length"` // 播放长度（秒）
+	SDKFileID  string `json:"sdkfileid"`
+}
+
+// VideoContent 视频消息
+type VideoContent struct {
+	MD5Sum     string `json:"md5sum"`
+	FileSize   int64  `json:"filesize"`
+	PlayLength int    `json:"play_length"`
+	SDKFileID  string `json:"sdkfileid"`
+}
+
+// FileContent 文件消息
+type FileContent struct {
+	MD5Sum    string `json:"md5sum"`
+	FileName  string `json:"filename"`
+	FileExt   string `json:"fileext"`
+	FileSize  int64  `json:"filesize"`
+	SDKFileID string `json:"sdkfileid"`
+}
+
+// LinkContent 链接消息
+type LinkContent struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	LinkURL     string `json:"link_url"`
+	ImageURL    string `json:"image_url"`
+}
+
+// LocationContent 位置消息
+type LocationContent struct {
+	Longitude float64 `json:"longitude"`
+	Latitude  float64 `json:"latitude"`
+	Address   string  `json:"address"`
+	Title     string  `json:"title"`
+	Zoom      int     `json:"zoom"`
+}
+
+// Message 会话存档消息（常用消息类型已解析，其他类型请使用 Raw 自行解析）
+type Message struct {
+	Seq      uint64           `json:"-"`
+	MsgID    string           `json:"msgid"`
+	Action   string           `json:"action"`  // 消息动作：send、recall、switch
+	From     string           `json:"from"`    // 消息发送方id
+	ToList   []string         `json:"tolist"`  // 消息接收方列表
+	RoomID   string           `json:"roomid"`  // 群聊消息的群id，单聊为空
+	MsgTime  int64            `json:"msgtime"` // 消息发送时间戳（毫秒）
+	MsgType  string           `json:"msgtype"` // 消息类型
+	Time     int64            `json:"time"`    // 切换企业日志的时间（毫秒），action 为 switch 时返回
+	User     string           `json:"user"`    // 切换企业的成员，action 为 switch 时返回
+	Text     *TextContent     `json:"text"`
+	Image    *ImageContent    `json:"image"`
+	Revoke   *RevokeContent   `json:"revoke"`
+	Voice    *VoiceContent    `json:"voice"`
+	Video    *VideoContent    `json:"video"`
+	File     *FileContent     `json:"file"`
+	Link     *LinkContent     `json:"link"`
+	Location *LocationContent `json:"location"`
+	Raw      json.RawMessage  `json:"-"` // 解密后的原始消息
+}
+
+// Archive 会话内容存档的拉取与解密
+type Archive struct {
+	sdk  SDK
+	keys map[int]*wx.PrivateKey
+}
+
+// NewArchive returns new Archive，keys 为「公钥版本号 -> 私钥」（企业微信管理后台设置的会话存档公钥对应的私钥，更新公钥后需保留旧版本私钥以解密历史消息）
+func NewArchive(sdk SDK, keys map[int]*wx.PrivateKey) *Archive {
+	return &Archive{
+		sdk:  sdk,
+		keys: keys,
+	}
+}
+
+// Fetch 拉取并解密会话存档，seq 为上次拉取的最后一条消息的 seq（首次为0），limit 最大为1000
+func (a *Archive) Fetch(ctx context.Context, seq uint64, limit int) ([]*Message, error) {
+	b, err := a.sdk.GetChatData(ctx, seq, limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(ResultChatData)
+
+	if err = json.Unmarshal(b, result); err != nil {
+		return nil, err
+	}
+
+	if result.ErrCode != 0 {
+		return nil, wx.NewAPIError(result.ErrCode, result.ErrMsg)
+	}
+
+	msgs := make([]*Message, 0, len(result.ChatData))
+
+	for _, data := range result.ChatData {
+		msg, err := a.Decrypt(data)
+
+		if err != nil {
+			return nil, fmt.Errorf("msgaudit: seq %d: %w", data.Seq, err)
+		}
+
+		msgs = append(msgs, msg)
+	}
+
+	return msgs, nil
+}
+
+// Decrypt 解密单条会话存档：使用对应版本的私钥解密 encrypt_random_key，再通过 SDK 解密消息内容
+func (a *Archive) Decrypt(data *ChatData) (*Message, error) {
+	pk, ok := a.keys[data.PublicKeyVer]
+
+	if !ok {
+		return nil, fmt.Errorf("private key of version %d not found", data.PublicKeyVer)
+	}
+
+	cipherText, err := base64.StdEncoding.DecodeString(data.EncryptRandomKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	randomKey, err := pk.Decrypt(cipherText)
+
+	if err != nil {
+		return nil, err
+	}
+
+	plainText, err := a.sdk.DecryptData(string(randomKey), data.EncryptChatMsg)
+
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &Message{
+		Seq: data.Seq,
+		Raw: plainText,
+	}
+
+	if err = json.Unmarshal(plainText, msg); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// DownloadMedia 下载媒体文件（图片、语音、视频、文件等），分片拉取并依次写入 w
+func (a *Archive) DownloadMedia(ctx context.Context, sdkFileID string, w io.Writer) error {
+	if len(sdkFileID) == 0 {
+		return errors.New("empty sdkfileid")
+	}
+
+	indexBuf := ""
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		media, err := a.sdk.GetMediaData(ctx, indexBuf, sdkFileID)
+
+		if err != nil {
+			return err
+		}
+
+		if _, err = w.Write(media.Data); err != nil {
+			return err
+		}
+
+		if media.IsFinish {
+			return nil
+		}
+
+		indexBuf = media.OutIndexBuf
+	}
+}
@@ -0,0 +1,140 @@
+package msgaudit
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/wx"
+)
+
+type testSDK struct {
+	chatdata []byte
+	messages map[string][]byte // randomKey + encryptMsg -> plaintext
+	media    []*MediaData
+	indexes  []string
+}
+
+func (s *testSDK) GetChatData(ctx context.Context, seq uint64, limit int) ([]byte, error) {
+	return s.chatdata, nil
+}
+
+func (s *testSDK) DecryptData(randomKey, encryptMsg string) ([]byte, error) {
+	b, ok := s.messages[randomKey+encryptMsg]
+
+	if !ok {
+		return nil, errors.New("decrypt failed")
+	}
+
+	return b, nil
+}
+
+func (s *testSDK) GetMediaData(ctx context.Context, indexBuf, sdkFileID string) (*MediaData, error) {
+	s.indexes = append(s.indexes, indexBuf)
+
+	media := s.media[0]
+	s.media = s.media[1:]
+
+	return media, nil
+}
+
+func newTestArchiveKey(t *testing.T) (*wx.PrivateKey, *rsa.PublicKey) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	assert.Nil(t, err)
+
+	pk, err := wx.NewPrivateKeyFromPemBlock(wx.RSA_PKCS1, pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	}))
+	assert.Nil(t, err)
+
+	return pk, &key.PublicKey
+}
+
+func TestArchiveFetch(t *testing.T) {
+	pk, pub := newTestArchiveKey(t)
+
+	encryptKey, err := rsa.EncryptPKCS1v15(rand.Reader, pub, []byte("RANDOM_KEY"))
+	assert.Nil(t, err)
+
+	sdk := &testSDK{
+		chatdata: []byte(fmt.Sprintf(`{"errcode":0,"errmsg":"ok","chatdata":[{"seq":196,"msgid":"CAQQ2fbb4QUY0On2rYSAgAMgip/yzgs=","publickey_ver":3,"encrypt_random_key":"%s","encrypt_chat_msg":"ENCRYPT_MSG_1"},{"seq":197,"msgid":"CAQQ2fbb4QUY0On2rYSAgAMgip/yzgt=","publickey_ver":3,"encrypt_random_key":"%s","encrypt_chat_msg":"ENCRYPT_MSG_2"}]}`, base64.StdEncoding.EncodeToString(encryptKey), base64.StdEncoding.EncodeToString(encryptKey))),
+		messages: map[string][]byte{
+			"RANDOM_KEYENCRYPT_MSG_1": []byte(`{"msgid":"CAQQ2fbb4QUY0On2rYSAgAMgip/yzgs=","action":"send","from":"XuJinSheng","tolist":["icefog"],"roomid":"","msgtime":1547087894783,"msgtype":"text","text":{"content":"test"}}`),
+			"RANDOM_KEYENCRYPT_MSG_2": []byte(`{"msgid":"CAQQ2fbb4QUY0On2rYSAgAMgip/yzgt=","action":"send","from":"XuJinSheng","tolist":["icefog"],"roomid":"wrx7_ACAAA5SEjXJuZOHkmO1dCzaS-Eg","msgtime":1547087894783,"msgtype":"image","image":{"md5sum":"50de8e5ae8ffe4f1df7a93841f71993a","filesize":70961,"sdkfileid":"CtYBMzA2OTAy"}}`),
+		},
+	}
+
+	archive := NewArchive(sdk, map[int]*wx.PrivateKey{3: pk})
+
+	msgs, err := archive.Fetch(context.TODO(), 195, 100)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(msgs))
+
+	assert.Equal(t, uint64(196), msgs[0].Seq)
+	assert.Equal(t, ActionSend, msgs[0].Action)
+	assert.Equal(t, []string{"icefog"}, msgs[0].ToList)
+	assert.Equal(t, &TextContent{Content: "test"}, msgs[0].Text)
+	assert.Nil(t, msgs[0].Image)
+
+	assert.Equal(t, uint64(197), msgs[1].Seq)
+	assert.Equal(t, "wrx7_ACAAA5SEjXJuZOHkmO1dCzaS-Eg", msgs[1].RoomID)
+	assert.Equal(t, &ImageContent{
+		MD5Sum:    "50de8e5ae8ffe4f1df7a93841f71993a",
+		FileSize:  70961,
+		SDKFileID: "CtYBMzA2OTAy",
+	}, msgs[1].Image)
+	assert.Contains(t, string(msgs[1].Raw), `"msgtype":"image"`)
+}
+
+func TestArchiveFetchError(t *testing.T) {
+	pk, _ := newTestArchiveKey(t)
+
+	// 接口错误
+	archive := NewArchive(&testSDK{
+		chatdata: []byte(`{"errcode":10001,"errmsg":"network error"}`),
+	}, map[int]*wx.PrivateKey{1: pk})
+
+	_, err := archive.Fetch(context.TODO(), 0, 100)
+
+	assert.True(t, wx.IsAPIError(err, 10001))
+
+	// 私钥版本不存在
+	archive = NewArchive(&testSDK{
+		chatdata: []byte(`{"errcode":0,"errmsg":"ok","chatdata":[{"seq":1,"msgid":"MSGID","publickey_ver":2,"encrypt_random_key":"","encrypt_chat_msg":""}]}`),
+	}, map[int]*wx.PrivateKey{1: pk})
+
+	_, err = archive.Fetch(context.TODO(), 0, 100)
+
+	assert.NotNil(t, err)
+}
+
+func TestArchiveDownloadMedia(t *testing.T) {
+	sdk := &testSDK{
+		media: []*MediaData{
+			{OutIndexBuf: "INDEX_1", Data: []byte("hello ")},
+			{OutIndexBuf: "INDEX_2", Data: []byte("world")},
+			{Data: []byte("!"), IsFinish: true},
+		},
+	}
+
+	archive := NewArchive(sdk, nil)
+
+	var buf bytes.Buffer
+
+	err := archive.DownloadMedia(context.TODO(), "CtYBMzA2OTAy", &buf)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "hello world!", buf.String())
+	assert.Equal(t, []string{"", "INDEX_1", "INDEX_2"}, sdk.indexes)
+}
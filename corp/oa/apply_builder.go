@@ -0,0 +1,127 @@
+package oa
+
+import (
+	"strconv"
+	"time"
+)
+
+// 日期控件的展示类型
+const (
+	DateTypeDay  = "day"  // 日期
+	DateTypeHour = "hour" // 日期+时间
+)
+
+// 选择控件的选择方式
+const (
+	SelectorSingle = "single" // 单选
+	SelectorMulti  = "multi"  // 多选
+)
+
+// ApplyDataBuilder 审批申请数据（apply_data）构造器，控件 id 可通过 GetTemplateDetail 获取；
+// 表格控件的每一行同样使用 ApplyDataBuilder 构造
+type ApplyDataBuilder struct {
+	contents []*ApplyContent
+}
+
+// NewApplyDataBuilder returns new apply data builder
+func NewApplyDataBuilder() *ApplyDataBuilder {
+	return new(ApplyDataBuilder)
+}
+
+func (b *ApplyDataBuilder) add(control ControlType, id string, value *ControlValue) *ApplyDataBuilder {
+	b.contents = append(b.contents, &ApplyContent{
+		Control: control,
+		ID:      id,
+		Value:   value,
+	})
+
+	return b
+}
+
+// Text 文本控件
+func (b *ApplyDataBuilder) Text(id, text string) *ApplyDataBuilder {
+	return b.add(ControlText, id, &ControlValue{Text: text})
+}
+
+// Textarea 多行文本控件
+func (b *ApplyDataBuilder) Textarea(id, text string) *ApplyDataBuilder {
+	return b.add(ControlTextarea, id, &ControlValue{Text: text})
+}
+
+// Number 数字控件
+func (b *ApplyDataBuilder) Number(id string, number float64) *ApplyDataBuilder {
+	return b.add(ControlNumber, id, &ControlValue{NewNumber: strconv.FormatFloat(number, 'f', -1, 64)})
+}
+
+// Money 金额控件（单位：元，保留两位小数）
+func (b *ApplyDataBuilder) Money(id string, money float64) *ApplyDataBuilder {
+	return b.add(ControlMoney, id, &ControlValue{NewMoney: strconv.FormatFloat(money, 'f', 2, 64)})
+}
+
+// Date 日期控件，dateType 为 DateTypeDay 或 DateTypeHour
+func (b *ApplyDataBuilder) Date(id, dateType string, t time.Time) *ApplyDataBuilder {
+	return b.add(ControlDate, id, &ControlValue{
+		Date: &DateValue{
+			Type:       dateType,
+			STimestamp: strconv.FormatInt(t.Unix(), 10),
+		},
+	})
+}
+
+// Selector 单选/多选控件，selectorType 为 SelectorSingle 或 SelectorMulti，keys 为选项的 key
+func (b *ApplyDataBuilder) Selector(id, selectorType string, keys ...string) *ApplyDataBuilder {
+	options := make([]*SelectorOption, 0, len(keys))
+
+	for _, k := range keys {
+		options = append(options, &SelectorOption{Key: k})
+	}
+
+	return b.add(ControlSelector, id, &ControlValue{
+		Seletor: &SelectorValue{
+			Type:    selectorType,
+			Options: options,
+		},
+	})
+}
+
+// File 附件控件，fileIDs 为通过上传临时素材获取的 media_id
+func (b *ApplyDataBuilder) File(id string, fileIDs ...string) *ApplyDataBuilder {
+	files := make([]*FileValue, 0, len(fileIDs))
+
+	for _, v := range fileIDs {
+		files = append(files, &FileValue{FileID: v})
+	}
+
+	return b.add(ControlFile, id, &ControlValue{Files: files})
+}
+
+// Table 明细（表格）控件，每个 row 为表格的一行
+func (b *ApplyDataBuilder) Table(id string, rows ...*ApplyDataBuilder) *ApplyDataBuilder {
+	children := make([]*TableValue, 0, len(rows))
+
+	for _, row := range rows {
+		list := make([]*TableChildValue, 0, len(row.contents))
+
+		for _, v := range row.contents {
+			list = append(list, &TableChildValue{
+				Control: v.Control,
+				ID:      v.ID,
+				Title:   v.Title,
+				Value:   v.Value,
+			})
+		}
+
+		children = append(children, &TableValue{List: list})
+	}
+
+	return b.add(ControlTable, id, &ControlValue{Children: children})
+}
+
+// Build 返回审批申请数据
+func (b *ApplyDataBuilder) Build() *ApplyData {
+	contents := make([]*ApplyContent, len(b.contents))
+
+	copy(contents, b.contents)
+
+	return &ApplyData{Contents: contents}
+}
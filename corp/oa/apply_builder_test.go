@@ -0,0 +1,40 @@
+package oa
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyDataBuilder(t *testing.T) {
+	data := NewApplyDataBuilder().
+		Text("Text-1", "文本").
+		Number("Number-1", 12.5).
+		Money("Money-1", 700).
+		Date("Date-1", DateTypeDay, time.Unix(1569859200, 0)).
+		Selector("Selector-1", SelectorMulti, "option-1", "option-2").
+		File("File-1", "MEDIA_ID").
+		Table("Table-1",
+			NewApplyDataBuilder().Text("Text-2", "第一行"),
+			NewApplyDataBuilder().Text("Text-2", "第二行"),
+		).
+		Build()
+
+	b, err := json.Marshal(data)
+
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"contents":[
+	{"control":"Text","id":"Text-1","value":{"text":"文本"}},
+	{"control":"Number","id":"Number-1","value":{"new_number":"12.5"}},
+	{"control":"Money","id":"Money-1","value":{"new_money":"700.00"}},
+	{"control":"Date","id":"Date-1","value":{"date":{"type":"day","s_timestamp":"1569859200"}}},
+	{"control":"Selector","id":"Selector-1","value":{"selector":{"type":"multi","options":[{"key":"option-1"},{"key":"option-2"}]}}},
+	{"control":"File","id":"File-1","value":{"files":[{"file_id":"MEDIA_ID"}]}},
+	{"control":"Table","id":"Table-1","value":{"children":[
+		{"list":[{"control":"Text","id":"Text-2","value":{"text":"第一行"}}]},
+		{"list":[{"control":"Text","id":"Text-2","value":{"text":"第二行"}}]}
+	]}}
+]}`, string(b))
+}
@@ -46,7 +46,7 @@ type ControlValue struct {
 	NewMoney        string                  `json:"new_money,omitempty"`
 	Tips            interface{}             `json:"tips,omitempty"`
 	Date            *DateValue              `json:"date,omitempty"`
-	Seletor         *SelectorValue          `json:"seletor,omitempty"`
+	Seletor         *SelectorValue          `json:"selector,omitempty"`
 	Members         []*ContactMember        `json:"members,omitempty"`
 	Departments     []*ContactDepartment    `json:"departments,omitempty"`
 	Files           []*FileValue            `json:"files,omitempty"`
@@ -79,13 +79,13 @@ type SelectorConfig struct {
 }
 
 type SelectorValue struct {
-	Type    string
-	Options []*SelectorOption
+	Type    string            `json:"type"` // 选择方式：single-单选；multi-多选
+	Options []*SelectorOption `json:"options"`
 }
 
 type SelectorOption struct {
 	Key   string         `json:"key"`
-	Value []*DisplayText `json:"value"`
+	Value []*DisplayText `json:"value,omitempty"`
 }
 
 type ContactConfig struct {
@@ -123,7 +123,7 @@ type TableValue struct {
 type TableChildValue struct {
 	Control ControlType    `json:"control"`
 	ID      string         `json:"id"`
-	Title   []*DisplayText `json:"title"`
+	Title   []*DisplayText `json:"title,omitempty"`
 	Value   *ControlValue  `json:"value"`
 }
 
@@ -0,0 +1,32 @@
+package oa
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectorValue(t *testing.T) {
+	b := []byte(`{"selector":{"type":"single","options":[{"key":"option-15111111111","value":[{"text":"选项1","lang":"zh_CN"}]}]}}`)
+
+	v := new(ControlValue)
+
+	assert.Nil(t, json.Unmarshal(b, v))
+	assert.Equal(t, &SelectorValue{
+		Type: "single",
+		Options: []*SelectorOption{
+			{
+				Key: "option-15111111111",
+				Value: []*DisplayText{
+					{Text: "选项1", Lang: "zh_CN"},
+				},
+			},
+		},
+	}, v.Seletor)
+
+	out, err := json.Marshal(v)
+
+	assert.Nil(t, err)
+	assert.JSONEq(t, string(b), string(out))
+}
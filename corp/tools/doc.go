@@ -0,0 +1,132 @@
+package tools
+
+import (
+	"encoding/json"
+
+	"github.com/shenghui0779/gochat/urls"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// 文档类型
+const (
+	DocTypeDoc        = 3  // 文档
+	DocTypeSheet      = 4  // 表格
+	DocTypeSmartSheet = 10 // 智能表格
+)
+
+type ParamsDocCreate struct {
+	SpaceID    string   `json:"spaceid,omitempty"`     // 空间spaceid，若指定则在该空间下创建，否则创建在应用的默认空间
+	FatherID   string   `json:"fatherid,omitempty"`    // 父目录fileid，在根目录时为空间spaceid
+	DocType    int      `json:"doc_type"`              // 文档类型：3-文档，4-表格，10-智能表格
+	DocName    string   `json:"doc_name"`              // 文档名字（最多255个字符）
+	AdminUsers []string `json:"admin_users,omitempty"` // 文档管理员userid
+}
+
+type ResultDocCreate struct {
+	URL   string `json:"url"`   // 新建文档的访问链接
+	DocID string `json:"docid"` // 新建文档的docid
+}
+
+// CreateDoc 文档 - 新建文档
+func CreateDoc(params *ParamsDocCreate, result *ResultDocCreate) wx.Action {
+	return wx.NewPostAction(urls.CorpToolsDocCreate,
+		wx.WithBody(func() ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+type ParamsDocRename struct {
+	DocID   string `json:"docid"`
+	NewName string `json:"new_name"`
+}
+
+// RenameDoc 文档 - 重命名文档
+func RenameDoc(docID, newName string) wx.Action {
+	params := &ParamsDocRename{
+		DocID:   docID,
+		NewName: newName,
+	}
+
+	return wx.NewPostAction(urls.CorpToolsDocRename,
+		wx.WithBody(func() ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+	)
+}
+
+type ParamsDocDelete struct {
+	DocID string `json:"docid"`
+}
+
+// DeleteDoc 文档 - 删除文档
+func DeleteDoc(docID string) wx.Action {
+	params := &ParamsDocDelete{
+		DocID: docID,
+	}
+
+	return wx.NewPostAction(urls.CorpToolsDocDelete,
+		wx.WithBody(func() ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+	)
+}
+
+type ParamsDocBaseInfo struct {
+	DocID string `json:"docid"`
+}
+
+type ResultDocBaseInfo struct {
+	DocBaseInfo *DocBaseInfo `json:"doc_base_info"`
+}
+
+type DocBaseInfo struct {
+	DocID      string `json:"docid"`
+	DocName    string `json:"doc_name"`
+	CreateTime int64  `json:"create_time"`
+	ModifyTime int64  `json:"modify_time"`
+	DocType    int    `json:"doc_type"`
+}
+
+// GetDocBaseInfo 文档 - 获取文档基础信息
+func GetDocBaseInfo(docID string, result *ResultDocBaseInfo) wx.Action {
+	params := &ParamsDocBaseInfo{
+		DocID: docID,
+	}
+
+	return wx.NewPostAction(urls.CorpToolsDocGetBaseInfo,
+		wx.WithBody(func() ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+type ParamsDocShare struct {
+	DocID string `json:"docid"`
+}
+
+type ResultDocShare struct {
+	ShareURL string `json:"share_url"`
+}
+
+// ShareDoc 文档 - 获取文档分享链接
+func ShareDoc(docID string, result *ResultDocShare) wx.Action {
+	params := &ParamsDocShare{
+		DocID: docID,
+	}
+
+	return wx.NewPostAction(urls.CorpToolsDocShare,
+		wx.WithBody(func() ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
@@ -0,0 +1,151 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/corp"
+	"github.com/shenghui0779/gochat/mock"
+)
+
+func TestCreateDoc(t *testing.T) {
+	body := []byte(`{"spaceid":"SPACEID","fatherid":"FATHERID","doc_type":3,"doc_name":"DOC_NAME","admin_users":["USERID1","USERID2"]}`)
+	resp := []byte(`{
+	"errcode": 0,
+	"errmsg": "ok",
+	"url": "URL",
+	"docid": "DOCID"
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://qyapi.weixin.qq.com/cgi-bin/wedoc/create_doc?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	cp := corp.New("CORPID", corp.WithMockClient(client))
+
+	params := &ParamsDocCreate{
+		SpaceID:    "SPACEID",
+		FatherID:   "FATHERID",
+		DocType:    DocTypeDoc,
+		DocName:    "DOC_NAME",
+		AdminUsers: []string{"USERID1", "USERID2"},
+	}
+	result := new(ResultDocCreate)
+
+	err := cp.Do(context.TODO(), "ACCESS_TOKEN", CreateDoc(params, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultDocCreate{
+		URL:   "URL",
+		DocID: "DOCID",
+	}, result)
+}
+
+func TestRenameDoc(t *testing.T) {
+	body := []byte(`{"docid":"DOCID","new_name":"NEW_NAME"}`)
+	resp := []byte(`{"errcode":0,"errmsg":"ok"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://qyapi.weixin.qq.com/cgi-bin/wedoc/rename_doc?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	cp := corp.New("CORPID", corp.WithMockClient(client))
+
+	err := cp.Do(context.TODO(), "ACCESS_TOKEN", RenameDoc("DOCID", "NEW_NAME"))
+
+	assert.Nil(t, err)
+}
+
+func TestDeleteDoc(t *testing.T) {
+	body := []byte(`{"docid":"DOCID"}`)
+	resp := []byte(`{"errcode":0,"errmsg":"ok"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://qyapi.weixin.qq.com/cgi-bin/wedoc/del_doc?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	cp := corp.New("CORPID", corp.WithMockClient(client))
+
+	err := cp.Do(context.TODO(), "ACCESS_TOKEN", DeleteDoc("DOCID"))
+
+	assert.Nil(t, err)
+}
+
+func TestGetDocBaseInfo(t *testing.T) {
+	body := []byte(`{"docid":"DOCID"}`)
+	resp := []byte(`{
+	"errcode": 0,
+	"errmsg": "ok",
+	"doc_base_info": {
+		"docid": "DOCID",
+		"doc_name": "DOC_NAME",
+		"create_time": 1667222400,
+		"modify_time": 1667222500,
+		"doc_type": 3
+	}
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://qyapi.weixin.qq.com/cgi-bin/wedoc/get_doc_base_info?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	cp := corp.New("CORPID", corp.WithMockClient(client))
+
+	result := new(ResultDocBaseInfo)
+
+	err := cp.Do(context.TODO(), "ACCESS_TOKEN", GetDocBaseInfo("DOCID", result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultDocBaseInfo{
+		DocBaseInfo: &DocBaseInfo{
+			DocID:      "DOCID",
+			DocName:    "DOC_NAME",
+			CreateTime: 1667222400,
+			ModifyTime: 1667222500,
+			DocType:    DocTypeDoc,
+		},
+	}, result)
+}
+
+func TestShareDoc(t *testing.T) {
+	body := []byte(`{"docid":"DOCID"}`)
+	resp := []byte(`{
+	"errcode": 0,
+	"errmsg": "ok",
+	"share_url": "SHARE_URL"
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://qyapi.weixin.qq.com/cgi-bin/wedoc/doc_share?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	cp := corp.New("CORPID", corp.WithMockClient(client))
+
+	result := new(ResultDocShare)
+
+	err := cp.Do(context.TODO(), "ACCESS_TOKEN", ShareDoc("DOCID", result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultDocShare{
+		ShareURL: "SHARE_URL",
+	}, result)
+}
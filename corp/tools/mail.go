@@ -0,0 +1,38 @@
+package tools
+
+import (
+	"github.com/shenghui0779/gochat/urls"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// MailReceiver 邮件收件人
+type MailReceiver struct {
+	Emails  []string `json:"emails,omitempty"`  // 邮箱地址
+	UserIDs []string `json:"userids,omitempty"` // 企业内成员的userid
+}
+
+// MailAttachment 邮件附件
+type MailAttachment struct {
+	FileName string `json:"file_name"` // 文件名
+	Content  string `json:"content"`   // 文件内容（base64编码），所有附件加正文的大小不允许超过50M
+}
+
+type ParamsMailSend struct {
+	To             *MailReceiver     `json:"to"`                        // 收件人
+	CC             *MailReceiver     `json:"cc,omitempty"`              // 抄送
+	BCC            *MailReceiver     `json:"bcc,omitempty"`             // 密送
+	Subject        string            `json:"subject"`                   // 邮件标题
+	Content        string            `json:"content"`                   // 邮件正文
+	AttachmentList []*MailAttachment `json:"attachment_list,omitempty"` // 附件
+	ContentType    string            `json:"content_type,omitempty"`    // 内容类型：html（默认）、text
+	EnableIDTrans  int               `json:"enable_id_trans,omitempty"` // 是否开启id转译，0-否，1-是
+}
+
+// SendMail 邮件 - 发送普通邮件（应用邮箱）
+func SendMail(params *ParamsMailSend) wx.Action {
+	return wx.NewPostAction(urls.CorpToolsMailComposeSend,
+		wx.WithBody(func() ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+	)
+}
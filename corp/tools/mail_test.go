@@ -0,0 +1,50 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/corp"
+	"github.com/shenghui0779/gochat/mock"
+)
+
+func TestSendMail(t *testing.T) {
+	body := []byte(`{"to":{"emails":["t1@qq.com"],"userids":["zhangsan"]},"cc":{"emails":["t2@qq.com"]},"subject":"周会纪要","content":"<p>会议内容</p>","attachment_list":[{"file_name":"a.txt","content":"aGVsbG8="}],"enable_id_trans":1}`)
+	resp := []byte(`{"errcode":0,"errmsg":"ok"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://qyapi.weixin.qq.com/cgi-bin/exmail/app/compose_send?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	cp := corp.New("CORPID", corp.WithMockClient(client))
+
+	params := &ParamsMailSend{
+		To: &MailReceiver{
+			Emails:  []string{"t1@qq.com"},
+			UserIDs: []string{"zhangsan"},
+		},
+		CC: &MailReceiver{
+			Emails: []string{"t2@qq.com"},
+		},
+		Subject: "周会纪要",
+		Content: "<p>会议内容</p>",
+		AttachmentList: []*MailAttachment{
+			{
+				FileName: "a.txt",
+				Content:  "aGVsbG8=",
+			},
+		},
+		EnableIDTrans: 1,
+	}
+
+	err := cp.Do(context.TODO(), "ACCESS_TOKEN", SendMail(params))
+
+	assert.Nil(t, err)
+}
@@ -2,6 +2,7 @@ package event
 
 import (
 	"crypto/sha1"
+	"crypto/subtle"
 	"encoding/hex"
 	"encoding/xml"
 	"sort"
@@ -74,6 +75,7 @@ const (
 	EventCardPayOrder               EventType = "card_pay_order"               // 券点流水详情事件
 	EventSubmitMemberCardUserInfo   EventType = "submit_membercard_user_info"  // 会员卡激活
 	EventWxaMediaCheck              EventType = "wxa_media_check"              // 校验图片/音频是否含有违法违规内容
+	EventNearbyPOIAudit             EventType = "nearby_poi_audit"             // 附近的小程序地点审核结果
 	EventPublishJobFinish           EventType = "PUBLISHJOBFINISH"             // 发布任务结束
 	EventKFMsgOREvent               EventType = "kf_msg_or_event"              // 企业微信客服
 	EventEnterSession               EventType = "enter_session"                // 用户进入会话
@@ -168,3 +170,17 @@ func SignWithSHA1(token string, items ...string) string {
 
 	return hex.EncodeToString(h.Sum(nil))
 }
+
+// VerifyOffiaSignature 验证服务器地址的有效性（公众号/小程序URL验证），使用：token、timestamp、nonce、signature
+// 验证成功后，请原样返回 echostr 参数内容
+// [参考](https://developers.weixin.qq.com/doc/offiaccount/Basic_Information/Access_Overview.html)
+func VerifyOffiaSignature(token, timestamp, nonce, signature string) bool {
+	return subtle.ConstantTimeCompare([]byte(SignWithSHA1(token, timestamp, nonce)), []byte(signature)) == 1
+}
+
+// VerifyMsgSignature 验证加密消息签名（公众号、小程序、企业微信、第三方平台通用），使用：token、timestamp、nonce、msg_encrypt、msg_signature
+// 企业微信URL验证时，msg_encrypt 为 echostr 参数内容
+// [参考](https://developers.weixin.qq.com/doc/offiaccount/Message_Management/Message_encryption_and_decryption_instructions.html)
+func VerifyMsgSignature(token, timestamp, nonce, encrypt, signature string) bool {
+	return subtle.ConstantTimeCompare([]byte(SignWithSHA1(token, timestamp, nonce, encrypt)), []byte(signature)) == 1
+}
@@ -11,3 +11,15 @@ func TestSignWithSHA1(t *testing.T) {
 
 	assert.Equal(t, "ffb882ae55647757d3b807ff0e9b6098dfc2bc57", sign)
 }
+
+func TestVerifyOffiaSignature(t *testing.T) {
+	assert.True(t, VerifyOffiaSignature("2faf43d6343a802b6073aae5b3f2f109", "1606902086", "1246833592", "ffb882ae55647757d3b807ff0e9b6098dfc2bc57"))
+	assert.False(t, VerifyOffiaSignature("2faf43d6343a802b6073aae5b3f2f109", "1606902087", "1246833592", "ffb882ae55647757d3b807ff0e9b6098dfc2bc57"))
+}
+
+func TestVerifyMsgSignature(t *testing.T) {
+	signature := SignWithSHA1("2faf43d6343a802b6073aae5b3f2f109", "1606902086", "1246833592", "ENCRYPT")
+
+	assert.True(t, VerifyMsgSignature("2faf43d6343a802b6073aae5b3f2f109", "1606902086", "1246833592", "ENCRYPT", signature))
+	assert.False(t, VerifyMsgSignature("2faf43d6343a802b6073aae5b3f2f109", "1606902086", "1246833592", "ENCRYPT_OTHER", signature))
+}
@@ -0,0 +1,151 @@
+package eventhub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// DeadLetter 业务处理失败的消息事件
+type DeadLetter struct {
+	ID        string // 唯一标识
+	AppID     string // 接收消息的 appid/corpid（第三方平台为 component_appid）
+	Payload   []byte // 解密后的原始消息报文（XML）
+	Reason    string // 最近一次处理失败的原因
+	Attempts  int    // 重新分发失败的次数
+	CreatedAt int64  // 首次处理失败的时间戳
+}
+
+// DeadLetterStore 死信存储（如：数据库、Redis 等）
+type DeadLetterStore interface {
+	// Save 保存死信（ID 相同时覆盖）
+	Save(ctx context.Context, letter *DeadLetter) error
+
+	// List 获取全部待重新分发的死信
+	List(ctx context.Context) ([]*DeadLetter, error)
+
+	// Delete 删除死信（如：重新分发成功）
+	Delete(ctx context.Context, id string) error
+}
+
+type memDeadLetterStore struct {
+	letters map[string]*DeadLetter
+	mutex   sync.RWMutex
+}
+
+func (s *memDeadLetterStore) Save(ctx context.Context, letter *DeadLetter) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.letters[letter.ID] = letter
+
+	return nil
+}
+
+func (s *memDeadLetterStore) List(ctx context.Context) ([]*DeadLetter, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	letters := make([]*DeadLetter, 0, len(s.letters))
+
+	for _, v := range s.letters {
+		letters = append(letters, v)
+	}
+
+	sort.Slice(letters, func(i, j int) bool {
+		if letters[i].CreatedAt == letters[j].CreatedAt {
+			return letters[i].ID < letters[j].ID
+		}
+
+		return letters[i].CreatedAt < letters[j].CreatedAt
+	})
+
+	return letters, nil
+}
+
+func (s *memDeadLetterStore) Delete(ctx context.Context, id string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.letters, id)
+
+	return nil
+}
+
+// NewMemDeadLetterStore returns an in-memory dead-letter store (仅用于测试或单机，重启后丢失)
+func NewMemDeadLetterStore() DeadLetterStore {
+	return &memDeadLetterStore{
+		letters: make(map[string]*DeadLetter),
+	}
+}
+
+// bury 保存死信，payload 为解密后的原始报文（重新分发时按原报文解析，不丢失未定义的字段）
+func (h *Hub) bury(ctx context.Context, ep endpoint, payload []byte, reason error) error {
+	return h.deadletter.Save(ctx, &DeadLetter{
+		ID:        wx.Nonce(16),
+		AppID:     ep.id(),
+		Payload:   payload,
+		Reason:    reason.Error(),
+		CreatedAt: time.Now().Unix(),
+	})
+}
+
+// Redispatch 将死信重新分发给对应应用的业务处理（被动回复将被忽略）
+func (h *Hub) Redispatch(ctx context.Context, letter *DeadLetter) error {
+	ep, ok := h.lookup(letter.AppID)
+
+	if !ok {
+		return fmt.Errorf("eventhub: endpoint(%s) not registered", letter.AppID)
+	}
+
+	msg, err := ep.decode(letter.Payload)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = ep.dispatch(ctx, msg)
+
+	return err
+}
+
+// Replay 重新分发死信存储中的全部死信：成功的将被删除，失败的将更新失败原因与次数；返回重新分发成功的数量
+func (h *Hub) Replay(ctx context.Context) (int, error) {
+	if h.deadletter == nil {
+		return 0, errors.New("eventhub: dead-letter store not configured")
+	}
+
+	letters, err := h.deadletter.List(ctx)
+
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+
+	for _, letter := range letters {
+		if err = h.Redispatch(ctx, letter); err != nil {
+			letter.Reason = err.Error()
+			letter.Attempts++
+
+			if err = h.deadletter.Save(ctx, letter); err != nil {
+				return count, err
+			}
+
+			continue
+		}
+
+		if err = h.deadletter.Delete(ctx, letter.ID); err != nil {
+			return count, err
+		}
+
+		count++
+	}
+
+	return count, nil
+}
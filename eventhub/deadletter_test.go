@@ -0,0 +1,130 @@
+package eventhub
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/corp"
+	"github.com/shenghui0779/gochat/event"
+	"github.com/shenghui0779/gochat/minip"
+)
+
+func TestDeadLetter(t *testing.T) {
+	store := NewMemDeadLetterStore()
+	hub := New(WithDeadLetterStore(store))
+
+	down := true
+	received := make([]string, 0)
+
+	hub.Minip(minip.New("MINIP_APPID", "APPSECRET", minip.WithServerConfig(testToken, testAESKey)), NewMinipRouter().Message(event.MsgImage, func(ctx context.Context, mp *minip.Minip, msg interface{}) error {
+		if down {
+			return errors.New("downstream unavailable")
+		}
+
+		received = append(received, msg.(*minip.ImageMessage).MediaID)
+
+		return nil
+	}))
+
+	hub.Corp(corp.New("CORPID", corp.WithServerConfig(testToken, testAESKey)), func(ctx context.Context, cp *corp.Corp, msg corp.Message) (event.Reply, error) {
+		if down {
+			return nil, errors.New("downstream unavailable")
+		}
+
+		if text, ok := msg.(*corp.TextMessage); ok {
+			received = append(received, text.Content)
+		}
+
+		return nil, nil
+	})
+
+	// 业务处理失败，保存死信并正常应答
+	w := httptest.NewRecorder()
+	hub.ServeHTTP(w, mockRequest(t, "/callback", "MINIP_APPID", `<xml><ToUserName><![CDATA[gh_minip]]></ToUserName><Encrypt><![CDATA[%s]]></Encrypt></xml>`, `<xml><ToUserName><![CDATA[gh_minip]]></ToUserName><FromUserName><![CDATA[OPENID]]></FromUserName><CreateTime>1606902602</CreateTime><MsgType><![CDATA[image]]></MsgType><MediaId><![CDATA[MEDIA_ID]]></MediaId></xml>`))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "success", w.Body.String())
+
+	w = httptest.NewRecorder()
+	hub.ServeHTTP(w, mockRequest(t, "/callback", "CORPID", `<xml><ToUserName><![CDATA[CORPID]]></ToUserName><AgentID><![CDATA[1]]></AgentID><Encrypt><![CDATA[%s]]></Encrypt></xml>`, `<xml><ToUserName><![CDATA[CORPID]]></ToUserName><FromUserName><![CDATA[USERID]]></FromUserName><CreateTime>1348831860</CreateTime><MsgType><![CDATA[text]]></MsgType><Content><![CDATA[this is a test]]></Content><MsgId>1234567890123456</MsgId><AgentID>1</AgentID></xml>`))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "", w.Body.String())
+
+	letters, err := store.List(context.TODO())
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(letters))
+
+	appids := []string{letters[0].AppID, letters[1].AppID}
+
+	assert.ElementsMatch(t, []string{"MINIP_APPID", "CORPID"}, appids)
+	assert.Equal(t, "downstream unavailable", letters[0].Reason)
+
+	// 下游仍不可用
+	count, err := hub.Replay(context.TODO())
+
+	assert.Nil(t, err)
+	assert.Equal(t, 0, count)
+
+	letters, err = store.List(context.TODO())
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(letters))
+	assert.Equal(t, 1, letters[0].Attempts)
+
+	// 下游恢复
+	down = false
+
+	count, err = hub.Replay(context.TODO())
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+	assert.ElementsMatch(t, []string{"MEDIA_ID", "this is a test"}, received)
+
+	letters, err = store.List(context.TODO())
+
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(letters))
+}
+
+func TestRedispatchNotRegistered(t *testing.T) {
+	hub := New()
+
+	err := hub.Redispatch(context.TODO(), &DeadLetter{AppID: "UNKNOWN_APPID"})
+
+	assert.NotNil(t, err)
+
+	_, err = hub.Replay(context.TODO())
+
+	assert.NotNil(t, err)
+}
+
+func TestDeadLetterKeepsRawPayload(t *testing.T) {
+	store := NewMemDeadLetterStore()
+	hub := New(WithDeadLetterStore(store))
+
+	hub.Corp(corp.New("CORPID", corp.WithServerConfig(testToken, testAESKey)), func(ctx context.Context, cp *corp.Corp, msg corp.Message) (event.Reply, error) {
+		return nil, errors.New("downstream unavailable")
+	})
+
+	plain := `<xml><ToUserName><![CDATA[CORPID]]></ToUserName><FromUserName><![CDATA[sys]]></FromUserName><CreateTime>1403610513</CreateTime><MsgType><![CDATA[event]]></MsgType><Event><![CDATA[change_external_contact]]></Event><ChangeType><![CDATA[add_external_contact]]></ChangeType><UserID><![CDATA[zhangsan]]></UserID><ExternalUserID><![CDATA[woAJ2GCAAAXtWyujaWJHDDGi0mACAAAA]]></ExternalUserID><State><![CDATA[teststate]]></State><WelcomeCode><![CDATA[WELCOMECODE]]></WelcomeCode></xml>`
+
+	w := httptest.NewRecorder()
+	hub.ServeHTTP(w, mockRequest(t, "/callback", "CORPID", `<xml><ToUserName><![CDATA[CORPID]]></ToUserName><AgentID><![CDATA[1]]></AgentID><Encrypt><![CDATA[%s]]></Encrypt></xml>`, plain))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	letters, err := store.List(context.TODO())
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(letters))
+
+	// 死信保存解密后的原始报文，类型化消息未定义的字段不丢失
+	assert.Equal(t, plain, string(letters[0].Payload))
+}
@@ -0,0 +1,511 @@
+package eventhub
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"sync"
+
+	"github.com/shenghui0779/gochat/corp"
+	"github.com/shenghui0779/gochat/event"
+	"github.com/shenghui0779/gochat/minip"
+	"github.com/shenghui0779/gochat/offia"
+	"github.com/shenghui0779/gochat/oplatform"
+)
+
+// ErrNoEndpoint 未找到与回调请求匹配的应用（签名或解密均未通过）
+var ErrNoEndpoint = errors.New("eventhub: no endpoint matched")
+
+// ErrPlaintextMode 公众号/小程序为明文模式（未配置消息加解密密钥），统一回调仅支持安全模式
+var ErrPlaintextMode = errors.New("eventhub: plaintext mode is not supported, use safe mode (forgotten configure aeskey?)")
+
+// ErrJSONFormat 小程序消息推送为 JSON 格式，统一回调仅支持 XML 格式
+var ErrJSONFormat = errors.New("eventhub: JSON format is not supported, use XML format")
+
+// OffiaHandler 处理公众号消息事件，msg 为 offia.ParseMessage 返回的具体类型（如：*offia.TextMessage、*offia.ClickEvent）；
+// 返回的 reply 不为 nil 时将被动回复给用户
+type OffiaHandler func(ctx context.Context, oa *offia.Offia, msg interface{}) (event.Reply, error)
+
+// MinipHandler 处理小程序消息事件，msg 为 minip.ParseMessage 返回的具体类型（如：*minip.TextMessage、*minip.MediaCheckEvent）
+type MinipHandler func(ctx context.Context, mp *minip.Minip, msg interface{}) error
+
+// CorpHandler 处理企业微信回调消息，返回的 reply 不为 nil 时将被动回复给成员
+type CorpHandler func(ctx context.Context, cp *corp.Corp, msg corp.Message) (event.Reply, error)
+
+// OplatformHandler 处理第三方平台推送，msg 为 *oplatform.ComponentEvent 或 oplatform.ParseMessage 返回的授权方消息；
+// 授权方消息返回的 reply 不为 nil 时将代授权方被动回复
+type OplatformHandler func(ctx context.Context, op *oplatform.Oplatform, msg interface{}) (event.Reply, error)
+
+// envelope 加密消息外层报文
+type envelope struct {
+	XMLName xml.Name `xml:"xml"`
+	AppID   string   `xml:"AppId"` // 仅第三方平台授权事件
+	Encrypt string   `xml:"Encrypt"`
+}
+
+type endpoint interface {
+	// id returns appid/corpid
+	id() string
+
+	// verifyURL 验证服务器URL（GET请求），不匹配时 ok 为 false
+	verifyURL(query url.Values) (echo string, ok bool)
+
+	// decrypt 验证签名并解密消息（POST请求），raw 为解密后的原始报文（用于死信存储）；签名或解密不通过时 ok 为 false
+	decrypt(ctx context.Context, query url.Values, env *envelope, body []byte) (msg interface{}, raw []byte, ok bool, err error)
+
+	// dispatch 调用业务处理，返回应答报文
+	dispatch(ctx context.Context, msg interface{}) ([]byte, error)
+
+	// ack 无需回复时的应答报文
+	ack() []byte
+
+	// decode 将 XML 报文解析为消息（用于死信重新分发）
+	decode(b []byte) (interface{}, error)
+}
+
+// Hub 多产品统一回调入口，可同时接收公众号、小程序、企业微信、第三方平台的消息与事件推送
+//
+// 来源识别顺序：
+// 1. URL 参数 appid 或 URL 路径的最后一段（如：/callback/wx1234567890）与已注册的 appid/corpid 匹配时，仅由该应用处理；
+// 2. 否则按注册顺序依次校验签名并解密，首个通过的应用处理该请求。
+//
+// 限制：仅支持安全模式（加密）的 XML 格式推送；明文模式的公众号/小程序注册时返回 ErrPlaintextMode，
+// 数据格式为 JSON 的小程序推送返回 400（ErrJSONFormat），请在小程序后台将数据格式设置为 XML。
+type Hub struct {
+	endpoints  []endpoint
+	deadletter DeadLetterStore
+	mutex      sync.RWMutex
+}
+
+// Option 统一回调配置项
+type Option func(h *Hub)
+
+// WithDeadLetterStore 设置死信存储；业务处理失败时，解密后的消息将被保存并正常应答微信，之后可通过 Replay 重新分发
+func WithDeadLetterStore(store DeadLetterStore) Option {
+	return func(h *Hub) {
+		h.deadletter = store
+	}
+}
+
+// New returns new event hub
+func New(options ...Option) *Hub {
+	h := new(Hub)
+
+	for _, f := range options {
+		f(h)
+	}
+
+	return h
+}
+
+func (h *Hub) register(ep endpoint) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.endpoints = append(h.endpoints, ep)
+}
+
+// Offia 注册公众号及其消息事件处理器，明文模式返回 ErrPlaintextMode
+func (h *Hub) Offia(oa *offia.Offia, router *OffiaRouter) error {
+	if !oa.SafeMode() {
+		return ErrPlaintextMode
+	}
+
+	h.register(&offiaEndpoint{oa: oa, router: router})
+
+	return nil
+}
+
+// Minip 注册小程序及其消息事件处理器（消息推送数据格式需为 XML），明文模式返回 ErrPlaintextMode
+func (h *Hub) Minip(mp *minip.Minip, router *MinipRouter) error {
+	if !mp.SafeMode() {
+		return ErrPlaintextMode
+	}
+
+	h.register(&minipEndpoint{mp: mp, router: router})
+
+	return nil
+}
+
+// Corp 注册企业微信
+func (h *Hub) Corp(cp *corp.Corp, handler CorpHandler) {
+	h.register(&corpEndpoint{cp: cp, handler: handler})
+}
+
+// Oplatform 注册第三方平台（授权事件与代授权方接收的消息均由此处理）
+func (h *Hub) Oplatform(op *oplatform.Oplatform, handler OplatformHandler) {
+	h.register(&oplatformEndpoint{op: op, handler: handler})
+}
+
+func (h *Hub) lookup(appid string) (endpoint, bool) {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	for _, ep := range h.endpoints {
+		if ep.id() == appid {
+			return ep, true
+		}
+	}
+
+	return nil, false
+}
+
+func (h *Hub) candidates(r *http.Request) []endpoint {
+	hint := r.URL.Query().Get("appid")
+
+	if len(hint) == 0 {
+		hint = path.Base(r.URL.Path)
+	}
+
+	if ep, ok := h.lookup(hint); ok {
+		return []endpoint{ep}
+	}
+
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	endpoints := make([]endpoint, len(h.endpoints))
+	copy(endpoints, h.endpoints)
+
+	return endpoints
+}
+
+// ServeHTTP implements http.Handler
+func (h *Hub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	if r.Method == http.MethodGet {
+		for _, ep := range h.candidates(r) {
+			if echo, ok := ep.verifyURL(query); ok {
+				w.Write([]byte(echo))
+
+				return
+			}
+		}
+
+		http.Error(w, ErrNoEndpoint.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	if b := bytes.TrimSpace(body); len(b) != 0 && b[0] == '{' {
+		http.Error(w, ErrJSONFormat.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	env := new(envelope)
+
+	if err = xml.Unmarshal(body, env); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	for _, ep := range h.candidates(r) {
+		msg, raw, ok, err := ep.decrypt(r.Context(), query, env, body)
+
+		if !ok {
+			continue
+		}
+
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		resp, err := ep.dispatch(r.Context(), msg)
+
+		if err != nil {
+			if h.deadletter == nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+
+				return
+			}
+
+			// 保存死信后正常应答，避免微信重试
+			if serr := h.bury(r.Context(), ep, raw, err); serr != nil {
+				http.Error(w, serr.Error(), http.StatusInternalServerError)
+
+				return
+			}
+
+			resp = ep.ack()
+		}
+
+		if len(resp) != 0 && resp[0] == '<' {
+			w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+		}
+
+		w.Write(resp)
+
+		return
+	}
+
+	http.Error(w, ErrNoEndpoint.Error(), http.StatusBadRequest)
+}
+
+var success = []byte("success")
+
+func marshalReply(msg *event.ReplyMessage, err error) ([]byte, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	return xml.Marshal(msg)
+}
+
+type offiaEndpoint struct {
+	oa     *offia.Offia
+	router *OffiaRouter
+}
+
+func (ep *offiaEndpoint) id() string {
+	return ep.oa.AppID()
+}
+
+func (ep *offiaEndpoint) verifyURL(query url.Values) (string, bool) {
+	if !ep.oa.VerifyEventSign(query.Get("signature"), query.Get("timestamp"), query.Get("nonce")) {
+		return "", false
+	}
+
+	return query.Get("echostr"), true
+}
+
+func (ep *offiaEndpoint) decrypt(ctx context.Context, query url.Values, env *envelope, body []byte) (interface{}, []byte, bool, error) {
+	if len(env.AppID) != 0 || !ep.oa.VerifyEventSign(query.Get("msg_signature"), query.Get("timestamp"), query.Get("nonce"), env.Encrypt) {
+		return nil, nil, false, nil
+	}
+
+	raw, err := ep.oa.DecryptEvent(env.Encrypt)
+
+	if err != nil {
+		return nil, nil, false, nil
+	}
+
+	msg, err := ep.decode(raw)
+
+	return msg, raw, true, err
+}
+
+func (ep *offiaEndpoint) dispatch(ctx context.Context, msg interface{}) ([]byte, error) {
+	reply, err := ep.router.Handle(ctx, ep.oa, msg)
+
+	if err != nil {
+		return nil, err
+	}
+
+	v, ok := msg.(interface {
+		Header() *offia.MessageHeader
+	})
+
+	if reply == nil || !ok {
+		return success, nil
+	}
+
+	return marshalReply(ep.oa.Reply(v.Header().FromUserName, reply))
+}
+
+func (ep *offiaEndpoint) ack() []byte {
+	return success
+}
+
+func (ep *offiaEndpoint) decode(b []byte) (interface{}, error) {
+	return offia.ParseMessage(b)
+}
+
+type minipEndpoint struct {
+	mp     *minip.Minip
+	router *MinipRouter
+}
+
+func (ep *minipEndpoint) id() string {
+	return ep.mp.AppID()
+}
+
+func (ep *minipEndpoint) verifyURL(query url.Values) (string, bool) {
+	if !ep.mp.VerifyEventSign(query.Get("signature"), query.Get("timestamp"), query.Get("nonce")) {
+		return "", false
+	}
+
+	return query.Get("echostr"), true
+}
+
+func (ep *minipEndpoint) decrypt(ctx context.Context, query url.Values, env *envelope, body []byte) (interface{}, []byte, bool, error) {
+	if len(env.AppID) != 0 || !ep.mp.VerifyEventSign(query.Get("msg_signature"), query.Get("timestamp"), query.Get("nonce"), env.Encrypt) {
+		return nil, nil, false, nil
+	}
+
+	raw, err := ep.mp.DecryptEvent(env.Encrypt)
+
+	if err != nil {
+		return nil, nil, false, nil
+	}
+
+	msg, err := ep.decode(raw)
+
+	return msg, raw, true, err
+}
+
+func (ep *minipEndpoint) dispatch(ctx context.Context, msg interface{}) ([]byte, error) {
+	if err := ep.router.Handle(ctx, ep.mp, msg); err != nil {
+		return nil, err
+	}
+
+	return success, nil
+}
+
+func (ep *minipEndpoint) ack() []byte {
+	return success
+}
+
+func (ep *minipEndpoint) decode(b []byte) (interface{}, error) {
+	return minip.ParseMessage(b)
+}
+
+type corpEndpoint struct {
+	cp      *corp.Corp
+	handler CorpHandler
+}
+
+func (ep *corpEndpoint) id() string {
+	return ep.cp.CorpID()
+}
+
+func (ep *corpEndpoint) verifyURL(query url.Values) (string, bool) {
+	echo, err := ep.cp.VerifyURL(query.Get("msg_signature"), query.Get("timestamp"), query.Get("nonce"), query.Get("echostr"))
+
+	if err != nil {
+		return "", false
+	}
+
+	return echo, true
+}
+
+func (ep *corpEndpoint) decrypt(ctx context.Context, query url.Values, env *envelope, body []byte) (interface{}, []byte, bool, error) {
+	if len(env.AppID) != 0 || !ep.cp.VerifyEventSign(query.Get("msg_signature"), query.Get("timestamp"), query.Get("nonce"), env.Encrypt) {
+		return nil, nil, false, nil
+	}
+
+	raw, err := ep.cp.DecryptEvent(env.Encrypt)
+
+	if err != nil {
+		return nil, nil, false, nil
+	}
+
+	msg, err := ep.decode(raw)
+
+	return msg, raw, true, err
+}
+
+func (ep *corpEndpoint) dispatch(ctx context.Context, msg interface{}) ([]byte, error) {
+	m := msg.(corp.Message)
+
+	reply, err := ep.handler(ctx, ep.cp, m)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if reply == nil {
+		return ep.ack(), nil
+	}
+
+	return marshalReply(ep.cp.Reply(m.Header().FromUserName, reply))
+}
+
+// ack 无需回复时返回空串
+func (ep *corpEndpoint) ack() []byte {
+	return []byte{}
+}
+
+func (ep *corpEndpoint) decode(b []byte) (interface{}, error) {
+	return corp.ParseMessage(b)
+}
+
+type oplatformEndpoint struct {
+	op      *oplatform.Oplatform
+	handler OplatformHandler
+}
+
+func (ep *oplatformEndpoint) id() string {
+	return ep.op.AppID()
+}
+
+// verifyURL 第三方平台无URL验证请求
+func (ep *oplatformEndpoint) verifyURL(query url.Values) (string, bool) {
+	return "", false
+}
+
+func (ep *oplatformEndpoint) decrypt(ctx context.Context, query url.Values, env *envelope, body []byte) (interface{}, []byte, bool, error) {
+	if len(env.AppID) != 0 && env.AppID != ep.op.AppID() {
+		return nil, nil, false, nil
+	}
+
+	if !ep.op.VerifyEventSign(query.Get("msg_signature"), query.Get("timestamp"), query.Get("nonce"), env.Encrypt) {
+		return nil, nil, false, nil
+	}
+
+	raw, err := ep.op.DecryptEvent(env.Encrypt)
+
+	if err != nil {
+		return nil, nil, false, nil
+	}
+
+	// 授权事件（收到 component_verify_ticket 时自动更新票据，收到 unauthorized 时自动删除授权方的刷新令牌）
+	if len(env.AppID) != 0 {
+		e, err := ep.op.HandleComponentEvent(ctx, raw)
+
+		return e, raw, true, err
+	}
+
+	// 代授权方接收的消息
+	msg, err := ep.decode(raw)
+
+	return msg, raw, true, err
+}
+
+func (ep *oplatformEndpoint) dispatch(ctx context.Context, msg interface{}) ([]byte, error) {
+	reply, err := ep.handler(ctx, ep.op, msg)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if reply == nil {
+		return success, nil
+	}
+
+	v, ok := msg.(interface {
+		Header() *oplatform.MessageHeader
+	})
+
+	// 授权事件无需回复
+	if !ok {
+		return success, nil
+	}
+
+	return marshalReply(ep.op.Reply(v.Header().ToUserName, v.Header().FromUserName, reply))
+}
+
+func (ep *oplatformEndpoint) ack() []byte {
+	return success
+}
+
+func (ep *oplatformEndpoint) decode(b []byte) (interface{}, error) {
+	return oplatform.ParseMessage(b)
+}
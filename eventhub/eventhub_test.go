@@ -0,0 +1,225 @@
+package eventhub
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/corp"
+	"github.com/shenghui0779/gochat/corp/message"
+	"github.com/shenghui0779/gochat/event"
+	"github.com/shenghui0779/gochat/minip"
+	"github.com/shenghui0779/gochat/offia"
+	"github.com/shenghui0779/gochat/oplatform"
+)
+
+const (
+	testToken  = "2faf43d6343a802b6073aae5b3f2f109"
+	testAESKey = "jxAko083VoJ3lcPXJWzcGJ0M1tFVLgdD6qAq57GJY1U"
+)
+
+func mockRequest(t *testing.T, target, receiveid, envelope, plain string) *http.Request {
+	cipher, err := event.Encrypt(receiveid, testAESKey, "1234567890123456", []byte(plain))
+
+	assert.Nil(t, err)
+
+	encrypt := base64.StdEncoding.EncodeToString(cipher)
+
+	query := url.Values{}
+
+	query.Set("msg_signature", event.SignWithSHA1(testToken, "1606902086", "1246833592", encrypt))
+	query.Set("timestamp", "1606902086")
+	query.Set("nonce", "1246833592")
+
+	return httptest.NewRequest(http.MethodPost, target+"?"+query.Encode(), strings.NewReader(fmt.Sprintf(envelope, encrypt)))
+}
+
+func decryptReply(t *testing.T, receiveid string, body []byte) string {
+	reply := new(event.ReplyMessage)
+
+	assert.Nil(t, xml.Unmarshal(body, reply))
+
+	b, err := event.Decrypt(receiveid, testAESKey, string(reply.Encrypt))
+
+	assert.Nil(t, err)
+
+	return string(b)
+}
+
+func mockHub() *Hub {
+	hub := New()
+
+	hub.Offia(offia.New("OFFIA_APPID", "APPSECRET", offia.WithOriginID("gh_offia"), offia.WithServerConfig(testToken, testAESKey)), NewOffiaRouter().Message(event.MsgText, func(ctx context.Context, oa *offia.Offia, msg interface{}) (event.Reply, error) {
+		return offia.ReplyText("offia: " + msg.(*offia.TextMessage).Content), nil
+	}))
+
+	hub.Minip(minip.New("MINIP_APPID", "APPSECRET", minip.WithServerConfig(testToken, testAESKey)), NewMinipRouter().Message(event.MsgImage, func(ctx context.Context, mp *minip.Minip, msg interface{}) error {
+		return errors.New("downstream unavailable")
+	}))
+
+	hub.Corp(corp.New("CORPID", corp.WithServerConfig(testToken, testAESKey)), func(ctx context.Context, cp *corp.Corp, msg corp.Message) (event.Reply, error) {
+		if text, ok := msg.(*corp.TextMessage); ok {
+			return message.ReplyText("corp: " + text.Content), nil
+		}
+
+		return nil, nil
+	})
+
+	hub.Oplatform(oplatform.New("COMPONENT_APPID", "COMPONENT_APPSECRET", oplatform.WithServerConfig(testToken, testAESKey)), func(ctx context.Context, op *oplatform.Oplatform, msg interface{}) (event.Reply, error) {
+		if text, ok := msg.(*oplatform.TextMessage); ok {
+			return oplatform.ReplyText("oplatform: " + text.Content), nil
+		}
+
+		return nil, nil
+	})
+
+	return hub
+}
+
+func TestHubVerifyURL(t *testing.T) {
+	hub := mockHub()
+
+	// 公众号/小程序
+	query := url.Values{}
+
+	query.Set("signature", event.SignWithSHA1(testToken, "1606902086", "1246833592"))
+	query.Set("timestamp", "1606902086")
+	query.Set("nonce", "1246833592")
+	query.Set("echostr", "ECHOSTR")
+
+	w := httptest.NewRecorder()
+	hub.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/callback?"+query.Encode(), nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "ECHOSTR", w.Body.String())
+
+	// 企业微信
+	cipher, err := event.Encrypt("CORPID", testAESKey, "1234567890123456", []byte("1616140317555161061"))
+
+	assert.Nil(t, err)
+
+	echostr := base64.StdEncoding.EncodeToString(cipher)
+
+	query = url.Values{}
+
+	query.Set("msg_signature", event.SignWithSHA1(testToken, "1606902086", "1246833592", echostr))
+	query.Set("timestamp", "1606902086")
+	query.Set("nonce", "1246833592")
+	query.Set("echostr", echostr)
+
+	w = httptest.NewRecorder()
+	hub.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/callback/CORPID?"+query.Encode(), nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "1616140317555161061", w.Body.String())
+}
+
+func TestHubServeOffia(t *testing.T) {
+	hub := mockHub()
+
+	w := httptest.NewRecorder()
+	hub.ServeHTTP(w, mockRequest(t, "/callback", "OFFIA_APPID", `<xml><ToUserName><![CDATA[gh_offia]]></ToUserName><Encrypt><![CDATA[%s]]></Encrypt></xml>`, `<xml><ToUserName><![CDATA[gh_offia]]></ToUserName><FromUserName><![CDATA[OPENID]]></FromUserName><CreateTime>1606902602</CreateTime><MsgType><![CDATA[text]]></MsgType><Content><![CDATA[ILoveGochat]]></Content><MsgId>10086</MsgId></xml>`))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	plain := decryptReply(t, "OFFIA_APPID", w.Body.Bytes())
+
+	assert.Contains(t, plain, "<ToUserName><![CDATA[OPENID]]></ToUserName>")
+	assert.Contains(t, plain, "<Content><![CDATA[offia: ILoveGochat]]></Content>")
+}
+
+func TestHubServeMinip(t *testing.T) {
+	hub := mockHub()
+
+	w := httptest.NewRecorder()
+	hub.ServeHTTP(w, mockRequest(t, "/callback/MINIP_APPID", "MINIP_APPID", `<xml><ToUserName><![CDATA[gh_minip]]></ToUserName><Encrypt><![CDATA[%s]]></Encrypt></xml>`, `<xml><ToUserName><![CDATA[gh_minip]]></ToUserName><FromUserName><![CDATA[OPENID]]></FromUserName><CreateTime>1606902602</CreateTime><MsgType><![CDATA[text]]></MsgType><Content><![CDATA[hello]]></Content></xml>`))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "success", w.Body.String())
+
+	// 业务处理失败
+	w = httptest.NewRecorder()
+	hub.ServeHTTP(w, mockRequest(t, "/callback", "MINIP_APPID", `<xml><ToUserName><![CDATA[gh_minip]]></ToUserName><Encrypt><![CDATA[%s]]></Encrypt></xml>`, `<xml><ToUserName><![CDATA[gh_minip]]></ToUserName><FromUserName><![CDATA[OPENID]]></FromUserName><CreateTime>1606902602</CreateTime><MsgType><![CDATA[image]]></MsgType><MediaId><![CDATA[MEDIA_ID]]></MediaId></xml>`))
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestHubServeCorp(t *testing.T) {
+	hub := mockHub()
+
+	w := httptest.NewRecorder()
+	hub.ServeHTTP(w, mockRequest(t, "/callback", "CORPID", `<xml><ToUserName><![CDATA[CORPID]]></ToUserName><AgentID><![CDATA[1]]></AgentID><Encrypt><![CDATA[%s]]></Encrypt></xml>`, `<xml><ToUserName><![CDATA[CORPID]]></ToUserName><FromUserName><![CDATA[USERID]]></FromUserName><CreateTime>1348831860</CreateTime><MsgType><![CDATA[text]]></MsgType><Content><![CDATA[this is a test]]></Content><MsgId>1234567890123456</MsgId><AgentID>1</AgentID></xml>`))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	plain := decryptReply(t, "CORPID", w.Body.Bytes())
+
+	assert.Contains(t, plain, "<ToUserName><![CDATA[USERID]]></ToUserName>")
+	assert.Contains(t, plain, "<Content><![CDATA[corp: this is a test]]></Content>")
+}
+
+func TestHubServeOplatform(t *testing.T) {
+	hub := mockHub()
+
+	// 授权事件
+	w := httptest.NewRecorder()
+	hub.ServeHTTP(w, mockRequest(t, "/callback", "COMPONENT_APPID", `<xml><AppId><![CDATA[COMPONENT_APPID]]></AppId><Encrypt><![CDATA[%s]]></Encrypt></xml>`, `<xml><AppId>COMPONENT_APPID</AppId><CreateTime>1413192605</CreateTime><InfoType>component_verify_ticket</InfoType><ComponentVerifyTicket>TICKET</ComponentVerifyTicket></xml>`))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "success", w.Body.String())
+
+	// 授权事件签名错误
+	r := mockRequest(t, "/callback", "COMPONENT_APPID", `<xml><AppId><![CDATA[COMPONENT_APPID]]></AppId><Encrypt><![CDATA[%s]]></Encrypt></xml>`, `<xml><AppId>COMPONENT_APPID</AppId><CreateTime>1413192605</CreateTime><InfoType>component_verify_ticket</InfoType><ComponentVerifyTicket>FORGED</ComponentVerifyTicket></xml>`)
+	r.URL.RawQuery = strings.Replace(r.URL.RawQuery, "msg_signature=", "msg_signature=x", 1)
+
+	w = httptest.NewRecorder()
+	hub.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// 代授权方接收的消息
+	w = httptest.NewRecorder()
+	hub.ServeHTTP(w, mockRequest(t, "/callback", "COMPONENT_APPID", `<xml><ToUserName><![CDATA[gh_authorizer]]></ToUserName><Encrypt><![CDATA[%s]]></Encrypt></xml>`, `<xml><ToUserName><![CDATA[gh_authorizer]]></ToUserName><FromUserName><![CDATA[OPENID]]></FromUserName><CreateTime>1606902602</CreateTime><MsgType><![CDATA[text]]></MsgType><Content><![CDATA[hi]]></Content><MsgId>10086</MsgId></xml>`))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	plain := decryptReply(t, "COMPONENT_APPID", w.Body.Bytes())
+
+	assert.Contains(t, plain, "<FromUserName><![CDATA[gh_authorizer]]></FromUserName>")
+	assert.Contains(t, plain, "<Content><![CDATA[oplatform: hi]]></Content>")
+}
+
+func TestHubNoEndpoint(t *testing.T) {
+	hub := mockHub()
+
+	w := httptest.NewRecorder()
+	hub.ServeHTTP(w, mockRequest(t, "/callback", "UNKNOWN_APPID", `<xml><ToUserName><![CDATA[gh_unknown]]></ToUserName><Encrypt><![CDATA[%s]]></Encrypt></xml>`, `<xml><MsgType><![CDATA[text]]></MsgType></xml>`))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestHubUnsupportedMode(t *testing.T) {
+	hub := New()
+
+	// 明文模式
+	assert.Equal(t, ErrPlaintextMode, hub.Offia(offia.New("OFFIA_APPID", "APPSECRET"), NewOffiaRouter()))
+	assert.Equal(t, ErrPlaintextMode, hub.Minip(minip.New("MINIP_APPID", "APPSECRET", minip.WithServerConfig(testToken, "")), NewMinipRouter()))
+
+	// JSON 格式推送
+	hub = mockHub()
+
+	w := httptest.NewRecorder()
+	hub.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/callback/MINIP_APPID", strings.NewReader(`{"ToUserName":"gh_minip","Encrypt":"ENCRYPT"}`)))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), ErrJSONFormat.Error())
+}
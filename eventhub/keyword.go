@@ -0,0 +1,103 @@
+package eventhub
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/shenghui0779/gochat/event"
+	"github.com/shenghui0779/gochat/offia"
+)
+
+type keywordRule struct {
+	priority int
+	match    func(content string) bool
+	handler  OffiaHandler
+}
+
+// KeywordEngine 公众号关键词自动回复，按优先级（数值越大越优先，相同时按注册顺序）匹配文本消息；
+// 注册为文本消息处理器：router.Message(event.MsgText, engine.Handle)
+type KeywordEngine struct {
+	rules    []*keywordRule
+	fallback OffiaHandler
+	mutex    sync.RWMutex
+}
+
+func (e *KeywordEngine) add(priority int, match func(content string) bool, handler OffiaHandler) *KeywordEngine {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.rules = append(e.rules, &keywordRule{
+		priority: priority,
+		match:    match,
+		handler:  handler,
+	})
+
+	sort.SliceStable(e.rules, func(i, j int) bool {
+		return e.rules[i].priority > e.rules[j].priority
+	})
+
+	return e
+}
+
+// Exact 注册全匹配关键词（忽略首尾空白）
+func (e *KeywordEngine) Exact(keyword string, priority int, handler OffiaHandler) *KeywordEngine {
+	return e.add(priority, func(content string) bool {
+		return content == keyword
+	}, handler)
+}
+
+// Contains 注册半匹配关键词（消息内容包含关键词）
+func (e *KeywordEngine) Contains(keyword string, priority int, handler OffiaHandler) *KeywordEngine {
+	return e.add(priority, func(content string) bool {
+		return strings.Contains(content, keyword)
+	}, handler)
+}
+
+// Regexp 注册正则匹配规则
+func (e *KeywordEngine) Regexp(re *regexp.Regexp, priority int, handler OffiaHandler) *KeywordEngine {
+	return e.add(priority, re.MatchString, handler)
+}
+
+// Fallback 注册未匹配任何规则（含非文本消息）时的处理器（默认：不回复）
+func (e *KeywordEngine) Fallback(handler OffiaHandler) *KeywordEngine {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.fallback = handler
+
+	return e
+}
+
+// Handle 匹配规则并回复
+func (e *KeywordEngine) Handle(ctx context.Context, oa *offia.Offia, msg interface{}) (event.Reply, error) {
+	if handler := e.match(msg); handler != nil {
+		return handler(ctx, oa, msg)
+	}
+
+	return nil, nil
+}
+
+func (e *KeywordEngine) match(msg interface{}) OffiaHandler {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	if text, ok := msg.(*offia.TextMessage); ok {
+		content := strings.TrimSpace(text.Content)
+
+		for _, rule := range e.rules {
+			if rule.match(content) {
+				return rule.handler
+			}
+		}
+	}
+
+	return e.fallback
+}
+
+// NewKeywordEngine returns new keyword auto-reply engine
+func NewKeywordEngine() *KeywordEngine {
+	return new(KeywordEngine)
+}
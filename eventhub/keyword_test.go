@@ -0,0 +1,47 @@
+package eventhub
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/offia"
+)
+
+func TestKeywordEngine(t *testing.T) {
+	oa := offia.New("OFFIA_APPID", "APPSECRET")
+
+	engine := NewKeywordEngine().
+		Contains("价格", 0, replyWith("price")).
+		Exact("帮助", 0, replyWith("help")).
+		Regexp(regexp.MustCompile(`^订单\d+$`), 10, replyWith("order")).
+		Contains("订单", 5, replyWith("orders")).
+		Fallback(replyWith("fallback"))
+
+	cases := []struct {
+		msg    interface{}
+		expect string
+	}{
+		{offiaMessage(t, `<MsgType>text</MsgType><Content><![CDATA[ 帮助 ]]></Content>`), "help"},
+		{offiaMessage(t, `<MsgType>text</MsgType><Content>需要帮助</Content>`), "fallback"},
+		{offiaMessage(t, `<MsgType>text</MsgType><Content>订单123</Content>`), "order"},
+		{offiaMessage(t, `<MsgType>text</MsgType><Content>订单价格</Content>`), "orders"},
+		{offiaMessage(t, `<MsgType>text</MsgType><Content>价格表</Content>`), "price"},
+		{offiaMessage(t, `<MsgType>image</MsgType><MediaId>MEDIA_ID</MediaId>`), "fallback"},
+	}
+
+	for _, c := range cases {
+		reply, err := engine.Handle(context.TODO(), oa, c.msg)
+
+		assert.Nil(t, err)
+		assert.Equal(t, offia.ReplyText(c.expect), reply)
+	}
+
+	// 未设置 fallback
+	reply, err := NewKeywordEngine().Handle(context.TODO(), oa, offiaMessage(t, `<MsgType>text</MsgType><Content>帮助</Content>`))
+
+	assert.Nil(t, err)
+	assert.Nil(t, reply)
+}
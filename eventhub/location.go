@@ -0,0 +1,140 @@
+package eventhub
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/shenghui0779/gochat/event"
+	"github.com/shenghui0779/gochat/offia"
+)
+
+// Location 用户地理位置
+type Location struct {
+	AppID      string  // 公众号原始ID（ToUserName）
+	OpenID     string  // 用户openid（FromUserName）
+	Latitude   float64 // 地理位置纬度
+	Longitude  float64 // 地理位置经度
+	Precision  float64 // 地理位置精度
+	CreateTime int64   // 上报时间
+}
+
+// LocationHandler 处理聚合后的用户地理位置
+type LocationHandler func(ctx context.Context, loc *Location)
+
+// LocationAggregator 公众号上报地理位置事件聚合器；
+// 开启「获取用户地理位置」后微信每5秒推送一次 LOCATION 事件，聚合器按用户去重（保留最新位置），
+// 每个周期最多向 LocationHandler 投递一次；
+// 注册为地理位置事件处理器：router.On(event.EventLocation, "", aggregator.Handle)，并通过 go aggregator.Run(ctx) 启动投递
+type LocationAggregator struct {
+	interval time.Duration
+	handler  LocationHandler
+	fallback OffiaHandler
+	pending  map[string]*Location
+	mutex    sync.Mutex
+}
+
+// Fallback 注册非地理位置消息与事件的处理器（默认：不回复）
+func (a *LocationAggregator) Fallback(handler OffiaHandler) *LocationAggregator {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.fallback = handler
+
+	return a
+}
+
+// Handle 记录地理位置事件（不回复），其它消息交由 Fallback 处理
+func (a *LocationAggregator) Handle(ctx context.Context, oa *offia.Offia, msg interface{}) (event.Reply, error) {
+	e, ok := msg.(*offia.LocationEvent)
+
+	if !ok {
+		a.mutex.Lock()
+		fallback := a.fallback
+		a.mutex.Unlock()
+
+		if fallback != nil {
+			return fallback(ctx, oa, msg)
+		}
+
+		return nil, nil
+	}
+
+	loc := &Location{
+		AppID:      e.ToUserName,
+		OpenID:     e.FromUserName,
+		Latitude:   e.Latitude,
+		Longitude:  e.Longitude,
+		Precision:  e.Precision,
+		CreateTime: e.CreateTime,
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	key := loc.AppID + ":" + loc.OpenID
+
+	// 乱序到达时保留上报时间最新的位置
+	if v, ok := a.pending[key]; !ok || v.CreateTime <= loc.CreateTime {
+		a.pending[key] = loc
+	}
+
+	return nil, nil
+}
+
+// Flush 立即投递当前周期内聚合的地理位置（按 appid、openid 排序）
+func (a *LocationAggregator) Flush(ctx context.Context) {
+	a.mutex.Lock()
+
+	locations := make([]*Location, 0, len(a.pending))
+
+	for _, v := range a.pending {
+		locations = append(locations, v)
+	}
+
+	a.pending = make(map[string]*Location)
+
+	a.mutex.Unlock()
+
+	sort.Slice(locations, func(i, j int) bool {
+		if locations[i].AppID != locations[j].AppID {
+			return locations[i].AppID < locations[j].AppID
+		}
+
+		return locations[i].OpenID < locations[j].OpenID
+	})
+
+	for _, loc := range locations {
+		a.handler(ctx, loc)
+	}
+}
+
+// Run 按周期投递聚合的地理位置，直至 ctx 结束
+func (a *LocationAggregator) Run(ctx context.Context) error {
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			a.Flush(ctx)
+		}
+	}
+}
+
+// NewLocationAggregator returns new location event aggregator, handler 每个周期（interval）对每个用户最多调用一次；
+// interval <= 0 时使用默认周期：1分钟
+func NewLocationAggregator(interval time.Duration, handler LocationHandler) *LocationAggregator {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	return &LocationAggregator{
+		interval: interval,
+		handler:  handler,
+		pending:  make(map[string]*Location),
+	}
+}
@@ -0,0 +1,127 @@
+package eventhub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/event"
+	"github.com/shenghui0779/gochat/offia"
+)
+
+func locationEvent(openid string, createTime int64, lat, lng float64) *offia.LocationEvent {
+	e := &offia.LocationEvent{
+		Latitude:  lat,
+		Longitude: lng,
+		Precision: 65,
+	}
+
+	e.ToUserName = "gh_123456"
+	e.FromUserName = openid
+	e.CreateTime = createTime
+	e.MsgType = event.MsgEvent
+	e.Event = "LOCATION"
+
+	return e
+}
+
+func TestLocationAggregator(t *testing.T) {
+	oa := offia.New("OFFIA_APPID", "APPSECRET")
+
+	var locations []*Location
+
+	aggregator := NewLocationAggregator(time.Minute, func(ctx context.Context, loc *Location) {
+		locations = append(locations, loc)
+	}).Fallback(replyWith("fallback"))
+
+	msgs := []*offia.LocationEvent{
+		locationEvent("OPENID_B", 1600000000, 23.137466, 113.352425),
+		locationEvent("OPENID_A", 1600000005, 23.137470, 113.352430),
+		locationEvent("OPENID_B", 1600000010, 23.137480, 113.352440),
+		locationEvent("OPENID_B", 1600000005, 23.137475, 113.352435), // 乱序到达
+	}
+
+	for _, msg := range msgs {
+		reply, err := aggregator.Handle(context.TODO(), oa, msg)
+
+		assert.Nil(t, err)
+		assert.Nil(t, reply)
+	}
+
+	reply, err := aggregator.Handle(context.TODO(), oa, offiaMessage(t, `<MsgType>text</MsgType><Content>你好</Content>`))
+
+	assert.Nil(t, err)
+	assert.Equal(t, offia.ReplyText("fallback"), reply)
+
+	aggregator.Flush(context.TODO())
+
+	assert.Equal(t, []*Location{
+		{
+			AppID:      "gh_123456",
+			OpenID:     "OPENID_A",
+			Latitude:   23.137470,
+			Longitude:  113.352430,
+			Precision:  65,
+			CreateTime: 1600000005,
+		},
+		{
+			AppID:      "gh_123456",
+			OpenID:     "OPENID_B",
+			Latitude:   23.137480,
+			Longitude:  113.352440,
+			Precision:  65,
+			CreateTime: 1600000010,
+		},
+	}, locations)
+
+	// 已投递的位置不重复投递
+	aggregator.Flush(context.TODO())
+
+	assert.Len(t, locations, 2)
+}
+
+func TestLocationAggregatorRun(t *testing.T) {
+	oa := offia.New("OFFIA_APPID", "APPSECRET")
+
+	delivered := make(chan *Location, 1)
+
+	aggregator := NewLocationAggregator(10*time.Millisecond, func(ctx context.Context, loc *Location) {
+		delivered <- loc
+	})
+
+	_, err := aggregator.Handle(context.TODO(), oa, locationEvent("OPENID", 1600000000, 23.137466, 113.352425))
+
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- aggregator.Run(ctx)
+	}()
+
+	select {
+	case loc := <-delivered:
+		assert.Equal(t, "OPENID", loc.OpenID)
+	case <-time.After(time.Second):
+		t.Fatal("location not delivered")
+	}
+
+	cancel()
+
+	assert.Equal(t, context.Canceled, <-done)
+}
+
+func TestLocationAggregatorInvalidInterval(t *testing.T) {
+	aggregator := NewLocationAggregator(0, func(ctx context.Context, loc *Location) {})
+
+	assert.Equal(t, time.Minute, aggregator.interval)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.Equal(t, context.Canceled, aggregator.Run(ctx))
+}
@@ -0,0 +1,227 @@
+package eventhub
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/shenghui0779/gochat/event"
+	"github.com/shenghui0779/gochat/minip"
+	"github.com/shenghui0779/gochat/offia"
+)
+
+type routeKey struct {
+	event event.EventType
+	key   string
+}
+
+// OffiaRouter 公众号消息事件处理器注册表，按消息类型（MsgType）或事件类型 + EventKey 匹配处理器（如：CLICK 事件的 MENU_FAQ 菜单）；
+// 通过 hub.Offia(oa, router) 注册
+type OffiaRouter struct {
+	messages map[event.MsgType]OffiaHandler
+	events   map[routeKey]OffiaHandler
+	fallback OffiaHandler
+	mutex    sync.RWMutex
+}
+
+// Message 注册消息处理器（如：event.MsgText 对应 *offia.TextMessage）
+func (r *OffiaRouter) Message(msgType event.MsgType, handler OffiaHandler) *OffiaRouter {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.messages[msgType] = handler
+
+	return r
+}
+
+// On 注册事件处理器（event 忽略大小写），key 为空时匹配该事件的所有 EventKey；
+// 精确匹配 EventKey 的处理器优先
+func (r *OffiaRouter) On(eventType event.EventType, key string, handler OffiaHandler) *OffiaRouter {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.events[routeKey{event: event.EventType(strings.ToLower(string(eventType))), key: key}] = handler
+
+	return r
+}
+
+// Click 注册点击菜单拉取消息事件的处理器
+func (r *OffiaRouter) Click(key string, handler OffiaHandler) *OffiaRouter {
+	return r.On(event.EventClick, key, handler)
+}
+
+// View 注册点击菜单跳转链接事件的处理器（EventKey 为跳转的URL）
+func (r *OffiaRouter) View(url string, handler OffiaHandler) *OffiaRouter {
+	return r.On(event.EventView, url, handler)
+}
+
+// Fallback 注册未匹配的消息与事件的处理器（默认：不回复）
+func (r *OffiaRouter) Fallback(handler OffiaHandler) *OffiaRouter {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.fallback = handler
+
+	return r
+}
+
+// Handle 路由消息至对应的处理器
+func (r *OffiaRouter) Handle(ctx context.Context, oa *offia.Offia, msg interface{}) (event.Reply, error) {
+	if handler := r.match(msg); handler != nil {
+		return handler(ctx, oa, msg)
+	}
+
+	return nil, nil
+}
+
+func (r *OffiaRouter) match(msg interface{}) OffiaHandler {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	v, ok := msg.(interface {
+		Header() *offia.MessageHeader
+	})
+
+	if !ok {
+		return r.fallback
+	}
+
+	if msgType := v.Header().MsgType; msgType != event.MsgEvent {
+		if handler, ok := r.messages[msgType]; ok {
+			return handler
+		}
+
+		return r.fallback
+	}
+
+	if e, ok := msg.(interface {
+		EventType() event.EventType
+	}); ok {
+		if handler, ok := r.events[routeKey{event: e.EventType(), key: offiaEventKey(msg)}]; ok {
+			return handler
+		}
+
+		if handler, ok := r.events[routeKey{event: e.EventType()}]; ok {
+			return handler
+		}
+	}
+
+	return r.fallback
+}
+
+// offiaEventKey returns the EventKey of offia event, empty if the event has no EventKey
+func offiaEventKey(msg interface{}) string {
+	switch v := msg.(type) {
+	case *offia.SubscribeEvent:
+		return v.EventKey
+	case *offia.ScanEvent:
+		return v.EventKey
+	case *offia.ClickEvent:
+		return v.EventKey
+	case *offia.ViewEvent:
+		return v.EventKey
+	case *offia.ScanCodeEvent:
+		return v.EventKey
+	case *offia.PicEvent:
+		return v.EventKey
+	case *offia.LocationSelectEvent:
+		return v.EventKey
+	}
+
+	return ""
+}
+
+// NewOffiaRouter returns new offia message and event router
+func NewOffiaRouter() *OffiaRouter {
+	return &OffiaRouter{
+		messages: make(map[event.MsgType]OffiaHandler),
+		events:   make(map[routeKey]OffiaHandler),
+	}
+}
+
+// MinipRouter 小程序消息事件处理器注册表，按消息类型（MsgType）或事件类型匹配处理器；
+// 通过 hub.Minip(mp, router) 注册
+type MinipRouter struct {
+	messages map[event.MsgType]MinipHandler
+	events   map[event.EventType]MinipHandler
+	fallback MinipHandler
+	mutex    sync.RWMutex
+}
+
+// Message 注册消息处理器（如：event.MsgText 对应 *minip.TextMessage）
+func (r *MinipRouter) Message(msgType event.MsgType, handler MinipHandler) *MinipRouter {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.messages[msgType] = handler
+
+	return r
+}
+
+// Event 注册事件处理器（event 忽略大小写，如：wxa_media_check 对应 *minip.MediaCheckEvent）
+func (r *MinipRouter) Event(eventType event.EventType, handler MinipHandler) *MinipRouter {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.events[event.EventType(strings.ToLower(string(eventType)))] = handler
+
+	return r
+}
+
+// Fallback 注册未匹配的消息与事件的处理器（默认：忽略）
+func (r *MinipRouter) Fallback(handler MinipHandler) *MinipRouter {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.fallback = handler
+
+	return r
+}
+
+// Handle 路由消息至对应的处理器
+func (r *MinipRouter) Handle(ctx context.Context, mp *minip.Minip, msg interface{}) error {
+	if handler := r.match(msg); handler != nil {
+		return handler(ctx, mp, msg)
+	}
+
+	return nil
+}
+
+func (r *MinipRouter) match(msg interface{}) MinipHandler {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	v, ok := msg.(interface {
+		Header() *minip.MessageHeader
+	})
+
+	if !ok {
+		return r.fallback
+	}
+
+	if msgType := v.Header().MsgType; msgType != event.MsgEvent {
+		if handler, ok := r.messages[msgType]; ok {
+			return handler
+		}
+
+		return r.fallback
+	}
+
+	if e, ok := msg.(interface {
+		EventType() event.EventType
+	}); ok {
+		if handler, ok := r.events[e.EventType()]; ok {
+			return handler
+		}
+	}
+
+	return r.fallback
+}
+
+// NewMinipRouter returns new minip message and event router
+func NewMinipRouter() *MinipRouter {
+	return &MinipRouter{
+		messages: make(map[event.MsgType]MinipHandler),
+		events:   make(map[event.EventType]MinipHandler),
+	}
+}
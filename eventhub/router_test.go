@@ -0,0 +1,114 @@
+package eventhub
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/event"
+	"github.com/shenghui0779/gochat/minip"
+	"github.com/shenghui0779/gochat/offia"
+)
+
+func replyWith(content string) OffiaHandler {
+	return func(ctx context.Context, oa *offia.Offia, msg interface{}) (event.Reply, error) {
+		return offia.ReplyText(content), nil
+	}
+}
+
+func offiaMessage(t *testing.T, fields string) interface{} {
+	msg, err := offia.ParseMessage([]byte("<xml><ToUserName><![CDATA[gh_123456]]></ToUserName><FromUserName><![CDATA[OPENID]]></FromUserName>" + fields + "</xml>"))
+
+	assert.Nil(t, err)
+
+	return msg
+}
+
+func TestOffiaRouter(t *testing.T) {
+	oa := offia.New("OFFIA_APPID", "APPSECRET")
+
+	router := NewOffiaRouter().
+		Message(event.MsgText, replyWith("text")).
+		Click("MENU_FAQ", replyWith("faq")).
+		On(event.EventClick, "", replyWith("click")).
+		On("SUBSCRIBE", "", replyWith("welcome")).
+		Fallback(replyWith("fallback"))
+
+	cases := []struct {
+		msg    interface{}
+		expect string
+	}{
+		{offiaMessage(t, `<MsgType>event</MsgType><Event>CLICK</Event><EventKey>MENU_FAQ</EventKey>`), "faq"},
+		{offiaMessage(t, `<MsgType>event</MsgType><Event>CLICK</Event><EventKey>MENU_OTHER</EventKey>`), "click"},
+		{offiaMessage(t, `<MsgType>event</MsgType><Event>subscribe</Event><EventKey>qrscene_123</EventKey>`), "welcome"},
+		{offiaMessage(t, `<MsgType>event</MsgType><Event>VIEW</Event><EventKey>https://example.com</EventKey>`), "fallback"},
+		{offiaMessage(t, `<MsgType>text</MsgType><Content>MENU_FAQ</Content>`), "text"},
+		{offiaMessage(t, `<MsgType>image</MsgType><MediaId>MEDIA_ID</MediaId>`), "fallback"},
+	}
+
+	for _, c := range cases {
+		reply, err := router.Handle(context.TODO(), oa, c.msg)
+
+		assert.Nil(t, err)
+		assert.Equal(t, offia.ReplyText(c.expect), reply)
+	}
+
+	// 未设置 fallback
+	reply, err := NewOffiaRouter().Handle(context.TODO(), oa, offiaMessage(t, `<MsgType>text</MsgType>`))
+
+	assert.Nil(t, err)
+	assert.Nil(t, reply)
+}
+
+func TestMinipRouter(t *testing.T) {
+	mp := minip.New("MINIP_APPID", "APPSECRET")
+
+	var matched string
+
+	handleWith := func(name string) MinipHandler {
+		return func(ctx context.Context, mp *minip.Minip, msg interface{}) error {
+			matched = name
+
+			return nil
+		}
+	}
+
+	router := NewMinipRouter().
+		Message(event.MsgText, handleWith("text")).
+		Event("WXA_MEDIA_CHECK", func(ctx context.Context, mp *minip.Minip, msg interface{}) error {
+			if e := msg.(*minip.MediaCheckEvent); e.TraceID != "TRACE_ID" {
+				return errors.New("unexpected trace_id")
+			}
+
+			matched = "media_check"
+
+			return nil
+		}).
+		Fallback(handleWith("fallback"))
+
+	cases := []struct {
+		body   string
+		expect string
+	}{
+		{`<xml><MsgType>text</MsgType><Content>hello</Content></xml>`, "text"},
+		{`<xml><MsgType>event</MsgType><Event>wxa_media_check</Event><trace_id>TRACE_ID</trace_id></xml>`, "media_check"},
+		{`<xml><MsgType>event</MsgType><Event>user_enter_tempsession</Event></xml>`, "fallback"},
+		{`<xml><MsgType>image</MsgType></xml>`, "fallback"},
+	}
+
+	for _, c := range cases {
+		msg, err := minip.ParseMessage([]byte(c.body))
+
+		assert.Nil(t, err)
+		assert.Nil(t, router.Handle(context.TODO(), mp, msg))
+		assert.Equal(t, c.expect, matched)
+	}
+
+	// 未设置 fallback
+	msg, err := minip.ParseMessage([]byte(`<xml><MsgType>image</MsgType></xml>`))
+
+	assert.Nil(t, err)
+	assert.Nil(t, NewMinipRouter().Handle(context.TODO(), mp, msg))
+}
@@ -0,0 +1,114 @@
+// errcodegen 根据微信全局返回码说明（JSON）生成返回码说明表
+//
+//	go run ../internal/errcodegen -schema errcodes.json -package wx -output errcode_gen.go
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"sort"
+	"text/template"
+)
+
+// Code 返回码说明
+type Code struct {
+	Code int64  `json:"code"`
+	ZH   string `json:"zh"` // 中文说明
+	EN   string `json:"en"` // 英文说明
+}
+
+// Schema 返回码定义
+type Schema struct {
+	Doc   string  `json:"doc"` // 全局返回码文档链接
+	Codes []*Code `json:"codes"`
+}
+
+type data struct {
+	Package    string
+	SchemaFile string
+	*Schema
+}
+
+var tpl = template.Must(template.New("errcode").Parse(`// Code generated by errcodegen from {{.SchemaFile}}. DO NOT EDIT.
+
+package {{.Package}}
+
+// errcodes 微信全局返回码说明
+{{- if .Doc}}
+// [参考]({{.Doc}})
+{{- end}}
+var errcodes = map[int64]*ErrCodeDesc{
+{{- range .Codes}}
+	{{.Code}}: {ZH: {{printf "%q" .ZH}}, EN: {{printf "%q" .EN}}},
+{{- end}}
+}
+`))
+
+// Generate 根据 schema 生成代码
+func Generate(pkg, schemaFile string) ([]byte, error) {
+	b, err := ioutil.ReadFile(schemaFile)
+
+	if err != nil {
+		return nil, err
+	}
+
+	schema := new(Schema)
+
+	if err = json.Unmarshal(b, schema); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", schemaFile, err)
+	}
+
+	seen := make(map[int64]struct{}, len(schema.Codes))
+
+	for _, v := range schema.Codes {
+		if _, ok := seen[v.Code]; ok {
+			return nil, fmt.Errorf("parse %s: duplicate code %d", schemaFile, v.Code)
+		}
+
+		seen[v.Code] = struct{}{}
+	}
+
+	sort.SliceStable(schema.Codes, func(i, j int) bool {
+		return schema.Codes[i].Code < schema.Codes[j].Code
+	})
+
+	var buf bytes.Buffer
+
+	if err = tpl.Execute(&buf, &data{
+		Package:    pkg,
+		SchemaFile: filepath.Base(schemaFile),
+		Schema:     schema,
+	}); err != nil {
+		return nil, err
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+func main() {
+	schema := flag.String("schema", "errcodes.json", "schema file")
+	pkg := flag.String("package", "", "package name")
+	output := flag.String("output", "errcode_gen.go", "output file")
+
+	flag.Parse()
+
+	if len(*pkg) == 0 {
+		log.Fatal("errcodegen: -package is required")
+	}
+
+	src, err := Generate(*pkg, *schema)
+
+	if err != nil {
+		log.Fatal("errcodegen: ", err)
+	}
+
+	if err = ioutil.WriteFile(*output, src, 0644); err != nil {
+		log.Fatal("errcodegen: ", err)
+	}
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// 确保生成的代码与 schema 保持一致（修改 errcodes.json 后请执行 go generate）
+func TestGenerate(t *testing.T) {
+	src, err := Generate("wx", "../../wx/errcodes.json")
+
+	assert.Nil(t, err)
+
+	b, err := ioutil.ReadFile("../../wx/errcode_gen.go")
+
+	assert.Nil(t, err)
+	assert.Equal(t, string(b), string(src), "wx/errcode_gen.go is out of date, run go generate")
+}
@@ -0,0 +1,248 @@
+// eventgen 根据声明式的 schema（JSON）生成消息与事件的结构体及解析方法
+//
+//	go run ../internal/eventgen -schema events.json -package offia -output event_gen.go
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// Field 字段定义
+type Field struct {
+	Name    string `json:"name"`    // Go 字段名
+	XML     string `json:"xml"`     // XML 标签（支持 a>b 路径），默认同 Name
+	Type    string `json:"type"`    // Go 类型：string、int、int64、float64、[]string 或 types 中定义的类型
+	Comment string `json:"comment"` // 字段说明
+}
+
+// Tag returns xml tag
+func (f *Field) Tag() string {
+	if len(f.XML) != 0 {
+		return f.XML
+	}
+
+	return f.Name
+}
+
+// Type 自定义类型（如：列表项）
+type Type struct {
+	Name    string   `json:"name"`
+	Comment string   `json:"comment"`
+	Fields  []*Field `json:"fields"`
+}
+
+// Message 普通消息（按 MsgType 匹配）
+type Message struct {
+	Name    string   `json:"name"`     // 生成的结构体为 {Name}Message
+	MsgType []string `json:"msg_type"` // 匹配的 MsgType
+	Comment string   `json:"comment"`
+	Fields  []*Field `json:"fields"`
+}
+
+// Event 事件推送（MsgType 为 event，按 Event 匹配，忽略大小写）
+type Event struct {
+	Name    string   `json:"name"`  // 生成的结构体为 {Name}Event
+	Event   []string `json:"event"` // 匹配的 Event
+	Comment string   `json:"comment"`
+	Fields  []*Field `json:"fields"`
+}
+
+// Matches returns the lower-cased event values
+func (e *Event) Matches() []string {
+	matches := make([]string, 0, len(e.Event))
+
+	for _, v := range e.Event {
+		matches = append(matches, strings.ToLower(v))
+	}
+
+	return matches
+}
+
+// Schema 消息与事件定义
+type Schema struct {
+	Doc      string     `json:"doc"` // 消息推送文档链接
+	Types    []*Type    `json:"types"`
+	Messages []*Message `json:"messages"`
+	Events   []*Event   `json:"events"`
+}
+
+type data struct {
+	Package    string
+	SchemaFile string
+	*Schema
+}
+
+var tpl = template.Must(template.New("event").Parse(`// Code generated by eventgen from {{.SchemaFile}}. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"encoding/xml"
+	"strings"
+
+	"github.com/shenghui0779/gochat/event"
+)
+
+// MessageHeader 消息公共字段
+{{- if .Doc}}
+// [参考]({{.Doc}})
+{{- end}}
+type MessageHeader struct {
+	XMLName      xml.Name      ` + "`xml:\"xml\"`" + `
+	ToUserName   string        ` + "`xml:\"ToUserName\"`" + `   // 接收方帐号（原始ID）
+	FromUserName string        ` + "`xml:\"FromUserName\"`" + ` // 发送方帐号（openid）
+	CreateTime   int64         ` + "`xml:\"CreateTime\"`" + `   // 消息创建时间
+	MsgType      event.MsgType ` + "`xml:\"MsgType\"`" + `      // 消息类型
+}
+
+// Header returns the message header
+func (h *MessageHeader) Header() *MessageHeader {
+	return h
+}
+
+// EventHeader 事件推送公共字段
+type EventHeader struct {
+	MessageHeader
+	Event string ` + "`xml:\"Event\"`" + ` // 事件类型（原始大小写）
+}
+
+// EventType returns the lower-cased event type
+func (h *EventHeader) EventType() event.EventType {
+	return event.EventType(strings.ToLower(h.Event))
+}
+{{range .Types}}
+// {{.Name}} {{.Comment}}
+type {{.Name}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.Type}} ` + "`xml:\"{{.Tag}}\"`" + `{{if .Comment}} // {{.Comment}}{{end}}
+{{- end}}
+}
+{{end}}
+{{- range .Messages}}
+// {{.Name}}Message {{.Comment}}
+type {{.Name}}Message struct {
+	MessageHeader
+{{- range .Fields}}
+	{{.Name}} {{.Type}} ` + "`xml:\"{{.Tag}}\"`" + `{{if .Comment}} // {{.Comment}}{{end}}
+{{- end}}
+}
+{{end}}
+{{- range .Events}}
+// {{.Name}}Event {{.Comment}}
+type {{.Name}}Event struct {
+	EventHeader
+{{- range .Fields}}
+	{{.Name}} {{.Type}} ` + "`xml:\"{{.Tag}}\"`" + `{{if .Comment}} // {{.Comment}}{{end}}
+{{- end}}
+}
+{{end}}
+// UnknownMessage 未定义类型的消息或事件，保留原始报文
+type UnknownMessage struct {
+	EventHeader
+	Raw []byte ` + "`xml:\"-\"`" + `
+}
+
+// ParseMessage 将解密后的消息解析为具体类型，返回值为以下类型之一：
+//
+{{- range .Messages}}
+//   - *{{.Name}}Message
+{{- end}}
+{{- range .Events}}
+//   - *{{.Name}}Event
+{{- end}}
+//   - *UnknownMessage
+func ParseMessage(b []byte) (interface{}, error) {
+	header := new(EventHeader)
+
+	if err := xml.Unmarshal(b, header); err != nil {
+		return nil, err
+	}
+
+	var msg interface{}
+
+	switch header.MsgType {
+{{- range .Messages}}
+	case {{range $i, $v := .MsgType}}{{if $i}}, {{end}}"{{$v}}"{{end}}:
+		msg = new({{.Name}}Message)
+{{- end}}
+	case event.MsgEvent:
+		switch strings.ToLower(header.Event) {
+{{- range .Events}}
+		case {{range $i, $v := .Matches}}{{if $i}}, {{end}}"{{$v}}"{{end}}:
+			msg = new({{.Name}}Event)
+{{- end}}
+		}
+	}
+
+	if msg == nil {
+		return &UnknownMessage{
+			EventHeader: *header,
+			Raw:         b,
+		}, nil
+	}
+
+	if err := xml.Unmarshal(b, msg); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+`))
+
+// Generate 根据 schema 生成代码
+func Generate(pkg, schemaFile string) ([]byte, error) {
+	b, err := ioutil.ReadFile(schemaFile)
+
+	if err != nil {
+		return nil, err
+	}
+
+	schema := new(Schema)
+
+	if err = json.Unmarshal(b, schema); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", schemaFile, err)
+	}
+
+	var buf bytes.Buffer
+
+	if err = tpl.Execute(&buf, &data{
+		Package:    pkg,
+		SchemaFile: filepath.Base(schemaFile),
+		Schema:     schema,
+	}); err != nil {
+		return nil, err
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+func main() {
+	schema := flag.String("schema", "events.json", "schema file")
+	pkg := flag.String("package", "", "package name")
+	output := flag.String("output", "event_gen.go", "output file")
+
+	flag.Parse()
+
+	if len(*pkg) == 0 {
+		log.Fatal("eventgen: -package is required")
+	}
+
+	src, err := Generate(*pkg, *schema)
+
+	if err != nil {
+		log.Fatal("eventgen: ", err)
+	}
+
+	if err = ioutil.WriteFile(*output, src, 0644); err != nil {
+		log.Fatal("eventgen: ", err)
+	}
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// 确保生成的代码与 schema 保持一致（修改 events.json 后请执行 go generate）
+func TestGenerate(t *testing.T) {
+	for _, pkg := range []string{"offia", "minip"} {
+		src, err := Generate(pkg, "../../"+pkg+"/events.json")
+
+		assert.Nil(t, err)
+
+		b, err := ioutil.ReadFile("../../" + pkg + "/event_gen.go")
+
+		assert.Nil(t, err)
+		assert.Equal(t, string(b), string(src), pkg+"/event_gen.go is out of date, run go generate")
+	}
+}
@@ -0,0 +1,226 @@
+package mch
+
+import (
+	"strconv"
+
+	"github.com/shenghui0779/gochat/urls"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// ParamsDepositMicroPay 押金支付（付款码）参数
+type ParamsDepositMicroPay struct {
+	// 必填参数
+	OutTradeNO     string // 商户系统内部的订单号，32个字符内、可包含字母
+	TotalFee       int    // 押金总金额，单位为分
+	SpbillCreateIP string // 调用微信支付API的机器IP
+	AuthCode       string // 扫码支付付款码，设备读取用户微信中的条码或者二维码信息
+	Body           string // 商品或支付单简要描述
+	// 选填参数
+	DeviceInfo string // 终端设备号(门店号或收银设备ID)
+	Detail     string // 商品名称明细列表
+	Attach     string // 附加数据，在查询API中原样返回
+	FeeType    string // 符合ISO 4217标准的三位字母代码，默认人民币：CNY
+	TimeStart  string // 订单生成时间，格式为yyyyMMddHHmmss
+	TimeExpire string // 订单失效时间，格式为yyyyMMddHHmmss
+	GoodsTag   string // 商品标记
+	LimitPay   string // no_credit--指定不能使用信用卡支付
+	SceneInfo  string // 该字段用于上报支付的场景信息
+}
+
+// DepositMicroPay 押金支付（付款码），适用于租借等线下场景【注意：押金接口仅支持 HMAC-SHA256 签名】
+func DepositMicroPay(appid string, params *ParamsDepositMicroPay, options ...SLOption) wx.Action {
+	return wx.NewPostAction(urls.MchDepositMicroPay,
+		wx.WithWXML(func(mchid, apikey, nonce string) (wx.WXML, error) {
+			m := wx.WXML{
+				"deposit":          "Y",
+				"appid":            appid,
+				"mch_id":           mchid,
+				"nonce_str":        nonce,
+				"sign_type":        string(wx.SignHMacSHA256),
+				"auth_code":        params.AuthCode,
+				"fee_type":         "CNY",
+				"body":             params.Body,
+				"out_trade_no":     params.OutTradeNO,
+				"total_fee":        strconv.Itoa(params.TotalFee),
+				"spbill_create_ip": params.SpbillCreateIP,
+			}
+
+			for _, f := range options {
+				f(m)
+			}
+
+			if len(params.DeviceInfo) != 0 {
+				m["device_info"] = params.DeviceInfo
+			}
+
+			if len(params.Detail) != 0 {
+				m["detail"] = params.Detail
+			}
+
+			if len(params.Attach) != 0 {
+				m["attach"] = params.Attach
+			}
+
+			if len(params.FeeType) != 0 {
+				m["fee_type"] = params.FeeType
+			}
+
+			if len(params.TimeStart) != 0 {
+				m["time_start"] = params.TimeStart
+			}
+
+			if len(params.TimeExpire) != 0 {
+				m["time_expire"] = params.TimeExpire
+			}
+
+			if len(params.GoodsTag) != 0 {
+				m["goods_tag"] = params.GoodsTag
+			}
+
+			if len(params.LimitPay) != 0 {
+				m["limit_pay"] = params.LimitPay
+			}
+
+			if len(params.SceneInfo) != 0 {
+				m["scene_info"] = params.SceneInfo
+			}
+
+			// 签名
+			m["sign"] = wx.SignHMacSHA256.Do(apikey, m, true)
+
+			return m, nil
+		}))
+}
+
+// ParamsDepositFacePay 押金支付（人脸）参数
+type ParamsDepositFacePay struct {
+	// 必填参数
+	OutTradeNO     string // 商户系统内部的订单号，32个字符内、可包含字母
+	TotalFee       int    // 押金总金额，单位为分
+	SpbillCreateIP string // 调用微信支付API的机器IP
+	OpenID         string // 用户在商户 appid 下的唯一标识
+	FaceCode       string // 人脸凭证，用于刷脸支付
+	Body           string // 商品或支付单简要描述
+	// 选填参数
+	DeviceInfo string // 终端设备号(门店号或收银设备ID)
+	Detail     string // 商品名称明细列表
+	Attach     string // 附加数据，在查询API中原样返回
+	FeeType    string // 符合ISO 4217标准的三位字母代码，默认人民币：CNY
+	TimeStart  string // 订单生成时间，格式为yyyyMMddHHmmss
+	TimeExpire string // 订单失效时间，格式为yyyyMMddHHmmss
+	GoodsTag   string // 商品标记
+	SceneInfo  string // 该字段用于上报支付的场景信息
+}
+
+// DepositFacePay 押金支付（人脸），适用于租借等线下场景【注意：押金接口仅支持 HMAC-SHA256 签名】
+func DepositFacePay(appid string, params *ParamsDepositFacePay, options ...SLOption) wx.Action {
+	return wx.NewPostAction(urls.MchDepositFacePay,
+		wx.WithWXML(func(mchid, apikey, nonce string) (wx.WXML, error) {
+			m := wx.WXML{
+				"deposit":          "Y",
+				"appid":            appid,
+				"mch_id":           mchid,
+				"nonce_str":        nonce,
+				"sign_type":        string(wx.SignHMacSHA256),
+				"openid":           params.OpenID,
+				"face_code":        params.FaceCode,
+				"fee_type":         "CNY",
+				"body":             params.Body,
+				"out_trade_no":     params.OutTradeNO,
+				"total_fee":        strconv.Itoa(params.TotalFee),
+				"spbill_create_ip": params.SpbillCreateIP,
+			}
+
+			for _, f := range options {
+				f(m)
+			}
+
+			if len(params.DeviceInfo) != 0 {
+				m["device_info"] = params.DeviceInfo
+			}
+
+			if len(params.Detail) != 0 {
+				m["detail"] = params.Detail
+			}
+
+			if len(params.Attach) != 0 {
+				m["attach"] = params.Attach
+			}
+
+			if len(params.FeeType) != 0 {
+				m["fee_type"] = params.FeeType
+			}
+
+			if len(params.TimeStart) != 0 {
+				m["time_start"] = params.TimeStart
+			}
+
+			if len(params.TimeExpire) != 0 {
+				m["time_expire"] = params.TimeExpire
+			}
+
+			if len(params.GoodsTag) != 0 {
+				m["goods_tag"] = params.GoodsTag
+			}
+
+			if len(params.SceneInfo) != 0 {
+				m["scene_info"] = params.SceneInfo
+			}
+
+			// 签名
+			m["sign"] = wx.SignHMacSHA256.Do(apikey, m, true)
+
+			return m, nil
+		}))
+}
+
+// QueryDepositByTransactionID 根据微信订单号查询押金订单
+func QueryDepositByTransactionID(appid, transactionID string, options ...SLOption) wx.Action {
+	return depositAction(urls.MchDepositOrderQuery, appid, "transaction_id", transactionID, false, options...)
+}
+
+// QueryDepositByOutTradeNO 根据商户订单号查询押金订单
+func QueryDepositByOutTradeNO(appid, outTradeNO string, options ...SLOption) wx.Action {
+	return depositAction(urls.MchDepositOrderQuery, appid, "out_trade_no", outTradeNO, false, options...)
+}
+
+// ReverseDepositByTransactionID 根据微信订单号撤销押金订单（需要证书）
+// 支付失败或结果不明时调用，用户支付失败则关闭订单，支付成功则将押金退还给用户；已消费的押金订单不可撤销
+func ReverseDepositByTransactionID(appid, transactionID string, options ...SLOption) wx.Action {
+	return depositAction(urls.MchDepositReverse, appid, "transaction_id", transactionID, true, options...)
+}
+
+// ReverseDepositByOutTradeNO 根据商户订单号撤销押金订单（需要证书）
+// 支付失败或结果不明时调用，用户支付失败则关闭订单，支付成功则将押金退还给用户；已消费的押金订单不可撤销
+func ReverseDepositByOutTradeNO(appid, outTradeNO string, options ...SLOption) wx.Action {
+	return depositAction(urls.MchDepositReverse, appid, "out_trade_no", outTradeNO, true, options...)
+}
+
+func depositAction(reqURL, appid, key, value string, tls bool, options ...SLOption) wx.Action {
+	actOptions := []wx.ActionOption{
+		wx.WithWXML(func(mchid, apikey, nonce string) (wx.WXML, error) {
+			m := wx.WXML{
+				"appid":     appid,
+				"mch_id":    mchid,
+				key:         value,
+				"nonce_str": nonce,
+				"sign_type": string(wx.SignHMacSHA256),
+			}
+
+			for _, f := range options {
+				f(m)
+			}
+
+			// 签名
+			m["sign"] = wx.SignHMacSHA256.Do(apikey, m, true)
+
+			return m, nil
+		}),
+	}
+
+	if tls {
+		actOptions = append(actOptions, wx.WithTLS())
+	}
+
+	return wx.NewPostAction(reqURL, actOptions...)
+}
@@ -0,0 +1,91 @@
+package mch
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/mock"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+func TestDepositMicroPay(t *testing.T) {
+	action := DepositMicroPay("wx2421b1c4370ec43b", &ParamsDepositMicroPay{
+		OutTradeNO:     "1415757673",
+		TotalFee:       1,
+		SpbillCreateIP: "14.17.22.52",
+		AuthCode:       "120061098828009406",
+		Body:           "押金",
+	})
+
+	m, err := action.WXML("10000100", "192006250b4c09247ec02edce69f6a2d", "5K8264ILTKCH16CQ2502SI8ZNMTM67VS")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Y", m["deposit"])
+	assert.Equal(t, "HMAC-SHA256", m["sign_type"])
+	assert.Equal(t, "120061098828009406", m["auth_code"])
+	assert.False(t, action.IsTLS())
+
+	sign := m["sign"]
+	delete(m, "sign")
+
+	assert.Equal(t, wx.SignHMacSHA256.Do("192006250b4c09247ec02edce69f6a2d", m, true), sign)
+}
+
+func TestDepositFacePay(t *testing.T) {
+	action := DepositFacePay("wx2421b1c4370ec43b", &ParamsDepositFacePay{
+		OutTradeNO:     "1415757673",
+		TotalFee:       1,
+		SpbillCreateIP: "14.17.22.52",
+		OpenID:         "oUpF8uMuAJO_M2pxb1Q9zNjWeS6o",
+		FaceCode:       "FACE_CODE",
+		Body:           "押金",
+	})
+
+	m, err := action.WXML("10000100", "192006250b4c09247ec02edce69f6a2d", "5K8264ILTKCH16CQ2502SI8ZNMTM67VS")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Y", m["deposit"])
+	assert.Equal(t, "FACE_CODE", m["face_code"])
+	assert.Equal(t, "oUpF8uMuAJO_M2pxb1Q9zNjWeS6o", m["openid"])
+}
+
+func TestQueryDepositByOutTradeNO(t *testing.T) {
+	resp := []byte(`<xml>
+	<return_code>SUCCESS</return_code>
+	<return_msg>OK</return_msg>
+	<mch_id>10000100</mch_id>
+	<result_code>SUCCESS</result_code>
+	<trade_state>SUCCESS</trade_state>
+	<out_trade_no>1415757673</out_trade_no>
+</xml>`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/deposit/orderquery", gomock.Any()).Return(resp, nil)
+
+	mch := New("10000100", "192006250b4c09247ec02edce69f6a2d", WithMockClient(client))
+
+	r, err := mch.Do(context.TODO(), QueryDepositByOutTradeNO("wx2421b1c4370ec43b", "1415757673"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, TradeStateSuccess, r["trade_state"])
+}
+
+func TestReverseDepositByOutTradeNO(t *testing.T) {
+	action := ReverseDepositByOutTradeNO("wx2421b1c4370ec43b", "1415757673")
+
+	m, err := action.WXML("10000100", "192006250b4c09247ec02edce69f6a2d", "5K8264ILTKCH16CQ2502SI8ZNMTM67VS")
+
+	assert.Nil(t, err)
+	assert.True(t, action.IsTLS())
+	assert.Equal(t, "/deposit/reverse", action.URL())
+	assert.Equal(t, "1415757673", m["out_trade_no"])
+	assert.Equal(t, "HMAC-SHA256", m["sign_type"])
+}
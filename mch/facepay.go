@@ -0,0 +1,174 @@
+package mch
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/shenghui0779/gochat/urls"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// ParamsFacePayAuthInfo 获取刷脸调用凭证参数
+type ParamsFacePayAuthInfo struct {
+	// 必填参数
+	StoreID   string // 门店编号，由商户定义，各门店唯一
+	StoreName string // 门店名称，由商户定义（可用于展示）
+	DeviceID  string // 终端设备编号，由商户定义
+	RawData   string // 初始化数据，由微信人脸SDK的 getWxpayfaceRawdata 接口获得
+	// 选填参数
+	Attach string // 附加字段，字段格式使用Json
+}
+
+// GetFacePayAuthInfo 获取刷脸调用凭证（authinfo），用于刷脸设备调用微信人脸SDK的 getWxpayfaceCode 接口
+// 凭证有效期见返回的 expires_in（单位：秒），过期前可重复使用
+func GetFacePayAuthInfo(appid string, params *ParamsFacePayAuthInfo, options ...SLOption) wx.Action {
+	return wx.NewPostAction(urls.MchFacePayAuthInfo,
+		wx.WithWXML(func(mchid, apikey, nonce string) (wx.WXML, error) {
+			m := wx.WXML{
+				"appid":      appid,
+				"mch_id":     mchid,
+				"store_id":   params.StoreID,
+				"store_name": params.StoreName,
+				"device_id":  params.DeviceID,
+				"rawdata":    params.RawData,
+				"now":        strconv.FormatInt(time.Now().Unix(), 10),
+				"version":    "1",
+				"sign_type":  string(wx.SignMD5),
+				"nonce_str":  nonce,
+			}
+
+			for _, f := range options {
+				f(m)
+			}
+
+			if len(params.Attach) != 0 {
+				m["attach"] = params.Attach
+			}
+
+			// 签名
+			m["sign"] = wx.SignMD5.Do(apikey, m, true)
+
+			return m, nil
+		}))
+}
+
+// ParamsFacePay 人脸支付参数
+type ParamsFacePay struct {
+	// 必填参数
+	OutTradeNO     string // 商户系统内部的订单号，32个字符内、可包含字母
+	TotalFee       int    // 订单总金额，单位为分
+	SpbillCreateIP string // 调用微信支付API的机器IP
+	OpenID         string // 用户在商户 appid 下的唯一标识，由微信人脸SDK的 getWxpayfaceCode 接口返回
+	FaceCode       string // 人脸凭证，由微信人脸SDK的 getWxpayfaceCode 接口返回
+	Body           string // 商品或支付单简要描述
+	// 选填参数
+	DeviceInfo string // 终端设备号(门店号或收银设备ID)
+	Detail     string // 商品名称明细列表
+	Attach     string // 附加数据，在查询API中原样返回
+	FeeType    string // 符合ISO 4217标准的三位字母代码，默认人民币：CNY
+	GoodsTag   string // 商品标记，代金券或立减优惠功能的参数
+	SceneInfo  string // 该字段用于上报支付的场景信息
+}
+
+// FacePay 人脸支付
+// 提交支付请求后微信会同步返回支付结果，当返回结果为“系统错误”或“USERPAYING”时，请调用「人脸支付订单查询API」查询支付结果；结果不明时调用「人脸支付撤销订单API」
+func FacePay(appid string, params *ParamsFacePay, options ...SLOption) wx.Action {
+	return wx.NewPostAction(urls.MchFacePay,
+		wx.WithWXML(func(mchid, apikey, nonce string) (wx.WXML, error) {
+			m := wx.WXML{
+				"appid":            appid,
+				"mch_id":           mchid,
+				"nonce_str":        nonce,
+				"openid":           params.OpenID,
+				"face_code":        params.FaceCode,
+				"fee_type":         "CNY",
+				"body":             params.Body,
+				"out_trade_no":     params.OutTradeNO,
+				"total_fee":        strconv.Itoa(params.TotalFee),
+				"spbill_create_ip": params.SpbillCreateIP,
+			}
+
+			for _, f := range options {
+				f(m)
+			}
+
+			if len(params.DeviceInfo) != 0 {
+				m["device_info"] = params.DeviceInfo
+			}
+
+			if len(params.Detail) != 0 {
+				m["detail"] = params.Detail
+			}
+
+			if len(params.Attach) != 0 {
+				m["attach"] = params.Attach
+			}
+
+			if len(params.FeeType) != 0 {
+				m["fee_type"] = params.FeeType
+			}
+
+			if len(params.GoodsTag) != 0 {
+				m["goods_tag"] = params.GoodsTag
+			}
+
+			if len(params.SceneInfo) != 0 {
+				m["scene_info"] = params.SceneInfo
+			}
+
+			// 签名
+			m["sign"] = wx.SignMD5.Do(apikey, m, true)
+
+			return m, nil
+		}))
+}
+
+// QueryFacePayByTransactionID 根据微信订单号查询人脸支付订单
+func QueryFacePayByTransactionID(appid, transactionID string, options ...SLOption) wx.Action {
+	return facePayAction(urls.MchFacePayQuery, appid, "transaction_id", transactionID, false, options...)
+}
+
+// QueryFacePayByOutTradeNO 根据商户订单号查询人脸支付订单
+func QueryFacePayByOutTradeNO(appid, outTradeNO string, options ...SLOption) wx.Action {
+	return facePayAction(urls.MchFacePayQuery, appid, "out_trade_no", outTradeNO, false, options...)
+}
+
+// ReverseFacePayByTransactionID 根据微信订单号撤销人脸支付订单（需要证书）
+// 支付失败或结果不明时调用，用户支付失败则关闭订单，支付成功则将扣款退还给用户；仅支持撤销当天的交易
+func ReverseFacePayByTransactionID(appid, transactionID string, options ...SLOption) wx.Action {
+	return facePayAction(urls.MchFacePayReverse, appid, "transaction_id", transactionID, true, options...)
+}
+
+// ReverseFacePayByOutTradeNO 根据商户订单号撤销人脸支付订单（需要证书）
+// 支付失败或结果不明时调用，用户支付失败则关闭订单，支付成功则将扣款退还给用户；仅支持撤销当天的交易
+func ReverseFacePayByOutTradeNO(appid, outTradeNO string, options ...SLOption) wx.Action {
+	return facePayAction(urls.MchFacePayReverse, appid, "out_trade_no", outTradeNO, true, options...)
+}
+
+func facePayAction(reqURL, appid, key, value string, tls bool, options ...SLOption) wx.Action {
+	actOptions := []wx.ActionOption{
+		wx.WithWXML(func(mchid, apikey, nonce string) (wx.WXML, error) {
+			m := wx.WXML{
+				"appid":     appid,
+				"mch_id":    mchid,
+				key:         value,
+				"nonce_str": nonce,
+			}
+
+			for _, f := range options {
+				f(m)
+			}
+
+			// 签名
+			m["sign"] = wx.SignMD5.Do(apikey, m, true)
+
+			return m, nil
+		}),
+	}
+
+	if tls {
+		actOptions = append(actOptions, wx.WithTLS())
+	}
+
+	return wx.NewPostAction(reqURL, actOptions...)
+}
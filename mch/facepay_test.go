@@ -0,0 +1,101 @@
+package mch
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/mock"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+func TestGetFacePayAuthInfo(t *testing.T) {
+	action := GetFacePayAuthInfo("wx2421b1c4370ec43b", &ParamsFacePayAuthInfo{
+		StoreID:   "1001",
+		StoreName: "测试门店",
+		DeviceID:  "DEVICE_01",
+		RawData:   "RAW_DATA",
+	}, WithSubMchID("1900000109"))
+
+	m, err := action.WXML("10000100", "192006250b4c09247ec02edce69f6a2d", "5K8264ILTKCH16CQ2502SI8ZNMTM67VS")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "https://payapp.weixin.qq.com/face/get_wxpayface_authinfo", action.URL())
+	assert.Equal(t, "1001", m["store_id"])
+	assert.Equal(t, "RAW_DATA", m["rawdata"])
+	assert.Equal(t, "1", m["version"])
+	assert.Equal(t, "MD5", m["sign_type"])
+	assert.Equal(t, "1900000109", m["sub_mch_id"])
+	assert.NotEmpty(t, m["now"])
+
+	sign := m["sign"]
+	delete(m, "sign")
+
+	assert.Equal(t, wx.SignMD5.Do("192006250b4c09247ec02edce69f6a2d", m, true), sign)
+}
+
+func TestFacePay(t *testing.T) {
+	action := FacePay("wx2421b1c4370ec43b", &ParamsFacePay{
+		OutTradeNO:     "1415757673",
+		TotalFee:       1,
+		SpbillCreateIP: "14.17.22.52",
+		OpenID:         "oUpF8uMuAJO_M2pxb1Q9zNjWeS6o",
+		FaceCode:       "FACE_CODE",
+		Body:           "刷脸支付测试",
+		DeviceInfo:     "1000",
+	})
+
+	m, err := action.WXML("10000100", "192006250b4c09247ec02edce69f6a2d", "5K8264ILTKCH16CQ2502SI8ZNMTM67VS")
+
+	assert.Nil(t, err)
+	assert.False(t, action.IsTLS())
+	assert.Equal(t, "/pay/facepay", action.URL())
+	assert.Equal(t, "FACE_CODE", m["face_code"])
+	assert.Equal(t, "oUpF8uMuAJO_M2pxb1Q9zNjWeS6o", m["openid"])
+	assert.Equal(t, "1000", m["device_info"])
+	assert.Equal(t, "CNY", m["fee_type"])
+
+	sign := m["sign"]
+	delete(m, "sign")
+
+	assert.Equal(t, wx.SignMD5.Do("192006250b4c09247ec02edce69f6a2d", m, true), sign)
+}
+
+func TestQueryFacePayByOutTradeNO(t *testing.T) {
+	resp := []byte(`<xml>
+	<return_code>SUCCESS</return_code>
+	<return_msg>OK</return_msg>
+	<mch_id>10000100</mch_id>
+	<result_code>SUCCESS</result_code>
+	<trade_state>SUCCESS</trade_state>
+	<out_trade_no>1415757673</out_trade_no>
+</xml>`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/pay/facepayquery", gomock.Any()).Return(resp, nil)
+
+	mch := New("10000100", "192006250b4c09247ec02edce69f6a2d", WithMockClient(client))
+
+	r, err := mch.Do(context.TODO(), QueryFacePayByOutTradeNO("wx2421b1c4370ec43b", "1415757673"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, TradeStateSuccess, r["trade_state"])
+}
+
+func TestReverseFacePayByOutTradeNO(t *testing.T) {
+	action := ReverseFacePayByOutTradeNO("wx2421b1c4370ec43b", "1415757673")
+
+	m, err := action.WXML("10000100", "192006250b4c09247ec02edce69f6a2d", "5K8264ILTKCH16CQ2502SI8ZNMTM67VS")
+
+	assert.Nil(t, err)
+	assert.True(t, action.IsTLS())
+	assert.Equal(t, "/secapi/pay/facepayreverse", action.URL())
+	assert.Equal(t, "1415757673", m["out_trade_no"])
+}
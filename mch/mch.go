@@ -20,11 +20,15 @@ import (
 
 // Mch 微信支付
 type Mch struct {
-	mchid  string
-	apikey string
-	nonce  func() string
-	client wx.HTTPClient
-	tlscli wx.HTTPClient
+	mchid   string
+	apikey  string
+	nonce   func() string
+	client  wx.HTTPClient
+	baseURL string
+	tlscli  wx.HTTPClient
+	dials   []wx.DialOption
+	headers map[string]string
+	appids  []string
 }
 
 // MchID returns mchid
@@ -37,6 +41,11 @@ func (mch *Mch) ApiKey() string {
 	return mch.apikey
 }
 
+// endpoint returns the request url with base url applied
+func (mch *Mch) endpoint(reqURL string) string {
+	return wx.JoinURL(mch.baseURL, reqURL)
+}
+
 // Do exec action
 func (mch *Mch) Do(ctx context.Context, action wx.Action, options ...wx.HTTPOption) (wx.WXML, error) {
 	m, err := action.WXML(mch.mchid, mch.apikey, mch.nonce())
@@ -46,7 +55,9 @@ func (mch *Mch) Do(ctx context.Context, action wx.Action, options ...wx.HTTPOpti
 	}
 
 	if len(action.Method()) == 0 {
-		if len(action.URL()) == 0 {
+		reqURL := action.URL()
+
+		if len(reqURL) == 0 {
 			return m, nil
 		}
 
@@ -56,7 +67,7 @@ func (mch *Mch) Do(ctx context.Context, action wx.Action, options ...wx.HTTPOpti
 			query.Add(k, v)
 		}
 
-		return wx.WXML{"entrust_url": fmt.Sprintf("%s?%s", action.URL(), query.Encode())}, nil
+		return wx.WXML{"entrust_url": fmt.Sprintf("%s?%s", mch.endpoint(reqURL), query.Encode())}, nil
 	}
 
 	body, err := wx.FormatMap2XML(m)
@@ -66,12 +77,14 @@ func (mch *Mch) Do(ctx context.Context, action wx.Action, options ...wx.HTTPOpti
 		return nil, err
 	}
 
+	options = wx.PrependHTTPHeaders(options, mch.headers, wx.ActionHeaders(action))
+
 	var resp []byte
 
 	if action.IsTLS() {
-		resp, err = mch.tlscli.Do(ctx, action.Method(), action.URL(), body, options...)
+		resp, err = mch.tlscli.Do(ctx, action.Method(), mch.endpoint(action.URL()), body, options...)
 	} else {
-		resp, err = mch.client.Do(ctx, action.Method(), action.URL(), body, options...)
+		resp, err = mch.client.Do(ctx, action.Method(), mch.endpoint(action.URL()), body, options...)
 	}
 
 	if err != nil {
@@ -169,7 +182,7 @@ func (mch *Mch) DownloadBill(ctx context.Context, appid, billDate, billType stri
 		return nil, err
 	}
 
-	resp, err := mch.client.Do(ctx, http.MethodPost, urls.MchDownloadBill, body, wx.WithHTTPClose())
+	resp, err := mch.client.Do(ctx, http.MethodPost, mch.endpoint(urls.MchDownloadBill), body, wx.PrependHTTPHeaders([]wx.HTTPOption{wx.WithHTTPClose()}, mch.headers)...)
 
 	if err != nil {
 		return nil, err
@@ -213,7 +226,7 @@ func (mch *Mch) DownloadFundFlow(ctx context.Context, appid string, billDate, ac
 		return nil, err
 	}
 
-	resp, err := mch.tlscli.Do(ctx, http.MethodPost, urls.MchDownloadFundFlow, body, wx.WithHTTPClose())
+	resp, err := mch.tlscli.Do(ctx, http.MethodPost, mch.endpoint(urls.MchDownloadFundFlow), body, wx.PrependHTTPHeaders([]wx.HTTPOption{wx.WithHTTPClose()}, mch.headers)...)
 
 	if err != nil {
 		return nil, err
@@ -263,7 +276,7 @@ func (mch *Mch) BatchQueryComment(ctx context.Context, appid, beginTime, endTime
 		return nil, err
 	}
 
-	resp, err := mch.tlscli.Do(ctx, http.MethodPost, urls.MchBatchQueryComment, body, wx.WithHTTPClose())
+	resp, err := mch.tlscli.Do(ctx, http.MethodPost, mch.endpoint(urls.MchBatchQueryComment), body, wx.PrependHTTPHeaders([]wx.HTTPOption{wx.WithHTTPClose()}, mch.headers)...)
 
 	if err != nil {
 		return nil, err
@@ -303,6 +316,10 @@ func (mch *Mch) VerifyWXMLResult(m wx.WXML) error {
 		}
 	}
 
+	if len(mch.appids) != 0 {
+		return wx.CheckAppID(m["appid"], mch.appids...)
+	}
+
 	return nil
 }
 
@@ -352,6 +369,14 @@ func WithClient(c *http.Client) Option {
 	}
 }
 
+// WithDialOptions 设置 HTTP Client 的连接配置（如：强制 IPv4、自定义 DNS 解析），同时作用于 TLS HTTP Client；
+// 在所有配置项之后作用于默认或 WithClient、WithTLSClient 设置的 Client，与配置顺序无关；Client 的 Transport 不是 *http.Transport 时不生效
+func WithDialOptions(options ...wx.DialOption) Option {
+	return func(mch *Mch) {
+		mch.dials = options
+	}
+}
+
 // WithTLSClient 设置 TLS HTTP Client（带证书）
 func WithTLSClient(c *http.Client) Option {
 	return func(mch *Mch) {
@@ -359,6 +384,27 @@ func WithTLSClient(c *http.Client) Option {
 	}
 }
 
+// WithHeader 设置请求 header（如：User-Agent、链路追踪 header），作用于所有请求
+func WithHeader(key, value string) Option {
+	return func(mch *Mch) {
+		mch.headers[key] = value
+	}
+}
+
+// WithAppIDCheck 校验返回及回调通知中的 appid 属于给定的 appid（商户绑定的公众号、小程序等）
+func WithAppIDCheck(appids ...string) Option {
+	return func(mch *Mch) {
+		mch.appids = appids
+	}
+}
+
+// WithBaseURL 设置接口域名（默认：https://api.mch.weixin.qq.com），可用于切换备用域名（如：https://api2.mch.weixin.qq.com）
+func WithBaseURL(base string) Option {
+	return func(mch *Mch) {
+		mch.baseURL = base
+	}
+}
+
 // WithMockClient 设置 Mock Client
 func WithMockClient(c wx.HTTPClient) Option {
 	return func(mch *Mch) {
@@ -402,13 +448,18 @@ func New(mchid, apikey string, options ...Option) *Mch {
 		nonce: func() string {
 			return wx.Nonce(16)
 		},
-		client: wx.NewDefaultClient(),
-		tlscli: wx.NewDefaultClient(),
+		client:  wx.NewDefaultClient(),
+		baseURL: wx.BaseURLMchAPI,
+		tlscli:  wx.NewDefaultClient(),
+		headers: make(map[string]string),
 	}
 
 	for _, f := range options {
 		f(mch)
 	}
 
+	mch.client = wx.ApplyDialOptions(mch.client, mch.dials...)
+	mch.tlscli = wx.ApplyDialOptions(mch.tlscli, mch.dials...)
+
 	return mch
 }
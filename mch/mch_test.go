@@ -3,6 +3,7 @@ package mch
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"log"
 	"net/http"
 	"testing"
@@ -214,6 +215,10 @@ func TestVerifyWXMLResult(t *testing.T) {
 	}
 
 	assert.Nil(t, mch.VerifyWXMLResult(m))
+
+	// appid 校验
+	assert.Nil(t, New("10000100", "192006250b4c09247ec02edce69f6a2d", WithAppIDCheck("wx2421b1c4370ec43b")).VerifyWXMLResult(m))
+	assert.True(t, errors.Is(New("10000100", "192006250b4c09247ec02edce69f6a2d", WithAppIDCheck("wx_other_appid")).VerifyWXMLResult(m), wx.ErrAppIDMismatch))
 }
 
 func TestDecryptWithAES256ECB(t *testing.T) {
@@ -1,7 +1,11 @@
 package mch
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/shenghui0779/gochat/urls"
 	"github.com/shenghui0779/gochat/wx"
@@ -152,3 +156,170 @@ func ReverseByOutTradeNO(appid, outTradeNO string, options ...SLOption) wx.Actio
 		}),
 	)
 }
+
+// ErrMicroPayReversed 付款码支付结果不明确，已撤销订单（用户支付失败则订单已关闭，支付成功则已退款）
+var ErrMicroPayReversed = errors.New("micropay reversed")
+
+type microPaySetting struct {
+	interval       time.Duration
+	timeout        time.Duration
+	reverses       int
+	reverseWait    time.Duration
+	reverseTimeout time.Duration
+}
+
+// MicroPayOption 付款码支付自动撤销配置项
+type MicroPayOption func(s *microPaySetting)
+
+// WithMicroPayInterval 设置查询订单的间隔时间（默认：10秒）
+func WithMicroPayInterval(d time.Duration) MicroPayOption {
+	return func(s *microPaySetting) {
+		s.interval = d
+	}
+}
+
+// WithMicroPayTimeout 设置等待用户支付的超时时间，超时后撤销订单（默认：45秒）
+func WithMicroPayTimeout(d time.Duration) MicroPayOption {
+	return func(s *microPaySetting) {
+		s.timeout = d
+	}
+}
+
+// WithMicroPayReverses 设置撤销订单的最大调用次数（默认：3次）
+func WithMicroPayReverses(n int) MicroPayOption {
+	return func(s *microPaySetting) {
+		if n > 0 {
+			s.reverses = n
+		}
+	}
+}
+
+// WithMicroPayReverseInterval 设置撤销订单返回 recall=Y 或调用失败时，再次撤销的间隔时间（默认：1秒）
+func WithMicroPayReverseInterval(d time.Duration) MicroPayOption {
+	return func(s *microPaySetting) {
+		s.reverseWait = d
+	}
+}
+
+// WithMicroPayReverseTimeout 设置撤销订单的超时时间（默认：30秒）
+// 撤销使用独立的 context，调用方 ctx 取消后仍会撤销订单
+func WithMicroPayReverseTimeout(d time.Duration) MicroPayOption {
+	return func(s *microPaySetting) {
+		if d > 0 {
+			s.reverseTimeout = d
+		}
+	}
+}
+
+// MicroPayWithReverse 付款码支付，并按微信建议的流程处理支付结果：
+// 用户支付中或结果不明时，间隔查询订单直到支付成功或超时；超时或查询无果时调用「撤销订单API」冲正并返回 ErrMicroPayReversed；
+// 支付成功返回订单查询（或支付）结果，明确失败返回业务错误；
+// ctx 取消时同样会撤销订单，避免用户已扣款而调用方视为支付失败
+func (mch *Mch) MicroPayWithReverse(ctx context.Context, appid string, params *ParamsMicroPay, slOptions []SLOption, options ...MicroPayOption) (wx.WXML, error) {
+	setting := &microPaySetting{
+		interval:       10 * time.Second,
+		timeout:        45 * time.Second,
+		reverses:       3,
+		reverseWait:    time.Second,
+		reverseTimeout: 30 * time.Second,
+	}
+
+	for _, f := range options {
+		f(setting)
+	}
+
+	m, err := mch.Do(ctx, MicroPay(appid, params, slOptions...))
+
+	if err == nil {
+		result, perr := parseOrderResult(m)
+
+		if perr != nil {
+			return nil, perr
+		}
+
+		if result.Success() {
+			return m, nil
+		}
+
+		// 明确失败（如：付款码错误、余额不足等），无需撤销
+		if code := result.ErrCode; code != UserPaying && code != SystemError && code != BankError {
+			return nil, result.Err()
+		}
+	}
+
+	deadline := time.Now().Add(setting.timeout)
+
+polling:
+	for time.Now().Before(deadline) {
+		select {
+		case <-ctx.Done():
+			break polling
+		case <-time.After(setting.interval):
+		}
+
+		if m, err = mch.Do(ctx, QueryOrderByOutTradeNO(appid, params.OutTradeNO, slOptions...)); err != nil {
+			continue
+		}
+
+		result, perr := parseOrderResult(m)
+
+		if perr != nil || !result.Success() {
+			continue
+		}
+
+		if result.TradeState.Paid() {
+			return m, nil
+		}
+
+		if !result.TradeState.Paying() {
+			break
+		}
+	}
+
+	// 撤销不受调用方 ctx 影响（ctx 可能已取消）
+	reverseCtx, cancel := context.WithTimeout(context.Background(), setting.reverseTimeout)
+	defer cancel()
+
+	return nil, mch.reverseMicroPay(reverseCtx, appid, params.OutTradeNO, setting, slOptions...)
+}
+
+func (mch *Mch) reverseMicroPay(ctx context.Context, appid, outTradeNO string, setting *microPaySetting, options ...SLOption) error {
+	var err error
+
+	for i := 0; i < setting.reverses; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("micropay reverse: %w", ctx.Err())
+			case <-time.After(setting.reverseWait):
+			}
+		}
+
+		var m wx.WXML
+
+		if m, err = mch.Do(ctx, ReverseByOutTradeNO(appid, outTradeNO, options...)); err != nil {
+			continue
+		}
+
+		result, perr := parseOrderResult(m)
+
+		if perr != nil {
+			err = perr
+
+			continue
+		}
+
+		if result.Success() {
+			return ErrMicroPayReversed
+		}
+
+		err = result.Err()
+
+		// recall=N 表示无需继续调用撤销
+		if result.Recall == "N" {
+			return fmt.Errorf("micropay reverse: %w", err)
+		}
+	}
+
+	return fmt.Errorf("micropay reverse: %w", err)
+}
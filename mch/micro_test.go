@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/shenghui0779/gochat/mock"
@@ -191,3 +192,66 @@ func TestReverseByOutTradeNO(t *testing.T) {
 		"recall":      "N",
 	}, r)
 }
+
+func TestMicroPayWithReverse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	mch := New("10000100", "192006250b4c09247ec02edce69f6a2d", WithMockClient(client))
+
+	params := &ParamsMicroPay{
+		OutTradeNO:     "1415757673",
+		TotalFee:       1,
+		SpbillCreateIP: "14.17.22.52",
+		AuthCode:       "120061098828009406",
+		Body:           "付款码支付测试",
+	}
+
+	// 用户支付中 -> 查询支付成功
+	gomock.InOrder(
+		client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/pay/micropay", gomock.Any()).Return(mockOrderResp(`<result_code>FAIL</result_code><err_code>USERPAYING</err_code><err_code_des>需要用户输入支付密码</err_code_des>`), nil),
+		client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/pay/orderquery", gomock.Any()).Return(mockOrderResp(`<result_code>SUCCESS</result_code><trade_state>USERPAYING</trade_state>`), nil),
+		client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/pay/orderquery", gomock.Any()).Return(mockOrderResp(`<result_code>SUCCESS</result_code><trade_state>SUCCESS</trade_state>`), nil),
+	)
+
+	r, err := mch.MicroPayWithReverse(context.TODO(), "wx2421b1c4370ec43b", params, nil, WithMicroPayInterval(time.Millisecond), WithMicroPayTimeout(time.Second))
+
+	assert.Nil(t, err)
+	assert.Equal(t, TradeStateSuccess, r["trade_state"])
+
+	// 用户支付中 -> 超时撤销（recall=Y 时重试）
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/pay/micropay", gomock.Any()).Return(mockOrderResp(`<result_code>FAIL</result_code><err_code>USERPAYING</err_code><err_code_des>需要用户输入支付密码</err_code_des>`), nil)
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/pay/orderquery", gomock.Any()).Return(mockOrderResp(`<result_code>SUCCESS</result_code><trade_state>USERPAYING</trade_state>`), nil).AnyTimes()
+	gomock.InOrder(
+		client.EXPECT().Do(gomock.Any(), http.MethodPost, "https://api.mch.weixin.qq.com/secapi/pay/reverse", gomock.Any()).Return(mockOrderResp(`<result_code>FAIL</result_code><err_code>SYSTEMERROR</err_code><err_code_des>系统错误</err_code_des><recall>Y</recall>`), nil),
+		client.EXPECT().Do(gomock.Any(), http.MethodPost, "https://api.mch.weixin.qq.com/secapi/pay/reverse", gomock.Any()).Return(mockOrderResp(`<result_code>SUCCESS</result_code><recall>N</recall>`), nil),
+	)
+
+	_, err = mch.MicroPayWithReverse(context.TODO(), "wx2421b1c4370ec43b", params, nil, WithMicroPayInterval(time.Millisecond), WithMicroPayTimeout(10*time.Millisecond), WithMicroPayReverseInterval(time.Millisecond))
+
+	assert.Equal(t, ErrMicroPayReversed, err)
+
+	// 用户支付中 -> ctx 取消后仍撤销
+	client.EXPECT().Do(gomock.Any(), http.MethodPost, "https://api.mch.weixin.qq.com/pay/micropay", gomock.Any()).Return(mockOrderResp(`<result_code>FAIL</result_code><err_code>USERPAYING</err_code><err_code_des>需要用户输入支付密码</err_code_des>`), nil)
+	client.EXPECT().Do(gomock.Any(), http.MethodPost, "https://api.mch.weixin.qq.com/secapi/pay/reverse", gomock.Any()).DoAndReturn(func(ctx context.Context, method, reqURL string, body []byte, options ...wx.HTTPOption) ([]byte, error) {
+		assert.Nil(t, ctx.Err())
+
+		return mockOrderResp(`<result_code>SUCCESS</result_code><recall>N</recall>`), nil
+	})
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+
+	_, err = mch.MicroPayWithReverse(ctx, "wx2421b1c4370ec43b", params, nil)
+
+	assert.Equal(t, ErrMicroPayReversed, err)
+
+	// 明确失败 -> 不撤销
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/pay/micropay", gomock.Any()).Return(mockOrderResp(`<result_code>FAIL</result_code><err_code>AUTHCODEEXPIRE</err_code><err_code_des>二维码已过期</err_code_des>`), nil)
+
+	_, err = mch.MicroPayWithReverse(context.TODO(), "wx2421b1c4370ec43b", params, nil)
+
+	assert.EqualError(t, err, "AUTHCODEEXPIRE|二维码已过期")
+}
@@ -0,0 +1,147 @@
+package mch
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// ParseNotify 解析并验证回调通知（支付结果通知、委托代扣签约/扣款结果通知等）
+// 注意：退款结果通知需通过 DecryptWithAES256ECB 解密 req_info
+func (mch *Mch) ParseNotify(body []byte) (wx.WXML, error) {
+	m, err := wx.ParseXML2Map(body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if m["return_code"] != ResultSuccess {
+		return nil, errors.New(m["return_msg"])
+	}
+
+	if len(m["sign"]) == 0 {
+		return nil, errors.New("sign is missing")
+	}
+
+	// 签名验证
+	if err = mch.VerifyWXMLResult(m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// PayNotify 支付结果通知
+type PayNotify struct {
+	AppID              string   `wxml:"appid"`                // 公众账号ID
+	MchID              string   `wxml:"mch_id"`               // 商户号
+	SubAppID           string   `wxml:"sub_appid"`            // 子商户公众账号ID（服务商模式）
+	SubMchID           string   `wxml:"sub_mch_id"`           // 子商户号（服务商模式）
+	DeviceInfo         string   `wxml:"device_info"`          // 设备号
+	ResultCode         string   `wxml:"result_code"`          // 业务结果
+	ErrCode            string   `wxml:"err_code"`             // 错误代码
+	ErrCodeDes         string   `wxml:"err_code_des"`         // 错误代码描述
+	OpenID             string   `wxml:"openid"`               // 用户标识
+	IsSubscribe        bool     `wxml:"is_subscribe"`         // 是否关注公众账号
+	SubOpenID          string   `wxml:"sub_openid"`           // 用户子标识（服务商模式）
+	SubIsSubscribe     bool     `wxml:"sub_is_subscribe"`     // 是否关注子公众账号（服务商模式）
+	TradeType          string   `wxml:"trade_type"`           // 交易类型
+	BankType           string   `wxml:"bank_type"`            // 付款银行
+	TotalFee           int      `wxml:"total_fee"`            // 订单金额（分）
+	SettlementTotalFee int      `wxml:"settlement_total_fee"` // 应结订单金额（分）
+	FeeType            string   `wxml:"fee_type"`             // 货币种类
+	CashFee            int      `wxml:"cash_fee"`             // 现金支付金额（分）
+	CashFeeType        string   `wxml:"cash_fee_type"`        // 现金支付货币类型
+	CouponFee          int      `wxml:"coupon_fee"`           // 总代金券金额（分）
+	CouponCount        int      `wxml:"coupon_count"`         // 代金券使用数量
+	CouponTypes        []string `wxml:"coupon_type_$n"`       // 代金券类型
+	CouponIDs          []string `wxml:"coupon_id_$n"`         // 代金券ID
+	CouponFees         []int    `wxml:"coupon_fee_$n"`        // 单个代金券支付金额（分）
+	TransactionID      string   `wxml:"transaction_id"`       // 微信支付订单号
+	OutTradeNO         string   `wxml:"out_trade_no"`         // 商户订单号
+	Attach             string   `wxml:"attach"`               // 商家数据包
+	TimeEnd            string   `wxml:"time_end"`             // 支付完成时间，格式：yyyyMMddHHmmss
+}
+
+// ParsePayNotify 解析并验证支付结果通知
+// [参考](https://pay.weixin.qq.com/wiki/doc/api/jsapi.php?chapter=9_7&index=8)
+func (mch *Mch) ParsePayNotify(body []byte) (*PayNotify, error) {
+	m, err := mch.ParseNotify(body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	notify := new(PayNotify)
+
+	if err = wx.UnmarshalWXML(m, notify); err != nil {
+		return nil, err
+	}
+
+	return notify, nil
+}
+
+// ContractNotify 委托代扣 - 签约/解约结果通知
+type ContractNotify struct {
+	ResultCode              string `wxml:"result_code"`               // 业务结果
+	ErrCode                 string `wxml:"err_code"`                  // 错误代码
+	ErrCodeDes              string `wxml:"err_code_des"`              // 错误代码描述
+	MchID                   string `wxml:"mch_id"`                    // 商户号
+	ContractCode            string `wxml:"contract_code"`             // 签约协议号
+	PlanID                  string `wxml:"plan_id"`                   // 模板id
+	OpenID                  string `wxml:"openid"`                    // 用户标识
+	ChangeType              string `wxml:"change_type"`               // 变更类型（ADD：签约，DELETE：解约）
+	OperateTime             string `wxml:"operate_time"`              // 操作时间，格式：yyyy-MM-dd HH:mm:ss
+	ContractID              string `wxml:"contract_id"`               // 委托代扣协议id
+	ContractExpiredTime     string `wxml:"contract_expired_time"`     // 协议到期时间（签约时返回）
+	ContractTerminationMode string `wxml:"contract_termination_mode"` // 协议解约方式（解约时返回）
+	RequestSerial           string `wxml:"request_serial"`            // 请求序列号
+}
+
+// ParseContractNotify 委托代扣 - 解析并验证签约/解约结果通知
+// [参考](https://pay.weixin.qq.com/wiki/doc/api/pap.php?chapter=18_17&index=5)
+func (mch *Mch) ParseContractNotify(body []byte) (*ContractNotify, error) {
+	m, err := mch.ParseNotify(body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if changeType := m["change_type"]; changeType != ContractAdd && changeType != ContractDelete {
+		return nil, fmt.Errorf("invalid change_type: %s", changeType)
+	}
+
+	notify := new(ContractNotify)
+
+	if err = wx.UnmarshalWXML(m, notify); err != nil {
+		return nil, err
+	}
+
+	return notify, nil
+}
+
+// PappayNotify 委托代扣 - 扣款结果通知
+type PappayNotify struct {
+	PayNotify
+	TradeState string `wxml:"trade_state"` // 交易状态
+	ContractID string `wxml:"contract_id"` // 委托代扣协议id
+}
+
+// ParsePappayNotify 委托代扣 - 解析并验证扣款结果通知（通知参数同支付结果通知，额外包含 trade_state、contract_id）
+// [参考](https://pay.weixin.qq.com/wiki/doc/api/pap.php?chapter=18_7&index=10)
+func (mch *Mch) ParsePappayNotify(body []byte) (*PappayNotify, error) {
+	m, err := mch.ParseNotify(body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	notify := new(PappayNotify)
+
+	if err = wx.UnmarshalWXML(m, notify); err != nil {
+		return nil, err
+	}
+
+	return notify, nil
+}
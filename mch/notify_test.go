@@ -0,0 +1,173 @@
+package mch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/wx"
+)
+
+func TestParseContractNotify(t *testing.T) {
+	mch := New("10000100", "192006250b4c09247ec02edce69f6a2d")
+
+	m := wx.WXML{
+		"return_code":               "SUCCESS",
+		"return_msg":                "OK",
+		"result_code":               "SUCCESS",
+		"mch_id":                    "10000100",
+		"contract_code":             "100000",
+		"plan_id":                   "12535",
+		"openid":                    "onqOjjrXT-776SpHnfexGm1_P7iE",
+		"change_type":               "DELETE",
+		"operate_time":              "2016-07-01 10:00:00",
+		"contract_id":               "Wx15463511252015071056489715",
+		"contract_termination_mode": "2",
+		"request_serial":            "123",
+	}
+
+	m["sign"] = wx.SignMD5.Do(mch.ApiKey(), m, true)
+
+	body, err := wx.FormatMap2XML(m)
+	assert.Nil(t, err)
+
+	notify, err := mch.ParseContractNotify(body)
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ContractNotify{
+		ResultCode:              "SUCCESS",
+		MchID:                   "10000100",
+		ContractCode:            "100000",
+		PlanID:                  "12535",
+		OpenID:                  "onqOjjrXT-776SpHnfexGm1_P7iE",
+		ChangeType:              ContractDelete,
+		OperateTime:             "2016-07-01 10:00:00",
+		ContractID:              "Wx15463511252015071056489715",
+		ContractTerminationMode: ContractDeleteUser,
+		RequestSerial:           "123",
+	}, notify)
+
+	// 签名错误
+	m["plan_id"] = "12536"
+
+	body, err = wx.FormatMap2XML(m)
+	assert.Nil(t, err)
+
+	_, err = mch.ParseContractNotify(body)
+	assert.NotNil(t, err)
+}
+
+func TestParsePappayNotify(t *testing.T) {
+	mch := New("10000100", "192006250b4c09247ec02edce69f6a2d")
+
+	m := wx.WXML{
+		"return_code":    "SUCCESS",
+		"return_msg":     "OK",
+		"result_code":    "SUCCESS",
+		"appid":          "wx2421b1c4370ec43b",
+		"mch_id":         "10000100",
+		"nonce_str":      "IITRi8Iabbblz1Jc",
+		"openid":         "onqOjjrXT-776SpHnfexGm1_P7iE",
+		"trade_type":     "PAP",
+		"trade_state":    "SUCCESS",
+		"bank_type":      "CMC",
+		"total_fee":      "1",
+		"cash_fee":       "1",
+		"transaction_id": "1004400740201409030005092168",
+		"out_trade_no":   "1409811653",
+		"time_end":       "20140903131540",
+		"contract_id":    "Wx15463511252015071056489715",
+	}
+
+	m["sign"] = wx.SignMD5.Do(mch.ApiKey(), m, true)
+
+	body, err := wx.FormatMap2XML(m)
+	assert.Nil(t, err)
+
+	notify, err := mch.ParsePappayNotify(body)
+
+	assert.Nil(t, err)
+	assert.Equal(t, &PappayNotify{
+		PayNotify: PayNotify{
+			AppID:         "wx2421b1c4370ec43b",
+			MchID:         "10000100",
+			ResultCode:    "SUCCESS",
+			OpenID:        "onqOjjrXT-776SpHnfexGm1_P7iE",
+			TradeType:     "PAP",
+			BankType:      "CMC",
+			TotalFee:      1,
+			CashFee:       1,
+			TransactionID: "1004400740201409030005092168",
+			OutTradeNO:    "1409811653",
+			TimeEnd:       "20140903131540",
+		},
+		TradeState: "SUCCESS",
+		ContractID: "Wx15463511252015071056489715",
+	}, notify)
+
+	// 缺少签名
+	delete(m, "sign")
+
+	body, err = wx.FormatMap2XML(m)
+	assert.Nil(t, err)
+
+	_, err = mch.ParsePappayNotify(body)
+	assert.NotNil(t, err)
+}
+
+func TestParsePayNotify(t *testing.T) {
+	mch := New("10000100", "192006250b4c09247ec02edce69f6a2d")
+
+	m := wx.WXML{
+		"return_code":    "SUCCESS",
+		"return_msg":     "OK",
+		"appid":          "wx2421b1c4370ec43b",
+		"mch_id":         "10000100",
+		"nonce_str":      "5d2b6c2a8db53831f7eda20af46e531c",
+		"result_code":    "SUCCESS",
+		"openid":         "oUpF8uMEb4qRXf22hE3X68TekukE",
+		"is_subscribe":   "Y",
+		"trade_type":     "JSAPI",
+		"bank_type":      "CFT",
+		"total_fee":      "100",
+		"fee_type":       "CNY",
+		"cash_fee":       "90",
+		"coupon_fee":     "10",
+		"coupon_count":   "1",
+		"coupon_id_0":    "10000",
+		"coupon_fee_0":   "10",
+		"transaction_id": "1004400740201409030005092168",
+		"out_trade_no":   "1409811653",
+		"attach":         "支付测试",
+		"time_end":       "20140903131540",
+	}
+
+	m["sign"] = wx.SignMD5.Do(mch.ApiKey(), m, true)
+
+	body, err := wx.FormatMap2XML(m)
+	assert.Nil(t, err)
+
+	notify, err := mch.ParsePayNotify(body)
+
+	assert.Nil(t, err)
+	assert.Equal(t, &PayNotify{
+		AppID:         "wx2421b1c4370ec43b",
+		MchID:         "10000100",
+		ResultCode:    "SUCCESS",
+		OpenID:        "oUpF8uMEb4qRXf22hE3X68TekukE",
+		IsSubscribe:   true,
+		TradeType:     "JSAPI",
+		BankType:      "CFT",
+		TotalFee:      100,
+		FeeType:       "CNY",
+		CashFee:       90,
+		CouponFee:     10,
+		CouponCount:   1,
+		CouponIDs:     []string{"10000"},
+		CouponFees:    []int{10},
+		TransactionID: "1004400740201409030005092168",
+		OutTradeNO:    "1409811653",
+		Attach:        "支付测试",
+		TimeEnd:       "20140903131540",
+	}, notify)
+}
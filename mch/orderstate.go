@@ -0,0 +1,141 @@
+package mch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/shenghui0779/gochat/wx"
+)
+
+var (
+	// ErrOrderPaid 订单已支付（或已转入退款），不可关闭
+	ErrOrderPaid = errors.New("order paid")
+
+	// ErrOrderPaying 用户支付中（或已接收等待扣款），需稍后重新查询
+	ErrOrderPaying = errors.New("order paying")
+)
+
+// TradeState 订单交易状态
+type TradeState string
+
+// Paid 订单是否已支付（含转入退款）
+func (s TradeState) Paid() bool {
+	return s == TradeStateSuccess || s == TradeStateRefund
+}
+
+// Closed 订单是否已关闭（含已撤销）
+func (s TradeState) Closed() bool {
+	return s == TradeStateClosed || s == TradeStateRevoked
+}
+
+// Paying 订单是否支付中（用户支付中或等待扣款）
+func (s TradeState) Paying() bool {
+	return s == TradeStatePaying || s == TradeStateAccept
+}
+
+// Closable 订单是否可关闭（未支付或支付失败）
+func (s TradeState) Closable() bool {
+	return s == TradeStateNotpay || s == TradeStateError || s == TradeStatePayFail
+}
+
+// orderResult 订单类接口（支付、查询、关单、撤销）返回结果中用于判断状态的字段
+type orderResult struct {
+	ResultCode string     `wxml:"result_code"`  // 业务结果
+	ErrCode    string     `wxml:"err_code"`     // 错误代码
+	ErrCodeDes string     `wxml:"err_code_des"` // 错误代码描述
+	TradeState TradeState `wxml:"trade_state"`  // 交易状态（查询订单时返回）
+	Recall     string     `wxml:"recall"`       // 是否需要继续调用撤销（撤销订单时返回）：Y-需要，N-不需要
+}
+
+func parseOrderResult(m wx.WXML) (*orderResult, error) {
+	r := new(orderResult)
+
+	if err := wx.UnmarshalWXML(m, r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Success 业务结果是否成功
+func (r *orderResult) Success() bool {
+	return r.ResultCode == ResultSuccess
+}
+
+// Err 业务失败时的错误，格式为 err_code|err_code_des
+func (r *orderResult) Err() error {
+	return fmt.Errorf("%s|%s", r.ErrCode, r.ErrCodeDes)
+}
+
+// QueryOrderState 根据商户订单号查询订单交易状态，业务结果失败（如：ORDERNOTEXIST）时返回错误
+func (mch *Mch) QueryOrderState(ctx context.Context, appid, outTradeNO string, options ...SLOption) (TradeState, error) {
+	m, err := mch.Do(ctx, QueryOrderByOutTradeNO(appid, outTradeNO, options...))
+
+	if err != nil {
+		return "", err
+	}
+
+	result, err := parseOrderResult(m)
+
+	if err != nil {
+		return "", err
+	}
+
+	if !result.Success() {
+		return "", result.Err()
+	}
+
+	return result.TradeState, nil
+}
+
+// SafeCloseOrder 先查询订单状态再关单，保证关单幂等：
+// 已关闭（或已撤销）直接返回；未支付（或支付失败）则关单并返回 CLOSED；
+// 已支付返回 ErrOrderPaid，支付中返回 ErrOrderPaying；
+// 【注意：订单生成后不能马上调用关单接口，最短调用时间间隔为5分钟。】
+func (mch *Mch) SafeCloseOrder(ctx context.Context, appid, outTradeNO string, options ...SLOption) (TradeState, error) {
+	state, err := mch.QueryOrderState(ctx, appid, outTradeNO, options...)
+
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case state.Closed():
+		return state, nil
+	case state.Paid():
+		return state, ErrOrderPaid
+	case state.Paying():
+		return state, ErrOrderPaying
+	case !state.Closable():
+		return state, fmt.Errorf("order state %s not closable", state)
+	}
+
+	m, err := mch.Do(ctx, CloseOrder(appid, outTradeNO, options...))
+
+	if err != nil {
+		return state, err
+	}
+
+	result, err := parseOrderResult(m)
+
+	if err != nil {
+		return state, err
+	}
+
+	if result.Success() {
+		return TradeStateClosed, nil
+	}
+
+	// 查询与关单之间订单状态可能发生变化
+	switch result.ErrCode {
+	case OrderClosed:
+		return TradeStateClosed, nil
+	case OrderPaid:
+		return TradeStateSuccess, ErrOrderPaid
+	case UserPaying:
+		return TradeStatePaying, ErrOrderPaying
+	}
+
+	return state, result.Err()
+}
@@ -0,0 +1,82 @@
+package mch
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/mock"
+)
+
+func mockOrderResp(fields string) []byte {
+	return []byte(`<xml>
+	<return_code>SUCCESS</return_code>
+	<return_msg>OK</return_msg>
+	<mch_id>10000100</mch_id>` + fields + `
+</xml>`)
+}
+
+func TestTradeState(t *testing.T) {
+	assert.True(t, TradeState(TradeStateRefund).Paid())
+	assert.True(t, TradeState(TradeStateRevoked).Closed())
+	assert.True(t, TradeState(TradeStateAccept).Paying())
+	assert.True(t, TradeState(TradeStateNotpay).Closable())
+	assert.False(t, TradeState(TradeStateSuccess).Closable())
+}
+
+func TestSafeCloseOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	mch := New("10000100", "192006250b4c09247ec02edce69f6a2d", WithMockClient(client))
+
+	// 未支付 -> 关单
+	gomock.InOrder(
+		client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/pay/orderquery", gomock.Any()).Return(mockOrderResp(`<result_code>SUCCESS</result_code><trade_state>NOTPAY</trade_state>`), nil),
+		client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/pay/closeorder", gomock.Any()).Return(mockOrderResp(`<result_code>SUCCESS</result_code>`), nil),
+	)
+
+	state, err := mch.SafeCloseOrder(context.TODO(), "wx2421b1c4370ec43b", "1415983244")
+
+	assert.Nil(t, err)
+	assert.Equal(t, TradeState(TradeStateClosed), state)
+
+	// 已关闭 -> 不再关单
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/pay/orderquery", gomock.Any()).Return(mockOrderResp(`<result_code>SUCCESS</result_code><trade_state>CLOSED</trade_state>`), nil)
+
+	state, err = mch.SafeCloseOrder(context.TODO(), "wx2421b1c4370ec43b", "1415983244")
+
+	assert.Nil(t, err)
+	assert.Equal(t, TradeState(TradeStateClosed), state)
+
+	// 已支付
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/pay/orderquery", gomock.Any()).Return(mockOrderResp(`<result_code>SUCCESS</result_code><trade_state>SUCCESS</trade_state>`), nil)
+
+	state, err = mch.SafeCloseOrder(context.TODO(), "wx2421b1c4370ec43b", "1415983244")
+
+	assert.Equal(t, ErrOrderPaid, err)
+	assert.Equal(t, TradeState(TradeStateSuccess), state)
+
+	// 关单时订单已支付
+	gomock.InOrder(
+		client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/pay/orderquery", gomock.Any()).Return(mockOrderResp(`<result_code>SUCCESS</result_code><trade_state>NOTPAY</trade_state>`), nil),
+		client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/pay/closeorder", gomock.Any()).Return(mockOrderResp(`<result_code>FAIL</result_code><err_code>ORDERPAID</err_code><err_code_des>订单已支付</err_code_des>`), nil),
+	)
+
+	state, err = mch.SafeCloseOrder(context.TODO(), "wx2421b1c4370ec43b", "1415983244")
+
+	assert.Equal(t, ErrOrderPaid, err)
+	assert.Equal(t, TradeState(TradeStateSuccess), state)
+
+	// 订单不存在
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/pay/orderquery", gomock.Any()).Return(mockOrderResp(`<result_code>FAIL</result_code><err_code>ORDERNOTEXIST</err_code><err_code_des>订单不存在</err_code_des>`), nil)
+
+	_, err = mch.SafeCloseOrder(context.TODO(), "wx2421b1c4370ec43b", "1415983244")
+
+	assert.EqualError(t, err, "ORDERNOTEXIST|订单不存在")
+}
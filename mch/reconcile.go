@@ -0,0 +1,325 @@
+package mch
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// BillRecord 交易账单明细（金额单位：分）
+type BillRecord struct {
+	TradeTime     string // 交易时间
+	AppID         string // 公众账号ID
+	MchID         string // 商户号
+	SubMchID      string // 特约商户号
+	TransactionID string // 微信订单号
+	OutTradeNO    string // 商户订单号
+	OpenID        string // 用户标识
+	TradeType     string // 交易类型
+	TradeState    string // 交易状态（SUCCESS：支付，REFUND：退款）
+	TotalFee      int64  // 订单金额
+	RefundID      string // 微信退款单号
+	OutRefundNO   string // 商户退款单号
+	RefundFee     int64  // 退款金额
+	RefundStatus  string // 退款状态
+	Fee           string // 手续费（元，精确至小数点后5位）
+}
+
+// 账单列名（不同账单类型及版本的列名略有差异）
+var billColumns = map[string][]string{
+	"trade_time":     {"交易时间"},
+	"appid":          {"公众账号ID"},
+	"mch_id":         {"商户号"},
+	"sub_mch_id":     {"特约商户号", "子商户号"},
+	"transaction_id": {"微信订单号"},
+	"out_trade_no":   {"商户订单号"},
+	"openid":         {"用户标识"},
+	"trade_type":     {"交易类型"},
+	"trade_state":    {"交易状态"},
+	"total_fee":      {"订单金额", "总金额", "应结订单金额"},
+	"refund_id":      {"微信退款单号"},
+	"out_refund_no":  {"商户退款单号"},
+	"refund_fee":     {"退款金额", "申请退款金额"},
+	"refund_status":  {"退款状态"},
+	"fee":            {"手续费"},
+}
+
+// ParseBill 解析交易账单（DownloadBill 返回的数据，不含汇总行）
+func ParseBill(b []byte) ([]*BillRecord, error) {
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(b, []byte("\xef\xbb\xbf"))))
+
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+
+	header, err := r.Read()
+
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[string]int, len(billColumns))
+
+	for key, names := range billColumns {
+		for _, name := range names {
+			if i := indexOf(header, name); i >= 0 {
+				index[key] = i
+
+				break
+			}
+		}
+	}
+
+	if _, ok := index["out_trade_no"]; !ok {
+		return nil, errors.New("invalid bill: out_trade_no column not found")
+	}
+
+	records := make([]*BillRecord, 0)
+
+	for {
+		row, err := r.Read()
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		// 汇总行
+		if len(row) != 0 && strings.HasPrefix(row[0], "总") {
+			break
+		}
+
+		get := func(key string) string {
+			i, ok := index[key]
+
+			if !ok || i >= len(row) {
+				return ""
+			}
+
+			return strings.TrimPrefix(strings.TrimSpace(row[i]), "`")
+		}
+
+		record := &BillRecord{
+			TradeTime:     get("trade_time"),
+			AppID:         get("appid"),
+			MchID:         get("mch_id"),
+			SubMchID:      get("sub_mch_id"),
+			TransactionID: get("transaction_id"),
+			OutTradeNO:    get("out_trade_no"),
+			OpenID:        get("openid"),
+			TradeType:     get("trade_type"),
+			TradeState:    get("trade_state"),
+			RefundID:      get("refund_id"),
+			OutRefundNO:   get("out_refund_no"),
+			RefundStatus:  get("refund_status"),
+			Fee:           get("fee"),
+		}
+
+		for key, fee := range map[string]*int64{
+			"total_fee":  &record.TotalFee,
+			"refund_fee": &record.RefundFee,
+		} {
+			if *fee, err = yuanToFen(get(key)); err != nil {
+				return nil, fmt.Errorf("invalid bill: %s %w", key, err)
+			}
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func indexOf(header []string, name string) int {
+	for i, v := range header {
+		if strings.TrimPrefix(strings.TrimSpace(v), "\xef\xbb\xbf") == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// yuanToFen 将账单中的金额（元，如：0.01）转换为分，避免浮点误差
+func yuanToFen(s string) (int64, error) {
+	if len(s) == 0 {
+		return 0, nil
+	}
+
+	yuan, fen := s, ""
+
+	if i := strings.Index(s, "."); i >= 0 {
+		yuan, fen = s[:i], s[i+1:]
+	}
+
+	if len(fen) > 2 {
+		return 0, fmt.Errorf("amount %s has more than 2 decimals", s)
+	}
+
+	fen += strings.Repeat("0", 2-len(fen))
+
+	v, err := strconv.ParseInt(yuan+fen, 10, 64)
+
+	if err != nil {
+		return 0, fmt.Errorf("amount %s: %w", s, err)
+	}
+
+	return v, nil
+}
+
+// LocalOrder 商户系统的订单（金额单位：分）
+type LocalOrder struct {
+	OutTradeNO string // 商户订单号
+	TotalFee   int64  // 订单金额
+	RefundFee  int64  // 已退款总金额
+}
+
+// MismatchKind 对账差异类型
+type MismatchKind string
+
+// 微信支付对账差异类型
+const (
+	MismatchAmount          MismatchKind = "amount"           // 支付金额不一致
+	MismatchRefundAmount    MismatchKind = "refund_amount"    // 退款金额不一致
+	MismatchMissingLocal    MismatchKind = "missing_local"    // 账单中存在，本地订单缺失
+	MismatchMissingBill     MismatchKind = "missing_bill"     // 本地订单存在，账单中缺失
+	MismatchDuplicateRefund MismatchKind = "duplicate_refund" // 重复退款（同一商户退款单号出现多次）
+)
+
+// Mismatch 对账差异
+type Mismatch struct {
+	Kind        MismatchKind
+	OutTradeNO  string
+	OutRefundNO string        // 重复退款时的商户退款单号
+	BillFee     int64         // 账单金额（支付或退款）
+	LocalFee    int64         // 本地金额（支付或退款）
+	Records     []*BillRecord // 相关的账单明细
+}
+
+// ReconcileReport 对账结果
+type ReconcileReport struct {
+	Matched    int         // 对账一致的订单数
+	Mismatches []*Mismatch // 差异明细（按商户订单号排序）
+}
+
+type billOrder struct {
+	paid      int64
+	refunded  int64
+	records   []*BillRecord
+	refundNOs map[string][]*BillRecord
+}
+
+// Reconcile 对账：将账单明细与本地订单按商户订单号匹配，比较支付金额与退款金额
+func Reconcile(records []*BillRecord, orders []*LocalOrder) *ReconcileReport {
+	bills := make(map[string]*billOrder)
+
+	for _, v := range records {
+		bo, ok := bills[v.OutTradeNO]
+
+		if !ok {
+			bo = &billOrder{refundNOs: make(map[string][]*BillRecord)}
+			bills[v.OutTradeNO] = bo
+		}
+
+		bo.records = append(bo.records, v)
+
+		switch v.TradeState {
+		case TradeStateSuccess:
+			bo.paid += v.TotalFee
+		case TradeStateRefund:
+			bo.refunded += v.RefundFee
+			bo.refundNOs[v.OutRefundNO] = append(bo.refundNOs[v.OutRefundNO], v)
+		}
+	}
+
+	report := &ReconcileReport{
+		Mismatches: make([]*Mismatch, 0),
+	}
+
+	for _, order := range orders {
+		bo, ok := bills[order.OutTradeNO]
+
+		if !ok {
+			report.Mismatches = append(report.Mismatches, &Mismatch{
+				Kind:       MismatchMissingBill,
+				OutTradeNO: order.OutTradeNO,
+				LocalFee:   order.TotalFee,
+			})
+
+			continue
+		}
+
+		delete(bills, order.OutTradeNO)
+
+		matched := true
+
+		for refundNO, list := range bo.refundNOs {
+			if len(list) > 1 {
+				matched = false
+
+				report.Mismatches = append(report.Mismatches, &Mismatch{
+					Kind:        MismatchDuplicateRefund,
+					OutTradeNO:  order.OutTradeNO,
+					OutRefundNO: refundNO,
+					BillFee:     bo.refunded,
+					LocalFee:    order.RefundFee,
+					Records:     list,
+				})
+			}
+		}
+
+		if bo.paid != order.TotalFee {
+			matched = false
+
+			report.Mismatches = append(report.Mismatches, &Mismatch{
+				Kind:       MismatchAmount,
+				OutTradeNO: order.OutTradeNO,
+				BillFee:    bo.paid,
+				LocalFee:   order.TotalFee,
+				Records:    bo.records,
+			})
+		}
+
+		if bo.refunded != order.RefundFee {
+			matched = false
+
+			report.Mismatches = append(report.Mismatches, &Mismatch{
+				Kind:       MismatchRefundAmount,
+				OutTradeNO: order.OutTradeNO,
+				BillFee:    bo.refunded,
+				LocalFee:   order.RefundFee,
+				Records:    bo.records,
+			})
+		}
+
+		if matched {
+			report.Matched++
+		}
+	}
+
+	for outTradeNO, bo := range bills {
+		report.Mismatches = append(report.Mismatches, &Mismatch{
+			Kind:       MismatchMissingLocal,
+			OutTradeNO: outTradeNO,
+			BillFee:    bo.paid,
+			Records:    bo.records,
+		})
+	}
+
+	sort.SliceStable(report.Mismatches, func(i, j int) bool {
+		if report.Mismatches[i].OutTradeNO != report.Mismatches[j].OutTradeNO {
+			return report.Mismatches[i].OutTradeNO < report.Mismatches[j].OutTradeNO
+		}
+
+		return report.Mismatches[i].Kind < report.Mismatches[j].Kind
+	})
+
+	return report
+}
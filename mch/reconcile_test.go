@@ -0,0 +1,103 @@
+package mch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testBill = []byte("交易时间,公众账号ID,商户号,特约商户号,设备号,微信订单号,商户订单号,用户标识,交易类型,交易状态,付款银行,货币种类,应结订单金额,代金券金额,微信退款单号,商户退款单号,退款金额,充值券退款金额,退款类型,退款状态,商品名称,商户数据包,手续费,费率,订单金额,申请退款金额,费率备注\n" +
+	"`2014-11-10 16:33:45,`wx2421b1c4370ec43b,`10000100,`0,`1000,`1001690740201411100005734289,`1415640626,`085e9858e3ba5186aafcbaed1,`MICROPAY,`SUCCESS,`OTHERS,`CNY,`0.01,`0.00,`0,`0,`0.00,`0.00,`,`,`被扫支付测试,`订单额外描述,`0.00000,`0.60%,`0.01,`0.00,`\n" +
+	"`2014-11-10 16:46:14,`wx2421b1c4370ec43b,`10000100,`0,`1000,`1002780740201411100005729794,`1415635270,`085e9858e90ca40c0b5aee463,`JSAPI,`SUCCESS,`OTHERS,`CNY,`12.50,`0.00,`0,`0,`0.00,`0.00,`,`,`测试商品,`,`0.07500,`0.60%,`12.50,`0.00,`\n" +
+	"`2014-11-11 09:12:01,`wx2421b1c4370ec43b,`10000100,`0,`1000,`1002780740201411100005729794,`1415635270,`085e9858e90ca40c0b5aee463,`JSAPI,`REFUND,`OTHERS,`CNY,`0.00,`0.00,`50000000092014111100000001,`R1415635270,`2.50,`0.00,`ORIGINAL,`SUCCESS,`测试商品,`,`0.00000,`0.60%,`0.00,`2.50,`\n" +
+	"`2014-11-11 09:12:05,`wx2421b1c4370ec43b,`10000100,`0,`1000,`1002780740201411100005729794,`1415635270,`085e9858e90ca40c0b5aee463,`JSAPI,`REFUND,`OTHERS,`CNY,`0.00,`0.00,`50000000092014111100000002,`R1415635270,`2.50,`0.00,`ORIGINAL,`SUCCESS,`测试商品,`,`0.00000,`0.60%,`0.00,`2.50,`\n" +
+	"`2014-11-11 10:00:00,`wx2421b1c4370ec43b,`10000100,`0,`1000,`1003780740201411100005729795,`1415635999,`085e9858e90ca40c0b5aee464,`JSAPI,`SUCCESS,`OTHERS,`CNY,`1.00,`0.00,`0,`0,`0.00,`0.00,`,`,`测试商品,`,`0.00600,`0.60%,`1.00,`0.00,`\n" +
+	"总交易单数,应结订单总金额,退款总金额,充值券退款总金额,手续费总金额,订单总金额,申请退款总金额\n" +
+	"`5,`13.51,`5.00,`0.00,`0.08100,`13.51,`5.00\n")
+
+func TestParseBill(t *testing.T) {
+	records, err := ParseBill(testBill)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 5, len(records))
+	assert.Equal(t, &BillRecord{
+		TradeTime:     "2014-11-10 16:46:14",
+		AppID:         "wx2421b1c4370ec43b",
+		MchID:         "10000100",
+		SubMchID:      "0",
+		TransactionID: "1002780740201411100005729794",
+		OutTradeNO:    "1415635270",
+		OpenID:        "085e9858e90ca40c0b5aee463",
+		TradeType:     "JSAPI",
+		TradeState:    "SUCCESS",
+		TotalFee:      1250,
+		RefundID:      "0",
+		OutRefundNO:   "0",
+		RefundFee:     0,
+		RefundStatus:  "",
+		Fee:           "0.07500",
+	}, records[1])
+	assert.Equal(t, int64(250), records[2].RefundFee)
+
+	// 金额精度超过分
+	_, err = ParseBill([]byte("商户订单号,订单金额\n`1415635270,`0.075\n"))
+
+	assert.NotNil(t, err)
+
+	_, err = ParseBill([]byte("交易时间\n2014-11-10 16:46:14\n"))
+
+	assert.NotNil(t, err)
+}
+
+func TestReconcile(t *testing.T) {
+	records, err := ParseBill([]byte("交易时间,商户订单号,交易状态,总金额,商户退款单号,退款金额\n" +
+		"2014-11-10 16:33:45,1415640626,SUCCESS,0.01,0,0\n" +
+		"2014-11-10 16:46:14,1415635270,SUCCESS,12.50,0,0\n" +
+		"2014-11-11 09:12:01,1415635270,REFUND,0,R1415635270,2.50\n" +
+		"2014-11-11 09:12:05,1415635270,REFUND,0,R1415635270,2.50\n" +
+		"2014-11-11 10:00:00,1415635999,SUCCESS,1.00,0,0\n" +
+		"总交易单数,总交易额\n" +
+		"5,13.51\n"))
+
+	assert.Nil(t, err)
+
+	report := Reconcile(records, []*LocalOrder{
+		{OutTradeNO: "1415640626", TotalFee: 1},
+		{OutTradeNO: "1415635270", TotalFee: 1200, RefundFee: 250},
+		{OutTradeNO: "1415630000", TotalFee: 100},
+	})
+
+	assert.Equal(t, 1, report.Matched)
+	assert.Equal(t, 5, len(report.Mismatches))
+
+	kinds := make([]MismatchKind, 0, len(report.Mismatches))
+
+	for _, v := range report.Mismatches {
+		kinds = append(kinds, v.Kind)
+	}
+
+	assert.Equal(t, []MismatchKind{
+		MismatchMissingBill,
+		MismatchAmount,
+		MismatchDuplicateRefund,
+		MismatchRefundAmount,
+		MismatchMissingLocal,
+	}, kinds)
+
+	assert.Equal(t, int64(1250), report.Mismatches[1].BillFee)
+	assert.Equal(t, int64(1200), report.Mismatches[1].LocalFee)
+	assert.Equal(t, "R1415635270", report.Mismatches[2].OutRefundNO)
+	assert.Equal(t, 2, len(report.Mismatches[2].Records))
+	assert.Equal(t, int64(500), report.Mismatches[3].BillFee)
+	assert.Equal(t, "1415635999", report.Mismatches[4].OutTradeNO)
+	assert.Equal(t, int64(100), report.Mismatches[4].BillFee)
+}
+
+func TestYuanToFen(t *testing.T) {
+	for s, fen := range map[string]int64{"": 0, "0": 0, "0.01": 1, "0.1": 10, "12.50": 1250, "100": 10000, "-2.5": -250} {
+		v, err := yuanToFen(s)
+
+		assert.Nil(t, err)
+		assert.Equal(t, fen, v, s)
+	}
+}
@@ -2,6 +2,8 @@ package mch
 
 import (
 	"encoding/xml"
+	"io"
+	"net/http"
 
 	"github.com/shenghui0779/gochat/wx"
 )
@@ -28,3 +30,34 @@ func ReplyFail(msg string) *Reply {
 		ReturnMsg:  wx.CDATA(msg),
 	}
 }
+
+// WriteTo 将应答报文写入 w（w 为 http.ResponseWriter 时设置 Content-Type）
+func (r *Reply) WriteTo(w io.Writer) (int64, error) {
+	b, err := xml.Marshal(r)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if hw, ok := w.(http.ResponseWriter); ok {
+		hw.Header().Set("Content-Type", "text/xml; charset=utf-8")
+	}
+
+	n, err := w.Write(b)
+
+	return int64(n), err
+}
+
+// Ack 应答回调通知：err 为 nil 时回复成功，否则回复失败（微信将重新通知）；
+// 失败时固定回复 FAIL，不将 err 的内容返回给微信，err 请自行记录
+func Ack(w io.Writer, err error) error {
+	reply := ReplyOK()
+
+	if err != nil {
+		reply = ReplyFail(ResultFail)
+	}
+
+	_, err = reply.WriteTo(w)
+
+	return err
+}
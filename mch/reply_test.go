@@ -0,0 +1,23 @@
+package mch
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAck(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	assert.Nil(t, Ack(w, nil))
+	assert.Equal(t, "text/xml; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "<xml><return_code><![CDATA[SUCCESS]]></return_code><return_msg><![CDATA[OK]]></return_msg></xml>", w.Body.String())
+
+	w = httptest.NewRecorder()
+
+	// 不回复错误详情
+	assert.Nil(t, Ack(w, errors.New("db: connection refused")))
+	assert.Equal(t, "<xml><return_code><![CDATA[FAIL]]></return_code><return_msg><![CDATA[FAIL]]></return_msg></xml>", w.Body.String())
+}
@@ -0,0 +1,189 @@
+package mchv3
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// Action is the interface that handle wechat pay v3 api
+type Action interface {
+	// Method returns action method
+	Method() string
+
+	// URL returns request url
+	URL(mch *Mch) string
+
+	// Body returns body for post request
+	Body(mch *Mch) ([]byte, error)
+
+	// UploadForm returns form for uploading media
+	UploadForm() (wx.UploadForm, error)
+
+	// Decode decodes response
+	Decode(b []byte) error
+
+	// IsUpload specifies the request does upload
+	IsUpload() bool
+}
+
+// UnverifiedAction is the optional interface implemented by actions whose response carries no signature
+type UnverifiedAction interface {
+	// SkipVerify specifies the response is not signed (如：账单、电子回单文件下载)
+	SkipVerify() bool
+}
+
+type action struct {
+	method     string
+	reqURL     string
+	partnerURL string
+	query      url.Values
+	queryfunc  func(mch *Mch, query url.Values)
+	body       func(mch *Mch) ([]byte, error)
+	uploadform func() (wx.UploadForm, error)
+	decode     func(b []byte) error
+	upload     bool
+	skipverify bool
+}
+
+func (a *action) Method() string {
+	return a.method
+}
+
+func (a *action) URL(mch *Mch) string {
+	reqURL := a.reqURL
+
+	if mch.partner && len(a.partnerURL) != 0 {
+		reqURL = a.partnerURL
+	}
+
+	query := url.Values{}
+
+	for k, v := range a.query {
+		query[k] = v
+	}
+
+	if a.queryfunc != nil {
+		a.queryfunc(mch, query)
+	}
+
+	if len(query) == 0 {
+		return reqURL
+	}
+
+	return fmt.Sprintf("%s?%s", reqURL, query.Encode())
+}
+
+func (a *action) Body(mch *Mch) ([]byte, error) {
+	if a.body != nil {
+		return a.body(mch)
+	}
+
+	return nil, nil
+}
+
+func (a *action) UploadForm() (wx.UploadForm, error) {
+	if a.uploadform != nil {
+		return a.uploadform()
+	}
+
+	return wx.NewUploadForm(), nil
+}
+
+func (a *action) Decode(b []byte) error {
+	if a.decode != nil {
+		return a.decode(b)
+	}
+
+	return nil
+}
+
+func (a *action) IsUpload() bool {
+	return a.upload
+}
+
+func (a *action) SkipVerify() bool {
+	return a.skipverify
+}
+
+// ActionOption configures how we set up the action
+type ActionOption func(a *action)
+
+// WithQuery sets query params for action.
+func WithQuery(key, value string) ActionOption {
+	return func(a *action) {
+		a.query.Set(key, value)
+	}
+}
+
+// WithQueryFunc sets query params which depend on mch for action.
+func WithQueryFunc(f func(mch *Mch, query url.Values)) ActionOption {
+	return func(a *action) {
+		a.queryfunc = f
+	}
+}
+
+// WithPartnerURL sets request url for action in partner mode.
+func WithPartnerURL(reqURL string) ActionOption {
+	return func(a *action) {
+		a.partnerURL = reqURL
+	}
+}
+
+// WithBody sets post body for action.
+// 上传时，body 为参与签名的 meta 信息
+func WithBody(f func(mch *Mch) ([]byte, error)) ActionOption {
+	return func(a *action) {
+		a.body = f
+	}
+}
+
+// WithUpload sets uploadform for action.
+func WithUpload(f func() (wx.UploadForm, error)) ActionOption {
+	return func(a *action) {
+		a.upload = true
+		a.uploadform = f
+	}
+}
+
+// WithDecode sets response decode for action.
+func WithDecode(f func(b []byte) error) ActionOption {
+	return func(a *action) {
+		a.decode = f
+	}
+}
+
+// WithoutVerify skips the response signature verification for action.
+// 文件下载应答不含签名，需自行使用 hash_value 校验文件完整性
+func WithoutVerify() ActionOption {
+	return func(a *action) {
+		a.skipverify = true
+	}
+}
+
+// NewAction returns a new action
+func NewAction(method string, reqURL string, options ...ActionOption) Action {
+	a := &action{
+		method: method,
+		reqURL: reqURL,
+		query:  url.Values{},
+	}
+
+	for _, f := range options {
+		f(a)
+	}
+
+	return a
+}
+
+// NewGetAction returns a new action with GET method
+func NewGetAction(reqURL string, options ...ActionOption) Action {
+	return NewAction(http.MethodGet, reqURL, options...)
+}
+
+// NewPostAction returns a new action with POST method
+func NewPostAction(reqURL string, options ...ActionOption) Action {
+	return NewAction(http.MethodPost, reqURL, options...)
+}
@@ -0,0 +1,109 @@
+package mchv3
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/shenghui0779/gochat/urls"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// ParamsBusinessCirclePoints 商圈积分同步参数
+type ParamsBusinessCirclePoints struct {
+	SubMchID         string `json:"sub_mchid,omitempty"`         // 子商户号（服务商模式）
+	TransactionID    string `json:"transaction_id"`              // 微信订单号
+	AppID            string `json:"appid"`                       // 商圈公众号/小程序 appid
+	OpenID           string `json:"openid"`                      // 用户标识
+	EarnPoints       bool   `json:"earn_points"`                 // 是否获得积分
+	IncreasedPoints  int64  `json:"increased_points"`            // 订单新增积分值
+	PointsUpdateTime string `json:"points_update_time"`          // 积分更新时间，遵循rfc3339标准格式
+	NoPointsRemarks  string `json:"no_points_remarks,omitempty"` // 未获得积分的原因（earn_points 为 false 时必填）
+	TotalPoints      int64  `json:"total_points,omitempty"`      // 顾客积分总额
+}
+
+// NotifyBusinessCirclePoints 智慧商圈 - 商圈积分同步（用户在商圈内消费后，将积分结果同步给微信支付）
+func NotifyBusinessCirclePoints(params *ParamsBusinessCirclePoints) Action {
+	return NewPostAction(urls.MchV3BusinessCirclePointsNotify,
+		WithBody(func(mch *Mch) ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+	)
+}
+
+// 商圈积分授权状态
+const (
+	BusinessCircleUnauthorized = "UNAUTHORIZED" // 未授权
+	BusinessCircleAuthorized   = "AUTHORIZED"   // 已授权
+	BusinessCircleDeauthorized = "DEAUTHORIZED" // 已取消授权
+)
+
+// ResultBusinessCircleAuthorization 商圈积分授权结果
+type ResultBusinessCircleAuthorization struct {
+	OpenID          string `json:"openid"`           // 用户标识
+	AuthorizeState  string `json:"authorize_state"`  // 授权状态：UNAUTHORIZED、AUTHORIZED、DEAUTHORIZED
+	AuthorizeTime   string `json:"authorize_time"`   // 授权时间
+	DeauthorizeTime string `json:"deauthorize_time"` // 取消授权时间
+}
+
+// QueryBusinessCircleAuthorization 智慧商圈 - 商圈积分授权查询（服务商模式需通过 WithSubMchID 指定子商户）
+func QueryBusinessCircleAuthorization(appid, openid string, result *ResultBusinessCircleAuthorization, options ...SLOption) Action {
+	return NewGetAction(fmt.Sprintf("%s/%s", urls.MchV3BusinessCircleUserAuthorizations, openid),
+		WithQuery("appid", appid),
+		subMchQuery(options...),
+		WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// BusinessCirclePayment 商圈支付结果（回调通知 event_type 为 MALLTRANSACTION.SUCCESS）
+type BusinessCirclePayment struct {
+	MchID         string `json:"mchid"`          // 商户号
+	MerchantName  string `json:"merchant_name"`  // 商圈商户名称
+	ShopName      string `json:"shop_name"`      // 门店名称
+	ShopNumber    string `json:"shop_number"`    // 门店编号
+	AppID         string `json:"appid"`          // 小程序 appid
+	OpenID        string `json:"openid"`         // 用户标识
+	TimeEnd       string `json:"time_end"`       // 交易完成时间
+	Amount        int64  `json:"amount"`         // 支付金额，单位：分
+	TransactionID string `json:"transaction_id"` // 微信支付订单号
+	CommitTag     string `json:"commit_tag"`     // 手动提交积分标记（需要用户手动确认后才可积分）
+}
+
+// ParseBusinessCirclePaymentNotify 智慧商圈 - 解析商圈支付结果回调通知
+func ParseBusinessCirclePaymentNotify(mch *Mch, header http.Header, body []byte) (*BusinessCirclePayment, error) {
+	result := new(BusinessCirclePayment)
+
+	if _, err := mch.ParseNotify(header, body, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// BusinessCircleRefund 商圈退款结果（回调通知 event_type 为 MALLREFUND.SUCCESS）
+type BusinessCircleRefund struct {
+	MchID         string `json:"mchid"`          // 商户号
+	MerchantName  string `json:"merchant_name"`  // 商圈商户名称
+	ShopName      string `json:"shop_name"`      // 门店名称
+	ShopNumber    string `json:"shop_number"`    // 门店编号
+	AppID         string `json:"appid"`          // 小程序 appid
+	OpenID        string `json:"openid"`         // 用户标识
+	RefundTime    string `json:"refund_time"`    // 退款完成时间
+	PayAmount     int64  `json:"pay_amount"`     // 消费金额，单位：分
+	RefundAmount  int64  `json:"refund_amount"`  // 退款金额，单位：分
+	TransactionID string `json:"transaction_id"` // 微信支付订单号
+	RefundID      string `json:"refund_id"`      // 微信支付退款单号
+}
+
+// ParseBusinessCircleRefundNotify 智慧商圈 - 解析商圈退款成功回调通知
+func ParseBusinessCircleRefundNotify(mch *Mch, header http.Header, body []byte) (*BusinessCircleRefund, error) {
+	result := new(BusinessCircleRefund)
+
+	if _, err := mch.ParseNotify(header, body, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,104 @@
+package mchv3
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/mock"
+)
+
+func TestNotifyBusinessCirclePoints(t *testing.T) {
+	body := []byte(`{"sub_mchid":"1900000109","transaction_id":"4200000533202000000000000000","appid":"wxd678efh567hg6787","openid":"otPAN5xxxxxxxxrOEG6lUv_pzacc","earn_points":true,"increased_points":100,"points_update_time":"2020-05-20T13:29:35.120+08:00","total_points":888888}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/v3/businesscircle/points/notify", body, gomock.Any()).Return(nil, nil)
+
+	mch := newTestMch(t, WithPartner(), WithMockClient(client))
+
+	err := mch.Do(context.TODO(), NotifyBusinessCirclePoints(&ParamsBusinessCirclePoints{
+		SubMchID:         "1900000109",
+		TransactionID:    "4200000533202000000000000000",
+		AppID:            "wxd678efh567hg6787",
+		OpenID:           "otPAN5xxxxxxxxrOEG6lUv_pzacc",
+		EarnPoints:       true,
+		IncreasedPoints:  100,
+		PointsUpdateTime: "2020-05-20T13:29:35.120+08:00",
+		TotalPoints:      888888,
+	}))
+
+	assert.Nil(t, err)
+}
+
+func TestQueryBusinessCircleAuthorization(t *testing.T) {
+	resp := []byte(`{"openid":"otPAN5xxxxxxxxrOEG6lUv_pzacc","authorize_state":"AUTHORIZED","authorize_time":"2020-05-20T13:29:35.120+08:00"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.mch.weixin.qq.com/v3/businesscircle/user-authorizations/otPAN5xxxxxxxxrOEG6lUv_pzacc?appid=wxd678efh567hg6787&sub_mchid=1900000109", nil, gomock.Any()).Return(resp, nil)
+
+	mch := newTestMch(t, WithPartner(), WithMockClient(client))
+
+	result := new(ResultBusinessCircleAuthorization)
+
+	err := mch.Do(context.TODO(), QueryBusinessCircleAuthorization("wxd678efh567hg6787", "otPAN5xxxxxxxxrOEG6lUv_pzacc", result, WithSubMchID("1900000109")))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultBusinessCircleAuthorization{
+		OpenID:         "otPAN5xxxxxxxxrOEG6lUv_pzacc",
+		AuthorizeState: BusinessCircleAuthorized,
+		AuthorizeTime:  "2020-05-20T13:29:35.120+08:00",
+	}, result)
+}
+
+func TestParseBusinessCirclePaymentNotify(t *testing.T) {
+	mch := newTestMch(t)
+
+	header, body := mockNotify(t, mch, "MALLTRANSACTION.SUCCESS", []byte(`{"mchid":"1900000001","merchant_name":"腾讯广场","shop_name":"微信支付","shop_number":"123456","appid":"wxd678efh567hg6787","openid":"otPAN5xxxxxxxxrOEG6lUv_pzacc","time_end":"2020-05-20T13:29:35.120+08:00","amount":200,"transaction_id":"4200000533202000000000000000","commit_tag":"EA12345678"}`))
+
+	result, err := ParseBusinessCirclePaymentNotify(mch, header, body)
+
+	assert.Nil(t, err)
+	assert.Equal(t, &BusinessCirclePayment{
+		MchID:         "1900000001",
+		MerchantName:  "腾讯广场",
+		ShopName:      "微信支付",
+		ShopNumber:    "123456",
+		AppID:         "wxd678efh567hg6787",
+		OpenID:        "otPAN5xxxxxxxxrOEG6lUv_pzacc",
+		TimeEnd:       "2020-05-20T13:29:35.120+08:00",
+		Amount:        200,
+		TransactionID: "4200000533202000000000000000",
+		CommitTag:     "EA12345678",
+	}, result)
+}
+
+func TestParseBusinessCircleRefundNotify(t *testing.T) {
+	mch := newTestMch(t)
+
+	header, body := mockNotify(t, mch, "MALLREFUND.SUCCESS", []byte(`{"mchid":"1900000001","appid":"wxd678efh567hg6787","openid":"otPAN5xxxxxxxxrOEG6lUv_pzacc","refund_time":"2020-05-20T13:29:35.120+08:00","pay_amount":200,"refund_amount":100,"transaction_id":"4200000533202000000000000000","refund_id":"50000000382019052709732678859"}`))
+
+	result, err := ParseBusinessCircleRefundNotify(mch, header, body)
+
+	assert.Nil(t, err)
+	assert.Equal(t, &BusinessCircleRefund{
+		MchID:         "1900000001",
+		AppID:         "wxd678efh567hg6787",
+		OpenID:        "otPAN5xxxxxxxxrOEG6lUv_pzacc",
+		RefundTime:    "2020-05-20T13:29:35.120+08:00",
+		PayAmount:     200,
+		RefundAmount:  100,
+		TransactionID: "4200000533202000000000000000",
+		RefundID:      "50000000382019052709732678859",
+	}, result)
+}
@@ -0,0 +1,255 @@
+package mchv3
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/shenghui0779/gochat/urls"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// 投诉单状态
+const (
+	ComplaintPending    = "PENDING"    // 待处理
+	ComplaintProcessing = "PROCESSING" // 处理中
+	ComplaintProcessed  = "PROCESSED"  // 已处理完成
+)
+
+// ParamsComplaintList 查询投诉单列表参数
+type ParamsComplaintList struct {
+	Limit            int    // 分页大小，最大50，默认10
+	Offset           int    // 分页开始位置，从0开始
+	BeginDate        string // 开始日期，格式为yyyy-MM-DD
+	EndDate          string // 结束日期，格式为yyyy-MM-DD，查询时间跨度不超过30天
+	ComplaintedMchID string // 被诉商户号（服务商、渠道商可查询子商户的投诉单）
+}
+
+// ComplaintOrderInfo 投诉相关订单
+type ComplaintOrderInfo struct {
+	TransactionID string `json:"transaction_id"` // 微信订单号
+	OutTradeNO    string `json:"out_trade_no"`   // 商户订单号
+	Amount        int64  `json:"amount"`         // 订单金额，单位：分
+}
+
+// ComplaintMedia 投诉资料
+type ComplaintMedia struct {
+	MediaType string   `json:"media_type"` // 媒体文件业务类型：USER_COMPLAINT_IMAGE、OPERATION_IMAGE
+	MediaURL  []string `json:"media_url"`  // 媒体文件请求url（需使用商户身份下载）
+}
+
+// ComplaintServiceOrder 投诉相关服务单
+type ComplaintServiceOrder struct {
+	OrderID    string `json:"order_id"`     // 微信支付服务订单号
+	OutOrderNO string `json:"out_order_no"` // 商户服务订单号
+	State      string `json:"state"`        // 支付分服务单状态
+}
+
+// ComplaintInfo 投诉单
+type ComplaintInfo struct {
+	ComplaintID           string                   `json:"complaint_id"`            // 投诉单号
+	ComplaintTime         string                   `json:"complaint_time"`          // 投诉时间
+	ComplaintDetail       string                   `json:"complaint_detail"`        // 投诉详情
+	ComplaintState        string                   `json:"complaint_state"`         // 投诉单状态：PENDING、PROCESSING、PROCESSED
+	ComplaintedMchID      string                   `json:"complainted_mchid"`       // 被诉商户号
+	PayerPhone            string                   `json:"payer_phone"`             // 投诉人联系方式（密文，可使用 mch.Decrypt 解密）
+	PayerOpenID           string                   `json:"payer_openid"`            // 投诉人openid
+	ComplaintOrderInfo    []*ComplaintOrderInfo    `json:"complaint_order_info"`    // 投诉单关联订单信息
+	ComplaintFullRefunded bool                     `json:"complaint_full_refunded"` // 投诉单下所有订单是否已全额退款
+	IncomingUserResponse  bool                     `json:"incoming_user_response"`  // 投诉单是否有待回复的用户留言
+	UserComplaintTimes    int                      `json:"user_complaint_times"`    // 用户投诉次数
+	ComplaintMediaList    []*ComplaintMedia        `json:"complaint_media_list"`    // 投诉资料列表
+	ProblemDescription    string                   `json:"problem_description"`     // 问题描述
+	ProblemType           string                   `json:"problem_type"`            // 问题类型：REFUND、SERVICE_NOT_WORK、OTHERS
+	ApplyRefundAmount     int64                    `json:"apply_refund_amount"`     // 申请退款金额，单位：分
+	UserTagList           []string                 `json:"user_tag_list"`           // 用户标签列表
+	ServiceOrderInfo      []*ComplaintServiceOrder `json:"service_order_info"`      // 投诉单关联服务单信息
+}
+
+// ResultComplaintList 投诉单列表
+type ResultComplaintList struct {
+	Data       []*ComplaintInfo `json:"data"`
+	Limit      int              `json:"limit"`
+	Offset     int              `json:"offset"`
+	TotalCount int              `json:"total_count"`
+}
+
+// ListComplaints 消费者投诉 - 查询投诉单列表
+// [参考](https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter10_2_11.shtml)
+func ListComplaints(params *ParamsComplaintList, result *ResultComplaintList) Action {
+	return NewGetAction(urls.MchV3Complaints,
+		WithQueryFunc(func(mch *Mch, query url.Values) {
+			query.Set("begin_date", params.BeginDate)
+			query.Set("end_date", params.EndDate)
+
+			if params.Limit > 0 {
+				query.Set("limit", strconv.Itoa(params.Limit))
+			}
+
+			if params.Offset > 0 {
+				query.Set("offset", strconv.Itoa(params.Offset))
+			}
+
+			if len(params.ComplaintedMchID) != 0 {
+				query.Set("complainted_mchid", params.ComplaintedMchID)
+			}
+		}),
+		WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// GetComplaint 消费者投诉 - 查询投诉单详情
+func GetComplaint(complaintID string, result *ComplaintInfo) Action {
+	return NewGetAction(fmt.Sprintf("%s/%s", urls.MchV3Complaints, complaintID),
+		WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// ComplaintNegotiation 投诉协商历史
+type ComplaintNegotiation struct {
+	LogID              string          `json:"log_id"`               // 操作流水号
+	Operator           string          `json:"operator"`             // 操作人
+	OperateTime        string          `json:"operate_time"`         // 操作时间
+	OperateType        string          `json:"operate_type"`         // 操作类型
+	OperateDetails     string          `json:"operate_details"`      // 操作内容
+	ImageList          []string        `json:"image_list"`           // 图片凭证
+	ComplaintMediaList *ComplaintMedia `json:"complaint_media_list"` // 操作资料
+}
+
+// ResultComplaintNegotiations 投诉协商历史列表
+type ResultComplaintNegotiations struct {
+	Data       []*ComplaintNegotiation `json:"data"`
+	Limit      int                     `json:"limit"`
+	Offset     int                     `json:"offset"`
+	TotalCount int                     `json:"total_count"`
+}
+
+// ListComplaintNegotiations 消费者投诉 - 查询投诉协商历史（limit 最大300，默认100）
+func ListComplaintNegotiations(complaintID string, limit, offset int, result *ResultComplaintNegotiations) Action {
+	options := []ActionOption{
+		WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	}
+
+	if limit > 0 {
+		options = append(options, WithQuery("limit", strconv.Itoa(limit)))
+	}
+
+	if offset > 0 {
+		options = append(options, WithQuery("offset", strconv.Itoa(offset)))
+	}
+
+	return NewGetAction(fmt.Sprintf("%s/%s/negotiation-historys", urls.MchV3Complaints, complaintID), options...)
+}
+
+// ParamsComplaintResponse 回复用户参数
+type ParamsComplaintResponse struct {
+	ComplaintedMchID string   `json:"complainted_mchid"`         // 被诉商户号
+	ResponseContent  string   `json:"response_content"`          // 回复内容，最多200字符
+	ResponseImages   []string `json:"response_images,omitempty"` // 回复图片（通过商户上传反馈图片接口获取的 media_id）
+	JumpURL          string   `json:"jump_url,omitempty"`        // 跳转链接
+	JumpURLText      string   `json:"jump_url_text,omitempty"`   // 跳转链接文案
+}
+
+// ResponseComplaint 消费者投诉 - 回复用户
+func ResponseComplaint(complaintID string, params *ParamsComplaintResponse) Action {
+	return NewPostAction(fmt.Sprintf("%s/%s/response", urls.MchV3Complaints, complaintID),
+		WithBody(func(mch *Mch) ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+	)
+}
+
+// CompleteComplaint 消费者投诉 - 反馈处理完成
+func CompleteComplaint(complaintID, complaintedMchID string) Action {
+	return NewPostAction(fmt.Sprintf("%s/%s/complete", urls.MchV3Complaints, complaintID),
+		WithBody(func(mch *Mch) ([]byte, error) {
+			return json.Marshal(map[string]string{
+				"complainted_mchid": complaintedMchID,
+			})
+		}),
+	)
+}
+
+// ResultComplaintNotification 投诉通知回调地址
+type ResultComplaintNotification struct {
+	MchID string `json:"mchid"` // 商户号
+	URL   string `json:"url"`   // 通知地址
+}
+
+func complaintNotificationAction(method, notifyURL string, result *ResultComplaintNotification) Action {
+	options := []ActionOption{
+		WithDecode(func(b []byte) error {
+			if result == nil {
+				return nil
+			}
+
+			return json.Unmarshal(b, result)
+		}),
+	}
+
+	if len(notifyURL) != 0 {
+		options = append(options, WithBody(func(mch *Mch) ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(map[string]string{
+				"url": notifyURL,
+			})
+		}))
+	}
+
+	return NewAction(method, urls.MchV3ComplaintNotifications, options...)
+}
+
+// CreateComplaintNotification 消费者投诉 - 创建投诉通知回调地址
+func CreateComplaintNotification(notifyURL string, result *ResultComplaintNotification) Action {
+	return complaintNotificationAction(http.MethodPost, notifyURL, result)
+}
+
+// GetComplaintNotification 消费者投诉 - 查询投诉通知回调地址
+func GetComplaintNotification(result *ResultComplaintNotification) Action {
+	return complaintNotificationAction(http.MethodGet, "", result)
+}
+
+// UpdateComplaintNotification 消费者投诉 - 更新投诉通知回调地址
+func UpdateComplaintNotification(notifyURL string, result *ResultComplaintNotification) Action {
+	return complaintNotificationAction(http.MethodPut, notifyURL, result)
+}
+
+// DeleteComplaintNotification 消费者投诉 - 删除投诉通知回调地址
+func DeleteComplaintNotification() Action {
+	return complaintNotificationAction(http.MethodDelete, "", nil)
+}
+
+// 投诉通知动作类型
+const (
+	ComplaintActionCreateComplaint         = "CREATE_COMPLAINT"          // 用户提交投诉
+	ComplaintActionContinueComplaint       = "CONTINUE_COMPLAINT"        // 用户继续投诉
+	ComplaintActionUserResponse            = "USER_RESPONSE"             // 用户新留言
+	ComplaintActionResponseByPlatform      = "RESPONSE_BY_PLATFORM"      // 平台新留言
+	ComplaintActionSellerRefund            = "SELLER_REFUND"             // 商户发起全额退款
+	ComplaintActionMerchantResponse        = "MERCHANT_RESPONSE"         // 商户新回复
+	ComplaintActionMerchantConfirmComplete = "MERCHANT_CONFIRM_COMPLETE" // 商户反馈处理完成
+)
+
+// ComplaintNotify 投诉通知（回调通知 event_type 为 COMPLAINT.CREATE、COMPLAINT.STATE_CHANGE）
+type ComplaintNotify struct {
+	ComplaintID string `json:"complaint_id"` // 投诉单号
+	ActionType  string `json:"action_type"`  // 动作类型
+}
+
+// ParseComplaintNotify 消费者投诉 - 解析投诉通知（通知仅包含投诉单号及动作类型，详情请通过 GetComplaint 查询）
+func ParseComplaintNotify(mch *Mch, header http.Header, body []byte) (*ComplaintNotify, error) {
+	result := new(ComplaintNotify)
+
+	if _, err := mch.ParseNotify(header, body, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,221 @@
+package mchv3
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/mock"
+)
+
+func TestListComplaints(t *testing.T) {
+	resp := []byte(`{"data":[{"complaint_id":"200201820200101080076610000","complaint_time":"2015-05-20T13:29:35.120+08:00","complaint_detail":"反馈一个重复扣费的问题","complaint_state":"PENDING","complainted_mchid":"1900012181","payer_phone":"PAYER_PHONE","complaint_order_info":[{"transaction_id":"4200000404201909069117582536","out_trade_no":"20190906154617947762231","amount":3}],"complaint_full_refunded":true,"incoming_user_response":false,"user_complaint_times":1,"problem_type":"REFUND","apply_refund_amount":10}],"limit":5,"offset":0,"total_count":1}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.mch.weixin.qq.com/v3/merchant-service/complaints-v2?begin_date=2019-01-01&complainted_mchid=1900012181&end_date=2019-01-30&limit=5", nil, gomock.Any()).Return(resp, nil)
+
+	mch := newTestMch(t, WithMockClient(client))
+
+	result := new(ResultComplaintList)
+
+	err := mch.Do(context.TODO(), ListComplaints(&ParamsComplaintList{
+		Limit:            5,
+		BeginDate:        "2019-01-01",
+		EndDate:          "2019-01-30",
+		ComplaintedMchID: "1900012181",
+	}, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultComplaintList{
+		Data: []*ComplaintInfo{
+			{
+				ComplaintID:      "200201820200101080076610000",
+				ComplaintTime:    "2015-05-20T13:29:35.120+08:00",
+				ComplaintDetail:  "反馈一个重复扣费的问题",
+				ComplaintState:   ComplaintPending,
+				ComplaintedMchID: "1900012181",
+				PayerPhone:       "PAYER_PHONE",
+				ComplaintOrderInfo: []*ComplaintOrderInfo{
+					{
+						TransactionID: "4200000404201909069117582536",
+						OutTradeNO:    "20190906154617947762231",
+						Amount:        3,
+					},
+				},
+				ComplaintFullRefunded: true,
+				UserComplaintTimes:    1,
+				ProblemType:           "REFUND",
+				ApplyRefundAmount:     10,
+			},
+		},
+		Limit:      5,
+		TotalCount: 1,
+	}, result)
+}
+
+func TestGetComplaint(t *testing.T) {
+	mch := newTestMch(t)
+
+	phone, err := mch.Encrypt("13800138000")
+	assert.Nil(t, err)
+
+	resp := []byte(`{"complaint_id":"200201820200101080076610000","complaint_state":"PROCESSING","payer_phone":"` + phone + `","payer_openid":"oUpF8uMuAJO_M2pxb1Q9zNjWeS6o","complaint_media_list":[{"media_type":"USER_COMPLAINT_IMAGE","media_url":["https://api.mch.weixin.qq.com/v3/merchant-service/images/xxxxx"]}]}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.mch.weixin.qq.com/v3/merchant-service/complaints-v2/200201820200101080076610000", nil, gomock.Any()).Return(resp, nil)
+
+	mch = newTestMch(t, WithMockClient(client))
+
+	result := new(ComplaintInfo)
+
+	err = mch.Do(context.TODO(), GetComplaint("200201820200101080076610000", result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, ComplaintProcessing, result.ComplaintState)
+	assert.Equal(t, []*ComplaintMedia{
+		{
+			MediaType: "USER_COMPLAINT_IMAGE",
+			MediaURL:  []string{"https://api.mch.weixin.qq.com/v3/merchant-service/images/xxxxx"},
+		},
+	}, result.ComplaintMediaList)
+
+	// 测试中平台证书与商户私钥为同一密钥对
+	plainText, err := mch.Decrypt(result.PayerPhone)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "13800138000", plainText)
+}
+
+func TestListComplaintNegotiations(t *testing.T) {
+	resp := []byte(`{"data":[{"log_id":"300285320210322170000071077","operator":"投诉人","operate_time":"2015-05-20T13:29:35.120+08:00","operate_type":"USER_CREATE_COMPLAINT","operate_details":"已与用户电话沟通解决","image_list":["xxx"]}],"limit":50,"offset":0,"total_count":1}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.mch.weixin.qq.com/v3/merchant-service/complaints-v2/200201820200101080076610000/negotiation-historys?limit=50", nil, gomock.Any()).Return(resp, nil)
+
+	mch := newTestMch(t, WithMockClient(client))
+
+	result := new(ResultComplaintNegotiations)
+
+	err := mch.Do(context.TODO(), ListComplaintNegotiations("200201820200101080076610000", 50, 0, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultComplaintNegotiations{
+		Data: []*ComplaintNegotiation{
+			{
+				LogID:          "300285320210322170000071077",
+				Operator:       "投诉人",
+				OperateTime:    "2015-05-20T13:29:35.120+08:00",
+				OperateType:    "USER_CREATE_COMPLAINT",
+				OperateDetails: "已与用户电话沟通解决",
+				ImageList:      []string{"xxx"},
+			},
+		},
+		Limit:      50,
+		TotalCount: 1,
+	}, result)
+}
+
+func TestResponseComplaint(t *testing.T) {
+	body := []byte(`{"complainted_mchid":"1900012181","response_content":"已与用户沟通解决","response_images":["file23578_21798531.jpg"],"jump_url":"https://www.xxx.com/notify","jump_url_text":"查看订单详情"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/v3/merchant-service/complaints-v2/200201820200101080076610000/response", body, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	mch := newTestMch(t, WithMockClient(client))
+
+	err := mch.Do(context.TODO(), ResponseComplaint("200201820200101080076610000", &ParamsComplaintResponse{
+		ComplaintedMchID: "1900012181",
+		ResponseContent:  "已与用户沟通解决",
+		ResponseImages:   []string{"file23578_21798531.jpg"},
+		JumpURL:          "https://www.xxx.com/notify",
+		JumpURLText:      "查看订单详情",
+	}))
+
+	assert.Nil(t, err)
+}
+
+func TestCompleteComplaint(t *testing.T) {
+	body := []byte(`{"complainted_mchid":"1900012181"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/v3/merchant-service/complaints-v2/200201820200101080076610000/complete", body, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	mch := newTestMch(t, WithMockClient(client))
+
+	err := mch.Do(context.TODO(), CompleteComplaint("200201820200101080076610000", "1900012181"))
+
+	assert.Nil(t, err)
+}
+
+func TestComplaintNotification(t *testing.T) {
+	body := []byte(`{"url":"https://www.xxx.com/notify"}`)
+	resp := []byte(`{"mchid":"1900000001","url":"https://www.xxx.com/notify"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/v3/merchant-service/complaint-notifications", body, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(resp, nil)
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.mch.weixin.qq.com/v3/merchant-service/complaint-notifications", nil, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(resp, nil)
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPut, "https://api.mch.weixin.qq.com/v3/merchant-service/complaint-notifications", body, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(resp, nil)
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodDelete, "https://api.mch.weixin.qq.com/v3/merchant-service/complaint-notifications", nil, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	mch := newTestMch(t, WithMockClient(client))
+
+	expected := &ResultComplaintNotification{
+		MchID: "1900000001",
+		URL:   "https://www.xxx.com/notify",
+	}
+
+	result := new(ResultComplaintNotification)
+	assert.Nil(t, mch.Do(context.TODO(), CreateComplaintNotification("https://www.xxx.com/notify", result)))
+	assert.Equal(t, expected, result)
+
+	result = new(ResultComplaintNotification)
+	assert.Nil(t, mch.Do(context.TODO(), GetComplaintNotification(result)))
+	assert.Equal(t, expected, result)
+
+	result = new(ResultComplaintNotification)
+	assert.Nil(t, mch.Do(context.TODO(), UpdateComplaintNotification("https://www.xxx.com/notify", result)))
+	assert.Equal(t, expected, result)
+
+	assert.Nil(t, mch.Do(context.TODO(), DeleteComplaintNotification()))
+}
+
+func TestParseComplaintNotify(t *testing.T) {
+	mch := newTestMch(t)
+
+	header, body := mockNotify(t, mch, "COMPLAINT.CREATE", []byte(`{"complaint_id":"200201820200101080076610000","action_type":"CREATE_COMPLAINT"}`))
+
+	result, err := ParseComplaintNotify(mch, header, body)
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ComplaintNotify{
+		ComplaintID: "200201820200101080076610000",
+		ActionType:  ComplaintActionCreateComplaint,
+	}, result)
+}
@@ -0,0 +1,32 @@
+package mchv3
+
+// 转账批次状态
+const (
+	TransferBatchWaitPay    = "WAIT_PAY"   // 待付款，商户员工确认付款阶段
+	TransferBatchAccepted   = "ACCEPTED"   // 已受理，批次已受理成功，若发起批量转账的30分钟后，转账批次单仍处于该状态，可能原因是商户账户余额不足等
+	TransferBatchProcessing = "PROCESSING" // 转账中，已开始处理批次内的转账明细单
+	TransferBatchFinished   = "FINISHED"   // 已完成，批次内的所有转账明细单都已处理完成
+	TransferBatchClosed     = "CLOSED"     // 已关闭，可查询具体的批次关闭原因确认
+)
+
+// 转账明细状态
+const (
+	TransferDetailInit       = "INIT"       // 初始态，系统转账校验中
+	TransferDetailWaitPay    = "WAIT_PAY"   // 待确认，待商户确认，符合免密条件时，系统会自动扭转为转账中
+	TransferDetailProcessing = "PROCESSING" // 转账中，正在处理中，转账结果尚未明确
+	TransferDetailSuccess    = "SUCCESS"    // 转账成功
+	TransferDetailFail       = "FAIL"       // 转账失败，需要确认失败原因后，再决定是否重新发起对该笔明细单的转账（并非整个转账批次单）
+)
+
+// 电子回单受理类型
+const (
+	AcceptBatchTransfer    = "BATCH_TRANSFER"     // 批量转账明细电子回单
+	AcceptTransferToPocket = "TRANSFER_TO_POCKET" // 企业付款至零钱电子回单
+	AcceptTransferToBank   = "TRANSFER_TO_BANK"   // 企业付款至银行卡电子回单
+)
+
+// 电子回单状态
+const (
+	ReceiptAccepted = "ACCEPTED" // 已受理，电子签章已受理成功
+	ReceiptFinished = "FINISHED" // 已完成，电子签章已处理完成
+)
@@ -0,0 +1,396 @@
+package mchv3
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/shenghui0779/gochat/urls"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// BusinessLicenseInfo 营业执照/登记证书信息
+type BusinessLicenseInfo struct {
+	BusinessLicenseCopy   string `json:"business_license_copy"`     // 证件扫描件，通过图片上传接口获取 media_id
+	BusinessLicenseNumber string `json:"business_license_number"`   // 证件注册号
+	MerchantName          string `json:"merchant_name"`             // 商户名称
+	LegalPerson           string `json:"legal_person"`              // 经营者/法定代表人姓名
+	CompanyAddress        string `json:"company_address,omitempty"` // 注册地址
+	BusinessTime          string `json:"business_time,omitempty"`   // 营业期限，如：["2014-01-01","长期"]
+}
+
+// IDCardInfo 经营者/法人身份证信息
+type IDCardInfo struct {
+	IDCardCopy      string `json:"id_card_copy"`       // 身份证人像面照片，通过图片上传接口获取 media_id
+	IDCardNational  string `json:"id_card_national"`   // 身份证国徽面照片，通过图片上传接口获取 media_id
+	IDCardName      string `json:"id_card_name"`       // 身份证姓名（明文，请求时自动加密）
+	IDCardNumber    string `json:"id_card_number"`     // 身份证号码（明文，请求时自动加密）
+	IDCardValidTime string `json:"id_card_valid_time"` // 身份证有效期限，如：2026-06-06 或 长期
+}
+
+// ApplymentAccountInfo 结算银行账户
+type ApplymentAccountInfo struct {
+	BankAccountType string `json:"bank_account_type"`        // 账户类型：74-对公账户，75-对私账户
+	AccountBank     string `json:"account_bank"`             // 开户银行
+	AccountName     string `json:"account_name"`             // 开户名称（明文，请求时自动加密）
+	BankAddressCode string `json:"bank_address_code"`        // 开户银行省市编码
+	BankBranchID    string `json:"bank_branch_id,omitempty"` // 开户银行联行号
+	BankName        string `json:"bank_name,omitempty"`      // 开户银行全称（含支行）
+	AccountNumber   string `json:"account_number"`           // 银行账号（明文，请求时自动加密）
+}
+
+// ApplymentContactInfo 超级管理员信息
+type ApplymentContactInfo struct {
+	ContactType         string `json:"contact_type"`            // 超级管理员类型：65-经营者/法定代表人，66-负责人
+	ContactName         string `json:"contact_name"`            // 超级管理员姓名（明文，请求时自动加密）
+	ContactIDCardNumber string `json:"contact_id_card_number"`  // 超级管理员身份证件号码（明文，请求时自动加密）
+	MobilePhone         string `json:"mobile_phone"`            // 超级管理员手机（明文，请求时自动加密）
+	ContactEmail        string `json:"contact_email,omitempty"` // 超级管理员邮箱（明文，请求时自动加密）
+}
+
+// SalesSceneInfo 店铺信息
+type SalesSceneInfo struct {
+	StoreName           string `json:"store_name"`                       // 店铺名称
+	StoreURL            string `json:"store_url,omitempty"`              // 店铺链接，与店铺二维码二选一
+	StoreQRCode         string `json:"store_qr_code,omitempty"`          // 店铺二维码，通过图片上传接口获取 media_id
+	MiniProgramSubAppID string `json:"mini_program_sub_appid,omitempty"` // 小程序AppID
+}
+
+// ParamsEcommerceApplyment 二级商户进件参数
+type ParamsEcommerceApplyment struct {
+	OutRequestNO         string                `json:"out_request_no"`                   // 业务申请编号
+	OrganizationType     string                `json:"organization_type"`                // 主体类型：2401-小微商户，2500-个人卖家，4-个体工商户，2-企业，3-党政、机关及事业单位，1708-其他组织
+	BusinessLicenseInfo  *BusinessLicenseInfo  `json:"business_license_info,omitempty"`  // 营业执照/登记证书信息
+	IDDocType            string                `json:"id_doc_type,omitempty"`            // 经营者/法人证件类型，默认 IDENTIFICATION_TYPE_MAINLAND_IDCARD
+	IDCardInfo           *IDCardInfo           `json:"id_card_info,omitempty"`           // 经营者/法人身份证信息
+	NeedAccountInfo      bool                  `json:"need_account_info"`                // 是否填写结算银行账户
+	AccountInfo          *ApplymentAccountInfo `json:"account_info,omitempty"`           // 结算银行账户
+	ContactInfo          *ApplymentContactInfo `json:"contact_info"`                     // 超级管理员信息
+	SalesSceneInfo       *SalesSceneInfo       `json:"sales_scene_info"`                 // 店铺信息
+	MerchantShortname    string                `json:"merchant_shortname"`               // 商户简称
+	Qualifications       string                `json:"qualifications,omitempty"`         // 特殊资质，media_id 组成的JSON数组字符串
+	BusinessAdditionPics string                `json:"business_addition_pics,omitempty"` // 补充材料，media_id 组成的JSON数组字符串
+	BusinessAdditionDesc string                `json:"business_addition_desc,omitempty"` // 补充说明
+}
+
+// ResultEcommerceApplyment 二级商户进件结果
+type ResultEcommerceApplyment struct {
+	ApplymentID  int64  `json:"applyment_id"`   // 微信支付申请单号
+	OutRequestNO string `json:"out_request_no"` // 业务申请编号
+}
+
+// SubmitEcommerceApplyment 电商收付通 - 二级商户进件
+// 注意：身份证、银行账户、超级管理员等敏感信息会使用平台证书自动加密，需通过 WithPlatformCert 设置平台证书
+// [参考](https://pay.weixin.qq.com/wiki/doc/apiv3_partner/apis/chapter7_1_1.shtml)
+func SubmitEcommerceApplyment(params *ParamsEcommerceApplyment, result *ResultEcommerceApplyment) Action {
+	return NewPostAction(urls.MchV3EcommerceApplyments+"/",
+		WithBody(func(mch *Mch) ([]byte, error) {
+			// 不修改调用方传入的参数
+			p := *params
+
+			if params.IDCardInfo != nil {
+				v := *params.IDCardInfo
+
+				if err := mch.encryptFields(&v.IDCardName, &v.IDCardNumber); err != nil {
+					return nil, err
+				}
+
+				p.IDCardInfo = &v
+			}
+
+			if params.AccountInfo != nil {
+				v := *params.AccountInfo
+
+				if err := mch.encryptFields(&v.AccountName, &v.AccountNumber); err != nil {
+					return nil, err
+				}
+
+				p.AccountInfo = &v
+			}
+
+			if params.ContactInfo != nil {
+				v := *params.ContactInfo
+
+				if err := mch.encryptFields(&v.ContactName, &v.ContactIDCardNumber, &v.MobilePhone, &v.ContactEmail); err != nil {
+					return nil, err
+				}
+
+				p.ContactInfo = &v
+			}
+
+			return wx.MarshalNoEscapeHTML(&p)
+		}),
+		WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// AccountValidation 汇款账户验证信息
+type AccountValidation struct {
+	AccountName              string `json:"account_name"`               // 付款户名（密文，可通过 Mch.Decrypt 解密）
+	AccountNO                string `json:"account_no"`                 // 付款卡号（密文，可通过 Mch.Decrypt 解密）
+	PayAmount                int64  `json:"pay_amount"`                 // 汇款金额，单位：分
+	DestinationAccountNumber string `json:"destination_account_number"` // 收款卡号
+	DestinationAccountName   string `json:"destination_account_name"`   // 收款户名
+	DestinationAccountBank   string `json:"destination_account_bank"`   // 开户银行
+	City                     string `json:"city"`                       // 省市信息
+	Remark                   string `json:"remark"`                     // 备注信息
+	Deadline                 string `json:"deadline"`                   // 汇款截止时间
+}
+
+// AuditDetail 驳回原因详情
+type AuditDetail struct {
+	ParamName    string `json:"param_name"`    // 参数名称
+	RejectReason string `json:"reject_reason"` // 驳回原因
+}
+
+// ResultEcommerceApplymentQuery 二级商户进件申请状态
+type ResultEcommerceApplymentQuery struct {
+	ApplymentState     string             `json:"applyment_state"`      // 申请状态
+	ApplymentStateDesc string             `json:"applyment_state_desc"` // 申请状态描述
+	SignState          string             `json:"sign_state"`           // 签约状态
+	SignURL            string             `json:"sign_url"`             // 签约链接
+	SubMchID           string             `json:"sub_mchid"`            // 电商平台二级商户号
+	AccountValidation  *AccountValidation `json:"account_validation"`   // 汇款账户验证信息
+	AuditDetail        []*AuditDetail     `json:"audit_detail"`         // 驳回原因详情
+	LegalValidationURL string             `json:"legal_validation_url"` // 法人验证链接
+	OutRequestNO       string             `json:"out_request_no"`       // 业务申请编号
+	ApplymentID        int64              `json:"applyment_id"`         // 微信支付申请单号
+}
+
+// QueryEcommerceApplymentByID 电商收付通 - 通过申请单ID查询申请状态
+func QueryEcommerceApplymentByID(applymentID int64, result *ResultEcommerceApplymentQuery) Action {
+	return NewGetAction(fmt.Sprintf("%s/%d", urls.MchV3EcommerceApplyments, applymentID),
+		WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// QueryEcommerceApplymentByOutRequestNO 电商收付通 - 通过业务申请编号查询申请状态
+func QueryEcommerceApplymentByOutRequestNO(outRequestNO string, result *ResultEcommerceApplymentQuery) Action {
+	return NewGetAction(fmt.Sprintf("%s/%s", urls.MchV3EcommerceApplymentByOutRequest, outRequestNO),
+		WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// ParamsSettlementModify 修改结算账户参数
+type ParamsSettlementModify struct {
+	AccountType     string `json:"account_type"`             // 账户类型：ACCOUNT_TYPE_BUSINESS-对公银行账户，ACCOUNT_TYPE_PRIVATE-经营者个人银行卡
+	AccountBank     string `json:"account_bank"`             // 开户银行
+	BankAddressCode string `json:"bank_address_code"`        // 开户银行省市编码
+	BankName        string `json:"bank_name,omitempty"`      // 开户银行全称（含支行）
+	BankBranchID    string `json:"bank_branch_id,omitempty"` // 开户银行联行号
+	AccountNumber   string `json:"account_number"`           // 银行账号（明文，请求时自动加密）
+}
+
+// ModifySettlement 电商收付通 - 修改结算账户
+func ModifySettlement(subMchID string, params *ParamsSettlementModify) Action {
+	return NewPostAction(fmt.Sprintf("%s/%s/modify-settlement", urls.MchV3EcommerceSubMerchants, subMchID),
+		WithBody(func(mch *Mch) ([]byte, error) {
+			p := *params
+
+			if err := mch.encryptFields(&p.AccountNumber); err != nil {
+				return nil, err
+			}
+
+			return wx.MarshalNoEscapeHTML(&p)
+		}),
+	)
+}
+
+// ResultSettlement 结算账户
+type ResultSettlement struct {
+	AccountType      string `json:"account_type"`       // 账户类型
+	AccountBank      string `json:"account_bank"`       // 开户银行
+	BankName         string `json:"bank_name"`          // 开户银行全称（含支行）
+	BankBranchID     string `json:"bank_branch_id"`     // 开户银行联行号
+	AccountNumber    string `json:"account_number"`     // 银行账号（掩码）
+	VerifyResult     string `json:"verify_result"`      // 汇款验证结果：VERIFYING、VERIFY_SUCCESS、VERIFY_FAIL
+	VerifyFailReason string `json:"verify_fail_reason"` // 汇款验证失败原因
+}
+
+// QuerySettlement 电商收付通 - 查询结算账户
+func QuerySettlement(subMchID string, result *ResultSettlement) Action {
+	return NewGetAction(fmt.Sprintf("%s/%s/settlement", urls.MchV3EcommerceSubMerchants, subMchID),
+		WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// ResultEcommerceBalance 二级商户账户余额
+type ResultEcommerceBalance struct {
+	SubMchID        string `json:"sub_mchid"`        // 二级商户号
+	AccountType     string `json:"account_type"`     // 账户类型：BASIC-基本账户，FEES-手续费账户，OPERATION-运营账户，DEPOSIT-保证金账户
+	AvailableAmount int64  `json:"available_amount"` // 可用余额，单位：分
+	PendingAmount   int64  `json:"pending_amount"`   // 不可用余额，单位：分
+}
+
+// QueryEcommerceBalance 电商收付通 - 查询二级商户账户实时余额（accountType 为空时查询基本账户）
+func QueryEcommerceBalance(subMchID, accountType string, result *ResultEcommerceBalance) Action {
+	options := []ActionOption{
+		WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	}
+
+	if len(accountType) != 0 {
+		options = append(options, WithQuery("account_type", accountType))
+	}
+
+	return NewGetAction(fmt.Sprintf("%s/%s", urls.MchV3EcommerceFundBalance, subMchID), options...)
+}
+
+// ProfitSharingReceiver 分账接收方
+type ProfitSharingReceiver struct {
+	Type            string `json:"type"`                    // 分账接收方类型：MERCHANT_ID-商户，PERSONAL_OPENID-个人
+	ReceiverAccount string `json:"receiver_account"`        // 分账接收方账号
+	Amount          int64  `json:"amount"`                  // 分账金额，单位：分
+	Description     string `json:"description"`             // 分账描述
+	ReceiverName    string `json:"receiver_name,omitempty"` // 分账接收方名称（明文，请求时自动加密）
+}
+
+// ParamsEcommerceProfitSharing 请求分账参数
+type ParamsEcommerceProfitSharing struct {
+	SubMchID      string                   `json:"sub_mchid"`      // 二级商户号
+	TransactionID string                   `json:"transaction_id"` // 微信订单号
+	OutOrderNO    string                   `json:"out_order_no"`   // 商户分账单号
+	Receivers     []*ProfitSharingReceiver `json:"receivers"`      // 分账接收方列表
+	Finish        bool                     `json:"finish"`         // 是否分账完成
+}
+
+// ProfitSharingReceiverResult 分账接收方结果
+type ProfitSharingReceiverResult struct {
+	ReceiverMchID   string `json:"receiver_mchid"`   // 分账接收商户号
+	Amount          int64  `json:"amount"`           // 分账金额
+	Description     string `json:"description"`      // 分账描述
+	Result          string `json:"result"`           // 分账结果：PENDING、SUCCESS、CLOSED
+	FinishTime      string `json:"finish_time"`      // 完成时间
+	FailReason      string `json:"fail_reason"`      // 分账失败原因
+	Type            string `json:"type"`             // 分账接收方类型
+	ReceiverAccount string `json:"receiver_account"` // 分账接收方账号
+	DetailID        string `json:"detail_id"`        // 分账明细单号
+}
+
+// ResultEcommerceProfitSharing 分账结果
+type ResultEcommerceProfitSharing struct {
+	SubMchID      string                         `json:"sub_mchid"`      // 二级商户号
+	TransactionID string                         `json:"transaction_id"` // 微信订单号
+	OutOrderNO    string                         `json:"out_order_no"`   // 商户分账单号
+	OrderID       string                         `json:"order_id"`       // 微信分账单号
+	Status        string                         `json:"status"`         // 分账单状态：PROCESSING、FINISHED
+	Receivers     []*ProfitSharingReceiverResult `json:"receivers"`      // 分账接收方列表
+}
+
+// EcommerceProfitSharing 电商收付通 - 请求分账
+// [参考](https://pay.weixin.qq.com/wiki/doc/apiv3_partner/apis/chapter7_4_1.shtml)
+func EcommerceProfitSharing(appid string, params *ParamsEcommerceProfitSharing, result *ResultEcommerceProfitSharing) Action {
+	return NewPostAction(urls.MchV3EcommerceProfitSharingOrders,
+		WithBody(func(mch *Mch) ([]byte, error) {
+			p := *params
+			p.Receivers = make([]*ProfitSharingReceiver, 0, len(params.Receivers))
+
+			for _, v := range params.Receivers {
+				receiver := *v
+
+				if err := mch.encryptFields(&receiver.ReceiverName); err != nil {
+					return nil, err
+				}
+
+				p.Receivers = append(p.Receivers, &receiver)
+			}
+
+			return wx.MarshalNoEscapeHTML(&struct {
+				AppID string `json:"appid"`
+				*ParamsEcommerceProfitSharing
+			}{
+				AppID:                        appid,
+				ParamsEcommerceProfitSharing: &p,
+			})
+		}),
+		WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// QueryEcommerceProfitSharing 电商收付通 - 查询分账结果
+func QueryEcommerceProfitSharing(subMchID, transactionID, outOrderNO string, result *ResultEcommerceProfitSharing) Action {
+	return NewGetAction(urls.MchV3EcommerceProfitSharingOrders,
+		WithQuery("sub_mchid", subMchID),
+		WithQuery("transaction_id", transactionID),
+		WithQuery("out_order_no", outOrderNO),
+		WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// ParamsProfitSharingFinish 完结分账参数
+type ParamsProfitSharingFinish struct {
+	SubMchID      string `json:"sub_mchid"`      // 二级商户号
+	TransactionID string `json:"transaction_id"` // 微信订单号
+	OutOrderNO    string `json:"out_order_no"`   // 商户分账单号
+	Description   string `json:"description"`    // 分账描述
+}
+
+// ResultProfitSharingFinish 完结分账结果
+type ResultProfitSharingFinish struct {
+	SubMchID      string `json:"sub_mchid"`      // 二级商户号
+	TransactionID string `json:"transaction_id"` // 微信订单号
+	OutOrderNO    string `json:"out_order_no"`   // 商户分账单号
+	OrderID       string `json:"order_id"`       // 微信分账单号
+}
+
+// FinishEcommerceProfitSharing 电商收付通 - 完结分账
+func FinishEcommerceProfitSharing(params *ParamsProfitSharingFinish, result *ResultProfitSharingFinish) Action {
+	return NewPostAction(urls.MchV3EcommerceProfitSharingFinish,
+		WithBody(func(mch *Mch) ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+		WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// ParamsProfitSharingReceiverAdd 添加分账接收方参数
+type ParamsProfitSharingReceiverAdd struct {
+	Type         string `json:"type"`           // 接收方类型：MERCHANT_ID-商户，PERSONAL_OPENID-个人
+	Account      string `json:"account"`        // 接收方账号
+	Name         string `json:"name,omitempty"` // 接收方名称（明文，请求时自动加密）
+	RelationType string `json:"relation_type"`  // 与分账方的关系类型
+}
+
+// ResultProfitSharingReceiverAdd 添加分账接收方结果
+type ResultProfitSharingReceiverAdd struct {
+	Type    string `json:"type"`    // 接收方类型
+	Account string `json:"account"` // 接收方账号
+}
+
+// AddEcommerceProfitSharingReceiver 电商收付通 - 添加分账接收方
+func AddEcommerceProfitSharingReceiver(appid string, params *ParamsProfitSharingReceiverAdd, result *ResultProfitSharingReceiverAdd) Action {
+	return NewPostAction(urls.MchV3EcommerceProfitSharingAddReceiver,
+		WithBody(func(mch *Mch) ([]byte, error) {
+			p := *params
+
+			if err := mch.encryptFields(&p.Name); err != nil {
+				return nil, err
+			}
+
+			return wx.MarshalNoEscapeHTML(&struct {
+				AppID string `json:"appid"`
+				*ParamsProfitSharingReceiverAdd
+			}{
+				AppID:                          appid,
+				ParamsProfitSharingReceiverAdd: &p,
+			})
+		}),
+		WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
@@ -0,0 +1,372 @@
+package mchv3
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/mock"
+)
+
+func TestSubmitEcommerceApplyment(t *testing.T) {
+	resp := []byte(`{"applyment_id":2000002124775691,"out_request_no":"APPLYMENT_00000000001"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	mch := newTestMch(t, WithMockClient(client))
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/v3/ecommerce/applyments/", gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, method, reqURL string, body []byte, options ...interface{}) ([]byte, error) {
+		params := new(ParamsEcommerceApplyment)
+
+		assert.Nil(t, json.Unmarshal(body, params))
+		assert.Equal(t, "APPLYMENT_00000000001", params.OutRequestNO)
+
+		// 敏感信息需加密
+		for plainText, cipherText := range map[string]string{
+			"张三":                 params.IDCardInfo.IDCardName,
+			"110101199003078888": params.IDCardInfo.IDCardNumber,
+			"13900000000":        params.ContactInfo.MobilePhone,
+		} {
+			v, err := mch.Decrypt(cipherText)
+
+			assert.Nil(t, err)
+			assert.Equal(t, plainText, v)
+		}
+
+		return resp, nil
+	})
+
+	params := &ParamsEcommerceApplyment{
+		OutRequestNO:     "APPLYMENT_00000000001",
+		OrganizationType: "2401",
+		IDCardInfo: &IDCardInfo{
+			IDCardCopy:      "jTpGmxUX3FBWVQ5NJInE4d2I6_H7I4",
+			IDCardNational:  "47ZC6GC-vnrbEny_Ie_An5-tCpqxucuxi-vByf3Gjm7KE53JXvGy9tqZm2XAUf-4KGprrKhpVBDIUv0OF4wFNIO4kqg05InE4d2I6_H7I4",
+			IDCardName:      "张三",
+			IDCardNumber:    "110101199003078888",
+			IDCardValidTime: "2026-06-06",
+		},
+		ContactInfo: &ApplymentContactInfo{
+			ContactType: "65",
+			MobilePhone: "13900000000",
+		},
+		SalesSceneInfo: &SalesSceneInfo{
+			StoreName: "爱烧烤",
+			StoreURL:  "http://www.qq.com",
+		},
+		MerchantShortname: "爱烧烤",
+	}
+
+	result := new(ResultEcommerceApplyment)
+
+	err := mch.Do(context.TODO(), SubmitEcommerceApplyment(params, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultEcommerceApplyment{
+		ApplymentID:  2000002124775691,
+		OutRequestNO: "APPLYMENT_00000000001",
+	}, result)
+
+	// 调用方参数不被修改
+	assert.Equal(t, "张三", params.IDCardInfo.IDCardName)
+}
+
+func TestQueryEcommerceApplymentByID(t *testing.T) {
+	resp := []byte(`{"applyment_state":"REJECTED","applyment_state_desc":"已驳回","sub_mchid":"","audit_detail":[{"param_name":"id_card_copy","reject_reason":"身份证背面识别失败"}],"out_request_no":"APPLYMENT_00000000001","applyment_id":2000002124775691}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.mch.weixin.qq.com/v3/ecommerce/applyments/2000002124775691", nil, gomock.Any()).Return(resp, nil)
+
+	mch := newTestMch(t, WithMockClient(client))
+
+	result := new(ResultEcommerceApplymentQuery)
+
+	err := mch.Do(context.TODO(), QueryEcommerceApplymentByID(2000002124775691, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultEcommerceApplymentQuery{
+		ApplymentState:     "REJECTED",
+		ApplymentStateDesc: "已驳回",
+		AuditDetail: []*AuditDetail{
+			{
+				ParamName:    "id_card_copy",
+				RejectReason: "身份证背面识别失败",
+			},
+		},
+		OutRequestNO: "APPLYMENT_00000000001",
+		ApplymentID:  2000002124775691,
+	}, result)
+}
+
+func TestQueryEcommerceApplymentByOutRequestNO(t *testing.T) {
+	resp := []byte(`{"applyment_state":"FINISH","applyment_state_desc":"完成","sub_mchid":"1542488631","out_request_no":"APPLYMENT_00000000001","applyment_id":2000002124775691}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.mch.weixin.qq.com/v3/ecommerce/applyments/out-request-no/APPLYMENT_00000000001", nil, gomock.Any()).Return(resp, nil)
+
+	mch := newTestMch(t, WithMockClient(client))
+
+	result := new(ResultEcommerceApplymentQuery)
+
+	err := mch.Do(context.TODO(), QueryEcommerceApplymentByOutRequestNO("APPLYMENT_00000000001", result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultEcommerceApplymentQuery{
+		ApplymentState:     "FINISH",
+		ApplymentStateDesc: "完成",
+		SubMchID:           "1542488631",
+		OutRequestNO:       "APPLYMENT_00000000001",
+		ApplymentID:        2000002124775691,
+	}, result)
+}
+
+func TestModifySettlement(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	mch := newTestMch(t, WithMockClient(client))
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/v3/apply4sub/sub_merchants/1900013511/modify-settlement", gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, method, reqURL string, body []byte, options ...interface{}) ([]byte, error) {
+		params := new(ParamsSettlementModify)
+
+		assert.Nil(t, json.Unmarshal(body, params))
+
+		accountNumber, err := mch.Decrypt(params.AccountNumber)
+		assert.Nil(t, err)
+		assert.Equal(t, "6222000000000000", accountNumber)
+
+		return nil, nil
+	})
+
+	err := mch.Do(context.TODO(), ModifySettlement("1900013511", &ParamsSettlementModify{
+		AccountType:     "ACCOUNT_TYPE_PRIVATE",
+		AccountBank:     "工商银行",
+		BankAddressCode: "110000",
+		AccountNumber:   "6222000000000000",
+	}))
+
+	assert.Nil(t, err)
+}
+
+func TestQuerySettlement(t *testing.T) {
+	resp := []byte(`{"account_type":"ACCOUNT_TYPE_PRIVATE","account_bank":"工商银行","bank_name":"施秉县农村信用合作联社城关信用社","bank_branch_id":"402713354941","account_number":"62*************78","verify_result":"VERIFY_SUCCESS"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.mch.weixin.qq.com/v3/apply4sub/sub_merchants/1900013511/settlement", nil, gomock.Any()).Return(resp, nil)
+
+	mch := newTestMch(t, WithMockClient(client))
+
+	result := new(ResultSettlement)
+
+	err := mch.Do(context.TODO(), QuerySettlement("1900013511", result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultSettlement{
+		AccountType:   "ACCOUNT_TYPE_PRIVATE",
+		AccountBank:   "工商银行",
+		BankName:      "施秉县农村信用合作联社城关信用社",
+		BankBranchID:  "402713354941",
+		AccountNumber: "62*************78",
+		VerifyResult:  "VERIFY_SUCCESS",
+	}, result)
+}
+
+func TestQueryEcommerceBalance(t *testing.T) {
+	resp := []byte(`{"sub_mchid":"1900000109","account_type":"BASIC","available_amount":100,"pending_amount":100}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.mch.weixin.qq.com/v3/ecommerce/fund/balance/1900000109?account_type=BASIC", nil, gomock.Any()).Return(resp, nil)
+
+	mch := newTestMch(t, WithMockClient(client))
+
+	result := new(ResultEcommerceBalance)
+
+	err := mch.Do(context.TODO(), QueryEcommerceBalance("1900000109", "BASIC", result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultEcommerceBalance{
+		SubMchID:        "1900000109",
+		AccountType:     "BASIC",
+		AvailableAmount: 100,
+		PendingAmount:   100,
+	}, result)
+}
+
+func TestEcommerceProfitSharing(t *testing.T) {
+	resp := []byte(`{"sub_mchid":"1900000109","transaction_id":"4208450740201411110007820472","out_order_no":"P20150806125346","order_id":"3008450740201411110007820472","status":"PROCESSING","receivers":[{"receiver_mchid":"1900000110","amount":100,"description":"分给商户1900000110","result":"PENDING","type":"MERCHANT_ID","receiver_account":"1900000110","detail_id":"36011111111111111111111"}]}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	mch := newTestMch(t, WithMockClient(client))
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/v3/ecommerce/profitsharing/orders", gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, method, reqURL string, body []byte, options ...interface{}) ([]byte, error) {
+		params := make(map[string]interface{})
+
+		assert.Nil(t, json.Unmarshal(body, &params))
+		assert.Equal(t, "wx8888888888888888", params["appid"])
+
+		receiver := params["receivers"].([]interface{})[0].(map[string]interface{})
+
+		name, err := mch.Decrypt(receiver["receiver_name"].(string))
+		assert.Nil(t, err)
+		assert.Equal(t, "示例商户全称", name)
+
+		return resp, nil
+	})
+
+	params := &ParamsEcommerceProfitSharing{
+		SubMchID:      "1900000109",
+		TransactionID: "4208450740201411110007820472",
+		OutOrderNO:    "P20150806125346",
+		Receivers: []*ProfitSharingReceiver{
+			{
+				Type:            "MERCHANT_ID",
+				ReceiverAccount: "1900000110",
+				Amount:          100,
+				Description:     "分给商户1900000110",
+				ReceiverName:    "示例商户全称",
+			},
+		},
+	}
+
+	result := new(ResultEcommerceProfitSharing)
+
+	err := mch.Do(context.TODO(), EcommerceProfitSharing("wx8888888888888888", params, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultEcommerceProfitSharing{
+		SubMchID:      "1900000109",
+		TransactionID: "4208450740201411110007820472",
+		OutOrderNO:    "P20150806125346",
+		OrderID:       "3008450740201411110007820472",
+		Status:        "PROCESSING",
+		Receivers: []*ProfitSharingReceiverResult{
+			{
+				ReceiverMchID:   "1900000110",
+				Amount:          100,
+				Description:     "分给商户1900000110",
+				Result:          "PENDING",
+				Type:            "MERCHANT_ID",
+				ReceiverAccount: "1900000110",
+				DetailID:        "36011111111111111111111",
+			},
+		},
+	}, result)
+}
+
+func TestQueryEcommerceProfitSharing(t *testing.T) {
+	resp := []byte(`{"sub_mchid":"1900000109","transaction_id":"4208450740201411110007820472","out_order_no":"P20150806125346","order_id":"3008450740201411110007820472","status":"FINISHED"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.mch.weixin.qq.com/v3/ecommerce/profitsharing/orders?out_order_no=P20150806125346&sub_mchid=1900000109&transaction_id=4208450740201411110007820472", nil, gomock.Any()).Return(resp, nil)
+
+	mch := newTestMch(t, WithMockClient(client))
+
+	result := new(ResultEcommerceProfitSharing)
+
+	err := mch.Do(context.TODO(), QueryEcommerceProfitSharing("1900000109", "4208450740201411110007820472", "P20150806125346", result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultEcommerceProfitSharing{
+		SubMchID:      "1900000109",
+		TransactionID: "4208450740201411110007820472",
+		OutOrderNO:    "P20150806125346",
+		OrderID:       "3008450740201411110007820472",
+		Status:        "FINISHED",
+	}, result)
+}
+
+func TestFinishEcommerceProfitSharing(t *testing.T) {
+	body := []byte(`{"sub_mchid":"1900000109","transaction_id":"4208450740201411110007820472","out_order_no":"P20150806125346","description":"分账完结"}`)
+	resp := []byte(`{"sub_mchid":"1900000109","transaction_id":"4208450740201411110007820472","out_order_no":"P20150806125346","order_id":"3008450740201411110007820472"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/v3/ecommerce/profitsharing/finish-order", body, gomock.Any()).Return(resp, nil)
+
+	mch := newTestMch(t, WithMockClient(client))
+
+	params := &ParamsProfitSharingFinish{
+		SubMchID:      "1900000109",
+		TransactionID: "4208450740201411110007820472",
+		OutOrderNO:    "P20150806125346",
+		Description:   "分账完结",
+	}
+
+	result := new(ResultProfitSharingFinish)
+
+	err := mch.Do(context.TODO(), FinishEcommerceProfitSharing(params, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultProfitSharingFinish{
+		SubMchID:      "1900000109",
+		TransactionID: "4208450740201411110007820472",
+		OutOrderNO:    "P20150806125346",
+		OrderID:       "3008450740201411110007820472",
+	}, result)
+}
+
+func TestAddEcommerceProfitSharingReceiver(t *testing.T) {
+	body := []byte(`{"appid":"wx8888888888888888","type":"PERSONAL_OPENID","account":"oUpF8uMuAJO_M2pxb1Q9zNjWeS6o","relation_type":"STAFF"}`)
+	resp := []byte(`{"type":"PERSONAL_OPENID","account":"oUpF8uMuAJO_M2pxb1Q9zNjWeS6o"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/v3/ecommerce/profitsharing/receivers/add", body, gomock.Any()).Return(resp, nil)
+
+	mch := newTestMch(t, WithMockClient(client))
+
+	params := &ParamsProfitSharingReceiverAdd{
+		Type:         "PERSONAL_OPENID",
+		Account:      "oUpF8uMuAJO_M2pxb1Q9zNjWeS6o",
+		RelationType: "STAFF",
+	}
+
+	result := new(ResultProfitSharingReceiverAdd)
+
+	err := mch.Do(context.TODO(), AddEcommerceProfitSharingReceiver("wx8888888888888888", params, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultProfitSharingReceiverAdd{
+		Type:    "PERSONAL_OPENID",
+		Account: "oUpF8uMuAJO_M2pxb1Q9zNjWeS6o",
+	}, result)
+}
@@ -0,0 +1,246 @@
+package mchv3
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/shenghui0779/gochat/urls"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// FavorStockUseRule 代金券发放规则
+type FavorStockUseRule struct {
+	MaxCoupons         int                     `json:"max_coupons"`                   // 发放总上限
+	MaxAmount          int64                   `json:"max_amount"`                    // 总预算，单位：分
+	MaxAmountByDay     int64                   `json:"max_amount_by_day,omitempty"`   // 单天预算发放上限，单位：分
+	MaxCouponsPerUser  int                     `json:"max_coupons_per_user"`          // 单个用户可领个数
+	NaturalPersonLimit bool                    `json:"natural_person_limit"`          // 是否开启自然人限制
+	PreventAPIAbuse    bool                    `json:"prevent_api_abuse"`             // 是否开启防刷拦截
+	FixedNormalCoupon  *FavorFixedNormalCoupon `json:"fixed_normal_coupon,omitempty"` // 固定面额满减券使用规则（查询返回）
+}
+
+// FavorPatternInfo 代金券样式
+type FavorPatternInfo struct {
+	Description     string `json:"description"`                // 使用说明
+	MerchantLogo    string `json:"merchant_logo,omitempty"`    // 商户logo
+	MerchantName    string `json:"merchant_name,omitempty"`    // 品牌名称
+	BackgroundColor string `json:"background_color,omitempty"` // 背景颜色
+	CouponImage     string `json:"coupon_image,omitempty"`     // 券详情图片
+}
+
+// FavorFixedNormalCoupon 固定面额满减券使用规则
+type FavorFixedNormalCoupon struct {
+	CouponAmount       int64 `json:"coupon_amount"`       // 面额，单位：分
+	TransactionMinimum int64 `json:"transaction_minimum"` // 使用券金额门槛，单位：分
+}
+
+// FavorCouponUseRule 代金券核销规则
+type FavorCouponUseRule struct {
+	FixedNormalCoupon  *FavorFixedNormalCoupon `json:"fixed_normal_coupon"`       // 固定面额满减券使用规则
+	GoodsTag           []string                `json:"goods_tag,omitempty"`       // 订单优惠标记
+	LimitPay           []string                `json:"limit_pay,omitempty"`       // 指定付款方式
+	TradeType          []string                `json:"trade_type,omitempty"`      // 支付方式：MICROAPP、APPPAY、PPAY、CARD、FACE、OTHER
+	CombineUse         bool                    `json:"combine_use"`               // 是否可叠加其他优惠
+	AvailableItems     []string                `json:"available_items,omitempty"` // 可核销商品编码
+	AvailableMerchants []string                `json:"available_merchants"`       // 可用商户号
+}
+
+// ParamsFavorStockCreate 创建代金券批次参数
+type ParamsFavorStockCreate struct {
+	StockName          string              `json:"stock_name"`           // 批次名称
+	Comment            string              `json:"comment,omitempty"`    // 批次备注
+	BelongMerchant     string              `json:"belong_merchant"`      // 归属商户号
+	AvailableBeginTime string              `json:"available_begin_time"` // 可用时间-开始时间，遵循rfc3339标准格式
+	AvailableEndTime   string              `json:"available_end_time"`   // 可用时间-结束时间，遵循rfc3339标准格式
+	StockUseRule       *FavorStockUseRule  `json:"stock_use_rule"`       // 发放规则
+	PatternInfo        *FavorPatternInfo   `json:"pattern_info"`         // 样式设置
+	CouponUseRule      *FavorCouponUseRule `json:"coupon_use_rule"`      // 核销规则
+	NoCash             bool                `json:"no_cash"`              // 营销经费，true-免充值，false-预充值
+	StockType          string              `json:"stock_type"`           // 批次类型，仅支持：NORMAL-固定面额满减券批次
+	OutRequestNO       string              `json:"out_request_no"`       // 商户单据号
+}
+
+// ResultFavorStockCreate 创建代金券批次结果
+type ResultFavorStockCreate struct {
+	StockID    string `json:"stock_id"`    // 批次号
+	CreateTime string `json:"create_time"` // 创建时间
+}
+
+// CreateFavorStock 代金券 - 创建代金券批次（批次创建方为当前商户）
+// [参考](https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter9_1_1.shtml)
+func CreateFavorStock(params *ParamsFavorStockCreate, result *ResultFavorStockCreate) Action {
+	return NewPostAction(urls.MchV3FavorCouponStocks,
+		WithBody(func(mch *Mch) ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(&struct {
+				StockCreatorMchID string `json:"stock_creator_mchid"`
+				*ParamsFavorStockCreate
+			}{
+				StockCreatorMchID:      mch.mchid,
+				ParamsFavorStockCreate: params,
+			})
+		}),
+		WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// ResultFavorStockStatus 代金券批次状态变更结果
+type ResultFavorStockStatus struct {
+	StockID     string `json:"stock_id"`     // 批次号
+	StartTime   string `json:"start_time"`   // 生效时间（激活批次返回）
+	PauseTime   string `json:"pause_time"`   // 暂停时间（暂停批次返回）
+	RestartTime string `json:"restart_time"` // 重启时间（重启批次返回）
+}
+
+// StartFavorStock 代金券 - 激活代金券批次
+// [参考](https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter9_1_3.shtml)
+func StartFavorStock(stockID string, result *ResultFavorStockStatus) Action {
+	return favorStockStatus(stockID, "start", result)
+}
+
+// PauseFavorStock 代金券 - 暂停代金券批次
+// [参考](https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter9_1_13.shtml)
+func PauseFavorStock(stockID string, result *ResultFavorStockStatus) Action {
+	return favorStockStatus(stockID, "pause", result)
+}
+
+// RestartFavorStock 代金券 - 重启代金券批次
+// [参考](https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter9_1_14.shtml)
+func RestartFavorStock(stockID string, result *ResultFavorStockStatus) Action {
+	return favorStockStatus(stockID, "restart", result)
+}
+
+func favorStockStatus(stockID, op string, result *ResultFavorStockStatus) Action {
+	return NewPostAction(fmt.Sprintf("%s/%s/%s", urls.MchV3FavorStocks, stockID, op),
+		WithBody(func(mch *Mch) ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(map[string]string{
+				"stock_creator_mchid": mch.mchid,
+			})
+		}),
+		WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// ParamsFavorCouponSend 发放代金券参数
+type ParamsFavorCouponSend struct {
+	StockID           string `json:"stock_id"`                 // 批次号
+	OutRequestNO      string `json:"out_request_no"`           // 商户单据号
+	StockCreatorMchID string `json:"stock_creator_mchid"`      // 创建批次的商户号
+	CouponValue       int64  `json:"coupon_value,omitempty"`   // 指定面额发券，单位：分
+	CouponMinimum     int64  `json:"coupon_minimum,omitempty"` // 指定面额发券时的券门槛，单位：分
+}
+
+// ResultFavorCouponSend 发放代金券结果
+type ResultFavorCouponSend struct {
+	CouponID string `json:"coupon_id"` // 代金券id
+}
+
+// SendFavorCoupon 代金券 - 发放代金券（stock_creator_mchid 为空时默认为当前商户）
+// [参考](https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter9_1_2.shtml)
+func SendFavorCoupon(appid, openid string, params *ParamsFavorCouponSend, result *ResultFavorCouponSend) Action {
+	return NewPostAction(fmt.Sprintf("%s/%s/coupons", urls.MchV3FavorUsers, openid),
+		WithBody(func(mch *Mch) ([]byte, error) {
+			p := *params
+
+			if len(p.StockCreatorMchID) == 0 {
+				p.StockCreatorMchID = mch.mchid
+			}
+
+			return wx.MarshalNoEscapeHTML(&struct {
+				AppID string `json:"appid"`
+				*ParamsFavorCouponSend
+			}{
+				AppID:                 appid,
+				ParamsFavorCouponSend: &p,
+			})
+		}),
+		WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// ResultFavorStock 代金券批次详情
+type ResultFavorStock struct {
+	StockID            string             `json:"stock_id"`             // 批次号
+	StockCreatorMchID  string             `json:"stock_creator_mchid"`  // 创建批次的商户号
+	StockName          string             `json:"stock_name"`           // 批次名称
+	Status             string             `json:"status"`               // 批次状态：unactivated、audit、running、stoped、paused
+	CreateTime         string             `json:"create_time"`          // 创建时间
+	Description        string             `json:"description"`          // 使用说明
+	StockUseRule       *FavorStockUseRule `json:"stock_use_rule"`       // 满减券批次使用规则
+	AvailableBeginTime string             `json:"available_begin_time"` // 可用开始时间
+	AvailableEndTime   string             `json:"available_end_time"`   // 可用结束时间
+	DistributedCoupons int                `json:"distributed_coupons"`  // 已发券数量
+	NoCash             bool               `json:"no_cash"`              // 是否无资金流
+	StartTime          string             `json:"start_time"`           // 激活批次的时间
+	StopTime           string             `json:"stop_time"`            // 终止批次的时间
+	Singleitem         bool               `json:"singleitem"`           // 是否单品优惠
+	StockType          string             `json:"stock_type"`           // 批次类型
+}
+
+// QueryFavorStock 代金券 - 查询批次详情
+// [参考](https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter9_1_5.shtml)
+func QueryFavorStock(stockID string, result *ResultFavorStock) Action {
+	return NewGetAction(fmt.Sprintf("%s/%s", urls.MchV3FavorStocks, stockID),
+		WithQueryFunc(func(mch *Mch, query url.Values) {
+			query.Set("stock_creator_mchid", mch.mchid)
+		}),
+		WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// FavorConsumeInformation 代金券核销信息
+type FavorConsumeInformation struct {
+	ConsumeTime   string `json:"consume_time"`   // 核销时间
+	ConsumeMchID  string `json:"consume_mchid"`  // 核销商户号
+	TransactionID string `json:"transaction_id"` // 核销订单号
+}
+
+// ResultFavorCoupon 代金券详情
+type ResultFavorCoupon struct {
+	StockCreatorMchID       string                   `json:"stock_creator_mchid"`       // 创建批次的商户号
+	StockID                 string                   `json:"stock_id"`                  // 批次号
+	CouponID                string                   `json:"coupon_id"`                 // 代金券id
+	CouponName              string                   `json:"coupon_name"`               // 代金券名称
+	Status                  string                   `json:"status"`                    // 代金券状态：SENDED-可用，USED-已实扣，EXPIRED-已过期
+	Description             string                   `json:"description"`               // 使用说明
+	CreateTime              string                   `json:"create_time"`               // 领券时间
+	CouponType              string                   `json:"coupon_type"`               // 券类型：NORMAL-满减券，CUT_TO-减至券
+	NoCash                  bool                     `json:"no_cash"`                   // 是否无资金流
+	AvailableBeginTime      string                   `json:"available_begin_time"`      // 可用开始时间
+	AvailableEndTime        string                   `json:"available_end_time"`        // 可用结束时间
+	Singleitem              bool                     `json:"singleitem"`                // 是否单品优惠
+	NormalCouponInformation *FavorFixedNormalCoupon  `json:"normal_coupon_information"` // 满减券信息
+	ConsumeInformation      *FavorConsumeInformation `json:"consume_information"`       // 已实扣代金券核销信息
+}
+
+// QueryFavorCoupon 代金券 - 查询代金券详情
+// [参考](https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter9_1_6.shtml)
+func QueryFavorCoupon(appid, openid, couponID string, result *ResultFavorCoupon) Action {
+	return NewGetAction(fmt.Sprintf("%s/%s/coupons/%s", urls.MchV3FavorUsers, openid, couponID),
+		WithQuery("appid", appid),
+		WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// ParseFavorCouponUseNotify 代金券 - 解析核销事件回调通知（event_type 为 COUPON.USE）
+// [参考](https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter9_1_15.shtml)
+func ParseFavorCouponUseNotify(mch *Mch, header http.Header, body []byte) (*ResultFavorCoupon, error) {
+	result := new(ResultFavorCoupon)
+
+	if _, err := mch.ParseNotify(header, body, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,256 @@
+package mchv3
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/mock"
+)
+
+func TestCreateFavorStock(t *testing.T) {
+	body := []byte(`{"stock_creator_mchid":"1900000001","stock_name":"微信支付代金券批次","belong_merchant":"1900000001","available_begin_time":"2015-05-20T13:29:35+08:00","available_end_time":"2015-05-20T13:29:35+08:00","stock_use_rule":{"max_coupons":100,"max_amount":5000,"max_coupons_per_user":3,"natural_person_limit":false,"prevent_api_abuse":false},"pattern_info":{"description":"微信支付营销代金券"},"coupon_use_rule":{"fixed_normal_coupon":{"coupon_amount":50,"transaction_minimum":100},"combine_use":false,"available_merchants":["1900000001"]},"no_cash":false,"stock_type":"NORMAL","out_request_no":"89560002019101000121"}`)
+	resp := []byte(`{"stock_id":"9856000","create_time":"2015-05-20T13:29:35.120+08:00"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/v3/marketing/favor/coupon-stocks", body, gomock.Any()).Return(resp, nil)
+
+	mch := newTestMch(t, WithMockClient(client))
+
+	params := &ParamsFavorStockCreate{
+		StockName:          "微信支付代金券批次",
+		BelongMerchant:     "1900000001",
+		AvailableBeginTime: "2015-05-20T13:29:35+08:00",
+		AvailableEndTime:   "2015-05-20T13:29:35+08:00",
+		StockUseRule: &FavorStockUseRule{
+			MaxCoupons:        100,
+			MaxAmount:         5000,
+			MaxCouponsPerUser: 3,
+		},
+		PatternInfo: &FavorPatternInfo{
+			Description: "微信支付营销代金券",
+		},
+		CouponUseRule: &FavorCouponUseRule{
+			FixedNormalCoupon: &FavorFixedNormalCoupon{
+				CouponAmount:       50,
+				TransactionMinimum: 100,
+			},
+			AvailableMerchants: []string{"1900000001"},
+		},
+		StockType:    "NORMAL",
+		OutRequestNO: "89560002019101000121",
+	}
+
+	result := new(ResultFavorStockCreate)
+
+	err := mch.Do(context.TODO(), CreateFavorStock(params, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultFavorStockCreate{
+		StockID:    "9856000",
+		CreateTime: "2015-05-20T13:29:35.120+08:00",
+	}, result)
+}
+
+func TestStartFavorStock(t *testing.T) {
+	body := []byte(`{"stock_creator_mchid":"1900000001"}`)
+	resp := []byte(`{"start_time":"2015-05-20T13:29:35.120+08:00","stock_id":"9856000"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/v3/marketing/favor/stocks/9856000/start", body, gomock.Any()).Return(resp, nil)
+
+	mch := newTestMch(t, WithMockClient(client))
+
+	result := new(ResultFavorStockStatus)
+
+	err := mch.Do(context.TODO(), StartFavorStock("9856000", result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultFavorStockStatus{
+		StockID:   "9856000",
+		StartTime: "2015-05-20T13:29:35.120+08:00",
+	}, result)
+}
+
+func TestPauseFavorStock(t *testing.T) {
+	body := []byte(`{"stock_creator_mchid":"1900000001"}`)
+	resp := []byte(`{"pause_time":"2015-05-20T13:29:35.120+08:00","stock_id":"9856000"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/v3/marketing/favor/stocks/9856000/pause", body, gomock.Any()).Return(resp, nil)
+
+	mch := newTestMch(t, WithMockClient(client))
+
+	result := new(ResultFavorStockStatus)
+
+	err := mch.Do(context.TODO(), PauseFavorStock("9856000", result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultFavorStockStatus{
+		StockID:   "9856000",
+		PauseTime: "2015-05-20T13:29:35.120+08:00",
+	}, result)
+}
+
+func TestRestartFavorStock(t *testing.T) {
+	body := []byte(`{"stock_creator_mchid":"1900000001"}`)
+	resp := []byte(`{"restart_time":"2015-05-20T13:29:35.120+08:00","stock_id":"9856000"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/v3/marketing/favor/stocks/9856000/restart", body, gomock.Any()).Return(resp, nil)
+
+	mch := newTestMch(t, WithMockClient(client))
+
+	result := new(ResultFavorStockStatus)
+
+	err := mch.Do(context.TODO(), RestartFavorStock("9856000", result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultFavorStockStatus{
+		StockID:     "9856000",
+		RestartTime: "2015-05-20T13:29:35.120+08:00",
+	}, result)
+}
+
+func TestSendFavorCoupon(t *testing.T) {
+	body := []byte(`{"appid":"wx233544546545989","stock_id":"9856000","out_request_no":"89560002019101000121","stock_creator_mchid":"1900000001"}`)
+	resp := []byte(`{"coupon_id":"9867041"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/v3/marketing/favor/users/2323dfsdf342342/coupons", body, gomock.Any()).Return(resp, nil)
+
+	mch := newTestMch(t, WithMockClient(client))
+
+	params := &ParamsFavorCouponSend{
+		StockID:      "9856000",
+		OutRequestNO: "89560002019101000121",
+	}
+
+	result := new(ResultFavorCouponSend)
+
+	err := mch.Do(context.TODO(), SendFavorCoupon("wx233544546545989", "2323dfsdf342342", params, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultFavorCouponSend{
+		CouponID: "9867041",
+	}, result)
+}
+
+func TestQueryFavorStock(t *testing.T) {
+	resp := []byte(`{"stock_id":"9856000","stock_creator_mchid":"1900000001","stock_name":"微信支付代金券批次","status":"running","create_time":"2015-05-20T13:29:35.120+08:00","description":"微信支付营销","stock_use_rule":{"max_coupons":100,"max_amount":5000,"max_coupons_per_user":3,"natural_person_limit":false,"prevent_api_abuse":false,"fixed_normal_coupon":{"coupon_amount":50,"transaction_minimum":100}},"available_begin_time":"2015-05-20T13:29:35.120+08:00","available_end_time":"2015-05-20T13:29:35.120+08:00","distributed_coupons":10,"no_cash":false,"singleitem":false,"stock_type":"NORMAL"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.mch.weixin.qq.com/v3/marketing/favor/stocks/9856000?stock_creator_mchid=1900000001", nil, gomock.Any()).Return(resp, nil)
+
+	mch := newTestMch(t, WithMockClient(client))
+
+	result := new(ResultFavorStock)
+
+	err := mch.Do(context.TODO(), QueryFavorStock("9856000", result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultFavorStock{
+		StockID:           "9856000",
+		StockCreatorMchID: "1900000001",
+		StockName:         "微信支付代金券批次",
+		Status:            "running",
+		CreateTime:        "2015-05-20T13:29:35.120+08:00",
+		Description:       "微信支付营销",
+		StockUseRule: &FavorStockUseRule{
+			MaxCoupons:        100,
+			MaxAmount:         5000,
+			MaxCouponsPerUser: 3,
+			FixedNormalCoupon: &FavorFixedNormalCoupon{
+				CouponAmount:       50,
+				TransactionMinimum: 100,
+			},
+		},
+		AvailableBeginTime: "2015-05-20T13:29:35.120+08:00",
+		AvailableEndTime:   "2015-05-20T13:29:35.120+08:00",
+		DistributedCoupons: 10,
+		StockType:          "NORMAL",
+	}, result)
+}
+
+func TestQueryFavorCoupon(t *testing.T) {
+	resp := []byte(`{"stock_creator_mchid":"1900000001","stock_id":"9856000","coupon_id":"9867041","coupon_name":"微信支付代金券","status":"SENDED","description":"微信支付营销","create_time":"2015-05-20T13:29:35.120+08:00","coupon_type":"NORMAL","no_cash":false,"available_begin_time":"2015-05-20T13:29:35.120+08:00","available_end_time":"2015-05-20T13:29:35.120+08:00","singleitem":false,"normal_coupon_information":{"coupon_amount":100,"transaction_minimum":100}}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.mch.weixin.qq.com/v3/marketing/favor/users/2323dfsdf342342/coupons/9867041?appid=wx233544546545989", nil, gomock.Any()).Return(resp, nil)
+
+	mch := newTestMch(t, WithMockClient(client))
+
+	result := new(ResultFavorCoupon)
+
+	err := mch.Do(context.TODO(), QueryFavorCoupon("wx233544546545989", "2323dfsdf342342", "9867041", result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultFavorCoupon{
+		StockCreatorMchID:  "1900000001",
+		StockID:            "9856000",
+		CouponID:           "9867041",
+		CouponName:         "微信支付代金券",
+		Status:             "SENDED",
+		Description:        "微信支付营销",
+		CreateTime:         "2015-05-20T13:29:35.120+08:00",
+		CouponType:         "NORMAL",
+		AvailableBeginTime: "2015-05-20T13:29:35.120+08:00",
+		AvailableEndTime:   "2015-05-20T13:29:35.120+08:00",
+		NormalCouponInformation: &FavorFixedNormalCoupon{
+			CouponAmount:       100,
+			TransactionMinimum: 100,
+		},
+	}, result)
+}
+
+func TestParseFavorCouponUseNotify(t *testing.T) {
+	mch := newTestMch(t)
+
+	header, body := mockNotify(t, mch, "COUPON.USE", []byte(`{"stock_creator_mchid":"1900000001","stock_id":"9856000","coupon_id":"9867041","status":"USED","consume_information":{"consume_time":"2015-05-20T13:29:35.120+08:00","consume_mchid":"9856081","transaction_id":"2345234523"}}`))
+
+	result, err := ParseFavorCouponUseNotify(mch, header, body)
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultFavorCoupon{
+		StockCreatorMchID: "1900000001",
+		StockID:           "9856000",
+		CouponID:          "9867041",
+		Status:            "USED",
+		ConsumeInformation: &FavorConsumeInformation{
+			ConsumeTime:   "2015-05-20T13:29:35.120+08:00",
+			ConsumeMchID:  "9856081",
+			TransactionID: "2345234523",
+		},
+	}, result)
+}
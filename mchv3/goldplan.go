@@ -0,0 +1,78 @@
+package mchv3
+
+import (
+	"encoding/json"
+
+	"github.com/shenghui0779/gochat/urls"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// 点金计划操作类型
+const (
+	GoldPlanOpen  = "OPEN"  // 开通
+	GoldPlanClose = "CLOSE" // 关闭
+)
+
+// ResultGoldPlan 点金计划操作结果
+type ResultGoldPlan struct {
+	SubMchID string `json:"sub_mchid"` // 特约商户号
+}
+
+// ChangeGoldPlanStatus 点金计划（服务商）- 点金计划管理：为特约商户开通或关闭点金计划
+func ChangeGoldPlanStatus(subMchID, operationType string, result *ResultGoldPlan) Action {
+	return goldPlanAction(urls.MchV3GoldPlanChangeStatus, map[string]interface{}{
+		"sub_mchid":      subMchID,
+		"operation_type": operationType,
+	}, result)
+}
+
+// ChangeCustomPageStatus 点金计划（服务商）- 商家小票管理：为特约商户开通或关闭商家自定义小票（需先开通点金计划）
+func ChangeCustomPageStatus(subMchID, operationType string, result *ResultGoldPlan) Action {
+	return goldPlanAction(urls.MchV3GoldPlanChangeCustomPageStatus, map[string]interface{}{
+		"sub_mchid":      subMchID,
+		"operation_type": operationType,
+	}, result)
+}
+
+// SetAdvertisingIndustryFilter 点金计划（服务商）- 同业过滤标签管理：设置特约商户的同业过滤标签（行业标识取值详见微信支付文档），不展示同行业的广告
+func SetAdvertisingIndustryFilter(subMchID string, filters []string) Action {
+	return goldPlanAction(urls.MchV3GoldPlanSetAdvertisingFilter, map[string]interface{}{
+		"sub_mchid":                    subMchID,
+		"advertising_industry_filters": filters,
+	}, nil)
+}
+
+// OpenAdvertisingShow 点金计划（服务商）- 开通广告展示：在特约商户的支付结果页展示广告，可同时设置同业过滤标签（可选）
+func OpenAdvertisingShow(subMchID string, filters ...string) Action {
+	params := map[string]interface{}{
+		"sub_mchid": subMchID,
+	}
+
+	if len(filters) != 0 {
+		params["advertising_industry_filters"] = filters
+	}
+
+	return goldPlanAction(urls.MchV3GoldPlanOpenAdvertisingShow, params, nil)
+}
+
+// CloseAdvertisingShow 点金计划（服务商）- 关闭广告展示
+func CloseAdvertisingShow(subMchID string) Action {
+	return goldPlanAction(urls.MchV3GoldPlanCloseAdvertisingShow, map[string]interface{}{
+		"sub_mchid": subMchID,
+	}, nil)
+}
+
+func goldPlanAction(reqURL string, params map[string]interface{}, result *ResultGoldPlan) Action {
+	return NewPostAction(reqURL,
+		WithBody(func(mch *Mch) ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+		WithDecode(func(b []byte) error {
+			if result == nil {
+				return nil
+			}
+
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
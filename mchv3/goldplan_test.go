@@ -0,0 +1,90 @@
+package mchv3
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/mock"
+)
+
+func TestChangeGoldPlanStatus(t *testing.T) {
+	body := []byte(`{"operation_type":"OPEN","sub_mchid":"1900000109"}`)
+	resp := []byte(`{"sub_mchid":"1900000109"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/v3/goldplan/merchants/changegoldplanstatus", body, gomock.Any()).Return(resp, nil)
+
+	mch := newTestMch(t, WithPartner(), WithMockClient(client))
+
+	result := new(ResultGoldPlan)
+
+	err := mch.Do(context.TODO(), ChangeGoldPlanStatus("1900000109", GoldPlanOpen, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultGoldPlan{
+		SubMchID: "1900000109",
+	}, result)
+}
+
+func TestChangeCustomPageStatus(t *testing.T) {
+	body := []byte(`{"operation_type":"CLOSE","sub_mchid":"1900000109"}`)
+	resp := []byte(`{"sub_mchid":"1900000109"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/v3/goldplan/merchants/changecustompagestatus", body, gomock.Any()).Return(resp, nil)
+
+	mch := newTestMch(t, WithPartner(), WithMockClient(client))
+
+	result := new(ResultGoldPlan)
+
+	err := mch.Do(context.TODO(), ChangeCustomPageStatus("1900000109", GoldPlanClose, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, "1900000109", result.SubMchID)
+}
+
+func TestSetAdvertisingIndustryFilter(t *testing.T) {
+	body := []byte(`{"advertising_industry_filters":["E_COMMERCE","LOVE_MARRIAGE"],"sub_mchid":"1900000109"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/v3/goldplan/merchants/set-advertising-industry-filter", body, gomock.Any()).Return(nil, nil)
+
+	mch := newTestMch(t, WithPartner(), WithMockClient(client))
+
+	err := mch.Do(context.TODO(), SetAdvertisingIndustryFilter("1900000109", []string{"E_COMMERCE", "LOVE_MARRIAGE"}))
+
+	assert.Nil(t, err)
+}
+
+func TestAdvertisingShow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/v3/goldplan/merchants/open-advertising-show", []byte(`{"sub_mchid":"1900000109"}`), gomock.Any()).Return(nil, nil)
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/v3/goldplan/merchants/open-advertising-show", []byte(`{"advertising_industry_filters":["E_COMMERCE"],"sub_mchid":"1900000109"}`), gomock.Any()).Return(nil, nil)
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/v3/goldplan/merchants/close-advertising-show", []byte(`{"sub_mchid":"1900000109"}`), gomock.Any()).Return(nil, nil)
+
+	mch := newTestMch(t, WithPartner(), WithMockClient(client))
+
+	assert.Nil(t, mch.Do(context.TODO(), OpenAdvertisingShow("1900000109")))
+	assert.Nil(t, mch.Do(context.TODO(), OpenAdvertisingShow("1900000109", "E_COMMERCE")))
+	assert.Nil(t, mch.Do(context.TODO(), CloseAdvertisingShow("1900000109")))
+}
@@ -0,0 +1,359 @@
+package mchv3
+
+import (
+	"context"
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// Mch 微信支付v3
+type Mch struct {
+	mchid     string
+	apikey    string
+	serialno  string
+	prvkey    *wx.PrivateKey
+	pubserial string
+	pubkey    *wx.PublicKey
+	partner   bool
+	nonce     func() string
+	client    wx.HTTPClient
+	baseURL   string
+	dials     []wx.DialOption
+	headers   map[string]string
+	appids    []string
+	lenient   bool
+	noverify  bool
+}
+
+// APIError 微信支付v3错误应答（HTTP 状态码 4xx、5xx）
+// [参考](https://pay.weixin.qq.com/wiki/doc/apiv3/wechatpay/wechatpay2_0.shtml)
+type APIError struct {
+	StatusCode int             `json:"-"`
+	Code       string          `json:"code"`
+	Message    string          `json:"message"`
+	Detail     json.RawMessage `json:"detail,omitempty"`
+}
+
+// Error 格式为 code|message，如：PARAM_ERROR|参数错误
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s|%s", e.Code, e.Message)
+}
+
+// apiError 将非 2xx 应答转换为 *APIError，应答 body 无法解析时返回原错误
+func apiError(err error) error {
+	var statusErr *wx.HTTPStatusError
+
+	if !errors.As(err, &statusErr) {
+		return err
+	}
+
+	e := &APIError{StatusCode: statusErr.StatusCode}
+
+	if jerr := json.Unmarshal(statusErr.Body, e); jerr != nil || len(e.Code) == 0 {
+		return err
+	}
+
+	return e
+}
+
+// MchID returns mchid
+func (mch *Mch) MchID() string {
+	return mch.mchid
+}
+
+// ApiKey returns apiv3 key
+func (mch *Mch) ApiKey() string {
+	return mch.apikey
+}
+
+// IsPartner specifies the mch is in partner mode
+func (mch *Mch) IsPartner() bool {
+	return mch.partner
+}
+
+// SerialNO returns the serial number of merchant certificate
+func (mch *Mch) SerialNO() string {
+	return mch.serialno
+}
+
+// endpoint returns the request url with base url applied
+func (mch *Mch) endpoint(reqURL string) string {
+	return wx.JoinURL(mch.baseURL, reqURL)
+}
+
+// Do exec action
+func (mch *Mch) Do(ctx context.Context, action Action, options ...wx.HTTPOption) error {
+	body, err := action.Body(mch)
+
+	if err != nil {
+		return err
+	}
+
+	reqURL := mch.endpoint(action.URL(mch))
+
+	u, err := url.Parse(reqURL)
+
+	if err != nil {
+		return err
+	}
+
+	authorization, err := mch.Authorization(action.Method(), u.RequestURI(), body)
+
+	if err != nil {
+		return err
+	}
+
+	// 签名相关 header 放在最后，不可被覆盖
+	options = wx.PrependHTTPHeaders(options, mch.headers)
+	options = append(options,
+		wx.WithHTTPHeader("Authorization", authorization),
+		wx.WithHTTPHeader("Accept", "application/json"),
+	)
+
+	// 请求中包含敏感信息时，需使用平台证书加密并在请求头中带上平台证书序列号
+	if len(mch.pubserial) != 0 {
+		options = append(options, wx.WithHTTPHeader("Wechatpay-Serial", mch.pubserial))
+	}
+
+	// 配置了平台证书时验证应答签名，防止应答被篡改
+	verify := mch.pubkey != nil && !mch.noverify
+
+	if ua, ok := action.(UnverifiedAction); ok && ua.SkipVerify() {
+		verify = false
+	}
+
+	var header http.Header
+
+	if verify {
+		options = append(options, wx.WithHTTPResponseHeader(func(h http.Header) {
+			header = h
+		}))
+	}
+
+	var resp []byte
+
+	if action.IsUpload() {
+		form, ferr := action.UploadForm()
+
+		if ferr != nil {
+			return ferr
+		}
+
+		resp, err = mch.client.Upload(ctx, reqURL, form, options...)
+	} else {
+		options = append(options, wx.WithHTTPHeader("Content-Type", "application/json"))
+
+		resp, err = mch.client.Do(ctx, action.Method(), reqURL, body, options...)
+	}
+
+	if err != nil {
+		return apiError(err)
+	}
+
+	if verify {
+		if err = mch.VerifyNotify(header, resp); err != nil {
+			return fmt.Errorf("verify response: %w", err)
+		}
+	}
+
+	if mch.lenient {
+		return wx.DecodeLenient(resp, action.Decode)
+	}
+
+	return action.Decode(resp)
+}
+
+// Authorization 生成请求签名（Authorization 请求头）
+// [参考](https://pay.weixin.qq.com/wiki/doc/apiv3/wechatpay/wechatpay4_0.shtml)
+func (mch *Mch) Authorization(method, path string, body []byte) (string, error) {
+	if mch.prvkey == nil {
+		return "", errors.New("private key is nil (forgotten configure?)")
+	}
+
+	nonce := mch.nonce()
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	signStr := fmt.Sprintf("%s\n%s\n%s\n%s\n%s\n", method, path, timestamp, nonce, body)
+
+	sign, err := mch.prvkey.Sign(crypto.SHA256, []byte(signStr))
+
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(`WECHATPAY2-SHA256-RSA2048 mchid="%s",nonce_str="%s",signature="%s",timestamp="%s",serial_no="%s"`, mch.mchid, nonce, base64.StdEncoding.EncodeToString(sign), timestamp, mch.serialno), nil
+}
+
+// Encrypt 使用平台证书加密敏感信息（RSA-OAEP）
+// [参考](https://pay.weixin.qq.com/wiki/doc/apiv3/wechatpay/wechatpay4_3.shtml)
+func (mch *Mch) Encrypt(plainText string) (string, error) {
+	if mch.pubkey == nil {
+		return "", errors.New("platform certificate is nil (forgotten configure?)")
+	}
+
+	b, err := mch.pubkey.EncryptOAEP(crypto.SHA1, []byte(plainText))
+
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+// encryptFields 加密非空的敏感字段（原地替换为密文）
+func (mch *Mch) encryptFields(fields ...*string) error {
+	for _, v := range fields {
+		if len(*v) == 0 {
+			continue
+		}
+
+		cipherText, err := mch.Encrypt(*v)
+
+		if err != nil {
+			return err
+		}
+
+		*v = cipherText
+	}
+
+	return nil
+}
+
+// Decrypt 使用商户私钥解密应答中的敏感信息（RSA-OAEP）
+func (mch *Mch) Decrypt(cipherText string) (string, error) {
+	if mch.prvkey == nil {
+		return "", errors.New("private key is nil (forgotten configure?)")
+	}
+
+	b, err := base64.StdEncoding.DecodeString(cipherText)
+
+	if err != nil {
+		return "", err
+	}
+
+	plainText, err := mch.prvkey.DecryptOAEP(crypto.SHA1, b)
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(plainText), nil
+}
+
+// Option 支付配置项
+type Option func(mch *Mch)
+
+// WithPlatformCert 设置微信支付平台证书（用于敏感信息加密及应答签名验证）
+func WithPlatformCert(serialno string, pubkey *wx.PublicKey) Option {
+	return func(mch *Mch) {
+		mch.pubserial = serialno
+		mch.pubkey = pubkey
+	}
+}
+
+// WithPartner 设置为服务商模式，交易类接口将使用服务商接口地址及 sp_appid/sp_mchid 参数
+// [参考](https://pay.weixin.qq.com/wiki/doc/apiv3_partner/index.shtml)
+func WithPartner() Option {
+	return func(mch *Mch) {
+		mch.partner = true
+	}
+}
+
+// WithNonce 设置 Nonce（加密随机串）
+func WithNonce(f func() string) Option {
+	return func(mch *Mch) {
+		mch.nonce = f
+	}
+}
+
+// WithClient 设置 HTTP Client
+func WithClient(c *http.Client) Option {
+	return func(mch *Mch) {
+		mch.client = wx.NewHTTPClient(c)
+	}
+}
+
+// WithDialOptions 设置 HTTP Client 的连接配置（如：强制 IPv4、自定义 DNS 解析），
+// 在所有配置项之后作用于默认或 WithClient 设置的 Client，与配置顺序无关；Client 的 Transport 不是 *http.Transport 时不生效
+func WithDialOptions(options ...wx.DialOption) Option {
+	return func(mch *Mch) {
+		mch.dials = options
+	}
+}
+
+// WithHeader 设置请求 header（如：User-Agent、链路追踪 header），作用于所有请求
+func WithHeader(key, value string) Option {
+	return func(mch *Mch) {
+		mch.headers[key] = value
+	}
+}
+
+// WithAppIDCheck 校验回调通知解密数据中的 appid（服务商模式为 sp_appid）属于给定的 appid，以及 mchid（sp_mchid）与当前商户一致
+func WithAppIDCheck(appids ...string) Option {
+	return func(mch *Mch) {
+		mch.appids = appids
+	}
+}
+
+// WithLenientDecode 宽松解析返回结果，兼容数字与数字字符串等类型不一致的字段
+func WithLenientDecode() Option {
+	return func(mch *Mch) {
+		mch.lenient = true
+	}
+}
+
+// WithoutResponseVerify 不验证应答签名（默认配置了平台证书时验证，HTTP Client 无法获取应答 header 时使用）
+func WithoutResponseVerify() Option {
+	return func(mch *Mch) {
+		mch.noverify = true
+	}
+}
+
+// WithBaseURL 设置接口域名（默认：https://api.mch.weixin.qq.com），可用于切换备用域名（如：https://api2.mch.weixin.qq.com）
+func WithBaseURL(base string) Option {
+	return func(mch *Mch) {
+		mch.baseURL = base
+	}
+}
+
+// WithMockClient 设置 Mock Client
+func WithMockClient(c wx.HTTPClient) Option {
+	return func(mch *Mch) {
+		mch.client = c
+	}
+}
+
+// New returns new wechat pay v3
+// serialno 为商户API证书序列号，prvkey 为商户API证书私钥
+// [参考](https://pay.weixin.qq.com/wiki/doc/apiv3/wechatpay/wechatpay3_0.shtml)
+func New(mchid, apikey, serialno string, prvkey *wx.PrivateKey, options ...Option) *Mch {
+	mch := &Mch{
+		mchid:    mchid,
+		apikey:   apikey,
+		serialno: serialno,
+		prvkey:   prvkey,
+		nonce: func() string {
+			return wx.Nonce(32)
+		},
+		client:  wx.NewDefaultClient(),
+		baseURL: wx.BaseURLMchAPI,
+		headers: make(map[string]string),
+	}
+
+	for _, f := range options {
+		f(mch)
+	}
+
+	mch.client = wx.ApplyDialOptions(mch.client, mch.dials...)
+
+	return mch
+}
@@ -0,0 +1,121 @@
+package mchv3
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/shenghui0779/gochat/urls"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// ResultMediaUpload 媒体文件上传结果
+type ResultMediaUpload struct {
+	MediaID string `json:"media_id"` // 微信返回的媒体文件标识ID，可用于其它v3接口
+}
+
+// MediaMeta 媒体文件元信息（参与签名）
+type MediaMeta struct {
+	Filename string `json:"filename"` // 文件名称，需带上文件后缀
+	Sha256   string `json:"sha256"`   // 文件内容的SHA256摘要
+}
+
+// UploadImage 图片上传（仅支持JPG、BMP、PNG格式，文件大小不能超过2M）
+// [参考](https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter2_1_1.shtml)
+func UploadImage(imgPath string, result *ResultMediaUpload) Action {
+	return uploadMediaByPath(urls.MchV3MediaImageUpload, imgPath, result)
+}
+
+// UploadImageByBytes 图片上传（文件内容）
+func UploadImageByBytes(filename string, content []byte, result *ResultMediaUpload) Action {
+	return uploadMedia(urls.MchV3MediaImageUpload, filename, func() ([]byte, error) {
+		return content, nil
+	}, result)
+}
+
+// UploadVideo 视频上传（支持avi、wmv、mpeg、mp4、mov、mkv、flv、f4v、m4v、rmvb格式，文件大小不能超过5M）
+// [参考](https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter2_1_2.shtml)
+func UploadVideo(videoPath string, result *ResultMediaUpload) Action {
+	return uploadMediaByPath(urls.MchV3MediaVideoUpload, videoPath, result)
+}
+
+// UploadVideoByBytes 视频上传（文件内容）
+func UploadVideoByBytes(filename string, content []byte, result *ResultMediaUpload) Action {
+	return uploadMedia(urls.MchV3MediaVideoUpload, filename, func() ([]byte, error) {
+		return content, nil
+	}, result)
+}
+
+func uploadMediaByPath(reqURL, mediaPath string, result *ResultMediaUpload) Action {
+	_, filename := filepath.Split(mediaPath)
+
+	return uploadMedia(reqURL, filename, func() ([]byte, error) {
+		path, err := filepath.Abs(filepath.Clean(mediaPath))
+
+		if err != nil {
+			return nil, err
+		}
+
+		return ioutil.ReadFile(path)
+	}, result)
+}
+
+// uploadMedia v3 媒体上传为 multipart/form-data 请求：
+// meta 为文件名和文件摘要的JSON，file 为文件内容；签名时请求主体仅使用 meta 的JSON串。
+// 文件内容及 meta 在构建 Action 时生成，之后只读，Action 可并发复用
+func uploadMedia(reqURL, filename string, load func() ([]byte, error), result *ResultMediaUpload) Action {
+	content, meta, err := mediaMeta(filename, load)
+
+	return NewPostAction(reqURL,
+		WithBody(func(mch *Mch) ([]byte, error) {
+			if err != nil {
+				return nil, err
+			}
+
+			return meta, nil
+		}),
+		WithUpload(func() (wx.UploadForm, error) {
+			if err != nil {
+				return nil, err
+			}
+
+			// meta 需在 file 之前
+			return wx.NewUploadForm(
+				wx.WithFormField("meta", string(meta)),
+				wx.WithFormFile("file", filename, func(w io.Writer) error {
+					_, err := io.Copy(w, bytes.NewReader(content))
+
+					return err
+				}),
+			), nil
+		}),
+		WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+func mediaMeta(filename string, load func() ([]byte, error)) ([]byte, []byte, error) {
+	content, err := load()
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	h := sha256.Sum256(content)
+
+	meta, err := wx.MarshalNoEscapeHTML(&MediaMeta{
+		Filename: filename,
+		Sha256:   hex.EncodeToString(h[:]),
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return content, meta, nil
+}
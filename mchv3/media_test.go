@@ -0,0 +1,94 @@
+package mchv3
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"mime/multipart"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/mock"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+func TestUploadImageByBytes(t *testing.T) {
+	resp := []byte(`{"media_id":"6uqyGjGrCf2GtyXP8bxrbuH9-aAoTjH-rKeSl3Lf4_So6kdkQu4w8BYVP3bzLtvR38lxt4PjtCDXsQpzqge_hQEovHzOhsLleGFQVRF-U_0"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Upload(gomock.AssignableToTypeOf(context.TODO()), "https://api.mch.weixin.qq.com/v3/merchant/media/upload", gomock.AssignableToTypeOf(wx.NewUploadForm()), gomock.Any()).DoAndReturn(func(ctx context.Context, reqURL string, form wx.UploadForm, options ...wx.HTTPOption) ([]byte, error) {
+		buf := bytes.NewBuffer(nil)
+		w := multipart.NewWriter(buf)
+
+		assert.Nil(t, form.Write(w))
+		assert.Nil(t, w.Close())
+
+		r := multipart.NewReader(buf, w.Boundary())
+
+		f, err := r.ReadForm(1 << 20)
+		assert.Nil(t, err)
+
+		assert.Equal(t, []string{`{"filename":"test.jpg","sha256":"2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"}`}, f.Value["meta"])
+
+		fh, err := f.File["file"][0].Open()
+		assert.Nil(t, err)
+
+		defer fh.Close()
+
+		b, err := ioutil.ReadAll(fh)
+		assert.Nil(t, err)
+		assert.Equal(t, "hello", string(b))
+
+		return resp, nil
+	})
+
+	mch := newTestMch(t, WithMockClient(client))
+
+	result := new(ResultMediaUpload)
+
+	err := mch.Do(context.TODO(), UploadImageByBytes("test.jpg", []byte("hello"), result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultMediaUpload{
+		MediaID: "6uqyGjGrCf2GtyXP8bxrbuH9-aAoTjH-rKeSl3Lf4_So6kdkQu4w8BYVP3bzLtvR38lxt4PjtCDXsQpzqge_hQEovHzOhsLleGFQVRF-U_0",
+	}, result)
+}
+
+func TestUploadMediaBody(t *testing.T) {
+	mch := newTestMch(t)
+
+	// 签名时请求主体仅为 meta 的JSON串
+	body, err := UploadVideoByBytes("test.mp4", []byte("hello"), new(ResultMediaUpload)).Body(mch)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []byte(`{"filename":"test.mp4","sha256":"2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"}`), body)
+}
+
+func TestUploadMediaPartOrder(t *testing.T) {
+	action := UploadImageByBytes("test.jpg", []byte("hello"), new(ResultMediaUpload))
+
+	form, err := action.UploadForm()
+	assert.Nil(t, err)
+
+	buf := bytes.NewBuffer(nil)
+	w := multipart.NewWriter(buf)
+
+	assert.Nil(t, form.Write(w))
+	assert.Nil(t, w.Close())
+
+	r := multipart.NewReader(buf, w.Boundary())
+
+	// meta 需在 file 之前
+	for _, name := range []string{"meta", "file"} {
+		p, err := r.NextPart()
+
+		assert.Nil(t, err)
+		assert.Equal(t, name, p.FormName())
+	}
+}
@@ -0,0 +1,160 @@
+package mchv3
+
+import (
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// NotifyTimeWindow 回调通知（或应答）时间戳允许的最大偏差，超出视为过期（防重放）
+const NotifyTimeWindow = 5 * time.Minute
+
+// ErrNotifyExpired 回调通知（或应答）的时间戳超出 NotifyTimeWindow
+var ErrNotifyExpired = errors.New("notify timestamp expired")
+
+// NotifyResource 通知资源数据（加密）
+type NotifyResource struct {
+	Algorithm      string `json:"algorithm"`       // 加密算法类型，目前只支持AEAD_AES_256_GCM
+	CipherText     string `json:"ciphertext"`      // Base64编码后的数据密文
+	AssociatedData string `json:"associated_data"` // 附加数据
+	OriginalType   string `json:"original_type"`   // 原始回调类型
+	Nonce          string `json:"nonce"`           // 加密使用的随机串
+}
+
+// Notify 回调通知
+type Notify struct {
+	ID           string          `json:"id"`            // 通知的唯一ID
+	CreateTime   string          `json:"create_time"`   // 通知创建的时间
+	EventType    string          `json:"event_type"`    // 通知的类型
+	ResourceType string          `json:"resource_type"` // 通知的资源数据类型，支付成功通知为encrypt-resource
+	Summary      string          `json:"summary"`       // 回调摘要
+	Resource     *NotifyResource `json:"resource"`      // 通知资源数据
+}
+
+// VerifyNotify 使用平台证书验证回调通知签名
+// [参考](https://pay.weixin.qq.com/wiki/doc/apiv3/wechatpay/wechatpay4_1.shtml)
+func (mch *Mch) VerifyNotify(header http.Header, body []byte) error {
+	if mch.pubkey == nil {
+		return errors.New("platform certificate is nil (forgotten configure?)")
+	}
+
+	if serial := header.Get("Wechatpay-Serial"); len(mch.pubserial) != 0 && serial != mch.pubserial {
+		return fmt.Errorf("platform certificate serial mismatch, expect %s, got %s", mch.pubserial, serial)
+	}
+
+	return VerifyNotifySign(mch.pubkey, header, body)
+}
+
+// VerifyNotifySign 使用平台证书公钥验证回调通知（或应答）的签名，适用于自行处理 HTTP 请求的场景（如：gin、echo 等框架）
+// 签名串：Wechatpay-Timestamp + "\n" + Wechatpay-Nonce + "\n" + body + "\n"；
+// Wechatpay-Timestamp 与当前时间相差超过 NotifyTimeWindow 时返回 ErrNotifyExpired
+// [参考](https://pay.weixin.qq.com/wiki/doc/apiv3/wechatpay/wechatpay4_1.shtml)
+func VerifyNotifySign(pubkey *wx.PublicKey, header http.Header, body []byte) error {
+	timestamp, err := strconv.ParseInt(header.Get("Wechatpay-Timestamp"), 10, 64)
+
+	if err != nil {
+		return fmt.Errorf("invalid Wechatpay-Timestamp: %w", err)
+	}
+
+	if d := time.Since(time.Unix(timestamp, 0)); d > NotifyTimeWindow || d < -NotifyTimeWindow {
+		return ErrNotifyExpired
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(header.Get("Wechatpay-Signature"))
+
+	if err != nil {
+		return err
+	}
+
+	signStr := fmt.Sprintf("%s\n%s\n%s\n", header.Get("Wechatpay-Timestamp"), header.Get("Wechatpay-Nonce"), body)
+
+	return pubkey.Verify(crypto.SHA256, []byte(signStr), signature)
+}
+
+// DecryptResource 使用APIv3密钥解密通知资源数据（AEAD_AES_256_GCM）
+// [参考](https://pay.weixin.qq.com/wiki/doc/apiv3/wechatpay/wechatpay4_2.shtml)
+func (mch *Mch) DecryptResource(resource *NotifyResource) ([]byte, error) {
+	cipherText, err := base64.StdEncoding.DecodeString(resource.CipherText)
+
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher([]byte(mch.apikey))
+
+	if err != nil {
+		return nil, err
+	}
+
+	aesgcm, err := cipher.NewGCM(block)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return aesgcm.Open(nil, []byte(resource.Nonce), cipherText, []byte(resource.AssociatedData))
+}
+
+// ParseNotify 验证并解析回调通知，解密后的资源数据解析至 result
+func (mch *Mch) ParseNotify(header http.Header, body []byte, result interface{}) (*Notify, error) {
+	if err := mch.VerifyNotify(header, body); err != nil {
+		return nil, err
+	}
+
+	notify := new(Notify)
+
+	if err := json.Unmarshal(body, notify); err != nil {
+		return nil, err
+	}
+
+	if notify.Resource == nil {
+		return notify, nil
+	}
+
+	b, err := mch.DecryptResource(notify.Resource)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = mch.checkResource(b); err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(b, result); err != nil {
+		return nil, err
+	}
+
+	return notify, nil
+}
+
+func (mch *Mch) checkResource(b []byte) error {
+	if len(mch.appids) == 0 {
+		return nil
+	}
+
+	appidKey, mchidKey := "appid", "mchid"
+
+	if mch.partner {
+		appidKey, mchidKey = "sp_appid", "sp_mchid"
+	}
+
+	r := gjson.ParseBytes(b)
+
+	if mchid := r.Get(mchidKey).String(); len(mchid) != 0 && mchid != mch.mchid {
+		return fmt.Errorf("mchid mismatch, want: %s, got: %s", mch.mchid, mchid)
+	}
+
+	return wx.CheckAppID(r.Get(appidKey).String(), mch.appids...)
+}
@@ -0,0 +1,146 @@
+package mchv3
+
+import (
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// mockNotify 模拟微信支付回调通知（资源加密 + 平台签名）
+func mockNotify(t *testing.T, mch *Mch, eventType string, resource []byte) (http.Header, []byte) {
+	block, err := aes.NewCipher([]byte(mch.apikey))
+	assert.Nil(t, err)
+
+	aesgcm, err := cipher.NewGCM(block)
+	assert.Nil(t, err)
+
+	nonce := "fdasflkja484"
+	cipherText := aesgcm.Seal(nil, []byte(nonce), resource, []byte("transaction"))
+
+	body := []byte(fmt.Sprintf(`{"id":"EV-2018022511223320873","create_time":"2015-05-20T13:29:35+08:00","resource_type":"encrypt-resource","event_type":"%s","summary":"通知","resource":{"original_type":"transaction","algorithm":"AEAD_AES_256_GCM","ciphertext":"%s","associated_data":"transaction","nonce":"%s"}}`, eventType, base64.StdEncoding.EncodeToString(cipherText), nonce))
+
+	// 测试中平台证书与商户私钥为同一密钥对
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	sign, err := mch.prvkey.Sign(crypto.SHA256, []byte(fmt.Sprintf("%s\n%s\n%s\n", timestamp, "593BEC0C930BF1AFEB40B4A08C8FB242", body)))
+	assert.Nil(t, err)
+
+	header := http.Header{}
+	header.Set("Wechatpay-Timestamp", timestamp)
+	header.Set("Wechatpay-Nonce", "593BEC0C930BF1AFEB40B4A08C8FB242")
+	header.Set("Wechatpay-Signature", base64.StdEncoding.EncodeToString(sign))
+	header.Set("Wechatpay-Serial", "PUB_SERIAL_NO")
+
+	return header, body
+}
+
+func TestParseNotify(t *testing.T) {
+	mch := newTestMch(t)
+
+	header, body := mockNotify(t, mch, "TRANSACTION.SUCCESS", []byte(`{"out_trade_no":"1217752501201407033233368018","trade_state":"SUCCESS"}`))
+
+	result := new(ResultTransaction)
+
+	notify, err := mch.ParseNotify(header, body, result)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "TRANSACTION.SUCCESS", notify.EventType)
+	assert.Equal(t, &ResultTransaction{
+		OutTradeNO: "1217752501201407033233368018",
+		TradeState: "SUCCESS",
+	}, result)
+}
+
+func TestParseNotifyAppIDCheck(t *testing.T) {
+	mch := newTestMch(t, WithAppIDCheck("wxd678efh567hg6787"))
+
+	header, body := mockNotify(t, mch, "TRANSACTION.SUCCESS", []byte(`{"appid":"wxd678efh567hg6787","mchid":"1900000001","out_trade_no":"1217752501201407033233368018"}`))
+
+	_, err := mch.ParseNotify(header, body, new(ResultTransaction))
+
+	assert.Nil(t, err)
+
+	// appid 不一致
+	header, body = mockNotify(t, mch, "TRANSACTION.SUCCESS", []byte(`{"appid":"wx_other_appid","mchid":"1900000001","out_trade_no":"1217752501201407033233368018"}`))
+
+	_, err = mch.ParseNotify(header, body, new(ResultTransaction))
+
+	assert.True(t, errors.Is(err, wx.ErrAppIDMismatch))
+
+	// mchid 不一致
+	header, body = mockNotify(t, mch, "TRANSACTION.SUCCESS", []byte(`{"appid":"wxd678efh567hg6787","mchid":"1900000002","out_trade_no":"1217752501201407033233368018"}`))
+
+	_, err = mch.ParseNotify(header, body, new(ResultTransaction))
+
+	assert.NotNil(t, err)
+}
+
+func TestVerifyNotifyFail(t *testing.T) {
+	mch := newTestMch(t)
+
+	header, body := mockNotify(t, mch, "TRANSACTION.SUCCESS", []byte(`{}`))
+
+	// 篡改通知内容
+	body = append(body, ' ')
+
+	assert.NotNil(t, mch.VerifyNotify(header, body))
+
+	// 平台证书序列号不匹配
+	header.Set("Wechatpay-Serial", "OTHER_SERIAL_NO")
+
+	assert.NotNil(t, mch.VerifyNotify(header, body))
+
+	// 未设置平台证书
+	prvkey, err := wx.NewPrivateKeyFromPemBlock(wx.RSA_PKCS1, testPrivateKey)
+	assert.Nil(t, err)
+
+	assert.NotNil(t, New("1900000001", "APIv3Key-32Characters1234567890A", "MCH_SERIAL_NO", prvkey).VerifyNotify(header, body))
+}
+
+func TestVerifyNotifySign(t *testing.T) {
+	mch := newTestMch(t)
+
+	header, body := mockNotify(t, mch, "TRANSACTION.SUCCESS", []byte(`{}`))
+
+	pubkey, err := wx.NewPublicKeyFromPemBlock(wx.RSA_PKCS1, testPublicKey)
+	assert.Nil(t, err)
+
+	assert.Nil(t, VerifyNotifySign(pubkey, header, body))
+
+	// 篡改通知内容
+	assert.NotNil(t, VerifyNotifySign(pubkey, header, append(body, ' ')))
+}
+
+func TestVerifyNotifySignExpired(t *testing.T) {
+	mch := newTestMch(t)
+
+	body := []byte(`{"id":"EV-2018022511223320873"}`)
+
+	// 签名正确但时间戳已过期（重放）
+	sign, err := mch.prvkey.Sign(crypto.SHA256, []byte(fmt.Sprintf("%s\n%s\n%s\n", "1554208460", "593BEC0C930BF1AFEB40B4A08C8FB242", body)))
+	assert.Nil(t, err)
+
+	header := http.Header{}
+	header.Set("Wechatpay-Timestamp", "1554208460")
+	header.Set("Wechatpay-Nonce", "593BEC0C930BF1AFEB40B4A08C8FB242")
+	header.Set("Wechatpay-Signature", base64.StdEncoding.EncodeToString(sign))
+	header.Set("Wechatpay-Serial", "PUB_SERIAL_NO")
+
+	assert.Equal(t, ErrNotifyExpired, mch.VerifyNotify(header, body))
+
+	// 缺少时间戳
+	header.Del("Wechatpay-Timestamp")
+
+	assert.NotNil(t, mch.VerifyNotify(header, body))
+}
@@ -0,0 +1,234 @@
+package mchv3
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/shenghui0779/gochat/urls"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// 车牌颜色
+const (
+	PlateBlue      = "BLUE"      // 蓝色
+	PlateGreen     = "GREEN"     // 绿色
+	PlateYellow    = "YELLOW"    // 黄色
+	PlateBlack     = "BLACK"     // 黑色
+	PlateWhite     = "WHITE"     // 白色
+	PlateLimeGreen = "LIMEGREEN" // 黄绿色
+)
+
+// ResultParkingService 车牌服务开通信息
+type ResultParkingService struct {
+	PlateNumber     string `json:"plate_number"`      // 车牌号
+	PlateColor      string `json:"plate_color"`       // 车牌颜色
+	ServiceOpenTime string `json:"service_open_time"` // 车牌服务开通时间
+	OpenID          string `json:"openid"`            // 用户标识
+	ServiceState    string `json:"service_state"`     // 车牌服务开通状态：NORMAL-正常服务，PAUSE-暂停服务，OUT_SERVICE-未开通
+}
+
+// FindParkingService 停车服务 - 查询车牌服务开通信息
+func FindParkingService(appid, openid, plateNumber, plateColor string, result *ResultParkingService, options ...SLOption) Action {
+	return NewGetAction(urls.MchV3ParkingServicesFind,
+		WithQuery("appid", appid),
+		WithQuery("openid", openid),
+		WithQuery("plate_number", plateNumber),
+		WithQuery("plate_color", plateColor),
+		WithQueryFunc(func(mch *Mch, query url.Values) {
+			if !mch.partner {
+				return
+			}
+
+			sl := new(subMerchant)
+
+			for _, f := range options {
+				f(sl)
+			}
+
+			query.Set("sub_mchid", sl.mchid)
+
+			if len(sl.appid) != 0 {
+				query.Set("sub_appid", sl.appid)
+			}
+		}),
+		WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// ParamsParkingCreate 创建停车入场参数
+type ParamsParkingCreate struct {
+	OutParkingNO string `json:"out_parking_no"` // 商户入场id
+	PlateNumber  string `json:"plate_number"`   // 车牌号
+	PlateColor   string `json:"plate_color"`    // 车牌颜色
+	NotifyURL    string `json:"notify_url"`     // 入场状态变更回调地址
+	StartTime    string `json:"start_time"`     // 入场时间，遵循rfc3339标准格式
+	ParkingName  string `json:"parking_name"`   // 停车场名称
+	FreeDuration int    `json:"free_duration"`  // 免费时长，单位：秒
+}
+
+// ResultParking 停车入场信息
+type ResultParking struct {
+	ID           string `json:"id"`             // 停车入场id
+	OutParkingNO string `json:"out_parking_no"` // 商户入场id
+	PlateNumber  string `json:"plate_number"`   // 车牌号
+	PlateColor   string `json:"plate_color"`    // 车牌颜色
+	StartTime    string `json:"start_time"`     // 入场时间
+	ParkingName  string `json:"parking_name"`   // 停车场名称
+	FreeDuration int    `json:"free_duration"`  // 免费时长，单位：秒
+	State        string `json:"state"`          // 停车入场状态：NORMAL-正常，BLOCKED-不可用
+	BlockReason  string `json:"block_reason"`   // 不可用状态描述
+}
+
+// CreateParking 停车服务 - 创建停车入场（车辆入场时调用，用于后续扣费受理）
+func CreateParking(params *ParamsParkingCreate, result *ResultParking, options ...SLOption) Action {
+	return NewPostAction(urls.MchV3Parkings,
+		WithBody(func(mch *Mch) ([]byte, error) {
+			body := &struct {
+				SubMchID string `json:"sub_mchid,omitempty"`
+				*ParamsParkingCreate
+			}{
+				ParamsParkingCreate: params,
+			}
+
+			if mch.partner {
+				sl := new(subMerchant)
+
+				for _, f := range options {
+					f(sl)
+				}
+
+				body.SubMchID = sl.mchid
+			}
+
+			return wx.MarshalNoEscapeHTML(body)
+		}),
+		WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// ParkingInfo 停车场景信息
+type ParkingInfo struct {
+	ParkingID        string `json:"parking_id"`        // 停车入场id
+	PlateNumber      string `json:"plate_number"`      // 车牌号
+	PlateColor       string `json:"plate_color"`       // 车牌颜色
+	StartTime        string `json:"start_time"`        // 入场时间
+	EndTime          string `json:"end_time"`          // 出场时间
+	ParkingName      string `json:"parking_name"`      // 停车场名称
+	ChargingDuration int    `json:"charging_duration"` // 计费时长，单位：秒
+	DeviceID         string `json:"device_id"`         // 停车场设备id
+}
+
+// ParkingStateChange 停车入场状态变更（回调通知，通知地址为创建停车入场时的 notify_url）
+type ParkingStateChange struct {
+	SPMchID  string `json:"sp_mchid"`  // 服务商户号（服务商模式）
+	SubMchID string `json:"sub_mchid"` // 子商户号（服务商模式）
+	ResultParking
+	StateUpdateTime string `json:"state_update_time"` // 状态变更时间
+}
+
+// ParseParkingStateNotify 停车服务 - 解析停车入场状态变更回调通知（入场状态为 BLOCKED 时不可扣费）
+func ParseParkingStateNotify(mch *Mch, header http.Header, body []byte) (*ParkingStateChange, error) {
+	result := new(ParkingStateChange)
+
+	if _, err := mch.ParseNotify(header, body, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ParamsParkingTransaction 停车扣费受理参数
+type ParamsParkingTransaction struct {
+	Description   string             `json:"description"`              // 商品描述
+	Attach        string             `json:"attach,omitempty"`         // 附加数据
+	OutTradeNO    string             `json:"out_trade_no"`             // 商户订单号
+	GoodsTag      string             `json:"goods_tag,omitempty"`      // 订单优惠标记
+	NotifyURL     string             `json:"notify_url"`               // 扣费结果回调地址
+	ProfitSharing bool               `json:"profit_sharing,omitempty"` // 是否分账
+	Amount        *TransactionAmount `json:"amount"`                   // 订单金额
+	ParkingInfo   *ParkingInfo       `json:"parking_info"`             // 停车场景信息
+}
+
+// ResultParkingTransaction 停车扣费订单
+type ResultParkingTransaction struct {
+	AppID                 string                  `json:"appid"`                   // 应用ID
+	SubAppID              string                  `json:"sub_appid"`               // 子商户应用ID（服务商模式）
+	SPMchID               string                  `json:"sp_mchid"`                // 服务商户号（服务商模式）
+	SubMchID              string                  `json:"sub_mchid"`               // 子商户号（服务商模式）
+	Description           string                  `json:"description"`             // 商品描述
+	CreateTime            string                  `json:"create_time"`             // 订单创建时间
+	OutTradeNO            string                  `json:"out_trade_no"`            // 商户订单号
+	TransactionID         string                  `json:"transaction_id"`          // 微信支付订单号
+	TradeState            string                  `json:"trade_state"`             // 交易状态：SUCCESS、ACCEPTED（已受理）、PAY_FAIL、REFUND
+	TradeStateDescription string                  `json:"trade_state_description"` // 交易状态描述
+	SuccessTime           string                  `json:"success_time"`            // 支付完成时间
+	BankType              string                  `json:"bank_type"`               // 付款银行
+	UserRepaid            string                  `json:"user_repaid"`             // 用户是否已还款：Y、N
+	Attach                string                  `json:"attach"`                  // 附加数据
+	TradeScene            string                  `json:"trade_scene"`             // 交易场景：PARKING
+	ParkingInfo           *ParkingInfo            `json:"parking_info"`            // 停车场景信息
+	Payer                 *TransactionPayer       `json:"payer"`                   // 支付者
+	Amount                *TransactionPayerAmount `json:"amount"`                  // 订单金额
+}
+
+// CreateParkingTransaction 停车服务 - 扣费受理（车辆出场时调用，受理成功后微信支付异步扣款）
+func CreateParkingTransaction(appid string, params *ParamsParkingTransaction, result *ResultParkingTransaction, options ...SLOption) Action {
+	return NewPostAction(urls.MchV3ParkingTransactions,
+		WithBody(func(mch *Mch) ([]byte, error) {
+			body := &struct {
+				AppID      string `json:"appid"`
+				SubAppID   string `json:"sub_appid,omitempty"`
+				SubMchID   string `json:"sub_mchid,omitempty"`
+				TradeScene string `json:"trade_scene"`
+				*ParamsParkingTransaction
+			}{
+				AppID:                    appid,
+				TradeScene:               "PARKING",
+				ParamsParkingTransaction: params,
+			}
+
+			if mch.partner {
+				sl := new(subMerchant)
+
+				for _, f := range options {
+					f(sl)
+				}
+
+				body.SubAppID = sl.appid
+				body.SubMchID = sl.mchid
+			}
+
+			return wx.MarshalNoEscapeHTML(body)
+		}),
+		WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// QueryParkingTransaction 停车服务 - 查询扣费订单
+func QueryParkingTransaction(outTradeNO string, result *ResultParkingTransaction, options ...SLOption) Action {
+	return NewGetAction(fmt.Sprintf("%s/%s", urls.MchV3ParkingTransactionByOutTradeNO, outTradeNO),
+		subMchQuery(options...),
+		WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// ParseParkingTransactionNotify 停车服务 - 解析扣费结果回调通知（通知地址为扣费受理时的 notify_url，内容同查询扣费订单）
+func ParseParkingTransactionNotify(mch *Mch, header http.Header, body []byte) (*ResultParkingTransaction, error) {
+	result := new(ResultParkingTransaction)
+
+	if _, err := mch.ParseNotify(header, body, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
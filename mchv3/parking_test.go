@@ -0,0 +1,203 @@
+package mchv3
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/mock"
+)
+
+func TestFindParkingService(t *testing.T) {
+	resp := []byte(`{"plate_number":"粤B888888","plate_color":"BLUE","service_open_time":"2017-08-26T10:43:39+08:00","openid":"oUpF8uMuAJOM2pxb1Q","service_state":"NORMAL"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.mch.weixin.qq.com/v3/vehicle/parking/services/find?appid=wxcbda96de0b165486&openid=oUpF8uMuAJOM2pxb1Q&plate_color=BLUE&plate_number=%E7%B2%A4B888888&sub_mchid=1900000109", nil, gomock.Any()).Return(resp, nil)
+
+	mch := newTestMch(t, WithPartner(), WithMockClient(client))
+
+	result := new(ResultParkingService)
+
+	err := mch.Do(context.TODO(), FindParkingService("wxcbda96de0b165486", "oUpF8uMuAJOM2pxb1Q", "粤B888888", PlateBlue, result, WithSubMchID("1900000109")))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultParkingService{
+		PlateNumber:     "粤B888888",
+		PlateColor:      PlateBlue,
+		ServiceOpenTime: "2017-08-26T10:43:39+08:00",
+		OpenID:          "oUpF8uMuAJOM2pxb1Q",
+		ServiceState:    "NORMAL",
+	}, result)
+}
+
+func TestCreateParking(t *testing.T) {
+	body := []byte(`{"sub_mchid":"1900000109","out_parking_no":"1231243","plate_number":"粤B888888","plate_color":"BLUE","notify_url":"https://yoursite.com/wxpay.html","start_time":"2017-08-26T10:43:39+08:00","parking_name":"欢乐海岸停车场","free_duration":3600}`)
+	resp := []byte(`{"id":"5K8264ILTKCH16CQ250","out_parking_no":"1231243","plate_number":"粤B888888","plate_color":"BLUE","start_time":"2017-08-26T10:43:39+08:00","parking_name":"欢乐海岸停车场","free_duration":3600,"state":"NORMAL"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/v3/vehicle/parking/parkings", body, gomock.Any()).Return(resp, nil)
+
+	mch := newTestMch(t, WithPartner(), WithMockClient(client))
+
+	result := new(ResultParking)
+
+	err := mch.Do(context.TODO(), CreateParking(&ParamsParkingCreate{
+		OutParkingNO: "1231243",
+		PlateNumber:  "粤B888888",
+		PlateColor:   PlateBlue,
+		NotifyURL:    "https://yoursite.com/wxpay.html",
+		StartTime:    "2017-08-26T10:43:39+08:00",
+		ParkingName:  "欢乐海岸停车场",
+		FreeDuration: 3600,
+	}, result, WithSubMchID("1900000109")))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultParking{
+		ID:           "5K8264ILTKCH16CQ250",
+		OutParkingNO: "1231243",
+		PlateNumber:  "粤B888888",
+		PlateColor:   PlateBlue,
+		StartTime:    "2017-08-26T10:43:39+08:00",
+		ParkingName:  "欢乐海岸停车场",
+		FreeDuration: 3600,
+		State:        "NORMAL",
+	}, result)
+}
+
+func TestCreateParkingTransaction(t *testing.T) {
+	body := []byte(`{"appid":"wxcbda96de0b165486","sub_appid":"wxcbda96de0b165484","sub_mchid":"1900000109","trade_scene":"PARKING","description":"停车场扣费","out_trade_no":"20150806125346","notify_url":"https://yoursite.com/wxpay.html","amount":{"total":888,"currency":"CNY"},"parking_info":{"parking_id":"5K8264ILTKCH16CQ250","plate_number":"粤B888888","plate_color":"BLUE","start_time":"2017-08-26T10:43:39+08:00","end_time":"2017-08-26T11:43:39+08:00","parking_name":"欢乐海岸停车场","charging_duration":3600,"device_id":"12313"}}`)
+	resp := []byte(`{"appid":"wxcbda96de0b165486","sub_appid":"wxcbda96de0b165484","sp_mchid":"1900000001","sub_mchid":"1900000109","description":"停车场扣费","create_time":"2017-08-26T10:43:39+08:00","out_trade_no":"20150806125346","trade_state":"ACCEPTED","trade_scene":"PARKING"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/v3/vehicle/transactions/parking", body, gomock.Any()).Return(resp, nil)
+
+	mch := newTestMch(t, WithPartner(), WithMockClient(client))
+
+	result := new(ResultParkingTransaction)
+
+	err := mch.Do(context.TODO(), CreateParkingTransaction("wxcbda96de0b165486", &ParamsParkingTransaction{
+		Description: "停车场扣费",
+		OutTradeNO:  "20150806125346",
+		NotifyURL:   "https://yoursite.com/wxpay.html",
+		Amount: &TransactionAmount{
+			Total:    888,
+			Currency: "CNY",
+		},
+		ParkingInfo: &ParkingInfo{
+			ParkingID:        "5K8264ILTKCH16CQ250",
+			PlateNumber:      "粤B888888",
+			PlateColor:       PlateBlue,
+			StartTime:        "2017-08-26T10:43:39+08:00",
+			EndTime:          "2017-08-26T11:43:39+08:00",
+			ParkingName:      "欢乐海岸停车场",
+			ChargingDuration: 3600,
+			DeviceID:         "12313",
+		},
+	}, result, WithSubAppID("wxcbda96de0b165484"), WithSubMchID("1900000109")))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultParkingTransaction{
+		AppID:       "wxcbda96de0b165486",
+		SubAppID:    "wxcbda96de0b165484",
+		SPMchID:     "1900000001",
+		SubMchID:    "1900000109",
+		Description: "停车场扣费",
+		CreateTime:  "2017-08-26T10:43:39+08:00",
+		OutTradeNO:  "20150806125346",
+		TradeState:  "ACCEPTED",
+		TradeScene:  "PARKING",
+	}, result)
+}
+
+func TestQueryParkingTransaction(t *testing.T) {
+	resp := []byte(`{"appid":"wxcbda96de0b165486","out_trade_no":"20150806125346","transaction_id":"1217752501201407033233368018","trade_state":"SUCCESS","trade_scene":"PARKING","user_repaid":"N"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.mch.weixin.qq.com/v3/vehicle/transactions/out-trade-no/20150806125346", nil, gomock.Any()).Return(resp, nil)
+
+	mch := newTestMch(t, WithMockClient(client))
+
+	result := new(ResultParkingTransaction)
+
+	err := mch.Do(context.TODO(), QueryParkingTransaction("20150806125346", result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultParkingTransaction{
+		AppID:         "wxcbda96de0b165486",
+		OutTradeNO:    "20150806125346",
+		TransactionID: "1217752501201407033233368018",
+		TradeState:    "SUCCESS",
+		TradeScene:    "PARKING",
+		UserRepaid:    "N",
+	}, result)
+}
+
+func TestParseParkingStateNotify(t *testing.T) {
+	mch := newTestMch(t)
+
+	header, body := mockNotify(t, mch, "VEHICLE.ENTRANCE_STATE_CHANGE", []byte(`{"sp_mchid":"1900000001","sub_mchid":"1900000109","id":"5K8264ILTKCH16CQ250","out_parking_no":"1231243","plate_number":"粤B888888","plate_color":"BLUE","start_time":"2017-08-26T10:43:39+08:00","parking_name":"欢乐海岸停车场","free_duration":3600,"state":"BLOCKED","block_reason":"PAUSE","state_update_time":"2017-08-26T10:50:39+08:00"}`))
+
+	result, err := ParseParkingStateNotify(mch, header, body)
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ParkingStateChange{
+		SPMchID:  "1900000001",
+		SubMchID: "1900000109",
+		ResultParking: ResultParking{
+			ID:           "5K8264ILTKCH16CQ250",
+			OutParkingNO: "1231243",
+			PlateNumber:  "粤B888888",
+			PlateColor:   PlateBlue,
+			StartTime:    "2017-08-26T10:43:39+08:00",
+			ParkingName:  "欢乐海岸停车场",
+			FreeDuration: 3600,
+			State:        "BLOCKED",
+			BlockReason:  "PAUSE",
+		},
+		StateUpdateTime: "2017-08-26T10:50:39+08:00",
+	}, result)
+}
+
+func TestParseParkingTransactionNotify(t *testing.T) {
+	mch := newTestMch(t)
+
+	header, body := mockNotify(t, mch, "TRANSACTION.SUCCESS", []byte(`{"appid":"wxcbda96de0b165486","description":"停车场扣费","create_time":"2017-08-26T10:43:39+08:00","out_trade_no":"20150806125346","transaction_id":"1217752501201407033233368018","trade_state":"SUCCESS","trade_state_description":"支付成功","success_time":"2017-08-26T10:53:39+08:00","bank_type":"CMC","user_repaid":"N","trade_scene":"PARKING","parking_info":{"parking_id":"5K8264ILTKCH16CQ250","plate_number":"粤B888888","plate_color":"BLUE","start_time":"2017-08-26T10:43:39+08:00","end_time":"2017-08-26T10:50:39+08:00","parking_name":"欢乐海岸停车场","charging_duration":3600,"device_id":"12313"},"payer":{"openid":"oUpF8uMuAJO_M2pxb1Q9zNjWeS6o"},"amount":{"total":888,"currency":"CNY","payer_total":888,"discount_total":0}}`))
+
+	result, err := ParseParkingTransactionNotify(mch, header, body)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "1217752501201407033233368018", result.TransactionID)
+	assert.Equal(t, "SUCCESS", result.TradeState)
+	assert.Equal(t, "N", result.UserRepaid)
+	assert.Equal(t, &ParkingInfo{
+		ParkingID:        "5K8264ILTKCH16CQ250",
+		PlateNumber:      "粤B888888",
+		PlateColor:       PlateBlue,
+		StartTime:        "2017-08-26T10:43:39+08:00",
+		EndTime:          "2017-08-26T10:50:39+08:00",
+		ParkingName:      "欢乐海岸停车场",
+		ChargingDuration: 3600,
+		DeviceID:         "12313",
+	}, result.ParkingInfo)
+	assert.Equal(t, "oUpF8uMuAJO_M2pxb1Q9zNjWeS6o", result.Payer.OpenID)
+	assert.Equal(t, int64(888), result.Amount.Total)
+}
@@ -0,0 +1,351 @@
+package mchv3
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/shenghui0779/gochat/urls"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// SLOption 服务商模式配置项
+type SLOption func(sl *subMerchant)
+
+type subMerchant struct {
+	appid string
+	mchid string
+}
+
+// WithSubMchID 「服务商模式下」设置子商户号
+func WithSubMchID(mchid string) SLOption {
+	return func(sl *subMerchant) {
+		sl.mchid = mchid
+	}
+}
+
+// WithSubAppID 「服务商模式下」设置子商户应用ID
+func WithSubAppID(appid string) SLOption {
+	return func(sl *subMerchant) {
+		sl.appid = appid
+	}
+}
+
+// txnMerchant 交易请求中的商户信息，普通商户模式使用 appid/mchid，服务商模式使用 sp_appid/sp_mchid/sub_appid/sub_mchid
+type txnMerchant struct {
+	AppID    string `json:"appid,omitempty"`
+	MchID    string `json:"mchid,omitempty"`
+	SPAppID  string `json:"sp_appid,omitempty"`
+	SPMchID  string `json:"sp_mchid,omitempty"`
+	SubAppID string `json:"sub_appid,omitempty"`
+	SubMchID string `json:"sub_mchid,omitempty"`
+}
+
+func newTxnMerchant(mch *Mch, appid string, options ...SLOption) *txnMerchant {
+	if !mch.partner {
+		return &txnMerchant{
+			AppID: appid,
+			MchID: mch.mchid,
+		}
+	}
+
+	sl := new(subMerchant)
+
+	for _, f := range options {
+		f(sl)
+	}
+
+	return &txnMerchant{
+		SPAppID:  appid,
+		SPMchID:  mch.mchid,
+		SubAppID: sl.appid,
+		SubMchID: sl.mchid,
+	}
+}
+
+// txnMchQuery 交易查询的商户参数，普通商户模式为 mchid，服务商模式为 sp_mchid/sub_mchid
+func txnMchQuery(options ...SLOption) ActionOption {
+	return WithQueryFunc(func(mch *Mch, query url.Values) {
+		if !mch.partner {
+			query.Set("mchid", mch.mchid)
+
+			return
+		}
+
+		sl := new(subMerchant)
+
+		for _, f := range options {
+			f(sl)
+		}
+
+		query.Set("sp_mchid", mch.mchid)
+		query.Set("sub_mchid", sl.mchid)
+	})
+}
+
+// subMchQuery 服务商模式下设置 sub_mchid 查询参数
+func subMchQuery(options ...SLOption) ActionOption {
+	return WithQueryFunc(func(mch *Mch, query url.Values) {
+		if !mch.partner {
+			return
+		}
+
+		sl := new(subMerchant)
+
+		for _, f := range options {
+			f(sl)
+		}
+
+		query.Set("sub_mchid", sl.mchid)
+	})
+}
+
+// TransactionAmount 订单金额
+type TransactionAmount struct {
+	Total    int64  `json:"total"`              // 订单总金额，单位为分
+	Currency string `json:"currency,omitempty"` // 货币类型，CNY：人民币，境内商户号仅支持人民币
+}
+
+// TransactionPayer 支付者
+type TransactionPayer struct {
+	OpenID    string `json:"openid,omitempty"`     // 用户在直连商户appid下的唯一标识（普通商户模式）
+	SPOpenID  string `json:"sp_openid,omitempty"`  // 用户在服务商appid下的唯一标识（服务商模式，与 sub_openid 二选一）
+	SubOpenID string `json:"sub_openid,omitempty"` // 用户在子商户appid下的唯一标识（服务商模式，与 sp_openid 二选一）
+}
+
+// H5Info H5场景信息
+type H5Info struct {
+	Type        string `json:"type"`                   // 场景类型：iOS、Android、Wap
+	AppName     string `json:"app_name,omitempty"`     // 应用名称
+	AppURL      string `json:"app_url,omitempty"`      // 网站URL
+	BundleID    string `json:"bundle_id,omitempty"`    // iOS平台BundleID
+	PackageName string `json:"package_name,omitempty"` // Android平台PackageName
+}
+
+// TransactionSceneInfo 场景信息
+type TransactionSceneInfo struct {
+	PayerClientIP string  `json:"payer_client_ip"`     // 用户终端IP
+	DeviceID      string  `json:"device_id,omitempty"` // 商户端设备号
+	H5Info        *H5Info `json:"h5_info,omitempty"`   // H5场景信息（H5下单必填）
+}
+
+// TransactionSettleInfo 结算信息
+type TransactionSettleInfo struct {
+	ProfitSharing bool `json:"profit_sharing"` // 是否指定分账
+}
+
+// ParamsTransaction 下单参数
+type ParamsTransaction struct {
+	Description string                 `json:"description"`           // 商品描述
+	OutTradeNO  string                 `json:"out_trade_no"`          // 商户系统内部订单号
+	TimeExpire  string                 `json:"time_expire,omitempty"` // 订单失效时间，遵循rfc3339标准格式
+	Attach      string                 `json:"attach,omitempty"`      // 附加数据，在查询API和支付通知中原样返回
+	NotifyURL   string                 `json:"notify_url"`            // 异步接收微信支付结果通知的回调地址
+	GoodsTag    string                 `json:"goods_tag,omitempty"`   // 订单优惠标记
+	Amount      *TransactionAmount     `json:"amount"`                // 订单金额
+	Payer       *TransactionPayer      `json:"payer,omitempty"`       // 支付者（JSAPI下单必填）
+	SceneInfo   *TransactionSceneInfo  `json:"scene_info,omitempty"`  // 场景信息（H5下单必填）
+	SettleInfo  *TransactionSettleInfo `json:"settle_info,omitempty"` // 结算信息
+}
+
+// ResultPrepay 下单结果
+type ResultPrepay struct {
+	PrepayID string `json:"prepay_id"` // 预支付交易会话标识（JSAPI、APP下单返回）
+	H5URL    string `json:"h5_url"`    // 支付跳转链接（H5下单返回）
+	CodeURL  string `json:"code_url"`  // 二维码链接（Native下单返回）
+}
+
+// JSAPITransaction 基础支付 - JSAPI/小程序下单
+// [参考](https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter3_1_1.shtml)
+func JSAPITransaction(appid string, params *ParamsTransaction, result *ResultPrepay, options ...SLOption) Action {
+	return transaction(urls.MchV3TransactionsJSAPI, urls.MchV3PartnerTransactionsJSAPI, appid, params, result, options...)
+}
+
+// APPTransaction 基础支付 - APP下单
+// [参考](https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter3_2_1.shtml)
+func APPTransaction(appid string, params *ParamsTransaction, result *ResultPrepay, options ...SLOption) Action {
+	return transaction(urls.MchV3TransactionsAPP, urls.MchV3PartnerTransactionsAPP, appid, params, result, options...)
+}
+
+// H5Transaction 基础支付 - H5下单
+// [参考](https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter3_3_1.shtml)
+func H5Transaction(appid string, params *ParamsTransaction, result *ResultPrepay, options ...SLOption) Action {
+	return transaction(urls.MchV3TransactionsH5, urls.MchV3PartnerTransactionsH5, appid, params, result, options...)
+}
+
+// NativeTransaction 基础支付 - Native下单
+// [参考](https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter3_4_1.shtml)
+func NativeTransaction(appid string, params *ParamsTransaction, result *ResultPrepay, options ...SLOption) Action {
+	return transaction(urls.MchV3TransactionsNative, urls.MchV3PartnerTransactionsNative, appid, params, result, options...)
+}
+
+func transaction(reqURL, partnerURL, appid string, params *ParamsTransaction, result *ResultPrepay, options ...SLOption) Action {
+	return NewPostAction(reqURL,
+		WithPartnerURL(partnerURL),
+		WithBody(func(mch *Mch) ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(&struct {
+				*txnMerchant
+				*ParamsTransaction
+			}{
+				txnMerchant:       newTxnMerchant(mch, appid, options...),
+				ParamsTransaction: params,
+			})
+		}),
+		WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// TransactionPayerAmount 订单金额信息
+type TransactionPayerAmount struct {
+	Total         int64  `json:"total"`          // 订单总金额，单位为分
+	PayerTotal    int64  `json:"payer_total"`    // 用户支付金额，单位为分
+	Currency      string `json:"currency"`       // 货币类型
+	PayerCurrency string `json:"payer_currency"` // 用户支付币种
+}
+
+// ResultTransaction 订单信息
+type ResultTransaction struct {
+	AppID          string                  `json:"appid"`            // 直连商户申请的公众号或移动应用appid（普通商户模式）
+	MchID          string                  `json:"mchid"`            // 直连商户号（普通商户模式）
+	SPAppID        string                  `json:"sp_appid"`         // 服务商应用ID（服务商模式）
+	SPMchID        string                  `json:"sp_mchid"`         // 服务商户号（服务商模式）
+	SubAppID       string                  `json:"sub_appid"`        // 子商户应用ID（服务商模式）
+	SubMchID       string                  `json:"sub_mchid"`        // 子商户号（服务商模式）
+	OutTradeNO     string                  `json:"out_trade_no"`     // 商户订单号
+	TransactionID  string                  `json:"transaction_id"`   // 微信支付订单号
+	TradeType      string                  `json:"trade_type"`       // 交易类型：JSAPI、NATIVE、APP、MICROPAY、MWEB、FACEPAY
+	TradeState     string                  `json:"trade_state"`      // 交易状态：SUCCESS、REFUND、NOTPAY、CLOSED、REVOKED、USERPAYING、PAYERROR
+	TradeStateDesc string                  `json:"trade_state_desc"` // 交易状态描述
+	BankType       string                  `json:"bank_type"`        // 付款银行
+	Attach         string                  `json:"attach"`           // 附加数据
+	SuccessTime    string                  `json:"success_time"`     // 支付完成时间
+	Payer          *TransactionPayer       `json:"payer"`            // 支付者
+	Amount         *TransactionPayerAmount `json:"amount"`           // 订单金额
+}
+
+// QueryTransactionByID 基础支付 - 微信支付订单号查询
+// [参考](https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter3_1_2.shtml)
+func QueryTransactionByID(transactionID string, result *ResultTransaction, options ...SLOption) Action {
+	return NewGetAction(fmt.Sprintf("%s/%s", urls.MchV3TransactionsByID, transactionID),
+		WithPartnerURL(fmt.Sprintf("%s/%s", urls.MchV3PartnerTransactionsByID, transactionID)),
+		txnMchQuery(options...),
+		WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// QueryTransactionByOutTradeNO 基础支付 - 商户订单号查询
+// [参考](https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter3_1_2.shtml)
+func QueryTransactionByOutTradeNO(outTradeNO string, result *ResultTransaction, options ...SLOption) Action {
+	return NewGetAction(fmt.Sprintf("%s/%s", urls.MchV3TransactionsByOutTradeNO, outTradeNO),
+		WithPartnerURL(fmt.Sprintf("%s/%s", urls.MchV3PartnerTransactionsByOutTradeNO, outTradeNO)),
+		txnMchQuery(options...),
+		WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// CloseTransaction 基础支付 - 关闭订单
+// [参考](https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter3_1_3.shtml)
+func CloseTransaction(outTradeNO string, options ...SLOption) Action {
+	return NewPostAction(fmt.Sprintf("%s/%s/close", urls.MchV3TransactionsByOutTradeNO, outTradeNO),
+		WithPartnerURL(fmt.Sprintf("%s/%s/close", urls.MchV3PartnerTransactionsByOutTradeNO, outTradeNO)),
+		WithBody(func(mch *Mch) ([]byte, error) {
+			m := newTxnMerchant(mch, "", options...)
+
+			// 关闭订单无需 appid
+			m.SubAppID = ""
+
+			return wx.MarshalNoEscapeHTML(m)
+		}),
+	)
+}
+
+// RefundAmount 退款金额
+type RefundAmount struct {
+	Refund   int64  `json:"refund"`   // 退款金额，单位为分
+	Total    int64  `json:"total"`    // 原订单金额，单位为分
+	Currency string `json:"currency"` // 退款币种，目前只支持人民币：CNY
+}
+
+// ParamsRefund 申请退款参数
+type ParamsRefund struct {
+	TransactionID string        `json:"transaction_id,omitempty"` // 微信支付订单号，与 out_trade_no 二选一
+	OutTradeNO    string        `json:"out_trade_no,omitempty"`   // 商户订单号，与 transaction_id 二选一
+	OutRefundNO   string        `json:"out_refund_no"`            // 商户退款单号
+	Reason        string        `json:"reason,omitempty"`         // 退款原因
+	NotifyURL     string        `json:"notify_url,omitempty"`     // 退款结果回调url
+	FundsAccount  string        `json:"funds_account,omitempty"`  // 退款资金来源，AVAILABLE：可用余额账户
+	Amount        *RefundAmount `json:"amount"`                   // 金额信息
+}
+
+// RefundAmountDetail 退款金额详情
+type RefundAmountDetail struct {
+	Total            int64  `json:"total"`             // 订单金额
+	Refund           int64  `json:"refund"`            // 退款金额
+	PayerTotal       int64  `json:"payer_total"`       // 用户支付金额
+	PayerRefund      int64  `json:"payer_refund"`      // 用户退款金额
+	SettlementRefund int64  `json:"settlement_refund"` // 应结退款金额
+	SettlementTotal  int64  `json:"settlement_total"`  // 应结订单金额
+	DiscountRefund   int64  `json:"discount_refund"`   // 优惠退款金额
+	Currency         string `json:"currency"`          // 退款币种
+}
+
+// ResultRefund 退款信息
+type ResultRefund struct {
+	RefundID            string              `json:"refund_id"`             // 微信支付退款单号
+	OutRefundNO         string              `json:"out_refund_no"`         // 商户退款单号
+	TransactionID       string              `json:"transaction_id"`        // 微信支付订单号
+	OutTradeNO          string              `json:"out_trade_no"`          // 商户订单号
+	Channel             string              `json:"channel"`               // 退款渠道：ORIGINAL、BALANCE、OTHER_BALANCE、OTHER_BANKCARD
+	UserReceivedAccount string              `json:"user_received_account"` // 退款入账账户
+	SuccessTime         string              `json:"success_time"`          // 退款成功时间
+	CreateTime          string              `json:"create_time"`           // 退款创建时间
+	Status              string              `json:"status"`                // 退款状态：SUCCESS、CLOSED、PROCESSING、ABNORMAL
+	FundsAccount        string              `json:"funds_account"`         // 资金账户
+	Amount              *RefundAmountDetail `json:"amount"`                // 金额信息
+}
+
+// Refund 基础支付 - 申请退款
+// [参考](https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter3_1_9.shtml)
+func Refund(params *ParamsRefund, result *ResultRefund, options ...SLOption) Action {
+	return NewPostAction(urls.MchV3RefundDomestic,
+		WithBody(func(mch *Mch) ([]byte, error) {
+			body := &struct {
+				SubMchID string `json:"sub_mchid,omitempty"`
+				*ParamsRefund
+			}{
+				ParamsRefund: params,
+			}
+
+			// 退款接口地址不区分模式，服务商模式下需带上子商户号
+			if mch.partner {
+				body.SubMchID = newTxnMerchant(mch, "", options...).SubMchID
+			}
+
+			return wx.MarshalNoEscapeHTML(body)
+		}),
+		WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// QueryRefund 基础支付 - 查询单笔退款
+// [参考](https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter3_1_10.shtml)
+func QueryRefund(outRefundNO string, result *ResultRefund, options ...SLOption) Action {
+	return NewGetAction(fmt.Sprintf("%s/%s", urls.MchV3RefundDomestic, outRefundNO),
+		WithQueryFunc(func(mch *Mch, query url.Values) {
+			if mch.partner {
+				query.Set("sub_mchid", newTxnMerchant(mch, "", options...).SubMchID)
+			}
+		}),
+		WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
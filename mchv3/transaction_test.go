@@ -0,0 +1,290 @@
+package mchv3
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/mock"
+)
+
+func TestJSAPITransaction(t *testing.T) {
+	body := []byte(`{"appid":"wxd678efh567hg6787","mchid":"1900000001","description":"Image形象店-深圳腾大-QQ公仔","out_trade_no":"1217752501201407033233368018","notify_url":"https://www.weixin.qq.com/wxpay/pay.php","amount":{"total":100,"currency":"CNY"},"payer":{"openid":"oUpF8uMuAJO_M2pxb1Q9zNjWeS6o"}}`)
+	resp := []byte(`{"prepay_id":"wx201410272009395522657a690389285100"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/v3/pay/transactions/jsapi", body, gomock.Any()).Return(resp, nil)
+
+	mch := newTestMch(t, WithMockClient(client))
+
+	params := &ParamsTransaction{
+		Description: "Image形象店-深圳腾大-QQ公仔",
+		OutTradeNO:  "1217752501201407033233368018",
+		NotifyURL:   "https://www.weixin.qq.com/wxpay/pay.php",
+		Amount: &TransactionAmount{
+			Total:    100,
+			Currency: "CNY",
+		},
+		Payer: &TransactionPayer{
+			OpenID: "oUpF8uMuAJO_M2pxb1Q9zNjWeS6o",
+		},
+	}
+
+	result := new(ResultPrepay)
+
+	err := mch.Do(context.TODO(), JSAPITransaction("wxd678efh567hg6787", params, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultPrepay{
+		PrepayID: "wx201410272009395522657a690389285100",
+	}, result)
+}
+
+func TestPartnerJSAPITransaction(t *testing.T) {
+	body := []byte(`{"sp_appid":"wx8888888888888888","sp_mchid":"1900000001","sub_appid":"wxd678efh567hg6999","sub_mchid":"1900000109","description":"Image形象店-深圳腾大-QQ公仔","out_trade_no":"1217752501201407033233368018","notify_url":"https://www.weixin.qq.com/wxpay/pay.php","amount":{"total":100,"currency":"CNY"},"payer":{"sub_openid":"oUpF8uMuAJO_M2pxb1Q9zNjWeS6o"}}`)
+	resp := []byte(`{"prepay_id":"wx201410272009395522657a690389285100"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/v3/pay/partner/transactions/jsapi", body, gomock.Any()).Return(resp, nil)
+
+	mch := newTestMch(t, WithPartner(), WithMockClient(client))
+
+	params := &ParamsTransaction{
+		Description: "Image形象店-深圳腾大-QQ公仔",
+		OutTradeNO:  "1217752501201407033233368018",
+		NotifyURL:   "https://www.weixin.qq.com/wxpay/pay.php",
+		Amount: &TransactionAmount{
+			Total:    100,
+			Currency: "CNY",
+		},
+		Payer: &TransactionPayer{
+			SubOpenID: "oUpF8uMuAJO_M2pxb1Q9zNjWeS6o",
+		},
+	}
+
+	result := new(ResultPrepay)
+
+	err := mch.Do(context.TODO(), JSAPITransaction("wx8888888888888888", params, result, WithSubMchID("1900000109"), WithSubAppID("wxd678efh567hg6999")))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultPrepay{
+		PrepayID: "wx201410272009395522657a690389285100",
+	}, result)
+}
+
+func TestNativeTransaction(t *testing.T) {
+	body := []byte(`{"appid":"wxd678efh567hg6787","mchid":"1900000001","description":"Image形象店-深圳腾大-QQ公仔","out_trade_no":"1217752501201407033233368018","notify_url":"https://www.weixin.qq.com/wxpay/pay.php","amount":{"total":100}}`)
+	resp := []byte(`{"code_url":"weixin://wxpay/bizpayurl/up?pr=NwY5Mz9&groupid=00"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/v3/pay/transactions/native", body, gomock.Any()).Return(resp, nil)
+
+	mch := newTestMch(t, WithMockClient(client))
+
+	params := &ParamsTransaction{
+		Description: "Image形象店-深圳腾大-QQ公仔",
+		OutTradeNO:  "1217752501201407033233368018",
+		NotifyURL:   "https://www.weixin.qq.com/wxpay/pay.php",
+		Amount: &TransactionAmount{
+			Total: 100,
+		},
+	}
+
+	result := new(ResultPrepay)
+
+	err := mch.Do(context.TODO(), NativeTransaction("wxd678efh567hg6787", params, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultPrepay{
+		CodeURL: "weixin://wxpay/bizpayurl/up?pr=NwY5Mz9&groupid=00",
+	}, result)
+}
+
+func TestQueryTransactionByID(t *testing.T) {
+	resp := []byte(`{"appid":"wxd678efh567hg6787","mchid":"1900000001","out_trade_no":"1217752501201407033233368018","transaction_id":"1217752501201407033233368018","trade_type":"JSAPI","trade_state":"SUCCESS","trade_state_desc":"支付成功","bank_type":"CMC","success_time":"2018-06-08T10:34:56+08:00","payer":{"openid":"oUpF8uMuAJO_M2pxb1Q9zNjWeS6o"},"amount":{"total":100,"payer_total":100,"currency":"CNY","payer_currency":"CNY"}}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.mch.weixin.qq.com/v3/pay/transactions/id/1217752501201407033233368018?mchid=1900000001", nil, gomock.Any()).Return(resp, nil)
+
+	mch := newTestMch(t, WithMockClient(client))
+
+	result := new(ResultTransaction)
+
+	err := mch.Do(context.TODO(), QueryTransactionByID("1217752501201407033233368018", result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultTransaction{
+		AppID:          "wxd678efh567hg6787",
+		MchID:          "1900000001",
+		OutTradeNO:     "1217752501201407033233368018",
+		TransactionID:  "1217752501201407033233368018",
+		TradeType:      "JSAPI",
+		TradeState:     "SUCCESS",
+		TradeStateDesc: "支付成功",
+		BankType:       "CMC",
+		SuccessTime:    "2018-06-08T10:34:56+08:00",
+		Payer: &TransactionPayer{
+			OpenID: "oUpF8uMuAJO_M2pxb1Q9zNjWeS6o",
+		},
+		Amount: &TransactionPayerAmount{
+			Total:         100,
+			PayerTotal:    100,
+			Currency:      "CNY",
+			PayerCurrency: "CNY",
+		},
+	}, result)
+}
+
+func TestPartnerQueryTransactionByOutTradeNO(t *testing.T) {
+	resp := []byte(`{"sp_appid":"wx8888888888888888","sp_mchid":"1900000001","sub_mchid":"1900000109","out_trade_no":"1217752501201407033233368018","trade_state":"NOTPAY","trade_state_desc":"未支付"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.mch.weixin.qq.com/v3/pay/partner/transactions/out-trade-no/1217752501201407033233368018?sp_mchid=1900000001&sub_mchid=1900000109", nil, gomock.Any()).Return(resp, nil)
+
+	mch := newTestMch(t, WithPartner(), WithMockClient(client))
+
+	result := new(ResultTransaction)
+
+	err := mch.Do(context.TODO(), QueryTransactionByOutTradeNO("1217752501201407033233368018", result, WithSubMchID("1900000109")))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultTransaction{
+		SPAppID:        "wx8888888888888888",
+		SPMchID:        "1900000001",
+		SubMchID:       "1900000109",
+		OutTradeNO:     "1217752501201407033233368018",
+		TradeState:     "NOTPAY",
+		TradeStateDesc: "未支付",
+	}, result)
+}
+
+func TestCloseTransaction(t *testing.T) {
+	body := []byte(`{"mchid":"1900000001"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/v3/pay/transactions/out-trade-no/1217752501201407033233368018/close", body, gomock.Any()).Return(nil, nil)
+
+	mch := newTestMch(t, WithMockClient(client))
+
+	err := mch.Do(context.TODO(), CloseTransaction("1217752501201407033233368018"))
+
+	assert.Nil(t, err)
+}
+
+func TestPartnerCloseTransaction(t *testing.T) {
+	body := []byte(`{"sp_mchid":"1900000001","sub_mchid":"1900000109"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/v3/pay/partner/transactions/out-trade-no/1217752501201407033233368018/close", body, gomock.Any()).Return(nil, nil)
+
+	mch := newTestMch(t, WithPartner(), WithMockClient(client))
+
+	err := mch.Do(context.TODO(), CloseTransaction("1217752501201407033233368018", WithSubMchID("1900000109"), WithSubAppID("wxd678efh567hg6999")))
+
+	assert.Nil(t, err)
+}
+
+func TestPartnerRefund(t *testing.T) {
+	body := []byte(`{"sub_mchid":"1900000109","transaction_id":"1217752501201407033233368018","out_refund_no":"1217752501201407033233368018","reason":"商品已售完","amount":{"refund":888,"total":888,"currency":"CNY"}}`)
+	resp := []byte(`{"refund_id":"50000000382019052709732678859","out_refund_no":"1217752501201407033233368018","transaction_id":"1217752501201407033233368018","out_trade_no":"1217752501201407033233368018","channel":"ORIGINAL","user_received_account":"招商银行信用卡0403","create_time":"2020-12-01T16:18:12+08:00","status":"PROCESSING","amount":{"total":888,"refund":888,"payer_total":888,"payer_refund":888,"settlement_refund":888,"settlement_total":888,"discount_refund":0,"currency":"CNY"}}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/v3/refund/domestic/refunds", body, gomock.Any()).Return(resp, nil)
+
+	mch := newTestMch(t, WithPartner(), WithMockClient(client))
+
+	params := &ParamsRefund{
+		TransactionID: "1217752501201407033233368018",
+		OutRefundNO:   "1217752501201407033233368018",
+		Reason:        "商品已售完",
+		Amount: &RefundAmount{
+			Refund:   888,
+			Total:    888,
+			Currency: "CNY",
+		},
+	}
+
+	result := new(ResultRefund)
+
+	err := mch.Do(context.TODO(), Refund(params, result, WithSubMchID("1900000109")))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultRefund{
+		RefundID:            "50000000382019052709732678859",
+		OutRefundNO:         "1217752501201407033233368018",
+		TransactionID:       "1217752501201407033233368018",
+		OutTradeNO:          "1217752501201407033233368018",
+		Channel:             "ORIGINAL",
+		UserReceivedAccount: "招商银行信用卡0403",
+		CreateTime:          "2020-12-01T16:18:12+08:00",
+		Status:              "PROCESSING",
+		Amount: &RefundAmountDetail{
+			Total:            888,
+			Refund:           888,
+			PayerTotal:       888,
+			PayerRefund:      888,
+			SettlementRefund: 888,
+			SettlementTotal:  888,
+			Currency:         "CNY",
+		},
+	}, result)
+}
+
+func TestQueryRefund(t *testing.T) {
+	resp := []byte(`{"refund_id":"50000000382019052709732678859","out_refund_no":"1217752501201407033233368018","status":"SUCCESS"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.mch.weixin.qq.com/v3/refund/domestic/refunds/1217752501201407033233368018", nil, gomock.Any()).Return(resp, nil)
+
+	mch := newTestMch(t, WithMockClient(client))
+
+	result := new(ResultRefund)
+
+	err := mch.Do(context.TODO(), QueryRefund("1217752501201407033233368018", result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultRefund{
+		RefundID:    "50000000382019052709732678859",
+		OutRefundNO: "1217752501201407033233368018",
+		Status:      "SUCCESS",
+	}, result)
+}
@@ -0,0 +1,305 @@
+package mchv3
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/shenghui0779/gochat/urls"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// TransferDetailInput 转账明细
+type TransferDetailInput struct {
+	OutDetailNO    string `json:"out_detail_no"`       // 商户系统内部区分转账批次单下不同转账明细单的唯一标识
+	TransferAmount int64  `json:"transfer_amount"`     // 转账金额单位为“分”
+	TransferRemark string `json:"transfer_remark"`     // 单条转账备注（微信用户会收到该备注），UTF8编码，最多允许32个字符
+	OpenID         string `json:"openid"`              // 商户appid下，某用户的openid
+	UserName       string `json:"user_name,omitempty"` // 收款方真实姓名（明文，请求时自动使用平台证书加密）；转账金额 >= 2,000元时，该笔明细必须填写
+}
+
+// ParamsTransferBatch 发起商家转账参数
+type ParamsTransferBatch struct {
+	OutBatchNO         string                 `json:"out_batch_no"`                // 商户系统内部的商家批次单号，要求此参数只能由数字、大小写字母组成，在商户系统内部唯一
+	BatchName          string                 `json:"batch_name"`                  // 该笔批量转账的名称
+	BatchRemark        string                 `json:"batch_remark"`                // 转账说明，UTF8编码，最多允许32个字符
+	TotalAmount        int64                  `json:"total_amount"`                // 转账金额单位为“分”，转账总金额必须与批次内所有明细转账金额之和保持一致，否则无法发起转账操作
+	TotalNum           int                    `json:"total_num"`                   // 一个转账批次单最多发起一千笔转账，转账总笔数必须与批次内所有明细之和保持一致，否则无法发起转账操作
+	TransferDetailList []*TransferDetailInput `json:"transfer_detail_list"`        // 发起批量转账的明细列表，最多一千笔
+	TransferSceneID    string                 `json:"transfer_scene_id,omitempty"` // 该批次转账使用的转账场景，如不填写则使用商家的默认场景
+}
+
+// ResultTransferBatch 发起商家转账结果
+type ResultTransferBatch struct {
+	OutBatchNO string `json:"out_batch_no"` // 商户系统内部的商家批次单号
+	BatchID    string `json:"batch_id"`     // 微信批次单号，微信商家转账系统返回的唯一标识
+	CreateTime string `json:"create_time"`  // 批次受理成功时返回，按照使用rfc3339所定义的格式，格式为YYYY-MM-DDThh:mm:ss+TIMEZONE
+}
+
+// TransferBatch 商家转账 - 发起商家转账
+// 注意：转账明细中的收款方姓名会使用平台证书自动加密，需通过 WithPlatformCert 设置平台证书
+// [参考](https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter4_3_1.shtml)
+func TransferBatch(appid string, params *ParamsTransferBatch, result *ResultTransferBatch) Action {
+	return NewPostAction(urls.MchV3TransferBatches,
+		WithBody(func(mch *Mch) ([]byte, error) {
+			// 不修改调用方传入的参数
+			p := *params
+			p.TransferDetailList = make([]*TransferDetailInput, 0, len(params.TransferDetailList))
+
+			for _, v := range params.TransferDetailList {
+				detail := *v
+
+				if len(detail.UserName) != 0 {
+					cipherText, err := mch.Encrypt(detail.UserName)
+
+					if err != nil {
+						return nil, err
+					}
+
+					detail.UserName = cipherText
+				}
+
+				p.TransferDetailList = append(p.TransferDetailList, &detail)
+			}
+
+			return wx.MarshalNoEscapeHTML(&struct {
+				AppID string `json:"appid"`
+				*ParamsTransferBatch
+			}{
+				AppID:               appid,
+				ParamsTransferBatch: &p,
+			})
+		}),
+		WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// ParamsTransferBatchQuery 查询批次单参数
+type ParamsTransferBatchQuery struct {
+	NeedQueryDetail bool   // 是否查询转账明细单
+	Offset          int    // 该次请求资源的起始位置，从0开始，默认值为0
+	Limit           int    // 该次请求可返回的最大明细条数，最小20条，最大100条，不传则默认20条
+	DetailStatus    string // 查询明细单状态：ALL、SUCCESS、FAIL（查询转账明细单时必填）
+}
+
+// TransferBatchInfo 转账批次单
+type TransferBatchInfo struct {
+	MchID           string `json:"mchid"`             // 微信支付分配的商户号
+	OutBatchNO      string `json:"out_batch_no"`      // 商户系统内部的商家批次单号
+	BatchID         string `json:"batch_id"`          // 微信批次单号
+	AppID           string `json:"appid"`             // 申请商户号的appid或商户号绑定的appid
+	BatchStatus     string `json:"batch_status"`      // 批次状态
+	BatchType       string `json:"batch_type"`        // 批次类型：API、WEB
+	BatchName       string `json:"batch_name"`        // 该笔批量转账的名称
+	BatchRemark     string `json:"batch_remark"`      // 转账说明
+	CloseReason     string `json:"close_reason"`      // 批次关闭原因
+	TotalAmount     int64  `json:"total_amount"`      // 转账总金额
+	TotalNum        int    `json:"total_num"`         // 转账总笔数
+	CreateTime      string `json:"create_time"`       // 批次创建时间
+	UpdateTime      string `json:"update_time"`       // 批次最近一次状态变更的时间
+	SuccessAmount   int64  `json:"success_amount"`    // 转账成功金额
+	SuccessNum      int    `json:"success_num"`       // 转账成功笔数
+	FailAmount      int64  `json:"fail_amount"`       // 转账失败金额
+	FailNum         int    `json:"fail_num"`          // 转账失败笔数
+	TransferSceneID string `json:"transfer_scene_id"` // 转账场景ID
+}
+
+// TransferDetailBrief 转账明细单概要
+type TransferDetailBrief struct {
+	DetailID     string `json:"detail_id"`     // 微信支付系统内部区分转账批次单下不同转账明细单的唯一标识
+	OutDetailNO  string `json:"out_detail_no"` // 商户系统内部区分转账批次单下不同转账明细单的唯一标识
+	DetailStatus string `json:"detail_status"` // 明细状态
+}
+
+// ResultTransferBatchQuery 查询批次单结果
+type ResultTransferBatchQuery struct {
+	Limit              int                    `json:"limit"`
+	Offset             int                    `json:"offset"`
+	TransferBatch      *TransferBatchInfo     `json:"transfer_batch"`
+	TransferDetailList []*TransferDetailBrief `json:"transfer_detail_list"`
+}
+
+// QueryTransferBatchByBatchID 商家转账 - 通过微信批次单号查询批次单
+func QueryTransferBatchByBatchID(batchID string, params *ParamsTransferBatchQuery, result *ResultTransferBatchQuery) Action {
+	return NewGetAction(fmt.Sprintf("%s/%s", urls.MchV3TransferBatchByBatchID, batchID),
+		transferBatchQuery(params),
+		WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// QueryTransferBatchByOutBatchNO 商家转账 - 通过商家批次单号查询批次单
+func QueryTransferBatchByOutBatchNO(outBatchNO string, params *ParamsTransferBatchQuery, result *ResultTransferBatchQuery) Action {
+	return NewGetAction(fmt.Sprintf("%s/%s", urls.MchV3TransferBatchByOutBatchNO, outBatchNO),
+		transferBatchQuery(params),
+		WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+func transferBatchQuery(params *ParamsTransferBatchQuery) ActionOption {
+	return func(a *action) {
+		a.query.Set("need_query_detail", strconv.FormatBool(params.NeedQueryDetail))
+
+		if params.Offset > 0 {
+			a.query.Set("offset", strconv.Itoa(params.Offset))
+		}
+
+		if params.Limit > 0 {
+			a.query.Set("limit", strconv.Itoa(params.Limit))
+		}
+
+		if len(params.DetailStatus) != 0 {
+			a.query.Set("detail_status", params.DetailStatus)
+		}
+	}
+}
+
+// ResultTransferDetail 转账明细单
+type ResultTransferDetail struct {
+	MchID          string `json:"mchid"`           // 微信支付分配的商户号
+	OutBatchNO     string `json:"out_batch_no"`    // 商户系统内部的商家批次单号
+	BatchID        string `json:"batch_id"`        // 微信批次单号
+	AppID          string `json:"appid"`           // 申请商户号的appid或商户号绑定的appid
+	OutDetailNO    string `json:"out_detail_no"`   // 商户系统内部区分转账批次单下不同转账明细单的唯一标识
+	DetailID       string `json:"detail_id"`       // 微信支付系统内部区分转账批次单下不同转账明细单的唯一标识
+	DetailStatus   string `json:"detail_status"`   // 明细状态
+	TransferAmount int64  `json:"transfer_amount"` // 转账金额单位为“分”
+	TransferRemark string `json:"transfer_remark"` // 单条转账备注
+	FailReason     string `json:"fail_reason"`     // 明细失败原因
+	OpenID         string `json:"openid"`          // 收款用户openid
+	UserName       string `json:"user_name"`       // 收款方姓名（密文，可通过 Mch.Decrypt 解密）
+	InitiateTime   string `json:"initiate_time"`   // 转账发起的时间
+	UpdateTime     string `json:"update_time"`     // 明细最后一次状态变更的时间
+}
+
+// QueryTransferDetailByDetailID 商家转账 - 通过微信明细单号查询明细单
+func QueryTransferDetailByDetailID(batchID, detailID string, result *ResultTransferDetail) Action {
+	return NewGetAction(fmt.Sprintf("%s/%s/details/detail-id/%s", urls.MchV3TransferBatchByBatchID, batchID, detailID),
+		WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// QueryTransferDetailByOutDetailNO 商家转账 - 通过商家明细单号查询明细单
+func QueryTransferDetailByOutDetailNO(outBatchNO, outDetailNO string, result *ResultTransferDetail) Action {
+	return NewGetAction(fmt.Sprintf("%s/%s/details/out-detail-no/%s", urls.MchV3TransferBatchByOutBatchNO, outBatchNO, outDetailNO),
+		WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// ParamsTransferBillReceipt 转账电子回单申请参数
+type ParamsTransferBillReceipt struct {
+	OutBatchNO string `json:"out_batch_no"`
+}
+
+// ResultTransferBillReceipt 转账电子回单
+type ResultTransferBillReceipt struct {
+	OutBatchNO      string `json:"out_batch_no"`     // 商户系统内部的商家批次单号
+	SignatureNO     string `json:"signature_no"`     // 电子回单申请单号，申请单据的唯一标识
+	SignatureStatus string `json:"signature_status"` // 电子回单状态：ACCEPTED、FINISHED
+	HashType        string `json:"hash_type"`        // 电子回单文件的hash方法，回单状态为：FINISHED时返回
+	HashValue       string `json:"hash_value"`       // 电子回单文件的hash值，用于下载之后验证文件的完整、正确性，回单状态为：FINISHED时返回
+	DownloadURL     string `json:"download_url"`     // 电子回单文件的下载地址，回单状态为：FINISHED时返回，URL有效期10min
+	CreateTime      string `json:"create_time"`      // 电子签章单创建时间
+	UpdateTime      string `json:"update_time"`      // 电子签章单最近一次状态变更的时间
+}
+
+// ApplyTransferBillReceipt 电子回单 - 转账电子回单申请受理
+func ApplyTransferBillReceipt(outBatchNO string, result *ResultTransferBillReceipt) Action {
+	params := &ParamsTransferBillReceipt{
+		OutBatchNO: outBatchNO,
+	}
+
+	return NewPostAction(urls.MchV3TransferBillReceipt,
+		WithBody(func(mch *Mch) ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+		WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// QueryTransferBillReceipt 电子回单 - 查询转账电子回单
+func QueryTransferBillReceipt(outBatchNO string, result *ResultTransferBillReceipt) Action {
+	return NewGetAction(fmt.Sprintf("%s/%s", urls.MchV3TransferBillReceipt, outBatchNO),
+		WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// ParamsTransferDetailReceipt 转账明细电子回单受理参数
+type ParamsTransferDetailReceipt struct {
+	AcceptType  string `json:"accept_type"`            // 电子回单受理类型：BATCH_TRANSFER、TRANSFER_TO_POCKET、TRANSFER_TO_BANK
+	OutBatchNO  string `json:"out_batch_no,omitempty"` // 商家转账批次单号，受理类型为BATCH_TRANSFER时必填
+	OutDetailNO string `json:"out_detail_no"`          // 商家转账明细单号（企业付款时为商户订单号）
+}
+
+// ResultTransferDetailReceipt 转账明细电子回单
+type ResultTransferDetailReceipt struct {
+	AcceptType      string `json:"accept_type"`      // 电子回单受理类型
+	OutBatchNO      string `json:"out_batch_no"`     // 商家转账批次单号
+	OutDetailNO     string `json:"out_detail_no"`    // 商家转账明细单号
+	SignatureNO     string `json:"signature_no"`     // 电子回单受理单号
+	SignatureStatus string `json:"signature_status"` // 电子回单状态：ACCEPTED、FINISHED
+	HashType        string `json:"hash_type"`        // 电子回单文件的hash方法
+	HashValue       string `json:"hash_value"`       // 电子回单文件的hash值
+	DownloadURL     string `json:"download_url"`     // 电子回单文件的下载地址，URL有效期10min
+}
+
+// ApplyTransferDetailReceipt 电子回单 - 转账明细电子回单受理
+func ApplyTransferDetailReceipt(params *ParamsTransferDetailReceipt, result *ResultTransferDetailReceipt) Action {
+	return NewPostAction(urls.MchV3TransferDetailReceipt,
+		WithBody(func(mch *Mch) ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+		WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// QueryTransferDetailReceipt 电子回单 - 查询转账明细电子回单受理结果
+func QueryTransferDetailReceipt(params *ParamsTransferDetailReceipt, result *ResultTransferDetailReceipt) Action {
+	options := []ActionOption{
+		WithQuery("accept_type", params.AcceptType),
+		WithQuery("out_detail_no", params.OutDetailNO),
+		WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	}
+
+	if len(params.OutBatchNO) != 0 {
+		options = append(options, WithQuery("out_batch_no", params.OutBatchNO))
+	}
+
+	return NewGetAction(urls.MchV3TransferDetailReceipt, options...)
+}
+
+// ReceiptFile 电子回单文件
+type ReceiptFile struct {
+	Buffer []byte
+}
+
+// DownloadReceipt 电子回单 - 下载电子回单（download_url 来自回单查询结果，有效期10min）
+// 下载后请使用回单查询结果中的 hash_value 校验文件完整性
+func DownloadReceipt(downloadURL string, file *ReceiptFile) Action {
+	return NewGetAction(downloadURL,
+		WithoutVerify(),
+		WithDecode(func(b []byte) error {
+			file.Buffer = make([]byte, len(b))
+			copy(file.Buffer, b)
+
+			return nil
+		}),
+	)
+}
@@ -0,0 +1,348 @@
+package mchv3
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/mock"
+)
+
+func TestTransferBatch(t *testing.T) {
+	resp := []byte(`{"out_batch_no":"plfk2020042013","batch_id":"1030000071100999991182020050700019480001","create_time":"2015-05-20T13:29:35.120+08:00"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	mch := newTestMch(t, WithMockClient(client))
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/v3/transfer/batches", gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, method, reqURL string, body []byte, options ...interface{}) ([]byte, error) {
+		params := make(map[string]interface{})
+
+		assert.Nil(t, json.Unmarshal(body, &params))
+		assert.Equal(t, "wxf636efh567hg4356", params["appid"])
+		assert.Equal(t, "plfk2020042013", params["out_batch_no"])
+
+		details := params["transfer_detail_list"].([]interface{})
+		assert.Equal(t, 1, len(details))
+
+		// 收款方姓名需加密
+		name, err := mch.Decrypt(details[0].(map[string]interface{})["user_name"].(string))
+		assert.Nil(t, err)
+		assert.Equal(t, "张三", name)
+
+		return resp, nil
+	})
+
+	params := &ParamsTransferBatch{
+		OutBatchNO:  "plfk2020042013",
+		BatchName:   "2019年1月深圳分部报销单",
+		BatchRemark: "2019年1月深圳分部报销单",
+		TotalAmount: 4000000,
+		TotalNum:    1,
+		TransferDetailList: []*TransferDetailInput{
+			{
+				OutDetailNO:    "x23zy545Bd5436",
+				TransferAmount: 4000000,
+				TransferRemark: "2020年4月报销",
+				OpenID:         "o-MYE42l80oelYMDE34nYD456Xoy",
+				UserName:       "张三",
+			},
+		},
+	}
+
+	result := new(ResultTransferBatch)
+
+	err := mch.Do(context.TODO(), TransferBatch("wxf636efh567hg4356", params, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultTransferBatch{
+		OutBatchNO: "plfk2020042013",
+		BatchID:    "1030000071100999991182020050700019480001",
+		CreateTime: "2015-05-20T13:29:35.120+08:00",
+	}, result)
+
+	// 调用方参数不被修改
+	assert.Equal(t, "张三", params.TransferDetailList[0].UserName)
+}
+
+func TestQueryTransferBatchByBatchID(t *testing.T) {
+	resp := []byte(`{"limit":20,"offset":0,"transfer_batch":{"mchid":"1900001109","out_batch_no":"plfk2020042013","batch_id":"1030000071100999991182020050700019480001","appid":"wxf636efh567hg4356","batch_status":"ACCEPTED","batch_type":"API","batch_name":"2019年1月深圳分部报销单","batch_remark":"2019年1月深圳分部报销单","total_amount":4000000,"total_num":200},"transfer_detail_list":[{"detail_id":"1040000071100999991182020050700019500100","out_detail_no":"x23zy545Bd5436","detail_status":"SUCCESS"}]}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.mch.weixin.qq.com/v3/transfer/batches/batch-id/1030000071100999991182020050700019480001?detail_status=ALL&limit=20&need_query_detail=true", nil, gomock.Any()).Return(resp, nil)
+
+	mch := newTestMch(t, WithMockClient(client))
+
+	params := &ParamsTransferBatchQuery{
+		NeedQueryDetail: true,
+		Limit:           20,
+		DetailStatus:    "ALL",
+	}
+
+	result := new(ResultTransferBatchQuery)
+
+	err := mch.Do(context.TODO(), QueryTransferBatchByBatchID("1030000071100999991182020050700019480001", params, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultTransferBatchQuery{
+		Limit:  20,
+		Offset: 0,
+		TransferBatch: &TransferBatchInfo{
+			MchID:       "1900001109",
+			OutBatchNO:  "plfk2020042013",
+			BatchID:     "1030000071100999991182020050700019480001",
+			AppID:       "wxf636efh567hg4356",
+			BatchStatus: TransferBatchAccepted,
+			BatchType:   "API",
+			BatchName:   "2019年1月深圳分部报销单",
+			BatchRemark: "2019年1月深圳分部报销单",
+			TotalAmount: 4000000,
+			TotalNum:    200,
+		},
+		TransferDetailList: []*TransferDetailBrief{
+			{
+				DetailID:     "1040000071100999991182020050700019500100",
+				OutDetailNO:  "x23zy545Bd5436",
+				DetailStatus: TransferDetailSuccess,
+			},
+		},
+	}, result)
+}
+
+func TestQueryTransferBatchByOutBatchNO(t *testing.T) {
+	resp := []byte(`{"limit":20,"offset":0,"transfer_batch":{"mchid":"1900001109","out_batch_no":"plfk2020042013","batch_id":"1030000071100999991182020050700019480001","batch_status":"FINISHED"}}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.mch.weixin.qq.com/v3/transfer/batches/out-batch-no/plfk2020042013?need_query_detail=false", nil, gomock.Any()).Return(resp, nil)
+
+	mch := newTestMch(t, WithMockClient(client))
+
+	result := new(ResultTransferBatchQuery)
+
+	err := mch.Do(context.TODO(), QueryTransferBatchByOutBatchNO("plfk2020042013", &ParamsTransferBatchQuery{}, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultTransferBatchQuery{
+		Limit:  20,
+		Offset: 0,
+		TransferBatch: &TransferBatchInfo{
+			MchID:       "1900001109",
+			OutBatchNO:  "plfk2020042013",
+			BatchID:     "1030000071100999991182020050700019480001",
+			BatchStatus: TransferBatchFinished,
+		},
+	}, result)
+}
+
+func TestQueryTransferDetailByDetailID(t *testing.T) {
+	resp := []byte(`{"mchid":"1900001109","out_batch_no":"plfk2020042013","batch_id":"1030000071100999991182020050700019480001","appid":"wxf636efh567hg4356","out_detail_no":"x23zy545Bd5436","detail_id":"1040000071100999991182020050700019500100","detail_status":"SUCCESS","transfer_amount":200000,"transfer_remark":"2020年4月报销","openid":"o-MYE42l80oelYMDE34nYD456Xoy","initiate_time":"2015-05-20T13:29:35.120+08:00","update_time":"2015-05-20T13:29:35.120+08:00"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.mch.weixin.qq.com/v3/transfer/batches/batch-id/1030000071100999991182020050700019480001/details/detail-id/1040000071100999991182020050700019500100", nil, gomock.Any()).Return(resp, nil)
+
+	mch := newTestMch(t, WithMockClient(client))
+
+	result := new(ResultTransferDetail)
+
+	err := mch.Do(context.TODO(), QueryTransferDetailByDetailID("1030000071100999991182020050700019480001", "1040000071100999991182020050700019500100", result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultTransferDetail{
+		MchID:          "1900001109",
+		OutBatchNO:     "plfk2020042013",
+		BatchID:        "1030000071100999991182020050700019480001",
+		AppID:          "wxf636efh567hg4356",
+		OutDetailNO:    "x23zy545Bd5436",
+		DetailID:       "1040000071100999991182020050700019500100",
+		DetailStatus:   TransferDetailSuccess,
+		TransferAmount: 200000,
+		TransferRemark: "2020年4月报销",
+		OpenID:         "o-MYE42l80oelYMDE34nYD456Xoy",
+		InitiateTime:   "2015-05-20T13:29:35.120+08:00",
+		UpdateTime:     "2015-05-20T13:29:35.120+08:00",
+	}, result)
+}
+
+func TestQueryTransferDetailByOutDetailNO(t *testing.T) {
+	resp := []byte(`{"mchid":"1900001109","out_batch_no":"plfk2020042013","out_detail_no":"x23zy545Bd5436","detail_status":"FAIL","fail_reason":"ACCOUNT_FROZEN"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.mch.weixin.qq.com/v3/transfer/batches/out-batch-no/plfk2020042013/details/out-detail-no/x23zy545Bd5436", nil, gomock.Any()).Return(resp, nil)
+
+	mch := newTestMch(t, WithMockClient(client))
+
+	result := new(ResultTransferDetail)
+
+	err := mch.Do(context.TODO(), QueryTransferDetailByOutDetailNO("plfk2020042013", "x23zy545Bd5436", result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultTransferDetail{
+		MchID:        "1900001109",
+		OutBatchNO:   "plfk2020042013",
+		OutDetailNO:  "x23zy545Bd5436",
+		DetailStatus: TransferDetailFail,
+		FailReason:   "ACCOUNT_FROZEN",
+	}, result)
+}
+
+func TestApplyTransferBillReceipt(t *testing.T) {
+	body := []byte(`{"out_batch_no":"plfk2020042013"}`)
+	resp := []byte(`{"out_batch_no":"plfk2020042013","signature_no":"1050000010509999485212020110200058820001","signature_status":"ACCEPTED","create_time":"2020-07-01T12:00:00+08:00"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/v3/transfer/bill-receipt", body, gomock.Any()).Return(resp, nil)
+
+	mch := newTestMch(t, WithMockClient(client))
+
+	result := new(ResultTransferBillReceipt)
+
+	err := mch.Do(context.TODO(), ApplyTransferBillReceipt("plfk2020042013", result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultTransferBillReceipt{
+		OutBatchNO:      "plfk2020042013",
+		SignatureNO:     "1050000010509999485212020110200058820001",
+		SignatureStatus: ReceiptAccepted,
+		CreateTime:      "2020-07-01T12:00:00+08:00",
+	}, result)
+}
+
+func TestQueryTransferBillReceipt(t *testing.T) {
+	resp := []byte(`{"out_batch_no":"plfk2020042013","signature_no":"1050000010509999485212020110200058820001","signature_status":"FINISHED","hash_type":"SHA256","hash_value":"ACC2E2EA5A65D9E9A5DD9EB3A8E9B0C1","download_url":"https://api.mch.weixin.qq.com/v3/billdownload/file?token=xxx"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.mch.weixin.qq.com/v3/transfer/bill-receipt/plfk2020042013", nil, gomock.Any()).Return(resp, nil)
+
+	mch := newTestMch(t, WithMockClient(client))
+
+	result := new(ResultTransferBillReceipt)
+
+	err := mch.Do(context.TODO(), QueryTransferBillReceipt("plfk2020042013", result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultTransferBillReceipt{
+		OutBatchNO:      "plfk2020042013",
+		SignatureNO:     "1050000010509999485212020110200058820001",
+		SignatureStatus: ReceiptFinished,
+		HashType:        "SHA256",
+		HashValue:       "ACC2E2EA5A65D9E9A5DD9EB3A8E9B0C1",
+		DownloadURL:     "https://api.mch.weixin.qq.com/v3/billdownload/file?token=xxx",
+	}, result)
+}
+
+func TestApplyTransferDetailReceipt(t *testing.T) {
+	body := []byte(`{"accept_type":"BATCH_TRANSFER","out_batch_no":"plfk2020042013","out_detail_no":"x23zy545Bd5436"}`)
+	resp := []byte(`{"accept_type":"BATCH_TRANSFER","out_batch_no":"plfk2020042013","out_detail_no":"x23zy545Bd5436","signature_no":"1050000010509999485212020110200058820001","signature_status":"ACCEPTED"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.mch.weixin.qq.com/v3/transfer-detail/electronic-receipts", body, gomock.Any()).Return(resp, nil)
+
+	mch := newTestMch(t, WithMockClient(client))
+
+	params := &ParamsTransferDetailReceipt{
+		AcceptType:  AcceptBatchTransfer,
+		OutBatchNO:  "plfk2020042013",
+		OutDetailNO: "x23zy545Bd5436",
+	}
+
+	result := new(ResultTransferDetailReceipt)
+
+	err := mch.Do(context.TODO(), ApplyTransferDetailReceipt(params, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultTransferDetailReceipt{
+		AcceptType:      AcceptBatchTransfer,
+		OutBatchNO:      "plfk2020042013",
+		OutDetailNO:     "x23zy545Bd5436",
+		SignatureNO:     "1050000010509999485212020110200058820001",
+		SignatureStatus: ReceiptAccepted,
+	}, result)
+}
+
+func TestQueryTransferDetailReceipt(t *testing.T) {
+	resp := []byte(`{"accept_type":"TRANSFER_TO_POCKET","out_detail_no":"x23zy545Bd5436","signature_no":"1050000010509999485212020110200058820001","signature_status":"FINISHED","hash_type":"SHA256","hash_value":"ACC2E2EA5A65D9E9A5DD9EB3A8E9B0C1","download_url":"https://api.mch.weixin.qq.com/v3/billdownload/file?token=xxx"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.mch.weixin.qq.com/v3/transfer-detail/electronic-receipts?accept_type=TRANSFER_TO_POCKET&out_detail_no=x23zy545Bd5436", nil, gomock.Any()).Return(resp, nil)
+
+	mch := newTestMch(t, WithMockClient(client))
+
+	params := &ParamsTransferDetailReceipt{
+		AcceptType:  AcceptTransferToPocket,
+		OutDetailNO: "x23zy545Bd5436",
+	}
+
+	result := new(ResultTransferDetailReceipt)
+
+	err := mch.Do(context.TODO(), QueryTransferDetailReceipt(params, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultTransferDetailReceipt{
+		AcceptType:      AcceptTransferToPocket,
+		OutDetailNO:     "x23zy545Bd5436",
+		SignatureNO:     "1050000010509999485212020110200058820001",
+		SignatureStatus: ReceiptFinished,
+		HashType:        "SHA256",
+		HashValue:       "ACC2E2EA5A65D9E9A5DD9EB3A8E9B0C1",
+		DownloadURL:     "https://api.mch.weixin.qq.com/v3/billdownload/file?token=xxx",
+	}, result)
+}
+
+func TestDownloadReceipt(t *testing.T) {
+	resp := []byte("%PDF-1.4")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.mch.weixin.qq.com/v3/billdownload/file?token=xxx", nil, gomock.Any()).Return(resp, nil)
+
+	mch := newTestMch(t, WithMockClient(client))
+
+	file := new(ReceiptFile)
+
+	err := mch.Do(context.TODO(), DownloadReceipt("https://api.mch.weixin.qq.com/v3/billdownload/file?token=xxx", file))
+
+	assert.Nil(t, err)
+	assert.Equal(t, resp, file.Buffer)
+}
@@ -0,0 +1,165 @@
+package minip
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/shenghui0779/gochat/urls"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// AdSlot 广告位类型
+type AdSlot string
+
+// 微信支持的广告位类型
+const (
+	AdSlotBanner       AdSlot = "SLOT_ID_WEAPP_BANNER"       // 小程序banner
+	AdSlotRewardVideo  AdSlot = "SLOT_ID_WEAPP_REWARD_VIDEO" // 小程序激励视频
+	AdSlotInterstitial AdSlot = "SLOT_ID_WEAPP_INTERSTITIAL" // 小程序插屏广告
+	AdSlotVideoFeeds   AdSlot = "SLOT_ID_WEAPP_VIDEO_FEEDS"  // 小程序视频广告
+	AdSlotVideoBegin   AdSlot = "SLOT_ID_WEAPP_VIDEO_BEGIN"  // 小程序视频前贴
+	AdSlotBox          AdSlot = "SLOT_ID_WEAPP_BOX"          // 小程序格子广告
+	AdSlotTemplate     AdSlot = "SLOT_ID_WEAPP_TEMPLATE"     // 小程序原生模板广告
+)
+
+// ParamsAdStat 广告数据查询参数
+type ParamsAdStat struct {
+	Page      int    // 返回第几页数据，从1开始
+	PageSize  int    // 每页数据条数
+	StartDate string // 开始日期，格式：yyyy-mm-dd
+	EndDate   string // 结束日期，格式：yyyy-mm-dd
+	AdSlot    AdSlot // 广告位类型（可选，不填则返回全部广告位）
+}
+
+// AdBaseResp 广告数据接口返回状态（ret 不为0时表示失败）
+type AdBaseResp struct {
+	ErrMsg string `json:"err_msg"`
+	Ret    int64  `json:"ret"`
+}
+
+// AdStatItem 广告数据指标
+type AdStatItem struct {
+	SlotID        int64   `json:"slot_id"`
+	AdSlot        AdSlot  `json:"ad_slot"`
+	Date          string  `json:"date"`
+	ReqSuccCount  int64   `json:"req_succ_count"` // 拉取量
+	ExposureCount int64   `json:"exposure_count"` // 曝光量
+	ExposureRate  float64 `json:"exposure_rate"`  // 曝光率
+	ClickCount    int64   `json:"click_count"`    // 点击量
+	ClickRate     float64 `json:"click_rate"`     // 点击率
+	Income        int64   `json:"income"`         // 收入（分）
+	ECPM          float64 `json:"ecpm"`           // 广告千次曝光收益（分）
+}
+
+type ResultAdPosGeneral struct {
+	BaseResp *AdBaseResp   `json:"base_resp"`
+	List     []*AdStatItem `json:"list"`
+	Summary  *AdStatItem   `json:"summary"`
+	TotalNum int           `json:"total_num"`
+}
+
+// GetAdPosGeneral 广告 - 获取小程序广告汇总数据
+// [参考](https://developers.weixin.qq.com/miniprogram/dev/platform-capabilities/business-capabilities/ad/ad-api.html)
+func GetAdPosGeneral(params *ParamsAdStat, result *ResultAdPosGeneral) wx.Action {
+	return newAdStatAction("publisher_adpos_general", params, func(b []byte) error {
+		if err := json.Unmarshal(b, result); err != nil {
+			return err
+		}
+
+		return checkAdBaseResp(result.BaseResp)
+	})
+}
+
+// AdUnitStat 广告单元数据
+type AdUnitStat struct {
+	AdUnitID   string      `json:"ad_unit_id"`
+	AdUnitName string      `json:"ad_unit_name"`
+	StatItem   *AdStatItem `json:"stat_item"`
+}
+
+type ResultCustAdPosGeneral struct {
+	BaseResp *AdBaseResp   `json:"base_resp"`
+	List     []*AdUnitStat `json:"list"`
+	Summary  *AdStatItem   `json:"summary"`
+	TotalNum int           `json:"total_num"`
+}
+
+// GetCustAdPosGeneral 广告 - 获取小程序广告细分数据（按广告单元）
+// [参考](https://developers.weixin.qq.com/miniprogram/dev/platform-capabilities/business-capabilities/ad/ad-api.html)
+func GetCustAdPosGeneral(params *ParamsAdStat, result *ResultCustAdPosGeneral) wx.Action {
+	return newAdStatAction("publisher_cust_adpos_general", params, func(b []byte) error {
+		if err := json.Unmarshal(b, result); err != nil {
+			return err
+		}
+
+		return checkAdBaseResp(result.BaseResp)
+	})
+}
+
+// AdSlotRevenue 广告位结算收入
+type AdSlotRevenue struct {
+	SlotID             string `json:"slot_id"`
+	SlotSettledRevenue int64  `json:"slot_settled_revenue"` // 该广告位结算金额（分）
+}
+
+// AdSettlement 结算记录
+type AdSettlement struct {
+	Date           string           `json:"date"`            // 数据更新时间
+	Zone           string           `json:"zone"`            // 日期区间
+	Month          string           `json:"month"`           // 收入月份
+	Order          int              `json:"order"`           // 1 = 上半月，2 = 下半月
+	SettStatus     int              `json:"sett_status"`     // 1 = 结算中；2、3 = 已结算；4 = 付款中；5 = 已付款
+	SettledRevenue int64            `json:"settled_revenue"` // 区间内结算收入（分）
+	SettNo         string           `json:"sett_no"`         // 结算单编号
+	MailSendCnt    string           `json:"mail_send_cnt"`   // 申请补发结算单次数
+	SlotRevenue    []*AdSlotRevenue `json:"slot_revenue"`    // 分广告位的结算收入
+}
+
+type ResultAdSettlement struct {
+	BaseResp          *AdBaseResp     `json:"base_resp"`
+	Body              string          `json:"body"`                // 主体名称
+	PenaltyAll        int64           `json:"penalty_all"`         // 累计扣除金额（分）
+	RevenueAll        int64           `json:"revenue_all"`         // 累计收入（分）
+	SettledRevenueAll int64           `json:"settled_revenue_all"` // 累计已结算收入（分）
+	SettlementList    []*AdSettlement `json:"settlement_list"`
+	TotalNum          int             `json:"total_num"`
+}
+
+// GetAdSettlement 广告 - 获取小程序结算收入数据及结算主体信息
+// [参考](https://developers.weixin.qq.com/miniprogram/dev/platform-capabilities/business-capabilities/ad/ad-api.html)
+func GetAdSettlement(params *ParamsAdStat, result *ResultAdSettlement) wx.Action {
+	return newAdStatAction("publisher_settlement", params, func(b []byte) error {
+		if err := json.Unmarshal(b, result); err != nil {
+			return err
+		}
+
+		return checkAdBaseResp(result.BaseResp)
+	})
+}
+
+func newAdStatAction(action string, params *ParamsAdStat, decode func(b []byte) error) wx.Action {
+	options := []wx.ActionOption{
+		wx.WithQuery("action", action),
+		wx.WithQuery("page", strconv.Itoa(params.Page)),
+		wx.WithQuery("page_size", strconv.Itoa(params.PageSize)),
+		wx.WithQuery("start_date", params.StartDate),
+		wx.WithQuery("end_date", params.EndDate),
+	}
+
+	if len(params.AdSlot) != 0 {
+		options = append(options, wx.WithQuery("ad_slot", string(params.AdSlot)))
+	}
+
+	options = append(options, wx.WithDecode(decode))
+
+	return wx.NewGetAction(urls.MinipPublisherStat, options...)
+}
+
+// checkAdBaseResp 广告数据接口通过 base_resp 返回错误（不返回 errcode）
+func checkAdBaseResp(resp *AdBaseResp) error {
+	if resp != nil && resp.Ret != 0 {
+		return wx.NewAPIError(resp.Ret, resp.ErrMsg)
+	}
+
+	return nil
+}
@@ -0,0 +1,273 @@
+package minip
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/mock"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+func TestGetAdPosGeneral(t *testing.T) {
+	resp := []byte(`{
+	"base_resp": {
+		"err_msg": "ok",
+		"ret": 0
+	},
+	"list": [
+		{
+			"slot_id": 3030046789020061,
+			"ad_slot": "SLOT_ID_WEAPP_BANNER",
+			"date": "2020-04-13",
+			"req_succ_count": 443610,
+			"exposure_count": 181814,
+			"exposure_rate": 0.409850995,
+			"click_count": 1125,
+			"click_rate": 0.006187642,
+			"income": 59,
+			"ecpm": 0.324502
+		}
+	],
+	"summary": {
+		"req_succ_count": 443610,
+		"exposure_count": 181814,
+		"exposure_rate": 0.409850995,
+		"click_count": 1125,
+		"click_rate": 0.006187642,
+		"income": 59,
+		"ecpm": 0.324502
+	},
+	"total_num": 1
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.weixin.qq.com/publisher/stat?access_token=ACCESS_TOKEN&action=publisher_adpos_general&ad_slot=SLOT_ID_WEAPP_BANNER&end_date=2020-04-14&page=1&page_size=10&start_date=2020-04-13", nil).Return(resp, nil)
+
+	mp := New("APPID", "APPSECRET", WithMockClient(client))
+
+	params := &ParamsAdStat{
+		Page:      1,
+		PageSize:  10,
+		StartDate: "2020-04-13",
+		EndDate:   "2020-04-14",
+		AdSlot:    AdSlotBanner,
+	}
+	result := new(ResultAdPosGeneral)
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", GetAdPosGeneral(params, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultAdPosGeneral{
+		BaseResp: &AdBaseResp{
+			ErrMsg: "ok",
+		},
+		List: []*AdStatItem{
+			{
+				SlotID:        3030046789020061,
+				AdSlot:        AdSlotBanner,
+				Date:          "2020-04-13",
+				ReqSuccCount:  443610,
+				ExposureCount: 181814,
+				ExposureRate:  0.409850995,
+				ClickCount:    1125,
+				ClickRate:     0.006187642,
+				Income:        59,
+				ECPM:          0.324502,
+			},
+		},
+		Summary: &AdStatItem{
+			ReqSuccCount:  443610,
+			ExposureCount: 181814,
+			ExposureRate:  0.409850995,
+			ClickCount:    1125,
+			ClickRate:     0.006187642,
+			Income:        59,
+			ECPM:          0.324502,
+		},
+		TotalNum: 1,
+	}, result)
+}
+
+func TestGetCustAdPosGeneral(t *testing.T) {
+	resp := []byte(`{
+	"base_resp": {
+		"err_msg": "ok",
+		"ret": 0
+	},
+	"list": [
+		{
+			"ad_unit_id": "adunit-xxxxxxxxxxxx",
+			"ad_unit_name": "首页banner",
+			"stat_item": {
+				"date": "2020-04-13",
+				"exposure_count": 1000,
+				"click_count": 10,
+				"income": 30
+			}
+		}
+	],
+	"summary": {
+		"exposure_count": 1000,
+		"click_count": 10,
+		"income": 30
+	},
+	"total_num": 1
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.weixin.qq.com/publisher/stat?access_token=ACCESS_TOKEN&action=publisher_cust_adpos_general&end_date=2020-04-14&page=1&page_size=10&start_date=2020-04-13", nil).Return(resp, nil)
+
+	mp := New("APPID", "APPSECRET", WithMockClient(client))
+
+	params := &ParamsAdStat{
+		Page:      1,
+		PageSize:  10,
+		StartDate: "2020-04-13",
+		EndDate:   "2020-04-14",
+	}
+	result := new(ResultCustAdPosGeneral)
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", GetCustAdPosGeneral(params, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultCustAdPosGeneral{
+		BaseResp: &AdBaseResp{
+			ErrMsg: "ok",
+		},
+		List: []*AdUnitStat{
+			{
+				AdUnitID:   "adunit-xxxxxxxxxxxx",
+				AdUnitName: "首页banner",
+				StatItem: &AdStatItem{
+					Date:          "2020-04-13",
+					ExposureCount: 1000,
+					ClickCount:    10,
+					Income:        30,
+				},
+			},
+		},
+		Summary: &AdStatItem{
+			ExposureCount: 1000,
+			ClickCount:    10,
+			Income:        30,
+		},
+		TotalNum: 1,
+	}, result)
+}
+
+func TestGetAdSettlement(t *testing.T) {
+	resp := []byte(`{
+	"base_resp": {
+		"err_msg": "ok",
+		"ret": 0
+	},
+	"body": "测试公司",
+	"penalty_all": 0,
+	"revenue_all": 16850,
+	"settled_revenue_all": 10000,
+	"settlement_list": [
+		{
+			"date": "2020-04-16",
+			"zone": "2020年3月1日至15日",
+			"month": "202003",
+			"order": 1,
+			"sett_status": 3,
+			"settled_revenue": 10000,
+			"sett_no": "SETT_NO",
+			"mail_send_cnt": "0",
+			"slot_revenue": [
+				{
+					"slot_id": "3030046789020061",
+					"slot_settled_revenue": 10000
+				}
+			]
+		}
+	],
+	"total_num": 1
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.weixin.qq.com/publisher/stat?access_token=ACCESS_TOKEN&action=publisher_settlement&end_date=2020-04-30&page=1&page_size=10&start_date=2020-03-01", nil).Return(resp, nil)
+
+	mp := New("APPID", "APPSECRET", WithMockClient(client))
+
+	params := &ParamsAdStat{
+		Page:      1,
+		PageSize:  10,
+		StartDate: "2020-03-01",
+		EndDate:   "2020-04-30",
+	}
+	result := new(ResultAdSettlement)
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", GetAdSettlement(params, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultAdSettlement{
+		BaseResp: &AdBaseResp{
+			ErrMsg: "ok",
+		},
+		Body:              "测试公司",
+		RevenueAll:        16850,
+		SettledRevenueAll: 10000,
+		SettlementList: []*AdSettlement{
+			{
+				Date:           "2020-04-16",
+				Zone:           "2020年3月1日至15日",
+				Month:          "202003",
+				Order:          1,
+				SettStatus:     3,
+				SettledRevenue: 10000,
+				SettNo:         "SETT_NO",
+				MailSendCnt:    "0",
+				SlotRevenue: []*AdSlotRevenue{
+					{
+						SlotID:             "3030046789020061",
+						SlotSettledRevenue: 10000,
+					},
+				},
+			},
+		},
+		TotalNum: 1,
+	}, result)
+}
+
+func TestAdStatError(t *testing.T) {
+	resp := []byte(`{"base_resp":{"err_msg":"invalid date","ret":2009}}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.weixin.qq.com/publisher/stat?access_token=ACCESS_TOKEN&action=publisher_adpos_general&end_date=2020-04-14&page=1&page_size=10&start_date=2020-04-13", nil).Return(resp, nil)
+
+	mp := New("APPID", "APPSECRET", WithMockClient(client))
+
+	params := &ParamsAdStat{
+		Page:      1,
+		PageSize:  10,
+		StartDate: "2020-04-13",
+		EndDate:   "2020-04-14",
+	}
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", GetAdPosGeneral(params, new(ResultAdPosGeneral)))
+
+	assert.True(t, wx.IsAPIError(err, 2009))
+	assert.EqualError(t, err, "2009|invalid date")
+}
@@ -0,0 +1,171 @@
+package minip
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/shenghui0779/gochat/urls"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// AnalysisDateLayout 数据分析接口的日期格式
+const AnalysisDateLayout = "20060102"
+
+// ParamsAnalysisDate 数据分析日期范围（日趋势接口的开始日期与结束日期相同）
+type ParamsAnalysisDate struct {
+	BeginDate string `json:"begin_date"` // 开始日期，格式：yyyymmdd
+	EndDate   string `json:"end_date"`   // 结束日期，格式：yyyymmdd
+}
+
+// DailySummary 用户访问小程序数据概况
+type DailySummary struct {
+	RefDate    string `json:"ref_date"`    // 日期，格式：yyyymmdd
+	VisitTotal int64  `json:"visit_total"` // 累计用户数
+	SharePV    int64  `json:"share_pv"`    // 转发次数
+	ShareUV    int64  `json:"share_uv"`    // 转发人数
+}
+
+type ResultDailySummary struct {
+	List []*DailySummary `json:"list"`
+}
+
+// GetDailySummary 数据分析 - 获取用户访问小程序数据概况
+// [参考](https://developers.weixin.qq.com/miniprogram/dev/OpenApiDoc/data-analysis/others/getDailySummary.html)
+func GetDailySummary(date string, result *ResultDailySummary) wx.Action {
+	params := &ParamsAnalysisDate{
+		BeginDate: date,
+		EndDate:   date,
+	}
+
+	return wx.NewPostAction(urls.MinipAnalysisDailySummaryTrend,
+		wx.WithBody(func() ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// DailyVisitTrend 用户访问小程序数据日趋势
+type DailyVisitTrend struct {
+	RefDate         string  `json:"ref_date"`          // 日期，格式：yyyymmdd
+	SessionCnt      int64   `json:"session_cnt"`       // 打开次数
+	VisitPV         int64   `json:"visit_pv"`          // 访问次数
+	VisitUV         int64   `json:"visit_uv"`          // 访问人数
+	VisitUVNew      int64   `json:"visit_uv_new"`      // 新用户数
+	StayTimeUV      float64 `json:"stay_time_uv"`      // 人均停留时长（单位：秒）
+	StayTimeSession float64 `json:"stay_time_session"` // 次均停留时长（单位：秒）
+	VisitDepth      float64 `json:"visit_depth"`       // 平均访问深度
+}
+
+type ResultDailyVisitTrend struct {
+	List []*DailyVisitTrend `json:"list"`
+}
+
+// GetDailyVisitTrend 数据分析 - 获取用户访问小程序数据日趋势
+// [参考](https://developers.weixin.qq.com/miniprogram/dev/OpenApiDoc/data-analysis/visit-trend/getDailyVisitTrend.html)
+func GetDailyVisitTrend(date string, result *ResultDailyVisitTrend) wx.Action {
+	params := &ParamsAnalysisDate{
+		BeginDate: date,
+		EndDate:   date,
+	}
+
+	return wx.NewPostAction(urls.MinipAnalysisDailyVisitTrend,
+		wx.WithBody(func() ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// DailySummaryRange 按日期范围（包含首尾）分批获取数据概况并按日期合并；
+// fanout 设置并发数及请求间隔（如：wx.WithFanOutConcurrency、wx.WithFanOutInterval，默认串行且不限制间隔）
+func (mp *Minip) DailySummaryRange(ctx context.Context, accessToken string, begin, end time.Time, fanout []wx.FanOutOption, options ...wx.HTTPOption) ([]*DailySummary, error) {
+	dates, err := analysisDates(begin, end)
+
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([][]*DailySummary, len(dates))
+
+	err = wx.FanOut(ctx, len(dates), 1, func(ctx context.Context, i, _ int) error {
+		result := new(ResultDailySummary)
+
+		if err := mp.Do(ctx, accessToken, GetDailySummary(dates[i], result), options...); err != nil {
+			return err
+		}
+
+		results[i] = result.List
+
+		return nil
+	}, fanout...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*DailySummary, 0, len(dates))
+
+	for _, v := range results {
+		list = append(list, v...)
+	}
+
+	return list, nil
+}
+
+// DailyVisitTrendRange 按日期范围（包含首尾）分批获取访问日趋势并按日期合并；
+// fanout 设置并发数及请求间隔（如：wx.WithFanOutConcurrency、wx.WithFanOutInterval，默认串行且不限制间隔）
+func (mp *Minip) DailyVisitTrendRange(ctx context.Context, accessToken string, begin, end time.Time, fanout []wx.FanOutOption, options ...wx.HTTPOption) ([]*DailyVisitTrend, error) {
+	dates, err := analysisDates(begin, end)
+
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([][]*DailyVisitTrend, len(dates))
+
+	err = wx.FanOut(ctx, len(dates), 1, func(ctx context.Context, i, _ int) error {
+		result := new(ResultDailyVisitTrend)
+
+		if err := mp.Do(ctx, accessToken, GetDailyVisitTrend(dates[i], result), options...); err != nil {
+			return err
+		}
+
+		results[i] = result.List
+
+		return nil
+	}, fanout...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*DailyVisitTrend, 0, len(dates))
+
+	for _, v := range results {
+		list = append(list, v...)
+	}
+
+	return list, nil
+}
+
+// analysisDates 返回日期范围（包含首尾）内的每一天
+func analysisDates(begin, end time.Time) ([]string, error) {
+	if end.Before(begin) {
+		return nil, errors.New("end date before begin date")
+	}
+
+	dates := make([]string, 0)
+
+	for day := begin; !day.After(end); day = day.AddDate(0, 0, 1) {
+		dates = append(dates, day.Format(AnalysisDateLayout))
+	}
+
+	return dates, nil
+}
@@ -0,0 +1,145 @@
+package minip
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/mock"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+func TestGetDailySummary(t *testing.T) {
+	body := []byte(`{"begin_date":"20170313","end_date":"20170313"}`)
+	resp := []byte(`{
+	"list": [
+		{
+			"ref_date": "20170313",
+			"visit_total": 391,
+			"share_pv": 572,
+			"share_uv": 383
+		}
+	]
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/datacube/getweanalysisappiddailysummarytrend?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	mp := New("APPID", "APPSECRET", WithMockClient(client))
+
+	result := new(ResultDailySummary)
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", GetDailySummary("20170313", result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultDailySummary{
+		List: []*DailySummary{
+			{
+				RefDate:    "20170313",
+				VisitTotal: 391,
+				SharePV:    572,
+				ShareUV:    383,
+			},
+		},
+	}, result)
+}
+
+func TestGetDailyVisitTrend(t *testing.T) {
+	body := []byte(`{"begin_date":"20170313","end_date":"20170313"}`)
+	resp := []byte(`{
+	"list": [
+		{
+			"ref_date": "20170313",
+			"session_cnt": 142549,
+			"visit_pv": 472351,
+			"visit_uv": 55500,
+			"visit_uv_new": 5464,
+			"stay_time_session": 0,
+			"visit_depth": 1.9838
+		}
+	]
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/datacube/getweanalysisappiddailyvisittrend?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	mp := New("APPID", "APPSECRET", WithMockClient(client))
+
+	result := new(ResultDailyVisitTrend)
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", GetDailyVisitTrend("20170313", result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultDailyVisitTrend{
+		List: []*DailyVisitTrend{
+			{
+				RefDate:         "20170313",
+				SessionCnt:      142549,
+				VisitPV:         472351,
+				VisitUV:         55500,
+				VisitUVNew:      5464,
+				StayTimeSession: 0,
+				VisitDepth:      1.9838,
+			},
+		},
+	}, result)
+}
+
+func TestDailySummaryRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	for _, date := range []string{"20170313", "20170314", "20170315"} {
+		client.EXPECT().Do(gomock.Any(), http.MethodPost, "https://api.weixin.qq.com/datacube/getweanalysisappiddailysummarytrend?access_token=ACCESS_TOKEN", []byte(`{"begin_date":"`+date+`","end_date":"`+date+`"}`)).Return([]byte(`{"list":[{"ref_date":"`+date+`","visit_total":1}]}`), nil)
+	}
+
+	mp := New("APPID", "APPSECRET", WithMockClient(client))
+
+	begin := time.Date(2017, 3, 13, 0, 0, 0, 0, time.Local)
+
+	list, err := mp.DailySummaryRange(context.TODO(), "ACCESS_TOKEN", begin, begin.AddDate(0, 0, 2), []wx.FanOutOption{wx.WithFanOutConcurrency(2), wx.WithFanOutInterval(time.Millisecond)})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []*DailySummary{
+		{RefDate: "20170313", VisitTotal: 1},
+		{RefDate: "20170314", VisitTotal: 1},
+		{RefDate: "20170315", VisitTotal: 1},
+	}, list)
+
+	// 日期范围错误
+	_, err = mp.DailySummaryRange(context.TODO(), "ACCESS_TOKEN", begin, begin.AddDate(0, 0, -1), nil)
+
+	assert.NotNil(t, err)
+}
+
+func TestDailyVisitTrendRangeError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.Any(), http.MethodPost, "https://api.weixin.qq.com/datacube/getweanalysisappiddailyvisittrend?access_token=ACCESS_TOKEN", gomock.Any()).Return(nil, errors.New("45009|reach max api daily quota limit")).MinTimes(1)
+
+	mp := New("APPID", "APPSECRET", WithMockClient(client))
+
+	begin := time.Date(2017, 3, 13, 0, 0, 0, 0, time.Local)
+
+	_, err := mp.DailyVisitTrendRange(context.TODO(), "ACCESS_TOKEN", begin, begin.AddDate(0, 0, 29), nil)
+
+	assert.Equal(t, "45009|reach max api daily quota limit", err.Error())
+}
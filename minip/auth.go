@@ -122,3 +122,32 @@ func GetPaidUnionIDByOutTradeNO(openid, mchid, outTradeNO string, result *Result
 		}),
 	)
 }
+
+// CheckSessionKey 检验登录态（session_key 已失效时返回错误：87009|invalid signature）
+// [参考](https://developers.weixin.qq.com/miniprogram/dev/OpenApiDoc/user-login/checkSessionKey.html)
+func CheckSessionKey(openid, sessionKey string) wx.Action {
+	return wx.NewGetAction(urls.MinipCheckSession,
+		wx.WithQuery("openid", openid),
+		wx.WithQuery("signature", wx.HMacSHA256("", sessionKey)),
+		wx.WithQuery("sig_method", "hmac_sha256"),
+	)
+}
+
+// ResultSessionKeyReset 重置后的 session_key
+type ResultSessionKeyReset struct {
+	OpenID     string `json:"openid"`
+	SessionKey string `json:"session_key"`
+}
+
+// ResetUserSessionKey 重置用户的 session_key
+// [参考](https://developers.weixin.qq.com/miniprogram/dev/OpenApiDoc/user-login/ResetUserSessionKey.html)
+func ResetUserSessionKey(openid, sessionKey string, result *ResultSessionKeyReset) wx.Action {
+	return wx.NewGetAction(urls.MinipResetSessionKey,
+		wx.WithQuery("openid", openid),
+		wx.WithQuery("signature", wx.HMacSHA256("", sessionKey)),
+		wx.WithQuery("sig_method", "hmac_sha256"),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
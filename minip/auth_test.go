@@ -134,3 +134,43 @@ func TestGetPaidUnionIDByOutTradeNO(t *testing.T) {
 		UnionID: "oTmHYjg-tElZ68xxxxxxxxhy1Rgk",
 	}, result)
 }
+
+func TestCheckSessionKey(t *testing.T) {
+	resp := []byte(`{"errcode":0,"errmsg":"ok"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.weixin.qq.com/wxa/checksession?access_token=ACCESS_TOKEN&openid=OPENID&sig_method=hmac_sha256&signature=8de57d383996f9f82f51c6304717e359a463f5a5543c946dcaf5163f7e5aec5a", nil).Return(resp, nil)
+
+	mp := New("APPID", "APPSECRET", WithMockClient(client))
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", CheckSessionKey("OPENID", "SESSION_KEY"))
+
+	assert.Nil(t, err)
+}
+
+func TestResetUserSessionKey(t *testing.T) {
+	resp := []byte(`{"errcode":0,"errmsg":"ok","openid":"OPENID","session_key":"NEW_SESSION_KEY"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.weixin.qq.com/wxa/resetusersessionkey?access_token=ACCESS_TOKEN&openid=OPENID&sig_method=hmac_sha256&signature=8de57d383996f9f82f51c6304717e359a463f5a5543c946dcaf5163f7e5aec5a", nil).Return(resp, nil)
+
+	mp := New("APPID", "APPSECRET", WithMockClient(client))
+
+	result := new(ResultSessionKeyReset)
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", ResetUserSessionKey("OPENID", "SESSION_KEY", result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultSessionKeyReset{
+		OpenID:     "OPENID",
+		SessionKey: "NEW_SESSION_KEY",
+	}, result)
+}
@@ -0,0 +1,84 @@
+package minip
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/event"
+)
+
+func TestParseMessage(t *testing.T) {
+	msg, err := ParseMessage([]byte(`<xml><ToUserName><![CDATA[toUser]]></ToUserName><FromUserName><![CDATA[fromUser]]></FromUserName><CreateTime>1482048670</CreateTime><MsgType><![CDATA[miniprogrampage]]></MsgType><MsgId>1234567890123456</MsgId><Title><![CDATA[Title]]></Title><AppId><![CDATA[AppId]]></AppId><PagePath><![CDATA[PagePath]]></PagePath><ThumbUrl><![CDATA[ThumbUrl]]></ThumbUrl><ThumbMediaId><![CDATA[ThumbMediaId]]></ThumbMediaId></xml>`))
+
+	assert.Nil(t, err)
+
+	page, ok := msg.(*MinipPageMessage)
+
+	assert.True(t, ok)
+	assert.Equal(t, "AppId", page.AppID)
+	assert.Equal(t, "PagePath", page.PagePath)
+
+	msg, err = ParseMessage([]byte(`<xml><ToUserName><![CDATA[toUser]]></ToUserName><FromUserName><![CDATA[fromUser]]></FromUserName><CreateTime>1482048670</CreateTime><MsgType><![CDATA[event]]></MsgType><Event><![CDATA[user_enter_tempsession]]></Event><SessionFrom><![CDATA[sessionFrom]]></SessionFrom></xml>`))
+
+	assert.Nil(t, err)
+
+	session, ok := msg.(*UserEnterTempSessionEvent)
+
+	assert.True(t, ok)
+	assert.Equal(t, "sessionFrom", session.SessionFrom)
+
+	msg, err = ParseMessage([]byte(`<xml><ToUserName><![CDATA[gh_38cc49f9733b]]></ToUserName><FromUserName><![CDATA[oH1fu0FdHqpToe2T6gBj0WyB8iS1]]></FromUserName><CreateTime>1626959646</CreateTime><MsgType><![CDATA[event]]></MsgType><Event><![CDATA[wxa_media_check]]></Event><appid><![CDATA[wx8f16a5e6d0b4e8fb]]></appid><trace_id><![CDATA[60f96f1d-3845297a-1976a3ae]]></trace_id><version>2</version><result><suggest><![CDATA[pass]]></suggest><label>100</label></result></xml>`))
+
+	assert.Nil(t, err)
+
+	check, ok := msg.(*MediaCheckEvent)
+
+	assert.True(t, ok)
+	assert.Equal(t, "60f96f1d-3845297a-1976a3ae", check.TraceID)
+	assert.Equal(t, "pass", check.Suggest)
+	assert.Equal(t, 100, check.Label)
+
+	msg, err = ParseMessage([]byte(`<xml><ToUserName><![CDATA[gh_38cc49f9733b]]></ToUserName><FromUserName><![CDATA[oH1fu0FdHqpToe2T6gBj0WyB8iS1]]></FromUserName><CreateTime>1662480000</CreateTime><MsgType><![CDATA[event]]></MsgType><Event><![CDATA[trade_manage_remind_shipping]]></Event><transaction_id><![CDATA[4200001234567890]]></transaction_id><merchant_id><![CDATA[1230000109]]></merchant_id><sub_merchant_id><![CDATA[]]></sub_merchant_id><merchant_trade_no><![CDATA[1234323JKHDFE1243252]]></merchant_trade_no><pay_time>1662460000</pay_time><msg><![CDATA[请尽快发货]]></msg></xml>`))
+
+	assert.Nil(t, err)
+
+	shipping, ok := msg.(*TradeManageRemindShippingEvent)
+
+	assert.True(t, ok)
+	assert.Equal(t, "4200001234567890", shipping.TransactionID)
+	assert.Equal(t, "1234323JKHDFE1243252", shipping.MerchantTradeNO)
+	assert.Equal(t, int64(1662460000), shipping.PayTime)
+	assert.Equal(t, "请尽快发货", shipping.Msg)
+
+	msg, err = ParseMessage([]byte(`<xml><ToUserName><![CDATA[gh_38cc49f9733b]]></ToUserName><FromUserName><![CDATA[oH1fu0FdHqpToe2T6gBj0WyB8iS1]]></FromUserName><CreateTime>1662480000</CreateTime><MsgType><![CDATA[event]]></MsgType><Event><![CDATA[trade_manage_order_settlement]]></Event><transaction_id><![CDATA[4200001234567890]]></transaction_id><merchant_id><![CDATA[1230000109]]></merchant_id><merchant_trade_no><![CDATA[1234323JKHDFE1243252]]></merchant_trade_no><pay_time>1662460000</pay_time><shipped_time>1662470000</shipped_time><estimated_settlement_time>1663074800</estimated_settlement_time></xml>`))
+
+	assert.Nil(t, err)
+
+	settlement, ok := msg.(*TradeManageOrderSettlementEvent)
+
+	assert.True(t, ok)
+	assert.Equal(t, int64(1662470000), settlement.ShippedTime)
+	assert.Equal(t, int64(1663074800), settlement.EstimatedSettlementTime)
+
+	msg, err = ParseMessage([]byte(`<xml><ToUserName><![CDATA[gh_38cc49f9733b]]></ToUserName><FromUserName><![CDATA[oH1fu0FdHqpToe2T6gBj0WyB8iS1]]></FromUserName><CreateTime>1662480000</CreateTime><MsgType><![CDATA[event]]></MsgType><Event><![CDATA[trade_manage_remind_access_api]]></Event><msg><![CDATA[请尽快接入发货信息管理服务API]]></msg></xml>`))
+
+	assert.Nil(t, err)
+
+	access, ok := msg.(*TradeManageRemindAccessAPIEvent)
+
+	assert.True(t, ok)
+	assert.Equal(t, "请尽快接入发货信息管理服务API", access.Msg)
+
+	msg, err = ParseMessage([]byte(`<xml><ToUserName><![CDATA[gh_4346ac1514d8]]></ToUserName><FromUserName><![CDATA[od1P50M-fNQI5Gcq-trm4a7apsU8]]></FromUserName><CreateTime>1488856741</CreateTime><MsgType><![CDATA[event]]></MsgType><Event><![CDATA[nearby_poi_audit]]></Event><audit_id>11111</audit_id><status>3</status><reason></reason><poi_id>111111</poi_id></xml>`))
+
+	assert.Nil(t, err)
+
+	audit, ok := msg.(*NearbyPOIAuditEvent)
+
+	assert.True(t, ok)
+	assert.Equal(t, event.EventNearbyPOIAudit, audit.EventType())
+	assert.Equal(t, "11111", audit.AuditID)
+	assert.Equal(t, NearbyPOIAuditSuccess, audit.Status)
+	assert.Equal(t, "111111", audit.POIID)
+}
@@ -0,0 +1,125 @@
+package express
+
+import (
+	"encoding/json"
+
+	"github.com/shenghui0779/gochat/urls"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// ServiceType 快递公司服务类型
+type ServiceType struct {
+	ServiceType int    `json:"service_type"`
+	ServiceName string `json:"service_name"`
+}
+
+// Delivery 快递公司
+type Delivery struct {
+	DeliveryID   string         `json:"delivery_id"`
+	DeliveryName string         `json:"delivery_name"`
+	CanUseCash   int            `json:"can_use_cash"`  // 是否支持散单，1表示支持
+	CanGetQuota  int            `json:"can_get_quota"` // 是否支持查询面单余额，1表示支持
+	CashBizID    string         `json:"cash_biz_id"`   // 散单对应的bizid，当can_use_cash=1时有效
+	ServiceType  []*ServiceType `json:"service_type"`  // 支持的服务类型
+}
+
+// ResultAllDelivery 快递公司列表
+type ResultAllDelivery struct {
+	Count int         `json:"count"`
+	Data  []*Delivery `json:"data"`
+}
+
+// GetAllDelivery 物流助手 - 获取支持的快递公司列表
+// [参考](https://developers.weixin.qq.com/miniprogram/dev/OpenApiDoc/express/express-by-business/getAllDelivery.html)
+func GetAllDelivery(result *ResultAllDelivery) wx.Action {
+	return wx.NewGetAction(urls.MinipExpressBusinessGetAllDelivery,
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// ParamsQuotaGet 获取电子面单余额参数
+type ParamsQuotaGet struct {
+	DeliveryID string `json:"delivery_id"`
+	BizID      string `json:"biz_id"`
+}
+
+// ResultQuotaGet 电子面单余额
+type ResultQuotaGet struct {
+	QuotaNum int `json:"quota_num"`
+}
+
+// GetQuota 物流助手 - 获取电子面单余额（仅在使用加盟类快递公司时调用）
+// [参考](https://developers.weixin.qq.com/miniprogram/dev/OpenApiDoc/express/express-by-business/getQuota.html)
+func GetQuota(deliveryID, bizID string, result *ResultQuotaGet) wx.Action {
+	params := &ParamsQuotaGet{
+		DeliveryID: deliveryID,
+		BizID:      bizID,
+	}
+
+	return wx.NewPostAction(urls.MinipExpressBusinessGetQuota,
+		wx.WithBody(func() ([]byte, error) {
+			return json.Marshal(params)
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// 绑定动作类型
+const (
+	AccountBind   = "bind"   // 绑定
+	AccountUnbind = "unbind" // 解除绑定
+)
+
+// ParamsAccountBind 绑定、解绑物流账号参数
+type ParamsAccountBind struct {
+	Type          string `json:"type"`                     // bind表示绑定，unbind表示解除绑定
+	BizID         string `json:"biz_id"`                   // 快递公司客户编码
+	DeliveryID    string `json:"delivery_id"`              // 快递公司ID
+	Password      string `json:"password,omitempty"`       // 快递公司客户密码
+	RemarkContent string `json:"remark_content,omitempty"` // 备注内容（提交EMS审核需要）
+}
+
+// BindAccount 物流助手 - 绑定、解绑物流账号
+// [参考](https://developers.weixin.qq.com/miniprogram/dev/OpenApiDoc/express/express-by-business/bindAccount.html)
+func BindAccount(params *ParamsAccountBind) wx.Action {
+	return wx.NewPostAction(urls.MinipExpressBusinessBindAccount,
+		wx.WithBody(func() ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+	)
+}
+
+// Account 物流账号
+type Account struct {
+	BizID           string         `json:"biz_id"`
+	DeliveryID      string         `json:"delivery_id"`
+	CreateTime      int64          `json:"create_time"`
+	UpdateTime      int64          `json:"update_time"`
+	StatusCode      int            `json:"status_code"` // 绑定状态，0-绑定成功
+	Alias           string         `json:"alias"`
+	RemarkWrongMsg  string         `json:"remark_wrong_msg"`
+	RemarkContent   string         `json:"remark_content"`
+	QuotaNum        int            `json:"quota_num"`
+	QuotaUpdateTime int64          `json:"quota_update_time"`
+	ServiceType     []*ServiceType `json:"service_type"`
+}
+
+// ResultAllAccount 已绑定的物流账号列表
+type ResultAllAccount struct {
+	Count int        `json:"count"`
+	List  []*Account `json:"list"`
+}
+
+// GetAllAccount 物流助手 - 获取所有绑定的物流账号
+// [参考](https://developers.weixin.qq.com/miniprogram/dev/OpenApiDoc/express/express-by-business/getAllAccount.html)
+func GetAllAccount(result *ResultAllAccount) wx.Action {
+	return wx.NewGetAction(urls.MinipExpressBusinessGetAllAccount,
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
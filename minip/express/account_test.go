@@ -0,0 +1,173 @@
+package express
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/minip"
+	"github.com/shenghui0779/gochat/mock"
+)
+
+func TestGetAllDelivery(t *testing.T) {
+	resp := []byte(`{
+	"count": 1,
+	"data": [
+		{
+			"delivery_id": "SF",
+			"delivery_name": "顺丰速运",
+			"can_use_cash": 1,
+			"can_get_quota": 0,
+			"cash_biz_id": "SF_CASH",
+			"service_type": [
+				{
+					"service_type": 0,
+					"service_name": "标准快递"
+				}
+			]
+		}
+	]
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.weixin.qq.com/cgi-bin/express/business/delivery/getall?access_token=ACCESS_TOKEN", nil).Return(resp, nil)
+
+	mp := minip.New("APPID", "APPSECRET", minip.WithMockClient(client))
+
+	result := new(ResultAllDelivery)
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", GetAllDelivery(result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultAllDelivery{
+		Count: 1,
+		Data: []*Delivery{
+			{
+				DeliveryID:   "SF",
+				DeliveryName: "顺丰速运",
+				CanUseCash:   1,
+				CashBizID:    "SF_CASH",
+				ServiceType: []*ServiceType{
+					{
+						ServiceType: 0,
+						ServiceName: "标准快递",
+					},
+				},
+			},
+		},
+	}, result)
+}
+
+func TestGetQuota(t *testing.T) {
+	body := []byte(`{"delivery_id":"YDA","biz_id":"xyz"}`)
+	resp := []byte(`{"errcode":0,"errmsg":"ok","quota_num":210}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/express/business/quota/get?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	mp := minip.New("APPID", "APPSECRET", minip.WithMockClient(client))
+
+	result := new(ResultQuotaGet)
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", GetQuota("YDA", "xyz", result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultQuotaGet{
+		QuotaNum: 210,
+	}, result)
+}
+
+func TestBindAccount(t *testing.T) {
+	body := []byte(`{"type":"bind","biz_id":"123456","delivery_id":"YUNDA","password":"xyz123","remark_content":"备注"}`)
+	resp := []byte(`{"errcode":0,"errmsg":"ok"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/express/business/account/bind?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	mp := minip.New("APPID", "APPSECRET", minip.WithMockClient(client))
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", BindAccount(&ParamsAccountBind{
+		Type:          AccountBind,
+		BizID:         "123456",
+		DeliveryID:    "YUNDA",
+		Password:      "xyz123",
+		RemarkContent: "备注",
+	}))
+
+	assert.Nil(t, err)
+}
+
+func TestGetAllAccount(t *testing.T) {
+	resp := []byte(`{
+	"count": 1,
+	"list": [
+		{
+			"biz_id": "123456",
+			"delivery_id": "YUNDA",
+			"create_time": 1555482786,
+			"update_time": 1556594222,
+			"status_code": 0,
+			"alias": "",
+			"remark_wrong_msg": "",
+			"remark_content": "",
+			"quota_num": 3,
+			"quota_update_time": 1556594222,
+			"service_type": [
+				{
+					"service_type": 0,
+					"service_name": "标准快件"
+				}
+			]
+		}
+	]
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.weixin.qq.com/cgi-bin/express/business/account/getall?access_token=ACCESS_TOKEN", nil).Return(resp, nil)
+
+	mp := minip.New("APPID", "APPSECRET", minip.WithMockClient(client))
+
+	result := new(ResultAllAccount)
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", GetAllAccount(result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultAllAccount{
+		Count: 1,
+		List: []*Account{
+			{
+				BizID:           "123456",
+				DeliveryID:      "YUNDA",
+				CreateTime:      1555482786,
+				UpdateTime:      1556594222,
+				QuotaNum:        3,
+				QuotaUpdateTime: 1556594222,
+				ServiceType: []*ServiceType{
+					{
+						ServiceType: 0,
+						ServiceName: "标准快件",
+					},
+				},
+			},
+		},
+	}, result)
+}
@@ -0,0 +1,194 @@
+// Package express 小程序物流助手，包含商家（寄件方）接口与运力方（快递公司、服务商）接口，按需引入，不增加 minip 核心包的体积
+package express
+
+import (
+	"encoding/json"
+
+	"github.com/shenghui0779/gochat/urls"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// Person 面单联系人
+type Person struct {
+	Name     string `json:"name"`
+	Tel      string `json:"tel,omitempty"`
+	Mobile   string `json:"mobile,omitempty"`
+	Company  string `json:"company,omitempty"`
+	PostCode string `json:"post_code,omitempty"`
+	Country  string `json:"country,omitempty"`
+	Province string `json:"province,omitempty"`
+	City     string `json:"city,omitempty"`
+	Area     string `json:"area,omitempty"`
+	Address  string `json:"address,omitempty"`
+}
+
+// CargoDetail 包裹商品详情
+type CargoDetail struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// Cargo 包裹信息
+type Cargo struct {
+	Count      int            `json:"count"`
+	Weight     float64        `json:"weight"`
+	SpaceX     float64        `json:"space_x"`
+	SpaceY     float64        `json:"space_y"`
+	SpaceZ     float64        `json:"space_z"`
+	DetailList []*CargoDetail `json:"detail_list"`
+}
+
+// Shop 商品信息（会展示到物流服务通知和电子面单中）
+type Shop struct {
+	WXAPath    string `json:"wxa_path"`
+	ImgURL     string `json:"img_url"`
+	GoodsName  string `json:"goods_name"`
+	GoodsCount int    `json:"goods_count"`
+}
+
+// Insured 保价信息
+type Insured struct {
+	UseInsured   int `json:"use_insured"`   // 是否保价，0 表示不保价，1 表示保价
+	InsuredValue int `json:"insured_value"` // 保价金额（分）
+}
+
+// 订单来源
+const (
+	OrderFromWxa   = 0 // 小程序订单
+	OrderFromOther = 2 // App或H5订单
+)
+
+// ParamsOrderAdd 生成运单参数
+type ParamsOrderAdd struct {
+	AddSource    int          `json:"add_source"`              // 订单来源，0为小程序订单，2为App或H5订单
+	WxAppID      string       `json:"wx_appid,omitempty"`      // App或H5的appid，add_source=2时必填
+	OrderID      string       `json:"order_id"`                // 订单ID，须保证全局唯一
+	OpenID       string       `json:"openid,omitempty"`        // 用户openid，add_source=2时不填
+	DeliveryID   string       `json:"delivery_id"`             // 快递公司ID
+	BizID        string       `json:"biz_id"`                  // 快递客户编码或者现付编码
+	CustomRemark string       `json:"custom_remark,omitempty"` // 快递备注信息
+	TagID        int          `json:"tagid,omitempty"`         // 订单标签id，用于平台型小程序区分平台上的入驻方
+	Sender       *Person      `json:"sender"`                  // 发件人信息
+	Receiver     *Person      `json:"receiver"`                // 收件人信息
+	Cargo        *Cargo       `json:"cargo"`                   // 包裹信息
+	Shop         *Shop        `json:"shop,omitempty"`          // 商品信息，会展示到物流服务通知和电子面单中
+	Insured      *Insured     `json:"insured"`                 // 保价信息
+	Service      *ServiceType `json:"service"`                 // 服务类型
+	ExpectTime   int64        `json:"expect_time,omitempty"`   // 预期的上门揽件时间（顺丰必须填写）
+}
+
+// WaybillData 运单信息
+type WaybillData struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// ResultOrderAdd 生成运单结果
+type ResultOrderAdd struct {
+	OrderID            string         `json:"order_id"`
+	WaybillID          string         `json:"waybill_id"`
+	WaybillData        []*WaybillData `json:"waybill_data"`
+	DeliveryResultCode int            `json:"delivery_resultcode"` // 快递侧错误码，下单失败时返回
+	DeliveryResultMsg  string         `json:"delivery_resultmsg"`  // 快递侧错误信息，下单失败时返回
+}
+
+// AddOrder 物流助手 - 生成运单
+// [参考](https://developers.weixin.qq.com/miniprogram/dev/OpenApiDoc/express/express-by-business/addOrder.html)
+func AddOrder(params *ParamsOrderAdd, result *ResultOrderAdd) wx.Action {
+	return wx.NewPostAction(urls.MinipExpressBusinessAddOrder,
+		wx.WithBody(func() ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// ParamsOrderGet 查询运单参数
+type ParamsOrderGet struct {
+	OrderID      string `json:"order_id"`
+	OpenID       string `json:"openid,omitempty"`
+	DeliveryID   string `json:"delivery_id"`
+	WaybillID    string `json:"waybill_id"`
+	PrintType    int    `json:"print_type,omitempty"` // 0-获取运单面单（默认），1-仅获取运单信息
+	CustomRemark string `json:"custom_remark,omitempty"`
+}
+
+// ResultOrderGet 查询运单结果
+type ResultOrderGet struct {
+	PrintHTML   string         `json:"print_html"` // 运单 html 的 BASE64 结果
+	WaybillData []*WaybillData `json:"waybill_data"`
+	DeliveryID  string         `json:"delivery_id"`
+	WaybillID   string         `json:"waybill_id"`
+	OrderID     string         `json:"order_id"`
+	OrderStatus int            `json:"order_status"` // 运单状态，0正常，1取消
+}
+
+// GetOrder 物流助手 - 获取运单数据
+// [参考](https://developers.weixin.qq.com/miniprogram/dev/OpenApiDoc/express/express-by-business/getOrder.html)
+func GetOrder(params *ParamsOrderGet, result *ResultOrderGet) wx.Action {
+	return wx.NewPostAction(urls.MinipExpressBusinessGetOrder,
+		wx.WithBody(func() ([]byte, error) {
+			return json.Marshal(params)
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// ParamsOrderCancel 取消运单参数
+type ParamsOrderCancel struct {
+	OrderID    string `json:"order_id"`
+	OpenID     string `json:"openid,omitempty"`
+	DeliveryID string `json:"delivery_id"`
+	WaybillID  string `json:"waybill_id"`
+}
+
+// CancelOrder 物流助手 - 取消运单
+// [参考](https://developers.weixin.qq.com/miniprogram/dev/OpenApiDoc/express/express-by-business/cancelOrder.html)
+func CancelOrder(params *ParamsOrderCancel) wx.Action {
+	return wx.NewPostAction(urls.MinipExpressBusinessCancelOrder,
+		wx.WithBody(func() ([]byte, error) {
+			return json.Marshal(params)
+		}),
+	)
+}
+
+// ParamsPathGet 查询运单轨迹参数
+type ParamsPathGet struct {
+	OrderID    string `json:"order_id"`
+	OpenID     string `json:"openid,omitempty"`
+	DeliveryID string `json:"delivery_id"`
+	WaybillID  string `json:"waybill_id"`
+}
+
+// PathItem 轨迹节点
+type PathItem struct {
+	ActionTime int64  `json:"action_time"` // 轨迹节点 Unix 时间戳
+	ActionType int    `json:"action_type"` // 轨迹节点类型
+	ActionMsg  string `json:"action_msg"`  // 轨迹节点详情
+}
+
+// ResultPathGet 运单轨迹
+type ResultPathGet struct {
+	OpenID       string      `json:"openid"`
+	DeliveryID   string      `json:"delivery_id"`
+	WaybillID    string      `json:"waybill_id"`
+	PathItemNum  int         `json:"path_item_num"`
+	PathItemList []*PathItem `json:"path_item_list"`
+}
+
+// GetPath 物流助手 - 查询运单轨迹
+// [参考](https://developers.weixin.qq.com/miniprogram/dev/OpenApiDoc/express/express-by-business/getPath.html)
+func GetPath(params *ParamsPathGet, result *ResultPathGet) wx.Action {
+	return wx.NewPostAction(urls.MinipExpressBusinessGetPath,
+		wx.WithBody(func() ([]byte, error) {
+			return json.Marshal(params)
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
@@ -0,0 +1,230 @@
+package express
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/minip"
+	"github.com/shenghui0779/gochat/mock"
+)
+
+func TestAddOrder(t *testing.T) {
+	body := []byte(`{"add_source":0,"order_id":"01234567890123456789","openid":"oABC123456","delivery_id":"SF","biz_id":"xyz","custom_remark":"易碎物品","sender":{"name":"张三","mobile":"1234567890","province":"广东省","city":"广州市","area":"海珠区","address":"XX路XX号XX大厦XX栋XX"},"receiver":{"name":"王小蒙","mobile":"020-38646543","province":"广东省","city":"广州市","area":"天河区","address":"XX路XX号XX大厦XX栋XX"},"cargo":{"count":2,"weight":5.5,"space_x":30.5,"space_y":20,"space_z":20,"detail_list":[{"name":"微信气泡狗-不倒翁","count":1}]},"shop":{"wxa_path":"/index/index?from=waybill&id=01234567890123456789","img_url":"https://mmbiz.qpic.cn/mmbiz_png/OiaFLUqewuIDNQnTiaCInIG8ibdosYHhQHPbXJUrqYSNIcBL60vo4LIjlcoNG1QPkeH5GWWEB41Ny895CokeAah8A/640","goods_name":"微信气泡狗-不倒翁","goods_count":1},"insured":{"use_insured":1,"insured_value":10000},"service":{"service_type":0,"service_name":"标准快递"}}`)
+	resp := []byte(`{
+	"errcode": 0,
+	"errmsg": "ok",
+	"order_id": "01234567890123456789",
+	"waybill_id": "123456789",
+	"waybill_data": [
+		{
+			"key": "SF_bagAddr",
+			"value": "广州"
+		}
+	]
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/express/business/order/add?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	mp := minip.New("APPID", "APPSECRET", minip.WithMockClient(client))
+
+	result := new(ResultOrderAdd)
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", AddOrder(&ParamsOrderAdd{
+		AddSource:    OrderFromWxa,
+		OrderID:      "01234567890123456789",
+		OpenID:       "oABC123456",
+		DeliveryID:   "SF",
+		BizID:        "xyz",
+		CustomRemark: "易碎物品",
+		Sender: &Person{
+			Name:     "张三",
+			Mobile:   "1234567890",
+			Province: "广东省",
+			City:     "广州市",
+			Area:     "海珠区",
+			Address:  "XX路XX号XX大厦XX栋XX",
+		},
+		Receiver: &Person{
+			Name:     "王小蒙",
+			Mobile:   "020-38646543",
+			Province: "广东省",
+			City:     "广州市",
+			Area:     "天河区",
+			Address:  "XX路XX号XX大厦XX栋XX",
+		},
+		Cargo: &Cargo{
+			Count:  2,
+			Weight: 5.5,
+			SpaceX: 30.5,
+			SpaceY: 20,
+			SpaceZ: 20,
+			DetailList: []*CargoDetail{
+				{
+					Name:  "微信气泡狗-不倒翁",
+					Count: 1,
+				},
+			},
+		},
+		Shop: &Shop{
+			WXAPath:    "/index/index?from=waybill&id=01234567890123456789",
+			ImgURL:     "https://mmbiz.qpic.cn/mmbiz_png/OiaFLUqewuIDNQnTiaCInIG8ibdosYHhQHPbXJUrqYSNIcBL60vo4LIjlcoNG1QPkeH5GWWEB41Ny895CokeAah8A/640",
+			GoodsName:  "微信气泡狗-不倒翁",
+			GoodsCount: 1,
+		},
+		Insured: &Insured{
+			UseInsured:   1,
+			InsuredValue: 10000,
+		},
+		Service: &ServiceType{
+			ServiceType: 0,
+			ServiceName: "标准快递",
+		},
+	}, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultOrderAdd{
+		OrderID:   "01234567890123456789",
+		WaybillID: "123456789",
+		WaybillData: []*WaybillData{
+			{
+				Key:   "SF_bagAddr",
+				Value: "广州",
+			},
+		},
+	}, result)
+}
+
+func TestGetOrder(t *testing.T) {
+	body := []byte(`{"order_id":"01234567890123456789","openid":"oABC123456","delivery_id":"SF","waybill_id":"123456789"}`)
+	resp := []byte(`{
+	"errcode": 0,
+	"errmsg": "ok",
+	"print_html": "PGh0bWw+dGVzdDwvaHRtbD4=",
+	"waybill_data": [
+		{
+			"key": "SF_bagAddr",
+			"value": "广州"
+		}
+	],
+	"delivery_id": "SF",
+	"waybill_id": "123456789",
+	"order_id": "01234567890123456789",
+	"order_status": 0
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/express/business/order/get?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	mp := minip.New("APPID", "APPSECRET", minip.WithMockClient(client))
+
+	result := new(ResultOrderGet)
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", GetOrder(&ParamsOrderGet{
+		OrderID:    "01234567890123456789",
+		OpenID:     "oABC123456",
+		DeliveryID: "SF",
+		WaybillID:  "123456789",
+	}, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultOrderGet{
+		PrintHTML: "PGh0bWw+dGVzdDwvaHRtbD4=",
+		WaybillData: []*WaybillData{
+			{
+				Key:   "SF_bagAddr",
+				Value: "广州",
+			},
+		},
+		DeliveryID: "SF",
+		WaybillID:  "123456789",
+		OrderID:    "01234567890123456789",
+	}, result)
+}
+
+func TestCancelOrder(t *testing.T) {
+	body := []byte(`{"order_id":"01234567890123456789","openid":"oABC123456","delivery_id":"SF","waybill_id":"123456789"}`)
+	resp := []byte(`{"errcode":0,"errmsg":"ok"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/express/business/order/cancel?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	mp := minip.New("APPID", "APPSECRET", minip.WithMockClient(client))
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", CancelOrder(&ParamsOrderCancel{
+		OrderID:    "01234567890123456789",
+		OpenID:     "oABC123456",
+		DeliveryID: "SF",
+		WaybillID:  "123456789",
+	}))
+
+	assert.Nil(t, err)
+}
+
+func TestGetPath(t *testing.T) {
+	body := []byte(`{"order_id":"01234567890123456789","openid":"oABC123456","delivery_id":"SF","waybill_id":"123456789"}`)
+	resp := []byte(`{
+	"errcode": 0,
+	"errmsg": "ok",
+	"openid": "oABC123456",
+	"delivery_id": "SF",
+	"waybill_id": "123456789",
+	"path_item_num": 1,
+	"path_item_list": [
+		{
+			"action_time": 1533052800,
+			"action_type": 100001,
+			"action_msg": "快递员已成功取件"
+		}
+	]
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/express/business/path/get?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	mp := minip.New("APPID", "APPSECRET", minip.WithMockClient(client))
+
+	result := new(ResultPathGet)
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", GetPath(&ParamsPathGet{
+		OrderID:    "01234567890123456789",
+		OpenID:     "oABC123456",
+		DeliveryID: "SF",
+		WaybillID:  "123456789",
+	}, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultPathGet{
+		OpenID:      "oABC123456",
+		DeliveryID:  "SF",
+		WaybillID:   "123456789",
+		PathItemNum: 1,
+		PathItemList: []*PathItem{
+			{
+				ActionTime: 1533052800,
+				ActionType: 100001,
+				ActionMsg:  "快递员已成功取件",
+			},
+		},
+	}, result)
+}
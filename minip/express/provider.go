@@ -0,0 +1,104 @@
+package express
+
+import (
+	"encoding/json"
+
+	"github.com/shenghui0779/gochat/urls"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+type ParamsTemplatePreview struct {
+	WaybillID       string          `json:"waybill_id"`       // 运单 ID
+	WaybillTemplate string          `json:"waybill_template"` // 面单 HTML 模板内容（需经过 Base64 编码）
+	WaybillData     string          `json:"waybill_data"`     // 面单数据，详情参考下单事件返回值中的 WaybillData
+	Custom          *ParamsOrderAdd `json:"custom"`           // 商户下单数据，即商家侧生成运单（AddOrder）的请求参数
+}
+
+type ResultTemplatePreview struct {
+	WaybillID               string `json:"waybill_id"`
+	RenderedWaybillTemplate string `json:"rendered_waybill_template"` // 渲染后的面单 HTML 文件（已经过 Base64 编码）
+}
+
+// PreviewTemplate 物流助手 - 运力方 - 预览面单模板（用于调试面单模板使用）
+// [参考](https://developers.weixin.qq.com/miniprogram/dev/OpenApiDoc/express/express-by-provider/previewTemplate.html)
+func PreviewTemplate(params *ParamsTemplatePreview, result *ResultTemplatePreview) wx.Action {
+	return wx.NewPostAction(urls.MinipExpressPreviewTemplate,
+		wx.WithBody(func() ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+type ParamsBusinessUpdate struct {
+	ShopAppID  string `json:"shop_app_id"`          // 商户的小程序 AppID，即审核商户事件中的 ShopAppID
+	BizID      string `json:"biz_id"`               // 商户账户
+	ResultCode int    `json:"result_code"`          // 审核结果，0 表示审核通过，其他表示审核失败
+	ResultMsg  string `json:"result_msg,omitempty"` // 审核错误原因，仅 result_code 不等于 0 时需要设置
+}
+
+// UpdateBusiness 物流助手 - 运力方 - 更新商户审核结果
+// [参考](https://developers.weixin.qq.com/miniprogram/dev/OpenApiDoc/express/express-by-provider/updateBusiness.html)
+func UpdateBusiness(params *ParamsBusinessUpdate) wx.Action {
+	return wx.NewPostAction(urls.MinipExpressUpdateBusiness,
+		wx.WithBody(func() ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+	)
+}
+
+type ParamsPathUpdate struct {
+	Token      string `json:"token"`       // 商户侧下单事件中推送的 Token 字段
+	WaybillID  string `json:"waybill_id"`  // 运单 ID
+	ActionTime int64  `json:"action_time"` // 轨迹变化 Unix 时间戳
+	ActionType int    `json:"action_type"` // 轨迹变化类型
+	ActionMsg  string `json:"action_msg"`  // 轨迹变化具体信息说明，展示在快递轨迹详情页中
+}
+
+// UpdatePath 物流助手 - 运力方 - 更新运单轨迹
+// [参考](https://developers.weixin.qq.com/miniprogram/dev/OpenApiDoc/express/express-by-provider/updatePath.html)
+func UpdatePath(params *ParamsPathUpdate) wx.Action {
+	return wx.NewPostAction(urls.MinipExpressUpdatePath,
+		wx.WithBody(func() ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+	)
+}
+
+type ParamsContactGet struct {
+	Token     string `json:"token"`
+	WaybillID string `json:"waybill_id"`
+}
+
+// Contact 面单联系人信息
+type Contact struct {
+	Address string `json:"address"`
+	Name    string `json:"name"`
+	Tel     string `json:"tel"`
+}
+
+type ResultContactGet struct {
+	WaybillID string   `json:"waybill_id"`
+	Sender    *Contact `json:"sender"`
+	Receiver  *Contact `json:"receiver"`
+}
+
+// GetContact 物流助手 - 运力方 - 获取面单联系人信息
+// [参考](https://developers.weixin.qq.com/miniprogram/dev/OpenApiDoc/express/express-by-provider/getContact.html)
+func GetContact(token, waybillID string, result *ResultContactGet) wx.Action {
+	params := &ParamsContactGet{
+		Token:     token,
+		WaybillID: waybillID,
+	}
+
+	return wx.NewPostAction(urls.MinipExpressGetContact,
+		wx.WithBody(func() ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
@@ -0,0 +1,177 @@
+package express
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/minip"
+	"github.com/shenghui0779/gochat/mock"
+)
+
+func TestPreviewTemplate(t *testing.T) {
+	body := []byte(`{"waybill_id":"1707110000000001","waybill_template":"PGh0bWw+dGVzdDwvaHRtbD4=","waybill_data":"拉格朗日-1-1-A11","custom":{"add_source":0,"order_id":"012345678","openid":"OPENID","delivery_id":"TEST","biz_id":"xyz","sender":{"name":"张三","tel":"10086","address":"广州市海珠区"},"receiver":{"name":"王小蒙","tel":"020-88888888","address":"北京市海淀区"},"cargo":{"count":2,"weight":5.5,"space_x":30.5,"space_y":20,"space_z":20,"detail_list":[{"name":"一千个凤梨","count":1}]},"insured":{"use_insured":0,"insured_value":0},"service":{"service_type":0,"service_name":"标准快递"}}}`)
+
+	resp := []byte(`{
+	"errcode": 0,
+	"errmsg": "ok",
+	"waybill_id": "1707110000000001",
+	"rendered_waybill_template": "PGh0bWw+dGVzdDwvaHRtbD4="
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/express/delivery/template/preview?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	mp := minip.New("APPID", "APPSECRET", minip.WithMockClient(client))
+
+	params := &ParamsTemplatePreview{
+		WaybillID:       "1707110000000001",
+		WaybillTemplate: "PGh0bWw+dGVzdDwvaHRtbD4=",
+		WaybillData:     "拉格朗日-1-1-A11",
+		Custom: &ParamsOrderAdd{
+			OrderID:    "012345678",
+			OpenID:     "OPENID",
+			DeliveryID: "TEST",
+			BizID:      "xyz",
+			Sender: &Person{
+				Name:    "张三",
+				Tel:     "10086",
+				Address: "广州市海珠区",
+			},
+			Receiver: &Person{
+				Name:    "王小蒙",
+				Tel:     "020-88888888",
+				Address: "北京市海淀区",
+			},
+			Cargo: &Cargo{
+				Count:  2,
+				Weight: 5.5,
+				SpaceX: 30.5,
+				SpaceY: 20,
+				SpaceZ: 20,
+				DetailList: []*CargoDetail{
+					{Name: "一千个凤梨", Count: 1},
+				},
+			},
+			Insured: &Insured{
+				UseInsured: 0,
+			},
+			Service: &ServiceType{
+				ServiceType: 0,
+				ServiceName: "标准快递",
+			},
+		},
+	}
+	result := new(ResultTemplatePreview)
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", PreviewTemplate(params, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultTemplatePreview{
+		WaybillID:               "1707110000000001",
+		RenderedWaybillTemplate: "PGh0bWw+dGVzdDwvaHRtbD4=",
+	}, result)
+}
+
+func TestUpdateBusiness(t *testing.T) {
+	body := []byte(`{"shop_app_id":"wxABCD","biz_id":"xyz","result_code":0}`)
+
+	resp := []byte(`{"errcode":0,"errmsg":"ok"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/express/delivery/service/business/update?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	mp := minip.New("APPID", "APPSECRET", minip.WithMockClient(client))
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", UpdateBusiness(&ParamsBusinessUpdate{
+		ShopAppID:  "wxABCD",
+		BizID:      "xyz",
+		ResultCode: 0,
+	}))
+
+	assert.Nil(t, err)
+}
+
+func TestUpdatePath(t *testing.T) {
+	body := []byte(`{"token":"TOKEN","waybill_id":"12345678901234567890","action_time":1523356924,"action_type":300001,"action_msg":"丽影邓丽君【7936800】已进行揽件扫描"}`)
+
+	resp := []byte(`{"errcode":0,"errmsg":"ok"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/express/delivery/path/update?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	mp := minip.New("APPID", "APPSECRET", minip.WithMockClient(client))
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", UpdatePath(&ParamsPathUpdate{
+		Token:      "TOKEN",
+		WaybillID:  "12345678901234567890",
+		ActionTime: 1523356924,
+		ActionType: 300001,
+		ActionMsg:  "丽影邓丽君【7936800】已进行揽件扫描",
+	}))
+
+	assert.Nil(t, err)
+}
+
+func TestGetContact(t *testing.T) {
+	body := []byte(`{"token":"TOKEN","waybill_id":"12345678901234567890"}`)
+
+	resp := []byte(`{
+	"errcode": 0,
+	"errmsg": "ok",
+	"waybill_id": "12345678901234567890",
+	"sender": {
+		"address": "广东省广州市海珠区XX路XX号XX大厦XX栋XX",
+		"name": "张三",
+		"tel": "020-88888888"
+	},
+	"receiver": {
+		"address": "广东省广州市天河区XX路XX号XX大厦XX栋XX",
+		"name": "王小蒙",
+		"tel": "18666666666"
+	}
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/express/delivery/contact/get?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	mp := minip.New("APPID", "APPSECRET", minip.WithMockClient(client))
+
+	result := new(ResultContactGet)
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", GetContact("TOKEN", "12345678901234567890", result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultContactGet{
+		WaybillID: "12345678901234567890",
+		Sender: &Contact{
+			Address: "广东省广州市海珠区XX路XX号XX大厦XX栋XX",
+			Name:    "张三",
+			Tel:     "020-88888888",
+		},
+		Receiver: &Contact{
+			Address: "广东省广州市天河区XX路XX号XX大厦XX栋XX",
+			Name:    "王小蒙",
+			Tel:     "18666666666",
+		},
+	}, result)
+}
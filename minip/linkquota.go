@@ -0,0 +1,289 @@
+package minip
+
+import (
+	"context"
+	"math"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/shenghui0779/gochat/urls"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// LinkKind 小程序链接类型
+type LinkKind string
+
+// 小程序链接类型
+const (
+	LinkScheme  LinkKind = "scheme"  // URL Scheme
+	LinkURLLink LinkKind = "urllink" // URL Link
+)
+
+// 微信公布的链接生成上限
+// [参考](https://developers.weixin.qq.com/miniprogram/dev/OpenApiDoc/qrcode-link/url-scheme/generateScheme.html)
+const (
+	LinkDailyLimit    = 500000 // URL Scheme 与 URL Link 每天生成总数上限
+	LinkLongTimeLimit = 100000 // 长期有效 URL Scheme / URL Link 各自的生成总数上限
+)
+
+// LinkQuotaStore 链接生成计数存储（如：Redis 等，多实例部署时需共享）
+type LinkQuotaStore interface {
+	// Get 获取计数（不存在时返回0）
+	Get(ctx context.Context, key string) (int64, error)
+
+	// Incr 计数加1，并返回加1后的计数
+	Incr(ctx context.Context, key string) (int64, error)
+
+	// Set 设置计数
+	Set(ctx context.Context, key string, n int64) error
+}
+
+type memLinkQuotaStore struct {
+	counts map[string]int64
+	mutex  sync.Mutex
+}
+
+func (s *memLinkQuotaStore) Get(ctx context.Context, key string) (int64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.counts[key], nil
+}
+
+func (s *memLinkQuotaStore) Incr(ctx context.Context, key string) (int64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.counts[key]++
+
+	return s.counts[key], nil
+}
+
+func (s *memLinkQuotaStore) Set(ctx context.Context, key string, n int64) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.counts[key] = n
+
+	return nil
+}
+
+// NewMemLinkQuotaStore returns an in-memory link quota store (仅用于测试或单机，重启后丢失)
+func NewMemLinkQuotaStore() LinkQuotaStore {
+	return &memLinkQuotaStore{
+		counts: make(map[string]int64),
+	}
+}
+
+// LinkUsage 链接生成用量
+type LinkUsage struct {
+	Kind          LinkKind
+	Date          string // 计数日期（北京时间），如：20060102
+	DailyUsed     int64  // 当日 URL Scheme 与 URL Link 生成总数
+	DailyLimit    int64
+	LongTimeUsed  int64 // 当前类型长期有效链接生成总数
+	LongTimeLimit int64
+}
+
+// DailyExhausted 当日生成数是否已达上限
+func (u *LinkUsage) DailyExhausted() bool {
+	return u.DailyUsed >= u.DailyLimit
+}
+
+// LongTimeExhausted 长期有效链接生成数是否已达上限
+func (u *LinkUsage) LongTimeExhausted() bool {
+	return u.LongTimeUsed >= u.LongTimeLimit
+}
+
+// LinkQuotaAlert 链接生成数达到告警阈值或上限时的回调
+type LinkQuotaAlert func(ctx context.Context, usage *LinkUsage)
+
+// LinkQuotaErrorHandler 自动计数失败时的回调（如：计数存储不可用），计数失败不影响接口调用结果
+type LinkQuotaErrorHandler func(ctx context.Context, kind LinkKind, err error)
+
+// LinkQuota 链接生成数本地计数（微信超出上限后生成失败，需提前告警）
+type LinkQuota struct {
+	store         LinkQuotaStore
+	dailyLimit    int64
+	longTimeLimit int64
+	threshold     float64
+	alert         LinkQuotaAlert
+	errhandler    LinkQuotaErrorHandler
+	now           func() time.Time
+}
+
+// LinkQuotaOption 链接计数设置项
+type LinkQuotaOption func(q *LinkQuota)
+
+// WithLinkQuotaStore 设置计数存储（默认：内存存储）
+func WithLinkQuotaStore(store LinkQuotaStore) LinkQuotaOption {
+	return func(q *LinkQuota) {
+		q.store = store
+	}
+}
+
+// WithLinkDailyLimit 设置每天生成总数上限（默认：LinkDailyLimit）
+func WithLinkDailyLimit(n int64) LinkQuotaOption {
+	return func(q *LinkQuota) {
+		q.dailyLimit = n
+	}
+}
+
+// WithLinkLongTimeLimit 设置长期有效链接生成总数上限（默认：LinkLongTimeLimit）
+func WithLinkLongTimeLimit(n int64) LinkQuotaOption {
+	return func(q *LinkQuota) {
+		q.longTimeLimit = n
+	}
+}
+
+// WithLinkAlertThreshold 设置告警阈值，取值 (0, 1]（默认：0.9，即用量达到上限的90%时告警）
+func WithLinkAlertThreshold(ratio float64) LinkQuotaOption {
+	return func(q *LinkQuota) {
+		if ratio > 0 && ratio <= 1 {
+			q.threshold = ratio
+		}
+	}
+}
+
+// WithLinkQuotaErrorHandler 设置自动计数失败时的回调（默认忽略）；
+// 链接已由微信生成，计数失败时 Minip.Do 仍返回成功，避免调用方重试导致重复生成
+func WithLinkQuotaErrorHandler(f LinkQuotaErrorHandler) LinkQuotaOption {
+	return func(q *LinkQuota) {
+		q.errhandler = f
+	}
+}
+
+// NewLinkQuota returns new link quota, alert 在用量首次达到告警阈值及达到上限时回调
+func NewLinkQuota(alert LinkQuotaAlert, options ...LinkQuotaOption) *LinkQuota {
+	q := &LinkQuota{
+		store:         NewMemLinkQuotaStore(),
+		dailyLimit:    LinkDailyLimit,
+		longTimeLimit: LinkLongTimeLimit,
+		threshold:     0.9,
+		alert:         alert,
+		now:           time.Now,
+	}
+
+	for _, f := range options {
+		f(q)
+	}
+
+	return q
+}
+
+// Record 记录一次链接生成，longTime 表示是否为长期有效链接
+func (q *LinkQuota) Record(ctx context.Context, kind LinkKind, longTime bool) (*LinkUsage, error) {
+	usage := q.usage(kind)
+
+	var err error
+
+	if usage.DailyUsed, err = q.store.Incr(ctx, q.dailyKey(usage.Date)); err != nil {
+		return nil, err
+	}
+
+	if longTime {
+		if usage.LongTimeUsed, err = q.store.Incr(ctx, q.longTimeKey(kind)); err != nil {
+			return nil, err
+		}
+	} else {
+		if usage.LongTimeUsed, err = q.store.Get(ctx, q.longTimeKey(kind)); err != nil {
+			return nil, err
+		}
+	}
+
+	if q.alert != nil && (q.reached(usage.DailyUsed, usage.DailyLimit) || (longTime && q.reached(usage.LongTimeUsed, usage.LongTimeLimit))) {
+		q.alert(ctx, usage)
+	}
+
+	return usage, nil
+}
+
+// Usage 获取当前用量
+func (q *LinkQuota) Usage(ctx context.Context, kind LinkKind) (*LinkUsage, error) {
+	usage := q.usage(kind)
+
+	var err error
+
+	if usage.DailyUsed, err = q.store.Get(ctx, q.dailyKey(usage.Date)); err != nil {
+		return nil, err
+	}
+
+	if usage.LongTimeUsed, err = q.store.Get(ctx, q.longTimeKey(kind)); err != nil {
+		return nil, err
+	}
+
+	return usage, nil
+}
+
+// SyncLongTime 以微信返回的 long_time_used 校准长期有效链接计数（QueryScheme、QueryURLLink 返回后自动校准）
+func (q *LinkQuota) SyncLongTime(ctx context.Context, kind LinkKind, used int64) error {
+	return q.store.Set(ctx, q.longTimeKey(kind), used)
+}
+
+func (q *LinkQuota) usage(kind LinkKind) *LinkUsage {
+	return &LinkUsage{
+		Kind:          kind,
+		Date:          q.now().In(linkQuotaZone).Format("20060102"),
+		DailyLimit:    q.dailyLimit,
+		LongTimeLimit: q.longTimeLimit,
+	}
+}
+
+// reached 计数恰好达到告警阈值或上限（计数逐一递增，每个节点仅告警一次）
+func (q *LinkQuota) reached(used, limit int64) bool {
+	return used == int64(math.Ceil(float64(limit)*q.threshold)) || used == limit
+}
+
+func (q *LinkQuota) dailyKey(date string) string {
+	return "link_quota:daily:" + date
+}
+
+func (q *LinkQuota) longTimeKey(kind LinkKind) string {
+	return "link_quota:long_time:" + string(kind)
+}
+
+// 微信每日额度按北京时间重置
+var linkQuotaZone = time.FixedZone("CST", 8*60*60)
+
+// track 根据 action 记录链接生成数或校准长期有效链接计数，失败时通过 errhandler 回调
+func (q *LinkQuota) track(ctx context.Context, action wx.Action, body []byte, resp gjson.Result) {
+	var (
+		kind LinkKind
+		err  error
+	)
+
+	// 按接口路径匹配（Action 可能通过 WithBaseURL 指定了域名）
+	path := action.URL()
+
+	if u, perr := url.Parse(path); perr == nil {
+		path = u.Path
+	}
+
+	switch path {
+	case urls.MinipGenerateScheme:
+		kind = LinkScheme
+		_, err = q.Record(ctx, kind, !gjson.GetBytes(body, "is_expire").Bool())
+	case urls.MinipGenerateURLLink:
+		kind = LinkURLLink
+		_, err = q.Record(ctx, kind, !gjson.GetBytes(body, "is_expire").Bool())
+	case urls.MinipQueryScheme:
+		kind = LinkScheme
+
+		if r := resp.Get("scheme_quota.long_time_used"); r.Exists() {
+			err = q.SyncLongTime(ctx, kind, r.Int())
+		}
+	case urls.MinipQueryURLLink:
+		kind = LinkURLLink
+
+		if r := resp.Get("url_link_quota.long_time_used"); r.Exists() {
+			err = q.SyncLongTime(ctx, kind, r.Int())
+		}
+	}
+
+	if err != nil && q.errhandler != nil {
+		q.errhandler(ctx, kind, err)
+	}
+}
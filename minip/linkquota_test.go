@@ -0,0 +1,155 @@
+package minip
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/mock"
+)
+
+func TestLinkQuotaRecord(t *testing.T) {
+	alerts := make([]*LinkUsage, 0)
+
+	q := NewLinkQuota(func(ctx context.Context, usage *LinkUsage) {
+		alerts = append(alerts, usage)
+	}, WithLinkDailyLimit(10), WithLinkLongTimeLimit(4), WithLinkAlertThreshold(0.8))
+
+	q.now = func() time.Time {
+		return time.Date(2021, 6, 1, 16, 30, 0, 0, time.UTC)
+	}
+
+	for i := 0; i < 10; i++ {
+		usage, err := q.Record(context.TODO(), LinkScheme, false)
+
+		assert.Nil(t, err)
+		assert.Equal(t, int64(i+1), usage.DailyUsed)
+	}
+
+	// 8 和 10 各告警一次，日期按北京时间计算
+	assert.Equal(t, 2, len(alerts))
+	assert.Equal(t, "20210602", alerts[0].Date)
+	assert.Equal(t, int64(8), alerts[0].DailyUsed)
+	assert.False(t, alerts[0].DailyExhausted())
+	assert.Equal(t, int64(10), alerts[1].DailyUsed)
+	assert.True(t, alerts[1].DailyExhausted())
+
+	alerts = alerts[:0]
+
+	assert.Nil(t, q.SyncLongTime(context.TODO(), LinkURLLink, 3))
+
+	usage, err := q.Record(context.TODO(), LinkURLLink, true)
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(4), usage.LongTimeUsed)
+	assert.True(t, usage.LongTimeExhausted())
+	assert.Equal(t, 1, len(alerts))
+
+	usage, err = q.Usage(context.TODO(), LinkScheme)
+
+	assert.Nil(t, err)
+	assert.Equal(t, &LinkUsage{
+		Kind:          LinkScheme,
+		Date:          "20210602",
+		DailyUsed:     11,
+		DailyLimit:    10,
+		LongTimeUsed:  0,
+		LongTimeLimit: 4,
+	}, usage)
+}
+
+func TestLinkQuotaTrack(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/wxa/generatescheme?access_token=ACCESS_TOKEN", []byte(`{"jump_wxa":{"path":"/pages/index/index"}}`)).Return([]byte(`{"errcode":0,"errmsg":"ok","openlink":"Scheme"}`), nil)
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/wxa/generate_urllink?access_token=ACCESS_TOKEN", []byte(`{"path":"/pages/index/index","is_expire":true,"expire_type":1,"expire_interval":1}`)).Return([]byte(`{"errcode":0,"errmsg":"ok","url_link":"URL Link"}`), nil)
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/wxa/queryscheme?access_token=ACCESS_TOKEN", []byte(`{"scheme":"weixin://dl/business/?t=XTSkBZlzqmn"}`)).Return([]byte(`{"errcode":0,"errmsg":"ok","scheme_quota":{"long_time_used":100,"long_time_limit":100000}}`), nil)
+
+	q := NewLinkQuota(nil)
+
+	mp := New("APPID", "APPSECRET", WithMockClient(client), WithLinkQuota(q))
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", GenerateScheme(&ParamsSchemeGenerate{JumpWxa: &SchemeJumpWxa{Path: "/pages/index/index"}}, new(ResultSchemeGenerate)))
+
+	assert.Nil(t, err)
+
+	usage, err := q.Usage(context.TODO(), LinkScheme)
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), usage.DailyUsed)
+	assert.Equal(t, int64(1), usage.LongTimeUsed)
+
+	err = mp.Do(context.TODO(), "ACCESS_TOKEN", GenerateURLLink(&ParamsURLLinkGenerate{
+		Path:           "/pages/index/index",
+		IsExpire:       true,
+		ExpireType:     1,
+		ExpireInterval: 1,
+	}, new(ResultURLLinkGenerate)))
+
+	assert.Nil(t, err)
+
+	usage, err = q.Usage(context.TODO(), LinkURLLink)
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), usage.DailyUsed)
+	assert.Equal(t, int64(0), usage.LongTimeUsed)
+
+	err = mp.Do(context.TODO(), "ACCESS_TOKEN", QueryScheme("weixin://dl/business/?t=XTSkBZlzqmn", new(ResultSchemeQuery)))
+
+	assert.Nil(t, err)
+
+	usage, err = q.Usage(context.TODO(), LinkScheme)
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(100), usage.LongTimeUsed)
+}
+
+type failLinkQuotaStore struct{}
+
+func (s failLinkQuotaStore) Get(ctx context.Context, key string) (int64, error) {
+	return 0, errors.New("store unavailable")
+}
+
+func (s failLinkQuotaStore) Incr(ctx context.Context, key string) (int64, error) {
+	return 0, errors.New("store unavailable")
+}
+
+func (s failLinkQuotaStore) Set(ctx context.Context, key string, n int64) error {
+	return errors.New("store unavailable")
+}
+
+func TestLinkQuotaTrackStoreFail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/wxa/generatescheme?access_token=ACCESS_TOKEN", []byte(`{"jump_wxa":{"path":"/pages/index/index"}}`)).Return([]byte(`{"errcode":0,"errmsg":"ok","openlink":"Scheme"}`), nil)
+
+	var errs []error
+
+	q := NewLinkQuota(nil, WithLinkQuotaStore(failLinkQuotaStore{}), WithLinkQuotaErrorHandler(func(ctx context.Context, kind LinkKind, err error) {
+		assert.Equal(t, LinkScheme, kind)
+
+		errs = append(errs, err)
+	}))
+
+	mp := New("APPID", "APPSECRET", WithMockClient(client), WithLinkQuota(q))
+
+	result := new(ResultSchemeGenerate)
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", GenerateScheme(&ParamsSchemeGenerate{JumpWxa: &SchemeJumpWxa{Path: "/pages/index/index"}}, result))
+
+	// 链接已生成，计数失败仅回调
+	assert.Nil(t, err)
+	assert.Equal(t, "Scheme", result.OpenLink)
+	assert.Equal(t, 1, len(errs))
+}
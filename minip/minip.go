@@ -22,6 +22,15 @@ type Minip struct {
 	aeskey    string
 	nonce     func() string
 	client    wx.HTTPClient
+	baseURL   string
+	tokenmgr  *wx.TokenManager
+	tokenopts []wx.TokenOption
+	dials     []wx.DialOption
+	headers   map[string]string
+	store     SessionStore
+	appidchk  bool
+	lenient   bool
+	linkquota *LinkQuota
 }
 
 // AppID returns appid
@@ -36,7 +45,9 @@ func (mp *Minip) AppSecret() string {
 
 // Code2Session 获取小程序授权的session_key
 func (mp *Minip) Code2Session(ctx context.Context, code string, options ...wx.HTTPOption) (*AuthSession, error) {
-	resp, err := mp.client.Do(ctx, http.MethodGet, fmt.Sprintf("%s?appid=%s&secret=%s&js_code=%s&grant_type=authorization_code", urls.MinipCode2Session, mp.appid, mp.appsecret, code), nil, options...)
+	options = wx.PrependHTTPHeaders(options, mp.headers)
+
+	resp, err := mp.client.Do(ctx, http.MethodGet, mp.endpoint(fmt.Sprintf("%s?appid=%s&secret=%s&js_code=%s&grant_type=authorization_code", urls.MinipCode2Session, mp.appid, mp.appsecret, code)), nil, options...)
 
 	if err != nil {
 		return nil, err
@@ -45,7 +56,7 @@ func (mp *Minip) Code2Session(ctx context.Context, code string, options ...wx.HT
 	r := gjson.ParseBytes(resp)
 
 	if code := r.Get("errcode").Int(); code != 0 {
-		return nil, fmt.Errorf("%d|%s", code, r.Get("errmsg").String())
+		return nil, wx.NewAPIError(code, r.Get("errmsg").String())
 	}
 
 	session := new(AuthSession)
@@ -54,12 +65,21 @@ func (mp *Minip) Code2Session(ctx context.Context, code string, options ...wx.HT
 		return nil, err
 	}
 
+	// 未配置存储时不保存 session_key
+	if mp.store != nil {
+		if err = mp.store.Set(ctx, session.OpenID, session.SessionKey); err != nil {
+			return nil, err
+		}
+	}
+
 	return session, nil
 }
 
 // AccessToken 获取小程序的access_token
 func (mp *Minip) AccessToken(ctx context.Context, options ...wx.HTTPOption) (*AccessToken, error) {
-	resp, err := mp.client.Do(ctx, http.MethodGet, fmt.Sprintf("%s?appid=%s&secret=%s&grant_type=client_credential", urls.MinipAccessToken, mp.appid, mp.appsecret), nil, options...)
+	options = wx.PrependHTTPHeaders(options, mp.headers)
+
+	resp, err := mp.client.Do(ctx, http.MethodGet, mp.endpoint(fmt.Sprintf("%s?appid=%s&secret=%s&grant_type=client_credential", urls.MinipAccessToken, mp.appid, mp.appsecret)), nil, options...)
 
 	if err != nil {
 		return nil, err
@@ -68,7 +88,7 @@ func (mp *Minip) AccessToken(ctx context.Context, options ...wx.HTTPOption) (*Ac
 	r := gjson.ParseBytes(resp)
 
 	if code := r.Get("errcode").Int(); code != 0 {
-		return nil, fmt.Errorf("%d|%s", code, r.Get("errmsg").String())
+		return nil, wx.NewAPIError(code, r.Get("errmsg").String())
 	}
 
 	token := new(AccessToken)
@@ -82,42 +102,68 @@ func (mp *Minip) AccessToken(ctx context.Context, options ...wx.HTTPOption) (*Ac
 
 // DecryptAuthInfo 解密授权信息
 func (mp *Minip) DecryptAuthInfo(sessionKey, iv, encryptedData string, result *AuthInfo) error {
-	key, err := base64.StdEncoding.DecodeString(sessionKey)
+	b, err := decryptData(sessionKey, iv, encryptedData)
 
 	if err != nil {
 		return err
 	}
 
-	ivb, err := base64.StdEncoding.DecodeString(iv)
-
-	if err != nil {
+	if err = json.Unmarshal(b, result); err != nil {
 		return err
 	}
 
-	cipherText, err := base64.StdEncoding.DecodeString(encryptedData)
+	if mp.appidchk {
+		return wx.CheckAppID(result.Watermark.AppID, mp.appid)
+	}
+
+	return nil
+}
+
+// decryptData 使用 session_key 解密开放数据
+func decryptData(sessionKey, iv, encryptedData string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(sessionKey)
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	cbc := wx.NewCBCCrypto(key, ivb, wx.AES_PKCS7)
+	ivb, err := base64.StdEncoding.DecodeString(iv)
+
+	if err != nil {
+		return nil, err
+	}
 
-	b, err := cbc.Decrypt(cipherText)
+	cipherText, err := base64.StdEncoding.DecodeString(encryptedData)
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return json.Unmarshal(b, result)
+	return wx.NewCBCCrypto(key, ivb, wx.AES_PKCS7).Decrypt(cipherText)
+}
+
+// TokenManager returns the access_token manager（缓存，过期前自动刷新；配置项通过 WithTokenOptions 设置）
+func (mp *Minip) TokenManager() *wx.TokenManager {
+	return mp.tokenmgr
+}
+
+// endpoint returns the request url with base url applied
+func (mp *Minip) endpoint(reqURL string) string {
+	return wx.JoinURL(mp.baseURL, reqURL)
 }
 
 // Do exec action
 func (mp *Minip) Do(ctx context.Context, accessToken string, action wx.Action, options ...wx.HTTPOption) error {
+	options = wx.PrependHTTPHeaders(options, mp.headers, wx.ActionHeaders(action))
+
 	var (
+		body []byte
 		resp []byte
 		err  error
 	)
 
+	reqURL := mp.endpoint(action.URL(accessToken))
+
 	if action.IsUpload() {
 		form, ferr := action.UploadForm()
 
@@ -126,15 +172,25 @@ func (mp *Minip) Do(ctx context.Context, accessToken string, action wx.Action, o
 			return ferr
 		}
 
-		resp, err = mp.client.Upload(ctx, action.URL(accessToken), form, options...)
+		if reqURL, options, err = wx.SignAction(action, reqURL, nil, options); err != nil {
+			return err
+		}
+
+		resp, err = mp.client.Upload(ctx, reqURL, form, options...)
 	} else {
-		body, berr := action.Body()
+		var berr error
+
+		body, berr = action.Body()
 
 		if berr != nil {
+			return berr
+		}
+
+		if reqURL, options, err = wx.SignAction(action, reqURL, body, options); err != nil {
 			return err
 		}
 
-		resp, err = mp.client.Do(ctx, action.Method(), action.URL(accessToken), body, options...)
+		resp, err = mp.client.Do(ctx, action.Method(), reqURL, body, options...)
 	}
 
 	if err != nil {
@@ -144,10 +200,33 @@ func (mp *Minip) Do(ctx context.Context, accessToken string, action wx.Action, o
 	r := gjson.ParseBytes(resp)
 
 	if code := r.Get("errcode").Int(); code != 0 {
-		return fmt.Errorf("%d|%s", code, r.Get("errmsg").String())
+		return wx.NewAPIError(code, r.Get("errmsg").String())
+	}
+
+	if mp.appidchk {
+		for _, path := range []string{"watermark.appid", "phone_info.watermark.appid"} {
+			if err = wx.CheckAppID(r.Get(path).String(), mp.appid); err != nil {
+				return err
+			}
+		}
+	}
+
+	if mp.lenient {
+		err = wx.DecodeLenient(resp, action.Decode)
+	} else {
+		err = action.Decode(resp)
+	}
+
+	if err != nil {
+		return err
 	}
 
-	return action.Decode(resp)
+	// 计数失败不影响调用结果（链接已生成）
+	if mp.linkquota != nil {
+		mp.linkquota.track(ctx, action, body, r)
+	}
+
+	return nil
 }
 
 // VerifyEventSign 验证事件消息签名
@@ -162,7 +241,7 @@ func (mp *Minip) VerifyEventSign(signature string, items ...string) bool {
 
 // DecryptEventMessage 事件消息解密
 func (mp *Minip) DecryptEventMessage(encrypt string) (wx.WXML, error) {
-	b, err := event.Decrypt(mp.appid, mp.aeskey, encrypt)
+	b, err := mp.DecryptEvent(encrypt)
 
 	if err != nil {
 		return nil, err
@@ -171,6 +250,16 @@ func (mp *Minip) DecryptEventMessage(encrypt string) (wx.WXML, error) {
 	return wx.ParseXML2Map(b)
 }
 
+// SafeMode 是否为安全模式（已配置消息加解密密钥）；明文模式下推送的消息不加密
+func (mp *Minip) SafeMode() bool {
+	return len(mp.aeskey) != 0
+}
+
+// DecryptEvent 事件消息解密，返回解密后的原始报文（XML），可用于消息存档或重新解析
+func (mp *Minip) DecryptEvent(encrypt string) ([]byte, error) {
+	return event.Decrypt(mp.appid, mp.aeskey, encrypt)
+}
+
 // Option 小程序配置项
 type Option func(mp *Minip)
 
@@ -183,6 +272,13 @@ func WithServerConfig(token, aeskey string) Option {
 	}
 }
 
+// WithSessionStore 设置用户 session_key 存储（默认不存储；设置后 Code2Session 时自动保存，用于 SessionKey）
+func WithSessionStore(store SessionStore) Option {
+	return func(mp *Minip) {
+		mp.store = store
+	}
+}
+
 // WithNonce 设置 Nonce（加密随机串）
 func WithNonce(f func() string) Option {
 	return func(mp *Minip) {
@@ -197,6 +293,63 @@ func WithClient(c *http.Client) Option {
 	}
 }
 
+// WithHeader 设置请求 header（如：User-Agent、链路追踪 header），作用于所有请求
+func WithHeader(key, value string) Option {
+	return func(mp *Minip) {
+		mp.headers[key] = value
+	}
+}
+
+// WithDialOptions 设置 HTTP Client 的连接配置（如：强制 IPv4、自定义 DNS 解析），
+// 在所有配置项之后作用于默认或 WithClient 设置的 Client，与配置顺序无关；Client 的 Transport 不是 *http.Transport 时不生效
+func WithDialOptions(options ...wx.DialOption) Option {
+	return func(mp *Minip) {
+		mp.dials = options
+	}
+}
+
+// WithAppIDCheck 校验返回及解密数据水印（watermark）中的 appid 与当前小程序一致
+func WithAppIDCheck() Option {
+	return func(mp *Minip) {
+		mp.appidchk = true
+	}
+}
+
+// WithLenientDecode 宽松解析返回结果，兼容数字与数字字符串等类型不一致的字段
+func WithLenientDecode() Option {
+	return func(mp *Minip) {
+		mp.lenient = true
+	}
+}
+
+// WithLinkQuota 设置 URL Scheme / URL Link 生成数本地计数（生成成功后自动计数，计数失败不影响调用结果）
+func WithLinkQuota(q *LinkQuota) Option {
+	return func(mp *Minip) {
+		mp.linkquota = q
+	}
+}
+
+// WithHTTPClient 设置已配置的 wx.HTTPClient（如：第三方平台代授权方调用时复用平台的 Client）
+func WithHTTPClient(c wx.HTTPClient) Option {
+	return func(mp *Minip) {
+		mp.client = c
+	}
+}
+
+// WithTokenOptions 设置 access_token 管理的配置项（如：wx.WithTokenStore）
+func WithTokenOptions(options ...wx.TokenOption) Option {
+	return func(mp *Minip) {
+		mp.tokenopts = options
+	}
+}
+
+// WithBaseURL 设置接口域名（默认：https://api.weixin.qq.com），可用于切换备用域名（如：https://api2.weixin.qq.com）
+func WithBaseURL(base string) Option {
+	return func(mp *Minip) {
+		mp.baseURL = base
+	}
+}
+
 // WithMockClient 设置 Mock Client
 func WithMockClient(c wx.HTTPClient) Option {
 	return func(mp *Minip) {
@@ -212,12 +365,26 @@ func New(appid, appsecret string, options ...Option) *Minip {
 		nonce: func() string {
 			return wx.Nonce(16)
 		},
-		client: wx.NewDefaultClient(),
+		client:  wx.NewDefaultClient(),
+		baseURL: wx.BaseURLAPI,
+		headers: make(map[string]string),
 	}
 
 	for _, f := range options {
 		f(mp)
 	}
 
+	mp.client = wx.ApplyDialOptions(mp.client, mp.dials...)
+
+	mp.tokenmgr = wx.NewTokenManager(func(ctx context.Context) (string, int64, error) {
+		token, err := mp.AccessToken(ctx)
+
+		if err != nil {
+			return "", 0, err
+		}
+
+		return token.Token, token.ExpiresIn, nil
+	}, mp.tokenopts...)
+
 	return mp
 }
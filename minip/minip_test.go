@@ -2,6 +2,8 @@ package minip
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
 	"net/http"
 	"testing"
 
@@ -73,6 +75,31 @@ func TestAccessToken(t *testing.T) {
 	}, accessToken)
 }
 
+func TestTokenManager(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.weixin.qq.com/cgi-bin/token?appid=APPID&secret=APPSECRET&grant_type=client_credential", nil).Return([]byte(`{"access_token":"ACCESS_TOKEN","expires_in":7200}`), nil).Times(1)
+
+	store := wx.NewMemTokenStore()
+
+	mp := New("APPID", "APPSECRET", WithMockClient(client), WithTokenOptions(wx.WithTokenStore(store, "APPID")))
+
+	for i := 0; i < 2; i++ {
+		accessToken, err := mp.TokenManager().Token(context.TODO())
+
+		assert.Nil(t, err)
+		assert.Equal(t, "ACCESS_TOKEN", accessToken)
+	}
+
+	snapshot, err := store.Get(context.TODO(), "APPID")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "ACCESS_TOKEN", snapshot.Token)
+}
+
 func TestVerifyEventSign(t *testing.T) {
 	mp := New("APPID", "APPSECRET", WithServerConfig("2faf43d6343a802b6073aae5b3f2f109", "jxAko083VoJ3lcPXJWzcGJ0M1tFVLgdD6qAq57GJY1U"))
 
@@ -95,3 +122,108 @@ func TestDecryptEventMessage(t *testing.T) {
 		"URL":          "http://182.92.100.180/webhook",
 	}, msg)
 }
+
+func TestDecryptAuthInfo(t *testing.T) {
+	key := []byte("1234567890abcdef")
+	iv := []byte("abcdef1234567890")
+
+	encrypt := func(plain string) string {
+		b, err := wx.NewCBCCrypto(key, iv, wx.AES_PKCS7).Encrypt([]byte(plain))
+
+		assert.Nil(t, err)
+
+		return base64.StdEncoding.EncodeToString(b)
+	}
+
+	sessionKey := base64.StdEncoding.EncodeToString(key)
+	ivStr := base64.StdEncoding.EncodeToString(iv)
+	data := encrypt(`{"openId":"OPENID","nickName":"Band","gender":1,"watermark":{"timestamp":1477314187,"appid":"APPID"}}`)
+
+	result := new(AuthInfo)
+
+	assert.Nil(t, New("APPID", "APPSECRET", WithAppIDCheck()).DecryptAuthInfo(sessionKey, ivStr, data, result))
+	assert.Equal(t, &AuthInfo{
+		OpenID:   "OPENID",
+		Nickname: "Band",
+		Gender:   1,
+		Watermark: Watermark{
+			Timestamp: 1477314187,
+			AppID:     "APPID",
+		},
+	}, result)
+
+	// appid 不一致
+	err := New("OTHER_APPID", "APPSECRET", WithAppIDCheck()).DecryptAuthInfo(sessionKey, ivStr, data, new(AuthInfo))
+
+	assert.True(t, errors.Is(err, wx.ErrAppIDMismatch))
+
+	// 未开启校验
+	assert.Nil(t, New("OTHER_APPID", "APPSECRET").DecryptAuthInfo(sessionKey, ivStr, data, new(AuthInfo)))
+}
+
+func TestAppIDCheck(t *testing.T) {
+	body := []byte(`{"code":"CODE"}`)
+	resp := []byte(`{"errcode":0,"errmsg":"ok","phone_info":{"phoneNumber":"xxxxxx","watermark":{"timestamp":1637744274,"appid":"OTHER_APPID"}}}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/wxa/business/getuserphonenumber?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	mp := New("APPID", "APPSECRET", WithMockClient(client), WithAppIDCheck())
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", GetPhoneNumber("CODE", new(ResultPhoneNumber)))
+
+	assert.True(t, errors.Is(err, wx.ErrAppIDMismatch))
+}
+
+func TestLenientDecode(t *testing.T) {
+	body := []byte(`{"begin_date":"20170313","end_date":"20170313"}`)
+	resp := []byte(`{"list":[{"ref_date":"20170313","session_cnt":"142549","visit_pv":472351,"stay_time_uv":"8.9"}]}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/datacube/getweanalysisappiddailyvisittrend?access_token=ACCESS_TOKEN", body).Return(resp, nil).Times(2)
+
+	// 严格解析
+	err := New("APPID", "APPSECRET", WithMockClient(client)).Do(context.TODO(), "ACCESS_TOKEN", GetDailyVisitTrend("20170313", new(ResultDailyVisitTrend)))
+
+	assert.NotNil(t, err)
+
+	// 宽松解析
+	result := new(ResultDailyVisitTrend)
+
+	err = New("APPID", "APPSECRET", WithMockClient(client), WithLenientDecode()).Do(context.TODO(), "ACCESS_TOKEN", GetDailyVisitTrend("20170313", result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultDailyVisitTrend{
+		List: []*DailyVisitTrend{
+			{
+				RefDate:    "20170313",
+				SessionCnt: 142549,
+				VisitPV:    472351,
+				StayTimeUV: 8.9,
+			},
+		},
+	}, result)
+}
+
+func TestDoBodyError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	mp := New("APPID", "APPSECRET", WithMockClient(client))
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", wx.NewPostAction("https://api.weixin.qq.com/wxa/test", wx.WithBody(func() ([]byte, error) {
+		return nil, errors.New("body failed")
+	})))
+
+	assert.EqualError(t, err, "body failed")
+}
@@ -0,0 +1,66 @@
+package minip
+
+import (
+	"encoding/json"
+
+	"github.com/shenghui0779/gochat/urls"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// 附近地点审核状态（见 NearbyPOIAuditEvent）
+const (
+	NearbyPOIAuditFailed  = 2 // 审核失败
+	NearbyPOIAuditSuccess = 3 // 审核通过
+)
+
+// ParamsNearbyPOIAdd 添加地点参数
+type ParamsNearbyPOIAdd struct {
+	IsCommNearby      string `json:"is_comm_nearby"`               // 必填，填 "1"
+	PicList           string `json:"pic_list"`                     // 门店图片，json字符串：{"list":["url"]}，最多9张
+	ServiceInfos      string `json:"service_infos"`                // 服务标签列表，json字符串
+	StoreName         string `json:"store_name"`                   // 门店名字
+	Hour              string `json:"hour"`                         // 营业时间，格式：11:11-12:12
+	Address           string `json:"address"`                      // 地址
+	CompanyName       string `json:"company_name"`                 // 主体名字
+	QualificationList string `json:"qualification_list"`           // 证明材料（主体名字与小程序主体不一致时必填）
+	ContractPhone     string `json:"contract_phone"`               // 门店电话
+	MapPOIID          string `json:"map_poi_id"`                   // 腾讯地图的地点id
+	KFInfo            string `json:"kf_info,omitempty"`            // 客服信息，json字符串
+	POIID             string `json:"poi_id,omitempty"`             // 已有的附近地点id（修改时填写）
+	StoreIntroduction string `json:"store_introduction,omitempty"` // 门店介绍
+}
+
+// ResultNearbyPOIAdd 添加地点结果
+type ResultNearbyPOIAdd struct {
+	Data *NearbyPOIAuditData `json:"data"`
+}
+
+// NearbyPOIAuditData 添加地点的审核信息
+type NearbyPOIAuditData struct {
+	AuditID           string `json:"audit_id"`           // 审核单id，审核结果通过 NearbyPOIAuditEvent 推送
+	POIID             string `json:"poi_id"`             // 附近地点id
+	RelatedCredential string `json:"related_credential"` // 经营资质证件号
+}
+
+// AddNearbyPOI 附近的小程序 - 添加地点（审核结果以 nearby_poi_audit 事件推送，通过 audit_id 关联）
+func AddNearbyPOI(params *ParamsNearbyPOIAdd, result *ResultNearbyPOIAdd) wx.Action {
+	return wx.NewPostAction(urls.MinipNearbyAddPOI,
+		wx.WithBody(func() ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// DeleteNearbyPOI 附近的小程序 - 删除地点
+func DeleteNearbyPOI(poiID string) wx.Action {
+	return wx.NewPostAction(urls.MinipNearbyDeletePOI,
+		wx.WithBody(func() ([]byte, error) {
+			return json.Marshal(map[string]string{
+				"poi_id": poiID,
+			})
+		}),
+	)
+}
@@ -0,0 +1,76 @@
+package minip
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/mock"
+)
+
+func TestAddNearbyPOI(t *testing.T) {
+	body := []byte(`{"is_comm_nearby":"1","pic_list":"{\"list\":[\"https://mmbiz.qlogo.cn/mmbiz_jpg/xxx/0\"]}","service_infos":"{\"service_infos\":[{\"id\":1,\"type\":1,\"name\":\"快递\",\"appid\":\"wx1373169e494e0c39\",\"path\":\"index\"}]}","store_name":"test","hour":"11:11-12:12","address":"新港中路397号","company_name":"深圳市腾讯计算机系统有限公司","qualification_list":"3LaLzqiTrQcD20DlX_o-OV1-nlYMu7sdVAL7SV2PrxVyjZFZZmB3O6LPGaYXlZWq","contract_phone":"1111111","map_poi_id":"2880999351367254311"}`)
+	resp := []byte(`{
+	"errcode": 0,
+	"errmsg": "ok",
+	"data": {
+		"audit_id": "xxxxx",
+		"poi_id": "xxxxx",
+		"related_credential": "xxxxx"
+	}
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/wxa/addnearbypoi?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	mp := New("APPID", "APPSECRET", WithMockClient(client))
+
+	result := new(ResultNearbyPOIAdd)
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", AddNearbyPOI(&ParamsNearbyPOIAdd{
+		IsCommNearby:      "1",
+		PicList:           `{"list":["https://mmbiz.qlogo.cn/mmbiz_jpg/xxx/0"]}`,
+		ServiceInfos:      `{"service_infos":[{"id":1,"type":1,"name":"快递","appid":"wx1373169e494e0c39","path":"index"}]}`,
+		StoreName:         "test",
+		Hour:              "11:11-12:12",
+		Address:           "新港中路397号",
+		CompanyName:       "深圳市腾讯计算机系统有限公司",
+		QualificationList: "3LaLzqiTrQcD20DlX_o-OV1-nlYMu7sdVAL7SV2PrxVyjZFZZmB3O6LPGaYXlZWq",
+		ContractPhone:     "1111111",
+		MapPOIID:          "2880999351367254311",
+	}, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultNearbyPOIAdd{
+		Data: &NearbyPOIAuditData{
+			AuditID:           "xxxxx",
+			POIID:             "xxxxx",
+			RelatedCredential: "xxxxx",
+		},
+	}, result)
+}
+
+func TestDeleteNearbyPOI(t *testing.T) {
+	body := []byte(`{"poi_id":"xxxxx"}`)
+	resp := []byte(`{"errcode":0,"errmsg":"ok"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/wxa/delnearbypoi?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	mp := New("APPID", "APPSECRET", WithMockClient(client))
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", DeleteNearbyPOI("xxxxx"))
+
+	assert.Nil(t, err)
+}
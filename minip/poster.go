@@ -0,0 +1,150 @@
+package minip
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/jpeg" // 小程序码默认为 jpeg
+	_ "image/png"  // 透明底色的小程序码为 png
+
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// PosterText 海报文字
+type PosterText struct {
+	Text  string
+	Point image.Point // 文字基线起点
+	Color color.Color
+}
+
+// PosterTextDrawer 海报文字绘制（标准库不支持字体渲染，可基于 golang.org/x/image/font 实现）
+type PosterTextDrawer func(dst draw.Image, text *PosterText) error
+
+// ParamsPoster 推广海报参数
+type ParamsPoster struct {
+	Background image.Image          // 背景图
+	QRCode     *ParamsQRCodeUnlimit // 小程序码参数
+	QRCodeRect image.Rectangle      // 小程序码在背景图上的区域（小程序码缩放至该区域大小）
+	Texts      []*PosterText        // 海报文字
+	TextDrawer PosterTextDrawer     // 文字绘制，Texts 不为空时必填
+}
+
+// GeneratePoster 生成推广海报：获取小程序码（数量不限）并与背景图、文字合成
+func (mp *Minip) GeneratePoster(ctx context.Context, accessToken string, params *ParamsPoster, options ...wx.HTTPOption) (*image.RGBA, error) {
+	qrcode := new(QRCode)
+
+	if err := mp.Do(ctx, accessToken, GetUnlimitQRCode(params.QRCode, qrcode), options...); err != nil {
+		return nil, err
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(qrcode.Buffer))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ComposePoster(params.Background, img, params.QRCodeRect, params.Texts, params.TextDrawer)
+}
+
+// ComposePoster 合成海报：将小程序码缩放绘制到背景图的指定区域，再绘制文字
+func ComposePoster(background, qrcode image.Image, rect image.Rectangle, texts []*PosterText, drawer PosterTextDrawer) (*image.RGBA, error) {
+	if background == nil || background.Bounds().Empty() {
+		return nil, errors.New("empty background")
+	}
+
+	if len(texts) != 0 && drawer == nil {
+		return nil, errors.New("nil text drawer")
+	}
+
+	bounds := background.Bounds()
+
+	dst := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+
+	draw.Draw(dst, dst.Bounds(), background, bounds.Min, draw.Src)
+
+	if qrcode != nil && !rect.Empty() {
+		draw.Draw(dst, rect, scaleImage(qrcode, rect.Dx(), rect.Dy()), image.Point{}, draw.Over)
+	}
+
+	for _, v := range texts {
+		if err := drawer(dst, v); err != nil {
+			return nil, err
+		}
+	}
+
+	return dst, nil
+}
+
+// scaleImage 双线性插值缩放图片
+func scaleImage(src image.Image, width, height int) *image.RGBA {
+	bounds := src.Bounds()
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	if bounds.Dx() == width && bounds.Dy() == height {
+		draw.Draw(dst, dst.Bounds(), src, bounds.Min, draw.Src)
+
+		return dst
+	}
+
+	sx := float64(bounds.Dx()) / float64(width)
+	sy := float64(bounds.Dy()) / float64(height)
+
+	for y := 0; y < height; y++ {
+		fy := (float64(y)+0.5)*sy - 0.5
+
+		if fy < 0 {
+			fy = 0
+		}
+
+		y0 := int(fy)
+		y1 := y0 + 1
+
+		if y1 >= bounds.Dy() {
+			y1 = bounds.Dy() - 1
+		}
+
+		ay := fy - float64(y0)
+
+		for x := 0; x < width; x++ {
+			fx := (float64(x)+0.5)*sx - 0.5
+
+			if fx < 0 {
+				fx = 0
+			}
+
+			x0 := int(fx)
+			x1 := x0 + 1
+
+			if x1 >= bounds.Dx() {
+				x1 = bounds.Dx() - 1
+			}
+
+			ax := fx - float64(x0)
+
+			c00 := color.RGBA64Model.Convert(src.At(bounds.Min.X+x0, bounds.Min.Y+y0)).(color.RGBA64)
+			c10 := color.RGBA64Model.Convert(src.At(bounds.Min.X+x1, bounds.Min.Y+y0)).(color.RGBA64)
+			c01 := color.RGBA64Model.Convert(src.At(bounds.Min.X+x0, bounds.Min.Y+y1)).(color.RGBA64)
+			c11 := color.RGBA64Model.Convert(src.At(bounds.Min.X+x1, bounds.Min.Y+y1)).(color.RGBA64)
+
+			lerp := func(v00, v10, v01, v11 uint16) uint16 {
+				top := float64(v00)*(1-ax) + float64(v10)*ax
+				bottom := float64(v01)*(1-ax) + float64(v11)*ax
+
+				return uint16(top*(1-ay) + bottom*ay + 0.5)
+			}
+
+			dst.Set(x, y, color.RGBA64{
+				R: lerp(c00.R, c10.R, c01.R, c11.R),
+				G: lerp(c00.G, c10.G, c01.G, c11.G),
+				B: lerp(c00.B, c10.B, c01.B, c11.B),
+				A: lerp(c00.A, c10.A, c01.A, c11.A),
+			})
+		}
+	}
+
+	return dst
+}
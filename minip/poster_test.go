@@ -0,0 +1,84 @@
+package minip
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/mock"
+)
+
+func TestGeneratePoster(t *testing.T) {
+	red := color.RGBA{R: 255, A: 255}
+	white := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+
+	code := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	draw.Draw(code, code.Bounds(), image.NewUniform(red), image.Point{}, draw.Src)
+
+	buf := new(bytes.Buffer)
+	assert.Nil(t, png.Encode(buf, code))
+
+	body := []byte(`{"scene":"a=1","is_hyaline":true}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/wxa/getwxacodeunlimit?access_token=ACCESS_TOKEN", body).Return(buf.Bytes(), nil)
+
+	mp := New("APPID", "APPSECRET", WithMockClient(client))
+
+	var texts []string
+
+	bg := image.NewRGBA(image.Rect(0, 0, 20, 20))
+	draw.Draw(bg, bg.Bounds(), image.NewUniform(white), image.Point{}, draw.Src)
+
+	poster, err := mp.GeneratePoster(context.TODO(), "ACCESS_TOKEN", &ParamsPoster{
+		Background: bg,
+		QRCode: &ParamsQRCodeUnlimit{
+			Scene:     "a=1",
+			IsHyaline: true,
+		},
+		QRCodeRect: image.Rect(10, 10, 18, 18),
+		Texts: []*PosterText{
+			{Text: "扫码领取", Point: image.Pt(2, 8), Color: color.Black},
+		},
+		TextDrawer: func(dst draw.Image, text *PosterText) error {
+			texts = append(texts, text.Text)
+
+			dst.Set(text.Point.X, text.Point.Y, text.Color)
+
+			return nil
+		},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, image.Rect(0, 0, 20, 20), poster.Bounds())
+	assert.Equal(t, []string{"扫码领取"}, texts)
+	assert.Equal(t, white, poster.RGBAAt(0, 0))
+	assert.Equal(t, red, poster.RGBAAt(10, 10))
+	assert.Equal(t, red, poster.RGBAAt(17, 17))
+	assert.Equal(t, white, poster.RGBAAt(18, 18))
+	assert.Equal(t, color.RGBA{A: 255}, poster.RGBAAt(2, 8))
+}
+
+func TestComposePoster(t *testing.T) {
+	bg := image.NewRGBA(image.Rect(0, 0, 20, 20))
+
+	_, err := ComposePoster(bg, nil, image.Rectangle{}, []*PosterText{{Text: "TEXT"}}, nil)
+
+	assert.NotNil(t, err)
+
+	_, err = ComposePoster(image.NewRGBA(image.Rectangle{}), nil, image.Rectangle{}, nil, nil)
+
+	assert.NotNil(t, err)
+}
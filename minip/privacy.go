@@ -0,0 +1,131 @@
+package minip
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/shenghui0779/gochat/urls"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// PrivacyVer 用户隐私保护指引的版本
+type PrivacyVer int
+
+// 微信支持的隐私保护指引版本
+const (
+	PrivacyVerOnline PrivacyVer = 1 // 现网版本
+	PrivacyVerDev    PrivacyVer = 2 // 开发版（默认）
+)
+
+// PrivacyOwnerSetting 收集方信息配置
+type PrivacyOwnerSetting struct {
+	ContactEmail         string `json:"contact_email,omitempty"`          // 信息收集方（开发者）的邮箱地址，四种联系方式至少要填一种
+	ContactPhone         string `json:"contact_phone,omitempty"`          // 信息收集方（开发者）的手机号
+	ContactQQ            string `json:"contact_qq,omitempty"`             // 信息收集方（开发者）的qq号
+	ContactWeixin        string `json:"contact_weixin,omitempty"`         // 信息收集方（开发者）的微信号
+	ExtFileMediaID       string `json:"ext_file_media_id,omitempty"`      // 自定义的补充文档（通过 UploadPrivacyExtFile 上传）
+	NoticeMethod         string `json:"notice_method"`                    // 通知方式，指的是当开发者收集信息有变动时，通过该方式通知用户
+	StoreExpireTimestamp string `json:"store_expire_timestamp,omitempty"` // 存储期限，指的是开发者收集用户信息存储多久（不填则默认“为实现产品目的所需的最短时间”）
+}
+
+// PrivacySetting 要收集的用户信息配置
+type PrivacySetting struct {
+	PrivacyKey   string `json:"privacy_key"`             // 用户信息类型的英文名称
+	PrivacyText  string `json:"privacy_text"`            // 该用户信息类型的用途
+	PrivacyLabel string `json:"privacy_label,omitempty"` // 用户信息类型的中文名称（仅查询时返回）
+}
+
+// PrivacyDesc 用户信息类型说明
+type PrivacyDesc struct {
+	PrivacyKey  string `json:"privacy_key"`  // 用户信息类型的英文key
+	PrivacyDesc string `json:"privacy_desc"` // 用户信息类型的中文描述
+}
+
+// PrivacyDescInfo 用户信息类型对应的中英文描述
+type PrivacyDescInfo struct {
+	PrivacyDescList []*PrivacyDesc `json:"privacy_desc_list"`
+}
+
+// ResultPrivacySetting 隐私设置查询结果
+type ResultPrivacySetting struct {
+	CodeExist    int                  `json:"code_exist"`    // 代码是否存在，0 不存在，1 存在（为0时，不能设置隐私保护指引）
+	PrivacyList  []string             `json:"privacy_list"`  // 代码检测出来的用户信息类型（privacy_key）
+	SettingList  []*PrivacySetting    `json:"setting_list"`  // 要收集的用户信息配置
+	UpdateTime   int64                `json:"update_time"`   // 更新时间
+	OwnerSetting *PrivacyOwnerSetting `json:"owner_setting"` // 收集方信息配置
+	PrivacyDesc  *PrivacyDescInfo     `json:"privacy_desc"`  // 用户信息类型对应的中英文描述
+}
+
+// GetPrivacySetting 查询小程序用户隐私保护指引
+func GetPrivacySetting(ver PrivacyVer, result *ResultPrivacySetting) wx.Action {
+	return wx.NewPostAction(urls.MinipGetPrivacySetting,
+		wx.WithBody(func() ([]byte, error) {
+			return json.Marshal(map[string]PrivacyVer{
+				"privacy_ver": ver,
+			})
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// ParamsPrivacySettingSet 隐私设置参数
+type ParamsPrivacySettingSet struct {
+	PrivacyVer   PrivacyVer           `json:"privacy_ver,omitempty"` // 1表示现网版本，即，传1则该接口设置的隐私协议直接生效；2表示开发版（默认）
+	OwnerSetting *PrivacyOwnerSetting `json:"owner_setting"`         // 收集方信息配置
+	SettingList  []*PrivacySetting    `json:"setting_list"`          // 要收集的用户信息配置，可选择的用户信息类型参考 GetPrivacySetting 的 privacy_desc
+}
+
+// SetPrivacySetting 配置小程序用户隐私保护指引
+func SetPrivacySetting(params *ParamsPrivacySettingSet) wx.Action {
+	return wx.NewPostAction(urls.MinipSetPrivacySetting,
+		wx.WithBody(func() ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+	)
+}
+
+// ResultPrivacyExtFileUpload 隐私协议补充文件上传结果
+type ResultPrivacyExtFileUpload struct {
+	ExtFileMediaID string `json:"ext_file_media_id"` // 文件的media_id，用于 PrivacyOwnerSetting.ExtFileMediaID
+}
+
+// UploadPrivacyExtFile 上传小程序用户隐私保护指引的补充文件（仅支持txt格式，大小不超过100K）
+func UploadPrivacyExtFile(path string, result *ResultPrivacyExtFileUpload) wx.Action {
+	_, filename := filepath.Split(path)
+
+	return wx.NewPostAction(urls.MinipUploadPrivacyExtFile,
+		wx.WithUpload(func() (wx.UploadForm, error) {
+			path, err := filepath.Abs(filepath.Clean(path))
+
+			if err != nil {
+				return nil, err
+			}
+
+			return wx.NewUploadForm(
+				wx.WithFormFile("file", filename, func(w io.Writer) error {
+					f, err := os.Open(path)
+
+					if err != nil {
+						return err
+					}
+
+					defer f.Close()
+
+					if _, err = io.Copy(w, f); err != nil {
+						return err
+					}
+
+					return nil
+				}),
+				wx.WithFormFileContentType("file", "text/plain"),
+			), nil
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
@@ -0,0 +1,155 @@
+package minip
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/mock"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+func TestGetPrivacySetting(t *testing.T) {
+	body := []byte(`{"privacy_ver":2}`)
+	resp := []byte(`{
+	"errcode": 0,
+	"errmsg": "ok",
+	"code_exist": 1,
+	"privacy_list": ["UserInfo", "Location"],
+	"setting_list": [
+		{
+			"privacy_key": "UserInfo",
+			"privacy_text": "登录",
+			"privacy_label": "用户信息（微信昵称、头像）"
+		}
+	],
+	"update_time": 1645523442,
+	"owner_setting": {
+		"contact_phone": "",
+		"contact_email": "1@qq.com",
+		"contact_qq": "",
+		"contact_weixin": "",
+		"notice_method": "弹窗提示",
+		"ext_file_media_id": "",
+		"store_expire_timestamp": ""
+	},
+	"privacy_desc": {
+		"privacy_desc_list": [
+			{
+				"privacy_key": "UserInfo",
+				"privacy_desc": "用户信息（微信昵称、头像）"
+			},
+			{
+				"privacy_key": "Location",
+				"privacy_desc": "位置信息"
+			}
+		]
+	}
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/component/getprivacysetting?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	mp := New("APPID", "APPSECRET", WithMockClient(client))
+
+	result := new(ResultPrivacySetting)
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", GetPrivacySetting(PrivacyVerDev, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultPrivacySetting{
+		CodeExist:   1,
+		PrivacyList: []string{"UserInfo", "Location"},
+		SettingList: []*PrivacySetting{
+			{
+				PrivacyKey:   "UserInfo",
+				PrivacyText:  "登录",
+				PrivacyLabel: "用户信息（微信昵称、头像）",
+			},
+		},
+		UpdateTime: 1645523442,
+		OwnerSetting: &PrivacyOwnerSetting{
+			ContactEmail: "1@qq.com",
+			NoticeMethod: "弹窗提示",
+		},
+		PrivacyDesc: &PrivacyDescInfo{
+			PrivacyDescList: []*PrivacyDesc{
+				{
+					PrivacyKey:  "UserInfo",
+					PrivacyDesc: "用户信息（微信昵称、头像）",
+				},
+				{
+					PrivacyKey:  "Location",
+					PrivacyDesc: "位置信息",
+				},
+			},
+		},
+	}, result)
+}
+
+func TestSetPrivacySetting(t *testing.T) {
+	body := []byte(`{"privacy_ver":1,"owner_setting":{"contact_email":"1@qq.com","notice_method":"弹窗提示"},"setting_list":[{"privacy_key":"UserInfo","privacy_text":"登录"},{"privacy_key":"Location","privacy_text":"展示附近门店"}]}`)
+	resp := []byte(`{"errcode":0,"errmsg":"ok"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/component/setprivacysetting?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	mp := New("APPID", "APPSECRET", WithMockClient(client))
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", SetPrivacySetting(&ParamsPrivacySettingSet{
+		PrivacyVer: PrivacyVerOnline,
+		OwnerSetting: &PrivacyOwnerSetting{
+			ContactEmail: "1@qq.com",
+			NoticeMethod: "弹窗提示",
+		},
+		SettingList: []*PrivacySetting{
+			{
+				PrivacyKey:  "UserInfo",
+				PrivacyText: "登录",
+			},
+			{
+				PrivacyKey:  "Location",
+				PrivacyText: "展示附近门店",
+			},
+		},
+	}))
+
+	assert.Nil(t, err)
+}
+
+func TestUploadPrivacyExtFile(t *testing.T) {
+	resp := []byte(`{
+	"errcode": 0,
+	"errmsg": "ok",
+	"ext_file_media_id": "xxxxx"
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Upload(gomock.AssignableToTypeOf(context.TODO()), "https://api.weixin.qq.com/cgi-bin/component/uploadprivacyextfile?access_token=ACCESS_TOKEN", gomock.AssignableToTypeOf(wx.NewUploadForm())).Return(resp, nil)
+
+	mp := New("APPID", "APPSECRET", WithMockClient(client))
+
+	result := new(ResultPrivacyExtFileUpload)
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", UploadPrivacyExtFile("../mock/privacy.txt", result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultPrivacyExtFileUpload{
+		ExtFileMediaID: "xxxxx",
+	}, result)
+}
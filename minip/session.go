@@ -0,0 +1,109 @@
+package minip
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// SessionStore 用户 session_key 存储（如：数据库、Redis 等）
+type SessionStore interface {
+	// Get 获取用户的 session_key
+	Get(ctx context.Context, openid string) (string, error)
+
+	// Set 保存用户的 session_key
+	Set(ctx context.Context, openid, sessionKey string) error
+}
+
+type memSessionStore struct {
+	keys  map[string]string
+	mutex sync.RWMutex
+}
+
+func (s *memSessionStore) Get(ctx context.Context, openid string) (string, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	key, ok := s.keys[openid]
+
+	if !ok {
+		return "", errors.New("session key not found")
+	}
+
+	return key, nil
+}
+
+func (s *memSessionStore) Set(ctx context.Context, openid, sessionKey string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.keys[openid] = sessionKey
+
+	return nil
+}
+
+// NewMemSessionStore returns an in-memory session store (仅用于测试或单机，重启后丢失)
+func NewMemSessionStore() SessionStore {
+	return &memSessionStore{
+		keys: make(map[string]string),
+	}
+}
+
+// ErrSessionExpired 用户 session_key 已失效（87009），无法重置，需重新调用 wx.login 并通过 Code2Session 获取
+var ErrSessionExpired = errors.New("session_key expired, call wx.login and Code2Session again")
+
+// SessionKey 获取用户有效的 session_key（需通过 WithSessionStore 设置存储，Code2Session 时自动保存）；
+// 已保存的 session_key 校验失效时返回 ErrSessionExpired
+func (mp *Minip) SessionKey(ctx context.Context, accessToken, openid string, options ...wx.HTTPOption) (string, error) {
+	if mp.store == nil {
+		return "", errors.New("session store is nil (forgotten configure?)")
+	}
+
+	sessionKey, err := mp.store.Get(ctx, openid)
+
+	if err != nil {
+		return "", err
+	}
+
+	if err = mp.checkSessionKey(ctx, accessToken, openid, sessionKey, options...); err != nil {
+		return "", err
+	}
+
+	return sessionKey, nil
+}
+
+// RefreshSessionKey 重置用户的 session_key 并保存（需通过 WithSessionStore 设置存储）；
+// 重置请求须使用当前有效的 session_key 签名，故仅在已保存的 session_key 校验通过时重置，否则返回 ErrSessionExpired
+func (mp *Minip) RefreshSessionKey(ctx context.Context, accessToken, openid string, options ...wx.HTTPOption) (string, error) {
+	sessionKey, err := mp.SessionKey(ctx, accessToken, openid, options...)
+
+	if err != nil {
+		return "", err
+	}
+
+	result := new(ResultSessionKeyReset)
+
+	if err = mp.Do(ctx, accessToken, ResetUserSessionKey(openid, sessionKey, result), options...); err != nil {
+		return "", err
+	}
+
+	if err = mp.store.Set(ctx, openid, result.SessionKey); err != nil {
+		return "", err
+	}
+
+	return result.SessionKey, nil
+}
+
+func (mp *Minip) checkSessionKey(ctx context.Context, accessToken, openid, sessionKey string, options ...wx.HTTPOption) error {
+	err := mp.Do(ctx, accessToken, CheckSessionKey(openid, sessionKey), options...)
+
+	// 87009: 登录态签名无效（session_key 已失效）
+	if wx.IsAPIError(err, 87009) {
+		return fmt.Errorf("%w: %v", ErrSessionExpired, err)
+	}
+
+	return err
+}
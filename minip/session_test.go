@@ -0,0 +1,98 @@
+package minip
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/mock"
+)
+
+func TestSessionKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.weixin.qq.com/wxa/checksession?access_token=ACCESS_TOKEN&openid=OPENID&sig_method=hmac_sha256&signature=8de57d383996f9f82f51c6304717e359a463f5a5543c946dcaf5163f7e5aec5a", nil).Return([]byte(`{"errcode":0,"errmsg":"ok"}`), nil)
+
+	store := NewMemSessionStore()
+
+	assert.Nil(t, store.Set(context.TODO(), "OPENID", "SESSION_KEY"))
+
+	mp := New("APPID", "APPSECRET", WithMockClient(client), WithSessionStore(store))
+
+	sessionKey, err := mp.SessionKey(context.TODO(), "ACCESS_TOKEN", "OPENID")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "SESSION_KEY", sessionKey)
+
+	// 未保存
+	_, err = mp.SessionKey(context.TODO(), "ACCESS_TOKEN", "UNKNOWN_OPENID")
+
+	assert.NotNil(t, err)
+}
+
+func TestSessionKeyExpired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	// session_key 已失效，不会发起重置请求
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.weixin.qq.com/wxa/checksession?access_token=ACCESS_TOKEN&openid=OPENID&sig_method=hmac_sha256&signature=8de57d383996f9f82f51c6304717e359a463f5a5543c946dcaf5163f7e5aec5a", nil).Return([]byte(`{"errcode":87009,"errmsg":"invalid signature"}`), nil).Times(2)
+
+	store := NewMemSessionStore()
+
+	assert.Nil(t, store.Set(context.TODO(), "OPENID", "SESSION_KEY"))
+
+	mp := New("APPID", "APPSECRET", WithMockClient(client), WithSessionStore(store))
+
+	_, err := mp.SessionKey(context.TODO(), "ACCESS_TOKEN", "OPENID")
+
+	assert.True(t, errors.Is(err, ErrSessionExpired))
+
+	_, err = mp.RefreshSessionKey(context.TODO(), "ACCESS_TOKEN", "OPENID")
+
+	assert.True(t, errors.Is(err, ErrSessionExpired))
+}
+
+func TestRefreshSessionKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	gomock.InOrder(
+		client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.weixin.qq.com/wxa/checksession?access_token=ACCESS_TOKEN&openid=OPENID&sig_method=hmac_sha256&signature=8de57d383996f9f82f51c6304717e359a463f5a5543c946dcaf5163f7e5aec5a", nil).Return([]byte(`{"errcode":0,"errmsg":"ok"}`), nil),
+		client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.weixin.qq.com/wxa/resetusersessionkey?access_token=ACCESS_TOKEN&openid=OPENID&sig_method=hmac_sha256&signature=8de57d383996f9f82f51c6304717e359a463f5a5543c946dcaf5163f7e5aec5a", nil).Return([]byte(`{"errcode":0,"errmsg":"ok","openid":"OPENID","session_key":"NEW_SESSION_KEY"}`), nil),
+	)
+
+	store := NewMemSessionStore()
+
+	assert.Nil(t, store.Set(context.TODO(), "OPENID", "SESSION_KEY"))
+
+	mp := New("APPID", "APPSECRET", WithMockClient(client), WithSessionStore(store))
+
+	sessionKey, err := mp.RefreshSessionKey(context.TODO(), "ACCESS_TOKEN", "OPENID")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "NEW_SESSION_KEY", sessionKey)
+
+	sessionKey, err = store.Get(context.TODO(), "OPENID")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "NEW_SESSION_KEY", sessionKey)
+}
+
+func TestSessionKeyWithoutStore(t *testing.T) {
+	mp := New("APPID", "APPSECRET")
+
+	_, err := mp.SessionKey(context.TODO(), "ACCESS_TOKEN", "OPENID")
+
+	assert.NotNil(t, err)
+}
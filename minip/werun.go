@@ -0,0 +1,73 @@
+package minip
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// ErrWeRunExpired 微信运动数据的水印时间超出允许范围（可能为重放的历史数据）
+var ErrWeRunExpired = errors.New("werun data expired")
+
+// WeRunStep 单日步数
+type WeRunStep struct {
+	Timestamp int64 `json:"timestamp"` // 时间戳，表示数据对应的时间（当天零点）
+	Step      int   `json:"step"`      // 微信运动步数
+}
+
+// WeRunData 微信运动数据（wx.getWeRunData 解密后）
+type WeRunData struct {
+	StepInfoList []*WeRunStep `json:"stepInfoList"` // 用户过去三十一天的微信运动步数
+	Watermark    Watermark    `json:"watermark"`    // 数据水印
+}
+
+// TodayStep 返回最近一天的步数
+func (d *WeRunData) TodayStep() int {
+	var (
+		latest int64
+		step   int
+	)
+
+	for _, v := range d.StepInfoList {
+		if v.Timestamp >= latest {
+			latest = v.Timestamp
+			step = v.Step
+		}
+	}
+
+	return step
+}
+
+// DecryptWeRunData 解密微信运动数据，并校验水印时间在 window 内（防止重放历史提交的加密数据）；
+// window <= 0 时不校验时间
+func (mp *Minip) DecryptWeRunData(sessionKey, iv, encryptedData string, window time.Duration) (*WeRunData, error) {
+	b, err := decryptData(sessionKey, iv, encryptedData)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(WeRunData)
+
+	if err = json.Unmarshal(b, result); err != nil {
+		return nil, err
+	}
+
+	if mp.appidchk {
+		if err = wx.CheckAppID(result.Watermark.AppID, mp.appid); err != nil {
+			return nil, err
+		}
+	}
+
+	if window > 0 {
+		// 水印时间不应早于 window，也不应晚于当前时间 window（容忍服务器时钟偏差）
+		if d := time.Since(time.Unix(result.Watermark.Timestamp, 0)); d > window || d < -window {
+			return nil, fmt.Errorf("%w, watermark timestamp: %d", ErrWeRunExpired, result.Watermark.Timestamp)
+		}
+	}
+
+	return result, nil
+}
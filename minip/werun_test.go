@@ -0,0 +1,66 @@
+package minip
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/wx"
+)
+
+func TestDecryptWeRunData(t *testing.T) {
+	key := []byte("1234567890abcdef")
+	iv := []byte("abcdef1234567890")
+
+	encrypt := func(plain string) string {
+		b, err := wx.NewCBCCrypto(key, iv, wx.AES_PKCS7).Encrypt([]byte(plain))
+
+		assert.Nil(t, err)
+
+		return base64.StdEncoding.EncodeToString(b)
+	}
+
+	sessionKey := base64.StdEncoding.EncodeToString(key)
+	ivStr := base64.StdEncoding.EncodeToString(iv)
+
+	now := time.Now().Unix()
+	data := encrypt(fmt.Sprintf(`{"stepInfoList":[{"timestamp":1445866601,"step":100},{"timestamp":1445876601,"step":120}],"watermark":{"timestamp":%d,"appid":"APPID"}}`, now))
+
+	mp := New("APPID", "APPSECRET", WithAppIDCheck())
+
+	result, err := mp.DecryptWeRunData(sessionKey, ivStr, data, 5*time.Minute)
+
+	assert.Nil(t, err)
+	assert.Equal(t, &WeRunData{
+		StepInfoList: []*WeRunStep{
+			{Timestamp: 1445866601, Step: 100},
+			{Timestamp: 1445876601, Step: 120},
+		},
+		Watermark: Watermark{
+			Timestamp: now,
+			AppID:     "APPID",
+		},
+	}, result)
+	assert.Equal(t, 120, result.TodayStep())
+
+	// 重放的历史数据
+	expired := encrypt(`{"stepInfoList":[{"timestamp":1445866601,"step":100}],"watermark":{"timestamp":1477314187,"appid":"APPID"}}`)
+
+	_, err = mp.DecryptWeRunData(sessionKey, ivStr, expired, 5*time.Minute)
+
+	assert.True(t, errors.Is(err, ErrWeRunExpired))
+
+	// 不校验时间
+	_, err = mp.DecryptWeRunData(sessionKey, ivStr, expired, 0)
+
+	assert.Nil(t, err)
+
+	// appid 不一致
+	_, err = New("OTHER_APPID", "APPSECRET", WithAppIDCheck()).DecryptWeRunData(sessionKey, ivStr, data, 5*time.Minute)
+
+	assert.True(t, errors.Is(err, wx.ErrAppIDMismatch))
+}
@@ -0,0 +1,163 @@
+package offia
+
+import (
+	"encoding/json"
+
+	"github.com/shenghui0779/gochat/urls"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// CommentType 评论类型
+type CommentType int
+
+// 微信支持的评论类型
+const (
+	CommentAll     CommentType = 0 // 普通评论&精选评论
+	CommentNormal  CommentType = 1 // 普通评论
+	CommentElected CommentType = 2 // 精选评论
+)
+
+// MaxCommentListCount 查看留言每次的最大数目（>=50会被拒绝）
+const MaxCommentListCount = 49
+
+// ParamsCommentOpen 打开/关闭已群发文章评论参数
+type ParamsCommentOpen struct {
+	MsgDataID int64 `json:"msg_data_id"` // 群发返回的msg_data_id
+	Index     int   `json:"index"`       // 多图文时，用来指定第几篇图文，从0开始，不带默认操作该msg_data_id的第一篇图文
+}
+
+// OpenComment 留言管理 - 打开已群发文章评论
+func OpenComment(msgDataID int64, index int) wx.Action {
+	params := &ParamsCommentOpen{
+		MsgDataID: msgDataID,
+		Index:     index,
+	}
+
+	return wx.NewPostAction(urls.OffiaCommentOpen,
+		wx.WithBody(func() ([]byte, error) {
+			return json.Marshal(params)
+		}),
+	)
+}
+
+// CloseComment 留言管理 - 关闭已群发文章评论
+func CloseComment(msgDataID int64, index int) wx.Action {
+	params := &ParamsCommentOpen{
+		MsgDataID: msgDataID,
+		Index:     index,
+	}
+
+	return wx.NewPostAction(urls.OffiaCommentClose,
+		wx.WithBody(func() ([]byte, error) {
+			return json.Marshal(params)
+		}),
+	)
+}
+
+// ParamsCommentList 查看指定文章的评论数据参数
+type ParamsCommentList struct {
+	MsgDataID int64       `json:"msg_data_id"` // 群发返回的msg_data_id
+	Index     int         `json:"index"`       // 多图文时，用来指定第几篇图文，从0开始
+	Begin     int         `json:"begin"`       // 起始位置
+	Count     int         `json:"count"`       // 获取数目（最大为 MaxCommentListCount）
+	Type      CommentType `json:"type"`        // 评论类型
+}
+
+// CommentReply 作者回复
+type CommentReply struct {
+	Content    string `json:"content"`     // 作者回复内容
+	CreateTime int64  `json:"create_time"` // 作者回复时间
+}
+
+// Comment 评论
+type Comment struct {
+	UserCommentID int64         `json:"user_comment_id"` // 用户评论id
+	OpenID        string        `json:"openid"`          // 用户openid
+	CreateTime    int64         `json:"create_time"`     // 评论时间
+	Content       string        `json:"content"`         // 评论内容
+	CommentType   int           `json:"comment_type"`    // 是否精选评论，0：否，1：是
+	Reply         *CommentReply `json:"reply,omitempty"` // 作者回复
+}
+
+// ResultCommentList 评论数据
+type ResultCommentList struct {
+	Total   int        `json:"total"`
+	Comment []*Comment `json:"comment"`
+}
+
+// ListComment 留言管理 - 查看指定文章的评论数据
+func ListComment(params *ParamsCommentList, result *ResultCommentList) wx.Action {
+	return wx.NewPostAction(urls.OffiaCommentList,
+		wx.WithBody(func() ([]byte, error) {
+			return json.Marshal(params)
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// ParamsCommentManage 评论管理参数
+type ParamsCommentManage struct {
+	MsgDataID     int64 `json:"msg_data_id"`     // 群发返回的msg_data_id
+	Index         int   `json:"index"`           // 多图文时，用来指定第几篇图文，从0开始
+	UserCommentID int64 `json:"user_comment_id"` // 用户评论id
+}
+
+// MarkElectComment 留言管理 - 将评论标记精选
+func MarkElectComment(msgDataID int64, index int, userCommentID int64) wx.Action {
+	return commentManage(urls.OffiaCommentMarkElect, msgDataID, index, userCommentID)
+}
+
+// UnmarkElectComment 留言管理 - 将评论取消精选
+func UnmarkElectComment(msgDataID int64, index int, userCommentID int64) wx.Action {
+	return commentManage(urls.OffiaCommentUnmarkElect, msgDataID, index, userCommentID)
+}
+
+// DeleteComment 留言管理 - 删除评论
+func DeleteComment(msgDataID int64, index int, userCommentID int64) wx.Action {
+	return commentManage(urls.OffiaCommentDelete, msgDataID, index, userCommentID)
+}
+
+// DeleteCommentReply 留言管理 - 删除回复
+func DeleteCommentReply(msgDataID int64, index int, userCommentID int64) wx.Action {
+	return commentManage(urls.OffiaCommentReplyDelete, msgDataID, index, userCommentID)
+}
+
+func commentManage(reqURL string, msgDataID int64, index int, userCommentID int64) wx.Action {
+	params := &ParamsCommentManage{
+		MsgDataID:     msgDataID,
+		Index:         index,
+		UserCommentID: userCommentID,
+	}
+
+	return wx.NewPostAction(reqURL,
+		wx.WithBody(func() ([]byte, error) {
+			return json.Marshal(params)
+		}),
+	)
+}
+
+// ParamsCommentReply 回复评论参数
+type ParamsCommentReply struct {
+	MsgDataID     int64  `json:"msg_data_id"`     // 群发返回的msg_data_id
+	Index         int    `json:"index"`           // 多图文时，用来指定第几篇图文，从0开始
+	UserCommentID int64  `json:"user_comment_id"` // 用户评论id
+	Content       string `json:"content"`         // 回复内容
+}
+
+// ReplyComment 留言管理 - 回复评论
+func ReplyComment(msgDataID int64, index int, userCommentID int64, content string) wx.Action {
+	params := &ParamsCommentReply{
+		MsgDataID:     msgDataID,
+		Index:         index,
+		UserCommentID: userCommentID,
+		Content:       content,
+	}
+
+	return wx.NewPostAction(urls.OffiaCommentReplyAdd,
+		wx.WithBody(func() ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+	)
+}
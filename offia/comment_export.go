@@ -0,0 +1,151 @@
+package offia
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// CommentExportFormat 留言导出格式
+type CommentExportFormat int
+
+// 支持的留言导出格式
+const (
+	CommentExportCSV  CommentExportFormat = iota // CSV（首行为列名）
+	CommentExportJSON                            // JSON数组，元素为 Comment
+)
+
+// 留言导出的 CSV 列名
+var commentCSVHeader = []string{"user_comment_id", "openid", "create_time", "content", "comment_type", "reply_content", "reply_create_time"}
+
+// ExportComments 留言管理 - 导出指定文章的全部评论（按页自动拉取），以 CSV 或 JSON 格式写入 w，返回导出的评论数
+func (oa *Offia) ExportComments(ctx context.Context, accessToken string, msgDataID int64, index int, format CommentExportFormat, w io.Writer, options ...wx.HTTPOption) (int, error) {
+	var enc commentEncoder
+
+	switch format {
+	case CommentExportCSV:
+		enc = &commentCSVEncoder{w: csv.NewWriter(w)}
+	case CommentExportJSON:
+		enc = &commentJSONEncoder{w: w}
+	default:
+		return 0, fmt.Errorf("unsupported comment export format: %d", format)
+	}
+
+	if err := enc.begin(); err != nil {
+		return 0, err
+	}
+
+	count := 0
+
+	for {
+		result := new(ResultCommentList)
+
+		params := &ParamsCommentList{
+			MsgDataID: msgDataID,
+			Index:     index,
+			Begin:     count,
+			Count:     MaxCommentListCount,
+			Type:      CommentAll,
+		}
+
+		if err := oa.Do(ctx, accessToken, ListComment(params, result), options...); err != nil {
+			return count, err
+		}
+
+		for _, v := range result.Comment {
+			if err := enc.encode(v); err != nil {
+				return count, err
+			}
+
+			count++
+		}
+
+		if len(result.Comment) == 0 || count >= result.Total {
+			break
+		}
+	}
+
+	return count, enc.end()
+}
+
+type commentEncoder interface {
+	begin() error
+	encode(c *Comment) error
+	end() error
+}
+
+type commentCSVEncoder struct {
+	w *csv.Writer
+}
+
+func (e *commentCSVEncoder) begin() error {
+	return e.w.Write(commentCSVHeader)
+}
+
+func (e *commentCSVEncoder) encode(c *Comment) error {
+	record := []string{
+		strconv.FormatInt(c.UserCommentID, 10),
+		c.OpenID,
+		strconv.FormatInt(c.CreateTime, 10),
+		c.Content,
+		strconv.Itoa(c.CommentType),
+		"",
+		"",
+	}
+
+	if c.Reply != nil {
+		record[5] = c.Reply.Content
+		record[6] = strconv.FormatInt(c.Reply.CreateTime, 10)
+	}
+
+	return e.w.Write(record)
+}
+
+func (e *commentCSVEncoder) end() error {
+	e.w.Flush()
+
+	return e.w.Error()
+}
+
+type commentJSONEncoder struct {
+	w     io.Writer
+	count int
+}
+
+func (e *commentJSONEncoder) begin() error {
+	_, err := io.WriteString(e.w, "[")
+
+	return err
+}
+
+func (e *commentJSONEncoder) encode(c *Comment) error {
+	b, err := wx.MarshalNoEscapeHTML(c)
+
+	if err != nil {
+		return err
+	}
+
+	if e.count != 0 {
+		if _, err = io.WriteString(e.w, ","); err != nil {
+			return err
+		}
+	}
+
+	if _, err = e.w.Write(b); err != nil {
+		return err
+	}
+
+	e.count++
+
+	return nil
+}
+
+func (e *commentJSONEncoder) end() error {
+	_, err := io.WriteString(e.w, "]")
+
+	return err
+}
@@ -0,0 +1,88 @@
+package offia
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/mock"
+)
+
+func mockCommentPages(client *mock.MockHTTPClient) {
+	listURL := "https://api.weixin.qq.com/cgi-bin/comment/list?access_token=ACCESS_TOKEN"
+
+	gomock.InOrder(
+		client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, listURL, []byte(`{"msg_data_id":2247483663,"index":1,"begin":0,"count":49,"type":0}`)).Return([]byte(`{"errcode":0,"errmsg":"ok","total":3,"comment":[{"user_comment_id":1,"openid":"OPENID1","create_time":1613812800,"content":"写得好，\"赞\"","comment_type":1,"reply":{"content":"谢谢","create_time":1613816400}},{"user_comment_id":2,"openid":"OPENID2","create_time":1613813400,"content":"<b>学习了</b>","comment_type":0}]}`), nil),
+		client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, listURL, []byte(`{"msg_data_id":2247483663,"index":1,"begin":2,"count":49,"type":0}`)).Return([]byte(`{"errcode":0,"errmsg":"ok","total":3,"comment":[{"user_comment_id":3,"openid":"OPENID3","create_time":1613814000,"content":"第一行\n第二行","comment_type":0}]}`), nil),
+	)
+}
+
+func TestExportCommentsCSV(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	mockCommentPages(client)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	var buf bytes.Buffer
+
+	n, err := oa.ExportComments(context.TODO(), "ACCESS_TOKEN", 2247483663, 1, CommentExportCSV, &buf)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, `user_comment_id,openid,create_time,content,comment_type,reply_content,reply_create_time
+1,OPENID1,1613812800,"写得好，""赞""",1,谢谢,1613816400
+2,OPENID2,1613813400,<b>学习了</b>,0,,
+3,OPENID3,1613814000,"第一行
+第二行",0,,
+`, buf.String())
+}
+
+func TestExportCommentsJSON(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	mockCommentPages(client)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	var buf bytes.Buffer
+
+	n, err := oa.ExportComments(context.TODO(), "ACCESS_TOKEN", 2247483663, 1, CommentExportJSON, &buf)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, `[{"user_comment_id":1,"openid":"OPENID1","create_time":1613812800,"content":"写得好，\"赞\"","comment_type":1,"reply":{"content":"谢谢","create_time":1613816400}},{"user_comment_id":2,"openid":"OPENID2","create_time":1613813400,"content":"<b>学习了</b>","comment_type":0},{"user_comment_id":3,"openid":"OPENID3","create_time":1613814000,"content":"第一行\n第二行","comment_type":0}]`, buf.String())
+}
+
+func TestExportCommentsEmpty(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/comment/list?access_token=ACCESS_TOKEN", gomock.Any()).Return([]byte(`{"errcode":0,"errmsg":"ok","total":0,"comment":[]}`), nil)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	var buf bytes.Buffer
+
+	n, err := oa.ExportComments(context.TODO(), "ACCESS_TOKEN", 2247483663, 0, CommentExportJSON, &buf)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 0, n)
+	assert.Equal(t, "[]", buf.String())
+
+	_, err = oa.ExportComments(context.TODO(), "ACCESS_TOKEN", 2247483663, 0, CommentExportFormat(9), &buf)
+
+	assert.NotNil(t, err)
+}
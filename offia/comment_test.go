@@ -0,0 +1,210 @@
+package offia
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/mock"
+)
+
+func TestOpenComment(t *testing.T) {
+	body := []byte(`{"msg_data_id":2247483663,"index":1}`)
+	resp := []byte(`{"errcode":0,"errmsg":"ok"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/comment/open?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	err := oa.Do(context.TODO(), "ACCESS_TOKEN", OpenComment(2247483663, 1))
+
+	assert.Nil(t, err)
+}
+
+func TestCloseComment(t *testing.T) {
+	body := []byte(`{"msg_data_id":2247483663,"index":1}`)
+	resp := []byte(`{"errcode":0,"errmsg":"ok"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/comment/close?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	err := oa.Do(context.TODO(), "ACCESS_TOKEN", CloseComment(2247483663, 1))
+
+	assert.Nil(t, err)
+}
+
+func TestListComment(t *testing.T) {
+	body := []byte(`{"msg_data_id":2247483663,"index":0,"begin":0,"count":49,"type":0}`)
+	resp := []byte(`{
+	"errcode": 0,
+	"errmsg": "ok",
+	"total": 2,
+	"comment": [
+		{
+			"user_comment_id": 1,
+			"openid": "OPENID1",
+			"create_time": 1613812800,
+			"content": "写得好",
+			"comment_type": 1,
+			"reply": {
+				"content": "谢谢",
+				"create_time": 1613816400
+			}
+		},
+		{
+			"user_comment_id": 2,
+			"openid": "OPENID2",
+			"create_time": 1613813400,
+			"content": "<b>学习了</b>",
+			"comment_type": 0
+		}
+	]
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/comment/list?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	result := new(ResultCommentList)
+
+	err := oa.Do(context.TODO(), "ACCESS_TOKEN", ListComment(&ParamsCommentList{
+		MsgDataID: 2247483663,
+		Index:     0,
+		Begin:     0,
+		Count:     MaxCommentListCount,
+		Type:      CommentAll,
+	}, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultCommentList{
+		Total: 2,
+		Comment: []*Comment{
+			{
+				UserCommentID: 1,
+				OpenID:        "OPENID1",
+				CreateTime:    1613812800,
+				Content:       "写得好",
+				CommentType:   1,
+				Reply: &CommentReply{
+					Content:    "谢谢",
+					CreateTime: 1613816400,
+				},
+			},
+			{
+				UserCommentID: 2,
+				OpenID:        "OPENID2",
+				CreateTime:    1613813400,
+				Content:       "<b>学习了</b>",
+			},
+		},
+	}, result)
+}
+
+func TestMarkElectComment(t *testing.T) {
+	body := []byte(`{"msg_data_id":2247483663,"index":0,"user_comment_id":1}`)
+	resp := []byte(`{"errcode":0,"errmsg":"ok"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/comment/markelect?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	err := oa.Do(context.TODO(), "ACCESS_TOKEN", MarkElectComment(2247483663, 0, 1))
+
+	assert.Nil(t, err)
+}
+
+func TestUnmarkElectComment(t *testing.T) {
+	body := []byte(`{"msg_data_id":2247483663,"index":0,"user_comment_id":1}`)
+	resp := []byte(`{"errcode":0,"errmsg":"ok"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/comment/unmarkelect?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	err := oa.Do(context.TODO(), "ACCESS_TOKEN", UnmarkElectComment(2247483663, 0, 1))
+
+	assert.Nil(t, err)
+}
+
+func TestDeleteComment(t *testing.T) {
+	body := []byte(`{"msg_data_id":2247483663,"index":0,"user_comment_id":1}`)
+	resp := []byte(`{"errcode":0,"errmsg":"ok"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/comment/delete?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	err := oa.Do(context.TODO(), "ACCESS_TOKEN", DeleteComment(2247483663, 0, 1))
+
+	assert.Nil(t, err)
+}
+
+func TestReplyComment(t *testing.T) {
+	body := []byte(`{"msg_data_id":2247483663,"index":0,"user_comment_id":1,"content":"<感谢支持>"}`)
+	resp := []byte(`{"errcode":0,"errmsg":"ok"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/comment/reply/add?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	err := oa.Do(context.TODO(), "ACCESS_TOKEN", ReplyComment(2247483663, 0, 1, "<感谢支持>"))
+
+	assert.Nil(t, err)
+}
+
+func TestDeleteCommentReply(t *testing.T) {
+	body := []byte(`{"msg_data_id":2247483663,"index":0,"user_comment_id":1}`)
+	resp := []byte(`{"errcode":0,"errmsg":"ok"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/comment/reply/delete?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	err := oa.Do(context.TODO(), "ACCESS_TOKEN", DeleteCommentReply(2247483663, 0, 1))
+
+	assert.Nil(t, err)
+}
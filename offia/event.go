@@ -0,0 +1,5 @@
+package offia
+
+// 消息与事件推送的结构体及 ParseMessage 由 events.json 生成（event_gen.go），新增消息或字段请修改 events.json 后执行 go generate
+
+//go:generate go run ../internal/eventgen -schema events.json -package offia -output event_gen.go
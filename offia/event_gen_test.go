@@ -0,0 +1,86 @@
+package offia
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/event"
+)
+
+func TestParseMessage(t *testing.T) {
+	msg, err := ParseMessage([]byte(`<xml><ToUserName><![CDATA[gh_3ad31c0ba9b5]]></ToUserName><FromUserName><![CDATA[OPENID]]></FromUserName><CreateTime>1606902602</CreateTime><MsgType><![CDATA[text]]></MsgType><Content><![CDATA[ILoveGochat]]></Content><MsgId>10086</MsgId></xml>`))
+
+	assert.Nil(t, err)
+
+	text, ok := msg.(*TextMessage)
+
+	assert.True(t, ok)
+	assert.Equal(t, "OPENID", text.FromUserName)
+	assert.Equal(t, int64(10086), text.MsgID)
+	assert.Equal(t, "ILoveGochat", text.Content)
+
+	msg, err = ParseMessage([]byte(`<xml><ToUserName><![CDATA[gh_3ad31c0ba9b5]]></ToUserName><FromUserName><![CDATA[OPENID]]></FromUserName><CreateTime>1606902602</CreateTime><MsgType><![CDATA[shortvideo]]></MsgType><MediaId><![CDATA[MEDIA_ID]]></MediaId><ThumbMediaId><![CDATA[THUMB_MEDIA_ID]]></ThumbMediaId><MsgId>10086</MsgId></xml>`))
+
+	assert.Nil(t, err)
+
+	video, ok := msg.(*VideoMessage)
+
+	assert.True(t, ok)
+	assert.Equal(t, "THUMB_MEDIA_ID", video.ThumbMediaID)
+
+	msg, err = ParseMessage([]byte(`<xml><ToUserName><![CDATA[gh_3ad31c0ba9b5]]></ToUserName><FromUserName><![CDATA[OPENID]]></FromUserName><CreateTime>1357290913</CreateTime><MsgType><![CDATA[voice]]></MsgType><MediaId><![CDATA[MEDIA_ID]]></MediaId><Format><![CDATA[amr]]></Format><Recognition><![CDATA[腾讯微信团队]]></Recognition><MsgId>10086</MsgId></xml>`))
+
+	assert.Nil(t, err)
+
+	voice, ok := msg.(*VoiceMessage)
+
+	assert.True(t, ok)
+	assert.Equal(t, "MEDIA_ID", voice.MediaID)
+	assert.Equal(t, "amr", voice.Format)
+	assert.Equal(t, "腾讯微信团队", voice.Recognition)
+
+	msg, err = ParseMessage([]byte(`<xml><ToUserName><![CDATA[gh_3ad31c0ba9b5]]></ToUserName><FromUserName><![CDATA[OPENID]]></FromUserName><CreateTime>123456789</CreateTime><MsgType><![CDATA[event]]></MsgType><Event><![CDATA[SCAN]]></Event><EventKey><![CDATA[SCENE_VALUE]]></EventKey><Ticket><![CDATA[TICKET]]></Ticket></xml>`))
+
+	assert.Nil(t, err)
+
+	scan, ok := msg.(*ScanEvent)
+
+	assert.True(t, ok)
+	assert.Equal(t, event.EventScan, scan.EventType())
+	assert.Equal(t, "SCENE_VALUE", scan.EventKey)
+	assert.Equal(t, "TICKET", scan.Ticket)
+
+	msg, err = ParseMessage([]byte(`<xml><ToUserName><![CDATA[gh_e136c6e50636]]></ToUserName><FromUserName><![CDATA[oMgHVjngRipVsoxg6TuX3vz6glDg]]></FromUserName><CreateTime>1408090816</CreateTime><MsgType><![CDATA[event]]></MsgType><Event><![CDATA[pic_weixin]]></Event><EventKey><![CDATA[6]]></EventKey><SendPicsInfo><Count>2</Count><PicList><item><PicMd5Sum><![CDATA[5a75aaca956d97be686719218f275c6b]]></PicMd5Sum></item><item><PicMd5Sum><![CDATA[1b5f7c23b5bf75682a53e7b6d163e185]]></PicMd5Sum></item></PicList></SendPicsInfo></xml>`))
+
+	assert.Nil(t, err)
+
+	pic, ok := msg.(*PicEvent)
+
+	assert.True(t, ok)
+	assert.Equal(t, 2, pic.Count)
+	assert.Equal(t, []string{"5a75aaca956d97be686719218f275c6b", "1b5f7c23b5bf75682a53e7b6d163e185"}, pic.PicMd5Sum)
+
+	msg, err = ParseMessage([]byte(`<xml><ToUserName><![CDATA[gh_123456789abc]]></ToUserName><FromUserName><![CDATA[otFpruAK8D-E6EfStSYonYSBZ8_4]]></FromUserName><CreateTime>1610969440</CreateTime><MsgType><![CDATA[event]]></MsgType><Event><![CDATA[subscribe_msg_popup_event]]></Event><SubscribeMsgPopupEvent><List><TemplateId><![CDATA[VRR0UEO9VJOLs0MHlU0OilqX6MVFDwH3_3gz3Oc0NIc]]></TemplateId><SubscribeStatusString><![CDATA[accept]]></SubscribeStatusString><PopupScene>2</PopupScene></List><List><TemplateId><![CDATA[9nLIlbOQZC5Y89AZteFEux3WCXRRRG5Wfzkpssu4bLI]]></TemplateId><SubscribeStatusString><![CDATA[reject]]></SubscribeStatusString><PopupScene>2</PopupScene></List></SubscribeMsgPopupEvent></xml>`))
+
+	assert.Nil(t, err)
+
+	popup, ok := msg.(*SubscribeMsgPopupEvent)
+
+	assert.True(t, ok)
+	assert.Equal(t, []*SubscribeMsgPopupItem{
+		{TemplateID: "VRR0UEO9VJOLs0MHlU0OilqX6MVFDwH3_3gz3Oc0NIc", SubscribeStatusString: "accept", PopupScene: "2"},
+		{TemplateID: "9nLIlbOQZC5Y89AZteFEux3WCXRRRG5Wfzkpssu4bLI", SubscribeStatusString: "reject", PopupScene: "2"},
+	}, popup.List)
+
+	// 未定义的事件
+	msg, err = ParseMessage([]byte(`<xml><ToUserName><![CDATA[gh_3ad31c0ba9b5]]></ToUserName><FromUserName><![CDATA[OPENID]]></FromUserName><CreateTime>123456789</CreateTime><MsgType><![CDATA[event]]></MsgType><Event><![CDATA[unknown_event]]></Event></xml>`))
+
+	assert.Nil(t, err)
+
+	unknown, ok := msg.(*UnknownMessage)
+
+	assert.True(t, ok)
+	assert.Equal(t, event.EventType("unknown_event"), unknown.EventType())
+	assert.NotEmpty(t, unknown.Raw)
+}
@@ -0,0 +1,331 @@
+package offia
+
+import (
+	"encoding/json"
+
+	"github.com/shenghui0779/gochat/urls"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// InvoiceCustomField 授权页自定义字段
+type InvoiceCustomField struct {
+	Key       string `json:"key"`
+	IsRequire int    `json:"is_require,omitempty"`
+	Notice    string `json:"notice,omitempty"`
+}
+
+// InvoiceUserField 授权页个人抬头字段
+type InvoiceUserField struct {
+	ShowTitle    int                   `json:"show_title,omitempty"`
+	ShowPhone    int                   `json:"show_phone,omitempty"`
+	ShowEmail    int                   `json:"show_email,omitempty"`
+	RequirePhone int                   `json:"require_phone,omitempty"`
+	RequireEmail int                   `json:"require_email,omitempty"`
+	CustomField  []*InvoiceCustomField `json:"custom_field,omitempty"`
+}
+
+// InvoiceBizField 授权页单位抬头字段
+type InvoiceBizField struct {
+	ShowTitle       int                   `json:"show_title,omitempty"`
+	ShowTaxNO       int                   `json:"show_tax_no,omitempty"`
+	ShowAddr        int                   `json:"show_addr,omitempty"`
+	ShowPhone       int                   `json:"show_phone,omitempty"`
+	ShowBankType    int                   `json:"show_bank_type,omitempty"`
+	ShowBankNO      int                   `json:"show_bank_no,omitempty"`
+	RequireTaxNO    int                   `json:"require_tax_no,omitempty"`
+	RequireAddr     int                   `json:"require_addr,omitempty"`
+	RequirePhone    int                   `json:"require_phone,omitempty"`
+	RequireBankType int                   `json:"require_bank_type,omitempty"`
+	RequireBankNO   int                   `json:"require_bank_no,omitempty"`
+	CustomField     []*InvoiceCustomField `json:"custom_field,omitempty"`
+}
+
+type ParamsInvoiceAuthField struct {
+	AuthField *InvoiceAuthField `json:"auth_field"`
+}
+
+// InvoiceAuthField 授权页抬头字段
+type InvoiceAuthField struct {
+	UserField *InvoiceUserField `json:"user_field"`
+	BizField  *InvoiceBizField  `json:"biz_field"`
+}
+
+// SetInvoiceAuthField 电子发票 - 设置授权页抬头字段
+// [参考](https://developers.weixin.qq.com/doc/offiaccount/WeChat_Invoice/E_Invoice/Vendor_API_List.html)
+func SetInvoiceAuthField(userField *InvoiceUserField, bizField *InvoiceBizField) wx.Action {
+	params := &ParamsInvoiceAuthField{
+		AuthField: &InvoiceAuthField{
+			UserField: userField,
+			BizField:  bizField,
+		},
+	}
+
+	return wx.NewPostAction(urls.OffiaInvoiceSetBizAttr,
+		wx.WithQuery("action", "set_auth_field"),
+		wx.WithBody(func() ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+	)
+}
+
+type ResultInvoiceAuthField struct {
+	UserField *InvoiceUserField `json:"user_field"`
+	BizField  *InvoiceBizField  `json:"biz_field"`
+}
+
+// GetInvoiceAuthField 电子发票 - 查询授权页抬头字段
+func GetInvoiceAuthField(result *ResultInvoiceAuthField) wx.Action {
+	return wx.NewPostAction(urls.OffiaInvoiceSetBizAttr,
+		wx.WithQuery("action", "get_auth_field"),
+		wx.WithBody(func() ([]byte, error) {
+			return []byte("{}"), nil
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// InvoiceAuthType 授权类型
+type InvoiceAuthType int
+
+// 微信支持的授权类型
+const (
+	InvoiceAuthApply   InvoiceAuthType = 0 // 开票授权
+	InvoiceAuthFill    InvoiceAuthType = 1 // 填写字段开票授权
+	InvoiceAuthReceipt InvoiceAuthType = 2 // 领票授权
+)
+
+type ParamsInvoiceAuthURL struct {
+	SPAppID     string          `json:"s_pappid"`               // 开票平台在微信的标识号
+	OrderID     string          `json:"order_id"`               // 订单id，在商户内单笔开票请求的唯一识别号
+	Money       int             `json:"money"`                  // 订单金额（分）
+	Timestamp   int64           `json:"timestamp"`              // 时间戳
+	Source      string          `json:"source"`                 // 开票来源：app、web、wxa
+	RedirectURL string          `json:"redirect_url,omitempty"` // 授权成功后跳转页面（source 为 web 时有效）
+	Ticket      string          `json:"ticket"`                 // wx_card 类型的 api_ticket
+	Type        InvoiceAuthType `json:"type"`                   // 授权类型
+}
+
+type ResultInvoiceAuthURL struct {
+	AuthURL string `json:"auth_url"`
+	AppID   string `json:"appid"` // source 为 wxa 时返回，授权页所在小程序的 appid
+}
+
+// GetInvoiceAuthURL 电子发票 - 获取授权页链接（ticket 通过 GetApiTicket 获取 wx_card 类型）
+func GetInvoiceAuthURL(params *ParamsInvoiceAuthURL, result *ResultInvoiceAuthURL) wx.Action {
+	return wx.NewPostAction(urls.OffiaInvoiceGetAuthURL,
+		wx.WithBody(func() ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+type ParamsInvoiceAuthData struct {
+	OrderID string `json:"order_id"`
+	SAppID  string `json:"s_appid"`
+}
+
+// InvoiceCustomValue 自定义字段值
+type InvoiceCustomValue struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type InvoiceUserAuthInfo struct {
+	UserField *InvoiceUserTitle `json:"user_field"`
+	BizField  *InvoiceBizTitle  `json:"biz_field"`
+}
+
+// InvoiceUserTitle 用户填写的个人抬头
+type InvoiceUserTitle struct {
+	Title       string                `json:"title"`
+	Phone       string                `json:"phone"`
+	Email       string                `json:"email"`
+	CustomField []*InvoiceCustomValue `json:"custom_field"`
+}
+
+// InvoiceBizTitle 用户填写的单位抬头
+type InvoiceBizTitle struct {
+	Title       string                `json:"title"`
+	TaxNO       string                `json:"tax_no"`
+	Addr        string                `json:"addr"`
+	Phone       string                `json:"phone"`
+	BankType    string                `json:"bank_type"`
+	BankNO      string                `json:"bank_no"`
+	CustomField []*InvoiceCustomValue `json:"custom_field"`
+}
+
+type ResultInvoiceAuthData struct {
+	InvoiceStatus string               `json:"invoice_status"` // 订单授权状态，auth success 表示授权成功
+	AuthTime      int64                `json:"auth_time"`
+	UserAuthInfo  *InvoiceUserAuthInfo `json:"user_auth_info"`
+}
+
+// GetInvoiceAuthData 电子发票 - 查询授权完成状态
+func GetInvoiceAuthData(orderID, sAppID string, result *ResultInvoiceAuthData) wx.Action {
+	params := &ParamsInvoiceAuthData{
+		OrderID: orderID,
+		SAppID:  sAppID,
+	}
+
+	return wx.NewPostAction(urls.OffiaInvoiceGetAuthData,
+		wx.WithBody(func() ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// InvoiceProductInfo 发票商品信息
+type InvoiceProductInfo struct {
+	Name  string `json:"name"`
+	Num   int    `json:"num,omitempty"`
+	Unit  string `json:"unit,omitempty"`
+	Price int    `json:"price,omitempty"`
+}
+
+// InvoiceUserData 发票具体内容
+type InvoiceUserData struct {
+	Fee                   int                   `json:"fee"`             // 发票金额（分）
+	Title                 string                `json:"title"`           // 发票抬头
+	BillingTime           int64                 `json:"billing_time"`    // 发票开具时间
+	BillingNO             string                `json:"billing_no"`      // 发票代码
+	BillingCode           string                `json:"billing_code"`    // 发票号码
+	Info                  []*InvoiceProductInfo `json:"info,omitempty"`  // 商品信息
+	FeeWithoutTax         int                   `json:"fee_without_tax"` // 不含税金额（分）
+	Tax                   int                   `json:"tax"`             // 税额（分）
+	SPdfMediaID           string                `json:"s_pdf_media_id"`  // 发票 pdf 文件上传后的 s_media_id
+	STripPdfMediaID       string                `json:"s_trip_pdf_media_id,omitempty"`
+	CheckCode             string                `json:"check_code"`             // 校验码
+	BuyerNumber           string                `json:"buyer_number,omitempty"` // 购买方纳税人识别号
+	BuyerAddressAndPhone  string                `json:"buyer_address_and_phone,omitempty"`
+	BuyerBankAccount      string                `json:"buyer_bank_account,omitempty"`
+	SellerNumber          string                `json:"seller_number,omitempty"`
+	SellerAddressAndPhone string                `json:"seller_address_and_phone,omitempty"`
+	SellerBankAccount     string                `json:"seller_bank_account,omitempty"`
+	Remarks               string                `json:"remarks,omitempty"`
+	Cashier               string                `json:"cashier,omitempty"`
+	Maker                 string                `json:"maker,omitempty"`
+}
+
+type InvoiceUserCard struct {
+	InvoiceUserData *InvoiceUserData `json:"invoice_user_data"`
+}
+
+type InvoiceCardExt struct {
+	NonceStr string           `json:"nonce_str"`
+	UserCard *InvoiceUserCard `json:"user_card"`
+}
+
+type ParamsInvoiceInsert struct {
+	OrderID string          `json:"order_id"` // 发票 order_id，与授权时的 order_id 一致
+	CardID  string          `json:"card_id"`  // 发票 card_id
+	AppID   string          `json:"appid"`    // 该订单号授权时使用的 appid
+	CardExt *InvoiceCardExt `json:"card_ext"`
+}
+
+type ResultInvoiceInsert struct {
+	Code    string `json:"code"` // 发票 code
+	OpenID  string `json:"openid"`
+	UnionID string `json:"unionid"`
+}
+
+// InsertInvoice 电子发票 - 将电子发票卡券插入用户卡包
+func InsertInvoice(params *ParamsInvoiceInsert, result *ResultInvoiceInsert) wx.Action {
+	return wx.NewPostAction(urls.OffiaInvoiceInsert,
+		wx.WithBody(func() ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// ReimburseStatus 发票报销状态
+type ReimburseStatus string
+
+// 微信支持的发票报销状态
+const (
+	InvoiceReimburseInit    ReimburseStatus = "INVOICE_REIMBURSE_INIT"    // 发票初始状态，未锁定
+	InvoiceReimburseLock    ReimburseStatus = "INVOICE_REIMBURSE_LOCK"    // 发票已锁定，无法重复提交报销
+	InvoiceReimburseClosure ReimburseStatus = "INVOICE_REIMBURSE_CLOSURE" // 发票已核销，从用户卡包中移除
+)
+
+type ParamsInvoice struct {
+	CardID      string `json:"card_id"`
+	EncryptCode string `json:"encrypt_code"`
+}
+
+// InvoiceUserInfo 发票的用户信息
+type InvoiceUserInfo struct {
+	Fee             int                   `json:"fee"`
+	Title           string                `json:"title"`
+	BillingTime     int64                 `json:"billing_time"`
+	BillingNO       string                `json:"billing_no"`
+	BillingCode     string                `json:"billing_code"`
+	Info            []*InvoiceProductInfo `json:"info"`
+	FeeWithoutTax   int                   `json:"fee_without_tax"`
+	Tax             int                   `json:"tax"`
+	Detail          string                `json:"detail"`
+	PdfURL          string                `json:"pdf_url"`
+	TripPdfURL      string                `json:"trip_pdf_url"`
+	CheckCode       string                `json:"check_code"`
+	BuyerNumber     string                `json:"buyer_number"`
+	ReimburseStatus ReimburseStatus       `json:"reimburse_status"`
+}
+
+type ResultInvoiceInfo struct {
+	CardID    string           `json:"card_id"`
+	BeginTime int64            `json:"begin_time"`
+	EndTime   int64            `json:"end_time"`
+	OpenID    string           `json:"openid"`
+	Type      string           `json:"type"`
+	Payee     string           `json:"payee"`
+	Detail    string           `json:"detail"`
+	UserInfo  *InvoiceUserInfo `json:"user_info"`
+}
+
+// GetInvoiceInfo 电子发票 - 报销方查询报销发票信息
+func GetInvoiceInfo(cardID, encryptCode string, result *ResultInvoiceInfo) wx.Action {
+	params := &ParamsInvoice{
+		CardID:      cardID,
+		EncryptCode: encryptCode,
+	}
+
+	return wx.NewPostAction(urls.OffiaInvoiceReimburseGetInfo,
+		wx.WithBody(func() ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+type ParamsInvoiceStatusUpdate struct {
+	CardID          string          `json:"card_id"`
+	EncryptCode     string          `json:"encrypt_code"`
+	ReimburseStatus ReimburseStatus `json:"reimburse_status"`
+}
+
+// UpdateInvoiceStatus 电子发票 - 报销方更新发票报销状态
+func UpdateInvoiceStatus(cardID, encryptCode string, status ReimburseStatus) wx.Action {
+	params := &ParamsInvoiceStatusUpdate{
+		CardID:          cardID,
+		EncryptCode:     encryptCode,
+		ReimburseStatus: status,
+	}
+
+	return wx.NewPostAction(urls.OffiaInvoiceReimburseUpdateStat,
+		wx.WithBody(func() ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+	)
+}
@@ -0,0 +1,327 @@
+package offia
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/mock"
+)
+
+func TestSetInvoiceAuthField(t *testing.T) {
+	body := []byte(`{"auth_field":{"user_field":{"show_title":1,"show_phone":1,"show_email":1,"require_phone":1,"require_email":1,"custom_field":[{"key":"field1"}]},"biz_field":{"show_title":1,"show_tax_no":1,"show_addr":1,"show_phone":1,"show_bank_type":1,"show_bank_no":1,"require_tax_no":1,"require_addr":1,"require_phone":1,"require_bank_type":1,"require_bank_no":1,"custom_field":[{"key":"field2"}]}}}`)
+
+	resp := []byte(`{"errcode":0,"errmsg":"ok"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/card/invoice/setbizattr?access_token=ACCESS_TOKEN&action=set_auth_field", body).Return(resp, nil)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	userField := &InvoiceUserField{
+		ShowTitle:    1,
+		ShowPhone:    1,
+		ShowEmail:    1,
+		RequirePhone: 1,
+		RequireEmail: 1,
+		CustomField:  []*InvoiceCustomField{{Key: "field1"}},
+	}
+
+	bizField := &InvoiceBizField{
+		ShowTitle:       1,
+		ShowTaxNO:       1,
+		ShowAddr:        1,
+		ShowPhone:       1,
+		ShowBankType:    1,
+		ShowBankNO:      1,
+		RequireTaxNO:    1,
+		RequireAddr:     1,
+		RequirePhone:    1,
+		RequireBankType: 1,
+		RequireBankNO:   1,
+		CustomField:     []*InvoiceCustomField{{Key: "field2"}},
+	}
+
+	err := oa.Do(context.TODO(), "ACCESS_TOKEN", SetInvoiceAuthField(userField, bizField))
+
+	assert.Nil(t, err)
+}
+
+func TestGetInvoiceAuthField(t *testing.T) {
+	resp := []byte(`{
+	"errcode": 0,
+	"errmsg": "ok",
+	"user_field": {
+		"show_title": 1,
+		"show_phone": 1,
+		"show_email": 1
+	},
+	"biz_field": {
+		"show_title": 1,
+		"show_tax_no": 1
+	}
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/card/invoice/setbizattr?access_token=ACCESS_TOKEN&action=get_auth_field", []byte(`{}`)).Return(resp, nil)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	result := new(ResultInvoiceAuthField)
+
+	err := oa.Do(context.TODO(), "ACCESS_TOKEN", GetInvoiceAuthField(result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultInvoiceAuthField{
+		UserField: &InvoiceUserField{
+			ShowTitle: 1,
+			ShowPhone: 1,
+			ShowEmail: 1,
+		},
+		BizField: &InvoiceBizField{
+			ShowTitle: 1,
+			ShowTaxNO: 1,
+		},
+	}, result)
+}
+
+func TestGetInvoiceAuthURL(t *testing.T) {
+	body := []byte(`{"s_pappid":"S_PAPPID","order_id":"1234","money":11,"timestamp":1474875876,"source":"web","redirect_url":"https://mp.weixin.qq.com","ticket":"TICKET","type":1}`)
+
+	resp := []byte(`{
+	"errcode": 0,
+	"errmsg": "ok",
+	"auth_url": "https://mp.weixin.qq.com/bizmall/authinvoice?action=list&s_pappid=S_PAPPID"
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/card/invoice/getauthurl?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	params := &ParamsInvoiceAuthURL{
+		SPAppID:     "S_PAPPID",
+		OrderID:     "1234",
+		Money:       11,
+		Timestamp:   1474875876,
+		Source:      "web",
+		RedirectURL: "https://mp.weixin.qq.com",
+		Ticket:      "TICKET",
+		Type:        InvoiceAuthFill,
+	}
+	result := new(ResultInvoiceAuthURL)
+
+	err := oa.Do(context.TODO(), "ACCESS_TOKEN", GetInvoiceAuthURL(params, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultInvoiceAuthURL{
+		AuthURL: "https://mp.weixin.qq.com/bizmall/authinvoice?action=list&s_pappid=S_PAPPID",
+	}, result)
+}
+
+func TestGetInvoiceAuthData(t *testing.T) {
+	body := []byte(`{"order_id":"111229","s_appid":"S_APPID"}`)
+
+	resp := []byte(`{
+	"errcode": 0,
+	"errmsg": "ok",
+	"invoice_status": "auth success",
+	"auth_time": 1480342498,
+	"user_auth_info": {
+		"user_field": {
+			"title": "Dd",
+			"phone": "18518218888",
+			"email": "2010@qq.com",
+			"custom_field": [
+				{
+					"key": "field1",
+					"value": "value1"
+				}
+			]
+		}
+	}
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/card/invoice/getauthdata?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	result := new(ResultInvoiceAuthData)
+
+	err := oa.Do(context.TODO(), "ACCESS_TOKEN", GetInvoiceAuthData("111229", "S_APPID", result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultInvoiceAuthData{
+		InvoiceStatus: "auth success",
+		AuthTime:      1480342498,
+		UserAuthInfo: &InvoiceUserAuthInfo{
+			UserField: &InvoiceUserTitle{
+				Title: "Dd",
+				Phone: "18518218888",
+				Email: "2010@qq.com",
+				CustomField: []*InvoiceCustomValue{
+					{Key: "field1", Value: "value1"},
+				},
+			},
+		},
+	}, result)
+}
+
+func TestInsertInvoice(t *testing.T) {
+	body := []byte(`{"order_id":"1234","card_id":"CARD_ID","appid":"APPID","card_ext":{"nonce_str":"NONCE_STR","user_card":{"invoice_user_data":{"fee":123,"title":"灌哥","billing_time":1478069425,"billing_no":"00100","billing_code":"00100","info":[{"name":"香蕉","num":1,"unit":"根","price":123}],"fee_without_tax":100,"tax":23,"s_pdf_media_id":"S_PDF_MEDIA_ID","check_code":"CHECK_CODE"}}}}`)
+
+	resp := []byte(`{
+	"errcode": 0,
+	"errmsg": "ok",
+	"code": "CODE",
+	"openid": "OPENID",
+	"unionid": "UNIONID"
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/card/invoice/insert?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	params := &ParamsInvoiceInsert{
+		OrderID: "1234",
+		CardID:  "CARD_ID",
+		AppID:   "APPID",
+		CardExt: &InvoiceCardExt{
+			NonceStr: "NONCE_STR",
+			UserCard: &InvoiceUserCard{
+				InvoiceUserData: &InvoiceUserData{
+					Fee:         123,
+					Title:       "灌哥",
+					BillingTime: 1478069425,
+					BillingNO:   "00100",
+					BillingCode: "00100",
+					Info: []*InvoiceProductInfo{
+						{Name: "香蕉", Num: 1, Unit: "根", Price: 123},
+					},
+					FeeWithoutTax: 100,
+					Tax:           23,
+					SPdfMediaID:   "S_PDF_MEDIA_ID",
+					CheckCode:     "CHECK_CODE",
+				},
+			},
+		},
+	}
+	result := new(ResultInvoiceInsert)
+
+	err := oa.Do(context.TODO(), "ACCESS_TOKEN", InsertInvoice(params, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultInvoiceInsert{
+		Code:    "CODE",
+		OpenID:  "OPENID",
+		UnionID: "UNIONID",
+	}, result)
+}
+
+func TestGetInvoiceInfo(t *testing.T) {
+	body := []byte(`{"card_id":"CARD_ID","encrypt_code":"ENCRYPT_CODE"}`)
+
+	resp := []byte(`{
+	"errcode": 0,
+	"errmsg": "ok",
+	"card_id": "CARD_ID",
+	"begin_time": 1469084420,
+	"end_time": 2100236420,
+	"openid": "OPENID",
+	"type": "广东省增值税普通发票",
+	"payee": "测试-收款方",
+	"detail": "detail",
+	"user_info": {
+		"fee": 123,
+		"title": "灌哥",
+		"billing_time": 1478069425,
+		"billing_no": "00100",
+		"billing_code": "00100",
+		"fee_without_tax": 100,
+		"tax": 23,
+		"pdf_url": "PDF_URL",
+		"check_code": "CHECK_CODE",
+		"reimburse_status": "INVOICE_REIMBURSE_INIT"
+	}
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/card/invoice/reimburse/getinvoiceinfo?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	result := new(ResultInvoiceInfo)
+
+	err := oa.Do(context.TODO(), "ACCESS_TOKEN", GetInvoiceInfo("CARD_ID", "ENCRYPT_CODE", result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultInvoiceInfo{
+		CardID:    "CARD_ID",
+		BeginTime: 1469084420,
+		EndTime:   2100236420,
+		OpenID:    "OPENID",
+		Type:      "广东省增值税普通发票",
+		Payee:     "测试-收款方",
+		Detail:    "detail",
+		UserInfo: &InvoiceUserInfo{
+			Fee:             123,
+			Title:           "灌哥",
+			BillingTime:     1478069425,
+			BillingNO:       "00100",
+			BillingCode:     "00100",
+			FeeWithoutTax:   100,
+			Tax:             23,
+			PdfURL:          "PDF_URL",
+			CheckCode:       "CHECK_CODE",
+			ReimburseStatus: InvoiceReimburseInit,
+		},
+	}, result)
+}
+
+func TestUpdateInvoiceStatus(t *testing.T) {
+	body := []byte(`{"card_id":"CARD_ID","encrypt_code":"ENCRYPT_CODE","reimburse_status":"INVOICE_REIMBURSE_LOCK"}`)
+
+	resp := []byte(`{"errcode":0,"errmsg":"ok"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/card/invoice/reimburse/updateinvoicestatus?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	err := oa.Do(context.TODO(), "ACCESS_TOKEN", UpdateInvoiceStatus("CARD_ID", "ENCRYPT_CODE", InvoiceReimburseLock))
+
+	assert.Nil(t, err)
+}
@@ -0,0 +1,195 @@
+package offia
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shenghui0779/gochat/urls"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// MassFilter 群发接收者（is_to_all 为 true 时群发给所有用户，否则群发给指定标签的用户）
+type MassFilter struct {
+	IsToAll bool  `json:"is_to_all"`
+	TagID   int64 `json:"tag_id,omitempty"`
+}
+
+type MassMedia struct {
+	MediaID string `json:"media_id"`
+}
+
+type ParamsMassSendAll struct {
+	Filter            *MassFilter `json:"filter"`
+	MPNews            *MassMedia  `json:"mpnews"`
+	MsgType           string      `json:"msgtype"`
+	SendIgnoreReprint int         `json:"send_ignore_reprint"`
+	ClientMsgID       string      `json:"clientmsgid,omitempty"`
+}
+
+type ResultMassSend struct {
+	MsgID     int64 `json:"msg_id"`
+	MsgDataID int64 `json:"msg_data_id"`
+}
+
+// MassSendAllNews 群发 - 根据标签进行群发图文消息（media_id 可使用草稿箱中的草稿）
+// [参考](https://developers.weixin.qq.com/doc/offiaccount/Message_Management/Batch_Sends_and_Originality_Checks.html)
+func MassSendAllNews(params *ParamsMassSendAll, result *ResultMassSend) wx.Action {
+	params.MsgType = "mpnews"
+
+	return wx.NewPostAction(urls.OffiaMassSendAll,
+		wx.WithBody(func() ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// 群发状态
+const (
+	MassSendSuccess = "SEND_SUCCESS" // 发送成功
+	MassSending     = "SENDING"      // 发送中
+	MassSendFail    = "SEND_FAIL"    // 发送失败
+	MassDeleted     = "DELETE"       // 已删除
+)
+
+type ParamsMassGet struct {
+	MsgID int64 `json:"msg_id"`
+}
+
+type ResultMassGet struct {
+	MsgID     int64  `json:"msg_id"`
+	MsgStatus string `json:"msg_status"`
+}
+
+// GetMass 群发 - 查询群发消息发送状态
+func GetMass(msgID int64, result *ResultMassGet) wx.Action {
+	params := &ParamsMassGet{
+		MsgID: msgID,
+	}
+
+	return wx.NewPostAction(urls.OffiaMassGet,
+		wx.WithBody(func() ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// MassError 群发失败
+type MassError struct {
+	MsgID  int64
+	Status string
+}
+
+func (e *MassError) Error() string {
+	return fmt.Sprintf("mass %d failed, status: %s", e.MsgID, e.Status)
+}
+
+// 群发状态轮询的退避间隔
+var (
+	massPollInterval    = time.Second
+	massPollMaxInterval = 30 * time.Second
+)
+
+// ParamsDraftMassSend 草稿群发参数
+type ParamsDraftMassSend struct {
+	MediaID           string      // 草稿 media_id
+	Filter            *MassFilter // 群发接收者，为空时群发给所有用户
+	SendIgnoreReprint bool        // 图文被判定为转载时是否继续群发
+	ClientMsgID       string      // 群发消息去重标识（24小时内相同 clientmsgid 只群发一次）
+}
+
+// ResultDraftMassSend 草稿群发结果
+type ResultDraftMassSend struct {
+	MsgID     int64
+	MsgDataID int64
+	MsgStatus string
+	Articles  []*DraftArticle // 群发的草稿图文
+}
+
+// MassSendDraft 群发 - 将草稿群发给全部粉丝或指定标签用户（同公众平台“群发”操作）：
+// 先获取草稿校验图文，再群发并轮询发送状态（指数退避：1s、2s、4s ... 最大30s）直至发送完成；
+// 发送失败返回 *MassError，超时请通过 ctx 控制
+func (oa *Offia) MassSendDraft(ctx context.Context, accessToken string, params *ParamsDraftMassSend, options ...wx.HTTPOption) (*ResultDraftMassSend, error) {
+	draft := new(ResultDraftGet)
+
+	if err := oa.Do(ctx, accessToken, GetDraft(params.MediaID, draft), options...); err != nil {
+		return nil, err
+	}
+
+	if len(draft.NewsItem) == 0 {
+		return nil, errors.New("empty draft")
+	}
+
+	filter := params.Filter
+
+	if filter == nil {
+		filter = &MassFilter{IsToAll: true}
+	}
+
+	sendParams := &ParamsMassSendAll{
+		Filter:      filter,
+		MPNews:      &MassMedia{MediaID: params.MediaID},
+		ClientMsgID: params.ClientMsgID,
+	}
+
+	if params.SendIgnoreReprint {
+		sendParams.SendIgnoreReprint = 1
+	}
+
+	sent := new(ResultMassSend)
+
+	if err := oa.Do(ctx, accessToken, MassSendAllNews(sendParams, sent), options...); err != nil {
+		return nil, err
+	}
+
+	result := &ResultDraftMassSend{
+		MsgID:     sent.MsgID,
+		MsgDataID: sent.MsgDataID,
+		Articles:  draft.NewsItem,
+	}
+
+	interval := massPollInterval
+
+	for {
+		status := new(ResultMassGet)
+
+		if err := oa.Do(ctx, accessToken, GetMass(sent.MsgID, status), options...); err != nil {
+			return nil, err
+		}
+
+		switch status.MsgStatus {
+		case MassSendSuccess:
+			result.MsgStatus = status.MsgStatus
+
+			return result, nil
+		case MassSending:
+		default:
+			return nil, &MassError{
+				MsgID:  sent.MsgID,
+				Status: status.MsgStatus,
+			}
+		}
+
+		timer := time.NewTimer(interval)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		if interval *= 2; interval > massPollMaxInterval {
+			interval = massPollMaxInterval
+		}
+	}
+}
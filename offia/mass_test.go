@@ -0,0 +1,138 @@
+package offia
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/mock"
+)
+
+func TestMassSendAllNews(t *testing.T) {
+	body := []byte(`{"filter":{"is_to_all":false,"tag_id":2},"mpnews":{"media_id":"MEDIA_ID"},"msgtype":"mpnews","send_ignore_reprint":0}`)
+	resp := []byte(`{
+	"errcode": 0,
+	"errmsg": "send job submission success",
+	"msg_id": 34182,
+	"msg_data_id": 206227730
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/message/mass/sendall?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	params := &ParamsMassSendAll{
+		Filter: &MassFilter{TagID: 2},
+		MPNews: &MassMedia{MediaID: "MEDIA_ID"},
+	}
+	result := new(ResultMassSend)
+
+	err := oa.Do(context.TODO(), "ACCESS_TOKEN", MassSendAllNews(params, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultMassSend{
+		MsgID:     34182,
+		MsgDataID: 206227730,
+	}, result)
+}
+
+func TestGetMass(t *testing.T) {
+	body := []byte(`{"msg_id":201053012}`)
+	resp := []byte(`{
+	"msg_id": 201053012,
+	"msg_status": "SEND_SUCCESS"
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/message/mass/get?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	result := new(ResultMassGet)
+
+	err := oa.Do(context.TODO(), "ACCESS_TOKEN", GetMass(201053012, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultMassGet{
+		MsgID:     201053012,
+		MsgStatus: MassSendSuccess,
+	}, result)
+}
+
+func TestMassSendDraft(t *testing.T) {
+	massPollInterval = time.Millisecond
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	gomock.InOrder(
+		client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/draft/get?access_token=ACCESS_TOKEN", []byte(`{"media_id":"MEDIA_ID"}`)).Return([]byte(`{"news_item":[{"title":"TITLE","content":"CONTENT","thumb_media_id":"THUMB_MEDIA_ID"}]}`), nil),
+		client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/message/mass/sendall?access_token=ACCESS_TOKEN", []byte(`{"filter":{"is_to_all":true},"mpnews":{"media_id":"MEDIA_ID"},"msgtype":"mpnews","send_ignore_reprint":1,"clientmsgid":"CLIENT_MSG_ID"}`)).Return([]byte(`{"errcode":0,"msg_id":34182,"msg_data_id":206227730}`), nil),
+		client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/message/mass/get?access_token=ACCESS_TOKEN", []byte(`{"msg_id":34182}`)).Return([]byte(`{"msg_id":34182,"msg_status":"SENDING"}`), nil).Times(2),
+		client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/message/mass/get?access_token=ACCESS_TOKEN", []byte(`{"msg_id":34182}`)).Return([]byte(`{"msg_id":34182,"msg_status":"SEND_SUCCESS"}`), nil),
+	)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	result, err := oa.MassSendDraft(context.TODO(), "ACCESS_TOKEN", &ParamsDraftMassSend{
+		MediaID:           "MEDIA_ID",
+		SendIgnoreReprint: true,
+		ClientMsgID:       "CLIENT_MSG_ID",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultDraftMassSend{
+		MsgID:     34182,
+		MsgDataID: 206227730,
+		MsgStatus: MassSendSuccess,
+		Articles: []*DraftArticle{
+			{
+				Title:        "TITLE",
+				Content:      "CONTENT",
+				ThumbMediaID: "THUMB_MEDIA_ID",
+			},
+		},
+	}, result)
+}
+
+func TestMassSendDraftFail(t *testing.T) {
+	massPollInterval = time.Millisecond
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	gomock.InOrder(
+		client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/draft/get?access_token=ACCESS_TOKEN", gomock.Any()).Return([]byte(`{"news_item":[{"title":"TITLE"}]}`), nil),
+		client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/message/mass/sendall?access_token=ACCESS_TOKEN", []byte(`{"filter":{"is_to_all":false,"tag_id":2},"mpnews":{"media_id":"MEDIA_ID"},"msgtype":"mpnews","send_ignore_reprint":0}`)).Return([]byte(`{"errcode":0,"msg_id":34182}`), nil),
+		client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/message/mass/get?access_token=ACCESS_TOKEN", gomock.Any()).Return([]byte(`{"msg_id":34182,"msg_status":"SEND_FAIL"}`), nil),
+	)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	_, err := oa.MassSendDraft(context.TODO(), "ACCESS_TOKEN", &ParamsDraftMassSend{
+		MediaID: "MEDIA_ID",
+		Filter:  &MassFilter{TagID: 2},
+	})
+
+	assert.Equal(t, &MassError{
+		MsgID:  34182,
+		Status: MassSendFail,
+	}, err)
+}
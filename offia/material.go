@@ -0,0 +1,98 @@
+package offia
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// materialPageSize 素材列表每页数量（微信限制：1～20）
+const materialPageSize = 20
+
+// EachMaterial 遍历指定类型（图片、语音、视频）的全部永久素材，f 返回 false 时停止遍历
+func (oa *Offia) EachMaterial(ctx context.Context, accessToken string, mediaType MediaType, f func(item *MaterialListItem) bool, options ...wx.HTTPOption) error {
+	for offset := 0; ; {
+		result := new(ResultMaterialList)
+
+		if err := oa.Do(ctx, accessToken, ListMatertial(mediaType, offset, materialPageSize, result), options...); err != nil {
+			return err
+		}
+
+		for _, item := range result.Item {
+			if !f(item) {
+				return nil
+			}
+		}
+
+		offset += len(result.Item)
+
+		if len(result.Item) == 0 || offset >= result.TotalCount {
+			return nil
+		}
+	}
+}
+
+// MaterialSink 永久素材下载内容的接收方（如：写入本地文件、对象存储等），会被并发调用
+type MaterialSink func(ctx context.Context, item *MaterialListItem, content []byte) error
+
+// DownloadMaterials 遍历指定类型（图片、语音、视频）的全部永久素材，分批下载后交由 sink 处理；
+// fanout 设置并发数及请求间隔（如：wx.WithFanOutConcurrency、wx.WithFanOutInterval，默认串行且不限制间隔）；
+// 任一素材下载或处理失败则停止下载并返回首个错误（视频素材通过 down_url 下载）
+func (oa *Offia) DownloadMaterials(ctx context.Context, accessToken string, mediaType MediaType, fanout []wx.FanOutOption, sink MaterialSink, options ...wx.HTTPOption) error {
+	if mediaType != MediaImage && mediaType != MediaVoice && mediaType != MediaVideo {
+		return fmt.Errorf("unsupported material type: %s", mediaType)
+	}
+
+	items := make([]*MaterialListItem, 0)
+
+	err := oa.EachMaterial(ctx, accessToken, mediaType, func(item *MaterialListItem) bool {
+		items = append(items, item)
+
+		return true
+	}, options...)
+
+	if err != nil {
+		return err
+	}
+
+	return wx.FanOut(ctx, len(items), 1, func(ctx context.Context, i, _ int) error {
+		content, err := oa.downloadMaterial(ctx, accessToken, mediaType, items[i].MediaID, options...)
+
+		if err == nil {
+			err = sink(ctx, items[i], content)
+		}
+
+		if err != nil {
+			return fmt.Errorf("material %s: %w", items[i].MediaID, err)
+		}
+
+		return nil
+	}, fanout...)
+}
+
+func (oa *Offia) downloadMaterial(ctx context.Context, accessToken string, mediaType MediaType, mediaID string, options ...wx.HTTPOption) ([]byte, error) {
+	if mediaType != MediaVideo {
+		result := new(ResultOtherMaterialGet)
+
+		if err := oa.Do(ctx, accessToken, GetOtherMaterial(mediaID, result), options...); err != nil {
+			return nil, err
+		}
+
+		return result.Buffer, nil
+	}
+
+	result := new(ResultVideoMaterialGet)
+
+	if err := oa.Do(ctx, accessToken, GetVideoMaterial(mediaID, result), options...); err != nil {
+		return nil, err
+	}
+
+	if len(result.DownURL) == 0 {
+		return nil, errors.New("empty video down_url")
+	}
+
+	return oa.client.Do(ctx, http.MethodGet, result.DownURL, nil, options...)
+}
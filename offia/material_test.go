@@ -0,0 +1,157 @@
+package offia
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/mock"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+func mockMaterialList(total, offset, count int) []byte {
+	result := &ResultMaterialList{
+		TotalCount: total,
+		Item:       make([]*MaterialListItem, 0),
+	}
+
+	for i := offset; i < total && i < offset+count; i++ {
+		result.Item = append(result.Item, &MaterialListItem{
+			MediaID: fmt.Sprintf("MEDIA_ID_%d", i),
+			Name:    fmt.Sprintf("%d.jpg", i),
+		})
+	}
+
+	result.ItemCount = len(result.Item)
+
+	b, _ := json.Marshal(result)
+
+	return b
+}
+
+func TestEachMaterial(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/material/batchget_material?access_token=ACCESS_TOKEN", []byte(`{"type":"image","offset":0,"count":20}`)).Return(mockMaterialList(23, 0, 20), nil)
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/material/batchget_material?access_token=ACCESS_TOKEN", []byte(`{"type":"image","offset":20,"count":20}`)).Return(mockMaterialList(23, 20, 20), nil)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	ids := make([]string, 0)
+
+	err := oa.EachMaterial(context.TODO(), "ACCESS_TOKEN", MediaImage, func(item *MaterialListItem) bool {
+		ids = append(ids, item.MediaID)
+
+		return true
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 23, len(ids))
+	assert.Equal(t, "MEDIA_ID_22", ids[22])
+
+	// 提前停止遍历
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/material/batchget_material?access_token=ACCESS_TOKEN", []byte(`{"type":"image","offset":0,"count":20}`)).Return(mockMaterialList(23, 0, 20), nil)
+
+	count := 0
+
+	err = oa.EachMaterial(context.TODO(), "ACCESS_TOKEN", MediaImage, func(item *MaterialListItem) bool {
+		count++
+
+		return count < 5
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 5, count)
+}
+
+func TestDownloadMaterials(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.Any(), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/material/batchget_material?access_token=ACCESS_TOKEN", []byte(`{"type":"image","offset":0,"count":20}`)).Return(mockMaterialList(3, 0, 20), nil)
+
+	for i := 0; i < 3; i++ {
+		client.EXPECT().Do(gomock.Any(), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/material/get_material?access_token=ACCESS_TOKEN", []byte(fmt.Sprintf(`{"media_id":"MEDIA_ID_%d"}`, i))).Return([]byte(fmt.Sprintf("IMAGE_%d", i)), nil)
+	}
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	var mutex sync.Mutex
+
+	contents := make([]string, 0)
+
+	err := oa.DownloadMaterials(context.TODO(), "ACCESS_TOKEN", MediaImage, []wx.FanOutOption{wx.WithFanOutConcurrency(2), wx.WithFanOutInterval(time.Millisecond)}, func(ctx context.Context, item *MaterialListItem, content []byte) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		contents = append(contents, item.MediaID+":"+string(content))
+
+		return nil
+	})
+
+	assert.Nil(t, err)
+
+	sort.Strings(contents)
+
+	assert.Equal(t, []string{"MEDIA_ID_0:IMAGE_0", "MEDIA_ID_1:IMAGE_1", "MEDIA_ID_2:IMAGE_2"}, contents)
+}
+
+func TestDownloadVideoMaterials(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.Any(), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/material/batchget_material?access_token=ACCESS_TOKEN", []byte(`{"type":"video","offset":0,"count":20}`)).Return(mockMaterialList(1, 0, 20), nil)
+	client.EXPECT().Do(gomock.Any(), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/material/get_material?access_token=ACCESS_TOKEN", []byte(`{"media_id":"MEDIA_ID_0"}`)).Return([]byte(`{"title":"TITLE","description":"DESCRIPTION","down_url":"DOWN_URL"}`), nil)
+	client.EXPECT().Do(gomock.Any(), http.MethodGet, "DOWN_URL", nil).Return([]byte("VIDEO"), nil)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	var content []byte
+
+	err := oa.DownloadMaterials(context.TODO(), "ACCESS_TOKEN", MediaVideo, nil, func(ctx context.Context, item *MaterialListItem, b []byte) error {
+		content = b
+
+		return nil
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("VIDEO"), content)
+}
+
+func TestDownloadMaterialsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.Any(), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/material/batchget_material?access_token=ACCESS_TOKEN", []byte(`{"type":"voice","offset":0,"count":20}`)).Return(mockMaterialList(1, 0, 20), nil)
+	client.EXPECT().Do(gomock.Any(), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/material/get_material?access_token=ACCESS_TOKEN", []byte(`{"media_id":"MEDIA_ID_0"}`)).Return([]byte(`{"errcode":40007,"errmsg":"invalid media_id"}`), nil)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	err := oa.DownloadMaterials(context.TODO(), "ACCESS_TOKEN", MediaVoice, nil, func(ctx context.Context, item *MaterialListItem, content []byte) error {
+		return nil
+	})
+
+	assert.EqualError(t, err, "material MEDIA_ID_0: 40007|invalid media_id（不合法的媒体文件 id）")
+	assert.True(t, wx.IsAPIError(err, 40007))
+
+	err = oa.DownloadMaterials(context.TODO(), "ACCESS_TOKEN", MediaThumb, nil, nil)
+
+	assert.EqualError(t, err, "unsupported material type: thumb")
+}
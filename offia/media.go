@@ -95,6 +95,39 @@ func UploadMediaByURL(mediaType MediaType, filename, url string, result *ResultM
 	)
 }
 
+// Media 临时素材
+type Media struct {
+	Buffer []byte
+}
+
+// GetTempMedia 素材管理 - 获取临时素材（如：语音消息的 MediaId）
+func GetTempMedia(mediaID string, media *Media) wx.Action {
+	return wx.NewGetAction(urls.OffiaMediaGet,
+		wx.WithQuery("media_id", mediaID),
+		wx.WithDecode(func(b []byte) error {
+			media.Buffer = make([]byte, len(b))
+
+			copy(media.Buffer, b)
+
+			return nil
+		}),
+	)
+}
+
+// GetJSSDKMedia 素材管理 - 获取高清语音素材（JSSDK 上传的语音，格式为 speex，16K 采样率）
+func GetJSSDKMedia(mediaID string, media *Media) wx.Action {
+	return wx.NewGetAction(urls.OffiaMediaGetJSSDK,
+		wx.WithQuery("media_id", mediaID),
+		wx.WithDecode(func(b []byte) error {
+			media.Buffer = make([]byte, len(b))
+
+			copy(media.Buffer, b)
+
+			return nil
+		}),
+	)
+}
+
 // ResultMaterialAdd 永久素材新增结果
 type ResultMaterialAdd struct {
 	MediaID string `json:"media_id"`
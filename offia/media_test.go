@@ -72,6 +72,46 @@ func TestUploadMediaByURL(t *testing.T) {
 	}, result)
 }
 
+func TestGetTempMedia(t *testing.T) {
+	resp := []byte("VOICE_BYTES")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.weixin.qq.com/cgi-bin/media/get?access_token=ACCESS_TOKEN&media_id=MEDIA_ID", nil).Return(resp, nil)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	media := new(Media)
+
+	err := oa.Do(context.TODO(), "ACCESS_TOKEN", GetTempMedia("MEDIA_ID", media))
+
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("VOICE_BYTES"), media.Buffer)
+}
+
+func TestGetJSSDKMedia(t *testing.T) {
+	resp := []byte("SPEEX_BYTES")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.weixin.qq.com/cgi-bin/media/get/jssdk?access_token=ACCESS_TOKEN&media_id=MEDIA_ID", nil).Return(resp, nil)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	media := new(Media)
+
+	err := oa.Do(context.TODO(), "ACCESS_TOKEN", GetJSSDKMedia("MEDIA_ID", media))
+
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("SPEEX_BYTES"), media.Buffer)
+}
+
 func TestAddMaterial(t *testing.T) {
 	resp := []byte(`{
 	"errcode": 0,
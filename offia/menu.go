@@ -1,6 +1,8 @@
 package offia
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 
 	"github.com/shenghui0779/gochat/urls"
@@ -107,6 +109,77 @@ func TryMatchMenu(userID string, result *ResultMenuMatch) wx.Action {
 	)
 }
 
+// MenuMatchReport 测试用户的菜单匹配结果
+type MenuMatchReport struct {
+	UserID      string         // 测试用户（OpenID 或微信号）
+	Conditional bool           // 是否匹配到个性化菜单（false 表示看到的是普通菜单或未匹配到已知菜单）
+	MenuID      int64          // 匹配到的菜单ID
+	MatchRule   *MenuMatchRule // 匹配到的个性化菜单规则
+	Button      []*MenuButton  // 用户看到的菜单按钮
+	Err         error          // 测试匹配失败的错误（如：用户不存在）
+}
+
+// TryMatchMenus 批量测试匹配个性化菜单，返回每个测试用户看到的菜单；
+// 测试匹配接口仅返回菜单按钮，通过与当前菜单配置比对按钮确定菜单ID（按钮完全相同的多个个性化菜单取第一个）
+func (oa *Offia) TryMatchMenus(ctx context.Context, accessToken string, userIDs []string, options ...wx.HTTPOption) ([]*MenuMatchReport, error) {
+	menus := new(ResultMenuGet)
+
+	if err := oa.Do(ctx, accessToken, GetMenu(menus), options...); err != nil {
+		return nil, err
+	}
+
+	reports := make([]*MenuMatchReport, 0, len(userIDs))
+
+	for _, userID := range userIDs {
+		report := &MenuMatchReport{UserID: userID}
+
+		result := new(ResultMenuMatch)
+
+		if err := oa.Do(ctx, accessToken, TryMatchMenu(userID, result), options...); err != nil {
+			report.Err = err
+			reports = append(reports, report)
+
+			continue
+		}
+
+		report.Button = result.Button
+
+		for _, menu := range menus.ConditionalMenu {
+			if sameMenuButtons(menu.Button, result.Button) {
+				report.Conditional = true
+				report.MenuID = menu.MenuID
+				report.MatchRule = &menu.MatchRule
+
+				break
+			}
+		}
+
+		if !report.Conditional {
+			report.MenuID = menus.Menu.MenuID
+		}
+
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
+
+func sameMenuButtons(a, b []*MenuButton) bool {
+	ab, err := json.Marshal(a)
+
+	if err != nil {
+		return false
+	}
+
+	bb, err := json.Marshal(b)
+
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(ab, bb)
+}
+
 // ConditionalMenu 个性化菜单
 type ConditionalMenu struct {
 	Button    []*MenuButton `json:"button"`    // 菜单按钮
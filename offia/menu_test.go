@@ -274,3 +274,47 @@ func TestDeleteConditionalMenu(t *testing.T) {
 
 	assert.Nil(t, err)
 }
+
+func TestTryMatchMenus(t *testing.T) {
+	menus := []byte(`{
+	"menu": {
+		"button": [{"type":"click","name":"今日歌曲","key":"V1001_TODAY_MUSIC","sub_button":[]}],
+		"menuid": 208396938
+	},
+	"conditionalmenu": [
+		{
+			"button": [{"type":"view","name":"搜索","url":"http://www.soso.com/","sub_button":[]}],
+			"matchrule": {"tag_id":"2","client_platform_type":"2"},
+			"menuid": 208396993
+		}
+	]
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.weixin.qq.com/cgi-bin/menu/get?access_token=ACCESS_TOKEN", nil).Return(menus, nil)
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/menu/trymatch?access_token=ACCESS_TOKEN", []byte(`{"user_id":"USER_A"}`)).Return([]byte(`{"button":[{"type":"view","name":"搜索","url":"http://www.soso.com/"}]}`), nil)
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/menu/trymatch?access_token=ACCESS_TOKEN", []byte(`{"user_id":"USER_B"}`)).Return([]byte(`{"button":[{"type":"click","name":"今日歌曲","key":"V1001_TODAY_MUSIC","sub_button":[]}]}`), nil)
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/menu/trymatch?access_token=ACCESS_TOKEN", []byte(`{"user_id":"USER_C"}`)).Return([]byte(`{"errcode":46002,"errmsg":"not exist menu info"}`), nil)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	reports, err := oa.TryMatchMenus(context.TODO(), "ACCESS_TOKEN", []string{"USER_A", "USER_B", "USER_C"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(reports))
+
+	assert.True(t, reports[0].Conditional)
+	assert.Equal(t, int64(208396993), reports[0].MenuID)
+	assert.Equal(t, &MenuMatchRule{TagID: "2", ClientPlatformType: "2"}, reports[0].MatchRule)
+
+	assert.False(t, reports[1].Conditional)
+	assert.Equal(t, int64(208396938), reports[1].MenuID)
+	assert.Nil(t, reports[1].Err)
+
+	assert.Equal(t, "USER_C", reports[2].UserID)
+	assert.NotNil(t, reports[2].Err)
+}
@@ -140,6 +140,24 @@ func SendTemplateMsg(msg *TemplateMsg) wx.Action {
 	)
 }
 
+// ResultTemplateMsgSend 模板消息发送结果
+type ResultTemplateMsgSend struct {
+	MsgID int64 `json:"msgid"` // 消息id，与模板消息发送完成事件（TEMPLATESENDJOBFINISH）中的 MsgID 对应
+}
+
+// SendTemplateMsgWithResult 基础消息能力 - 模板消息 - 发送模板消息，返回消息id（用于关联模板消息发送完成事件，见 Offia.WaitResult）
+// [参考](https://developers.weixin.qq.com/doc/offiaccount/Message_Management/Template_Message_Interface.html)
+func SendTemplateMsgWithResult(msg *TemplateMsg, result *ResultTemplateMsgSend) wx.Action {
+	return wx.NewPostAction(urls.OffiaTemplateMsgSend,
+		wx.WithBody(func() ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(msg)
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
 type ParamsTemplateSubscribe struct {
 	ToUser     string       `json:"touser"`                // 接收者openid
 	Scene      string       `json:"scene"`                 // 订阅场景值
@@ -150,7 +168,7 @@ type ParamsTemplateSubscribe struct {
 	Data       MsgTemplData `json:"data"`                  // 消息正文，value为消息内容文本（200字以内），没有固定格式，可用\n换行，color为整段消息内容的字体颜色（目前仅支持整段消息为一种颜色）
 }
 
-// SubscribeTemplateSubscribe 基础消息能力 - 公众号一次性订阅消息
+// SubscribeTemplate 基础消息能力 - 公众号一次性订阅消息 - 向已授权（见 Offia.SubscribeMsgAuthURL）的用户推送一条订阅消息
 func SubscribeTemplate(params *ParamsTemplateSubscribe) wx.Action {
 	return wx.NewPostAction(urls.OffiaTemplateSubscribe,
 		wx.WithBody(func() ([]byte, error) {
@@ -232,3 +232,34 @@ func TestSubscribeTemplate(t *testing.T) {
 
 	assert.Nil(t, err)
 }
+
+func TestSendTemplateMsgWithResult(t *testing.T) {
+	body := []byte(`{"touser":"OPENID","template_id":"TEMPLATE_ID","data":{"first":{"value":"恭喜你购买成功！"}}}`)
+
+	resp := []byte(`{"errcode":0,"errmsg":"ok","msgid":200228332}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/message/template/send?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	params := &TemplateMsg{
+		ToUser:     "OPENID",
+		TemplateID: "TEMPLATE_ID",
+		Data: MsgTemplData{
+			"first": {
+				Value: "恭喜你购买成功！",
+			},
+		},
+	}
+	result := new(ResultTemplateMsgSend)
+
+	err := oa.Do(context.TODO(), "ACCESS_TOKEN", SendTemplateMsgWithResult(params, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultTemplateMsgSend{MsgID: 200228332}, result)
+}
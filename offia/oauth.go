@@ -1,6 +1,7 @@
 package offia
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/shenghui0779/gochat/urls"
@@ -84,3 +85,23 @@ func GetApiTicket(ticketType TicketType, result *ResultApiTicket) wx.Action {
 		}),
 	)
 }
+
+// NewTicketManager returns a cached JSApi ticket manager (使用 oa.TokenManager() 获取 access_token)；
+// options 如：wx.WithTokenStore（需与 access_token 使用不同的 key）
+func NewTicketManager(oa *Offia, ticketType TicketType, options ...wx.TokenOption) *wx.TokenManager {
+	return wx.NewTokenManager(func(ctx context.Context) (string, int64, error) {
+		accessToken, err := oa.TokenManager().Token(ctx)
+
+		if err != nil {
+			return "", 0, err
+		}
+
+		result := new(ResultApiTicket)
+
+		if err = oa.Do(ctx, accessToken, GetApiTicket(ticketType, result)); err != nil {
+			return "", 0, err
+		}
+
+		return result.Ticket, result.ExpiresIn, nil
+	}, options...)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/shenghui0779/gochat/mock"
+	"github.com/shenghui0779/gochat/wx"
 )
 
 func TestCheckOAuthToken(t *testing.T) {
@@ -93,3 +94,31 @@ func TestGetApiTicket(t *testing.T) {
 		ExpiresIn: 7200,
 	}, result)
 }
+
+func TestTicketManager(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.weixin.qq.com/cgi-bin/token?grant_type=client_credential&appid=APPID&secret=APPSECRET", nil).Return([]byte(`{"access_token":"ACCESS_TOKEN","expires_in":7200}`), nil).Times(1)
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.weixin.qq.com/cgi-bin/ticket/getticket?access_token=ACCESS_TOKEN&type=jsapi", nil).Return([]byte(`{"errcode":0,"errmsg":"ok","ticket":"TICKET","expires_in":7200}`), nil).Times(1)
+
+	store := wx.NewMemTokenStore()
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client), WithTokenOptions(wx.WithTokenStore(store, "APPID:access_token")))
+
+	mgr := NewTicketManager(oa, JSAPITicket, wx.WithTokenStore(store, "APPID:jsapi_ticket"))
+
+	for i := 0; i < 2; i++ {
+		ticket, err := mgr.Token(context.TODO())
+
+		assert.Nil(t, err)
+		assert.Equal(t, "TICKET", ticket)
+	}
+
+	snapshot, err := store.Get(context.TODO(), "APPID:access_token")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "ACCESS_TOKEN", snapshot.Token)
+}
@@ -26,13 +26,22 @@ const (
 
 // Offia 微信公众号
 type Offia struct {
-	appid     string
-	appsecret string
-	originid  string
-	token     string
-	aeskey    string
-	nonce     func() string
-	client    wx.HTTPClient
+	appid      string
+	appsecret  string
+	originid   string
+	token      string
+	aeskey     string
+	nonce      func() string
+	client     wx.HTTPClient
+	baseURL    string
+	tokenmgr   *wx.TokenManager
+	tokenopts  []wx.TokenOption
+	dials      []wx.DialOption
+	headers    map[string]string
+	lenient    bool
+	results    TemplateResultStore
+	subauths   SubscribeAuthStore
+	transcoder VoiceTranscoder
 }
 
 // AppID returns appid
@@ -51,15 +60,17 @@ func (oa *Offia) OAuth2URL(scope AuthScope, redirectURL, state string) string {
 	return fmt.Sprintf("%s?appid=%s&redirect_uri=%s&response_type=code&scope=%s&state=%s#wechat_redirect", urls.Oauth2Authorize, oa.appid, redirectURL, scope, state)
 }
 
-// SubscribeMsgAuthURL 公众号一次性订阅消息授权URL（请使用 URLEncode 对 redirectURL 进行处理）
+// SubscribeMsgAuthURL 公众号一次性订阅消息授权URL（请使用 URLEncode 对 redirectURL 进行处理；scene 为 0-10000 的整数值，用户授权后原样带回并用于 SubscribeTemplate 发送）
 // [参考](https://developers.weixin.qq.com/doc/offiaccount/Message_Management/One-time_subscription_info.html)
 func (oa *Offia) SubscribeMsgAuthURL(scene, templateID, redirectURL, reserved string) string {
-	return fmt.Sprintf("%s?action=get_confirm&appid=%s&template_id=%s&redirect_url=%s&reserved=%s#wechat_redirect", urls.SubscribeMsgAuth, oa.appid, templateID, redirectURL, reserved)
+	return fmt.Sprintf("%s?action=get_confirm&appid=%s&scene=%s&template_id=%s&redirect_url=%s&reserved=%s#wechat_redirect", urls.SubscribeMsgAuth, oa.appid, scene, templateID, redirectURL, reserved)
 }
 
 // Code2OAuthToken 获取网页授权Token
 func (oa *Offia) Code2OAuthToken(ctx context.Context, code string, options ...wx.HTTPOption) (*OAuthToken, error) {
-	resp, err := oa.client.Do(ctx, http.MethodGet, fmt.Sprintf("%s?appid=%s&secret=%s&code=%s&grant_type=authorization_code", urls.OffiaSnsCode2Token, oa.appid, oa.appsecret, code), nil, options...)
+	options = wx.PrependHTTPHeaders(options, oa.headers)
+
+	resp, err := oa.client.Do(ctx, http.MethodGet, oa.endpoint(fmt.Sprintf("%s?appid=%s&secret=%s&code=%s&grant_type=authorization_code", urls.OffiaSnsCode2Token, oa.appid, oa.appsecret, code)), nil, options...)
 
 	if err != nil {
 		return nil, err
@@ -68,7 +79,7 @@ func (oa *Offia) Code2OAuthToken(ctx context.Context, code string, options ...wx
 	r := gjson.ParseBytes(resp)
 
 	if code := r.Get("errcode").Int(); code != 0 {
-		return nil, fmt.Errorf("%d|%s", code, r.Get("errmsg").String())
+		return nil, wx.NewAPIError(code, r.Get("errmsg").String())
 	}
 
 	token := new(OAuthToken)
@@ -82,7 +93,9 @@ func (oa *Offia) Code2OAuthToken(ctx context.Context, code string, options ...wx
 
 // RefreshOAuthToken 刷新网页授权AccessToken
 func (oa *Offia) RefreshOAuthToken(ctx context.Context, refreshToken string, options ...wx.HTTPOption) (*OAuthToken, error) {
-	resp, err := oa.client.Do(ctx, http.MethodGet, fmt.Sprintf("%s?appid=%s&grant_type=refresh_token&refresh_token=%s", urls.OffiaSnsRefreshAccessToken, oa.appid, refreshToken), nil, options...)
+	options = wx.PrependHTTPHeaders(options, oa.headers)
+
+	resp, err := oa.client.Do(ctx, http.MethodGet, oa.endpoint(fmt.Sprintf("%s?appid=%s&grant_type=refresh_token&refresh_token=%s", urls.OffiaSnsRefreshAccessToken, oa.appid, refreshToken)), nil, options...)
 
 	if err != nil {
 		return nil, err
@@ -91,7 +104,7 @@ func (oa *Offia) RefreshOAuthToken(ctx context.Context, refreshToken string, opt
 	r := gjson.ParseBytes(resp)
 
 	if code := r.Get("errcode").Int(); code != 0 {
-		return nil, fmt.Errorf("%d|%s", code, r.Get("errmsg").String())
+		return nil, wx.NewAPIError(code, r.Get("errmsg").String())
 	}
 
 	token := new(OAuthToken)
@@ -105,7 +118,9 @@ func (oa *Offia) RefreshOAuthToken(ctx context.Context, refreshToken string, opt
 
 // AccessToken 获取普通AccessToken
 func (oa *Offia) AccessToken(ctx context.Context, options ...wx.HTTPOption) (*AccessToken, error) {
-	resp, err := oa.client.Do(ctx, http.MethodGet, fmt.Sprintf("%s?grant_type=client_credential&appid=%s&secret=%s", urls.OffiaCgiBinAccessToken, oa.appid, oa.appsecret), nil, options...)
+	options = wx.PrependHTTPHeaders(options, oa.headers)
+
+	resp, err := oa.client.Do(ctx, http.MethodGet, oa.endpoint(fmt.Sprintf("%s?grant_type=client_credential&appid=%s&secret=%s", urls.OffiaCgiBinAccessToken, oa.appid, oa.appsecret)), nil, options...)
 
 	if err != nil {
 		return nil, err
@@ -114,7 +129,7 @@ func (oa *Offia) AccessToken(ctx context.Context, options ...wx.HTTPOption) (*Ac
 	r := gjson.ParseBytes(resp)
 
 	if code := r.Get("errcode").Int(); code != 0 {
-		return nil, fmt.Errorf("%d|%s", code, r.Get("errmsg").String())
+		return nil, wx.NewAPIError(code, r.Get("errmsg").String())
 	}
 
 	token := new(AccessToken)
@@ -126,13 +141,27 @@ func (oa *Offia) AccessToken(ctx context.Context, options ...wx.HTTPOption) (*Ac
 	return token, nil
 }
 
+// TokenManager returns the access_token manager（缓存，过期前自动刷新；配置项通过 WithTokenOptions 设置）
+func (oa *Offia) TokenManager() *wx.TokenManager {
+	return oa.tokenmgr
+}
+
+// endpoint returns the request url with base url applied
+func (oa *Offia) endpoint(reqURL string) string {
+	return wx.JoinURL(oa.baseURL, reqURL)
+}
+
 // Do exec action
 func (oa *Offia) Do(ctx context.Context, accessToken string, action wx.Action, options ...wx.HTTPOption) error {
+	options = wx.PrependHTTPHeaders(options, oa.headers, wx.ActionHeaders(action))
+
 	var (
 		resp []byte
 		err  error
 	)
 
+	reqURL := oa.endpoint(action.URL(accessToken))
+
 	if action.IsUpload() {
 		form, ferr := action.UploadForm()
 
@@ -140,7 +169,11 @@ func (oa *Offia) Do(ctx context.Context, accessToken string, action wx.Action, o
 			return ferr
 		}
 
-		resp, err = oa.client.Upload(ctx, action.URL(accessToken), form, options...)
+		if reqURL, options, err = wx.SignAction(action, reqURL, nil, options); err != nil {
+			return err
+		}
+
+		resp, err = oa.client.Upload(ctx, reqURL, form, options...)
 	} else {
 		body, berr := action.Body()
 
@@ -148,7 +181,11 @@ func (oa *Offia) Do(ctx context.Context, accessToken string, action wx.Action, o
 			return berr
 		}
 
-		resp, err = oa.client.Do(ctx, action.Method(), action.URL(accessToken), body, options...)
+		if reqURL, options, err = wx.SignAction(action, reqURL, body, options); err != nil {
+			return err
+		}
+
+		resp, err = oa.client.Do(ctx, action.Method(), reqURL, body, options...)
 	}
 
 	if err != nil {
@@ -158,7 +195,11 @@ func (oa *Offia) Do(ctx context.Context, accessToken string, action wx.Action, o
 	r := gjson.ParseBytes(resp)
 
 	if code := r.Get("errcode").Int(); code != 0 {
-		return fmt.Errorf("%d|%s", code, r.Get("errmsg").String())
+		return wx.NewAPIError(code, r.Get("errmsg").String())
+	}
+
+	if oa.lenient {
+		return wx.DecodeLenient(resp, action.Decode)
 	}
 
 	return action.Decode(resp)
@@ -176,7 +217,7 @@ func (oa *Offia) VerifyEventSign(signature string, items ...string) bool {
 
 // DecryptEventMessage 事件消息解密
 func (oa *Offia) DecryptEventMessage(encrypt string) (wx.WXML, error) {
-	b, err := event.Decrypt(oa.appid, oa.aeskey, encrypt)
+	b, err := oa.DecryptEvent(encrypt)
 
 	if err != nil {
 		return nil, err
@@ -185,6 +226,16 @@ func (oa *Offia) DecryptEventMessage(encrypt string) (wx.WXML, error) {
 	return wx.ParseXML2Map(b)
 }
 
+// SafeMode 是否为安全模式（已配置消息加解密密钥）；明文模式下推送的消息不加密
+func (oa *Offia) SafeMode() bool {
+	return len(oa.aeskey) != 0
+}
+
+// DecryptEvent 事件消息解密，返回解密后的原始报文（XML），可用于消息存档或重新解析
+func (oa *Offia) DecryptEvent(encrypt string) ([]byte, error) {
+	return event.Decrypt(oa.appid, oa.aeskey, encrypt)
+}
+
 // Reply 消息回复
 func (oa *Offia) Reply(openid string, reply event.Reply) (*event.ReplyMessage, error) {
 	body, err := reply.Bytes(oa.originid, openid)
@@ -249,6 +300,70 @@ func WithClient(c *http.Client) Option {
 	}
 }
 
+// WithHeader 设置请求 header（如：User-Agent、链路追踪 header），作用于所有请求
+func WithHeader(key, value string) Option {
+	return func(oa *Offia) {
+		oa.headers[key] = value
+	}
+}
+
+// WithDialOptions 设置 HTTP Client 的连接配置（如：强制 IPv4、自定义 DNS 解析），
+// 在所有配置项之后作用于默认或 WithClient 设置的 Client，与配置顺序无关；Client 的 Transport 不是 *http.Transport 时不生效
+func WithDialOptions(options ...wx.DialOption) Option {
+	return func(oa *Offia) {
+		oa.dials = options
+	}
+}
+
+// WithLenientDecode 宽松解析返回结果，兼容数字与数字字符串等类型不一致的字段
+func WithLenientDecode() Option {
+	return func(oa *Offia) {
+		oa.lenient = true
+	}
+}
+
+// WithTemplateResultStore 设置模板消息发送结果存储，用于 WaitResult（默认不存储；多实例部署时需使用共享存储，如：Redis）
+func WithTemplateResultStore(store TemplateResultStore) Option {
+	return func(oa *Offia) {
+		oa.results = store
+	}
+}
+
+// WithSubscribeAuthStore 设置用户订阅通知授权状态存储，用于 SubscribeAuthStatus、CanSendSubscribeMsg（默认不存储；多实例部署时需使用共享存储，如：Redis）
+func WithSubscribeAuthStore(store SubscribeAuthStore) Option {
+	return func(oa *Offia) {
+		oa.subauths = store
+	}
+}
+
+// WithVoiceTranscoder 设置语音转码，用于上传非 AMR、MP3 格式的语音
+func WithVoiceTranscoder(t VoiceTranscoder) Option {
+	return func(oa *Offia) {
+		oa.transcoder = t
+	}
+}
+
+// WithHTTPClient 设置已配置的 wx.HTTPClient（如：第三方平台代授权方调用时复用平台的 Client）
+func WithHTTPClient(c wx.HTTPClient) Option {
+	return func(oa *Offia) {
+		oa.client = c
+	}
+}
+
+// WithTokenOptions 设置 access_token 管理的配置项（如：wx.WithTokenStore）
+func WithTokenOptions(options ...wx.TokenOption) Option {
+	return func(oa *Offia) {
+		oa.tokenopts = options
+	}
+}
+
+// WithBaseURL 设置接口域名（默认：https://api.weixin.qq.com），可用于切换备用域名（如：https://api2.weixin.qq.com）
+func WithBaseURL(base string) Option {
+	return func(oa *Offia) {
+		oa.baseURL = base
+	}
+}
+
 // WithMockClient 设置 Mock Client
 func WithMockClient(c wx.HTTPClient) Option {
 	return func(oa *Offia) {
@@ -264,12 +379,26 @@ func New(appid, appsecret string, options ...Option) *Offia {
 		nonce: func() string {
 			return wx.Nonce(16)
 		},
-		client: wx.NewDefaultClient(),
+		client:  wx.NewDefaultClient(),
+		baseURL: wx.BaseURLAPI,
+		headers: make(map[string]string),
 	}
 
 	for _, f := range options {
 		f(oa)
 	}
 
+	oa.client = wx.ApplyDialOptions(oa.client, oa.dials...)
+
+	oa.tokenmgr = wx.NewTokenManager(func(ctx context.Context) (string, int64, error) {
+		token, err := oa.AccessToken(ctx)
+
+		if err != nil {
+			return "", 0, err
+		}
+
+		return token.Token, token.ExpiresIn, nil
+	}, oa.tokenopts...)
+
 	return oa
 }
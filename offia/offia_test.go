@@ -26,6 +26,12 @@ func TestOAuth2URL(t *testing.T) {
 	assert.Equal(t, "https://open.weixin.qq.com/connect/oauth2/authorize?appid=APPID&redirect_uri=RedirectURL&response_type=code&scope=snsapi_userinfo&state=STATE#wechat_redirect", oa.OAuth2URL(ScopeSnsapiUser, "RedirectURL", "STATE"))
 }
 
+func TestSubscribeMsgAuthURL(t *testing.T) {
+	oa := New("APPID", "APPSECRET")
+
+	assert.Equal(t, "https://mp.weixin.qq.com/mp/subscribemsg?action=get_confirm&appid=APPID&scene=1000&template_id=TEMPLATE_ID&redirect_url=RedirectURL&reserved=RESERVED#wechat_redirect", oa.SubscribeMsgAuthURL("1000", "TEMPLATE_ID", "RedirectURL", "RESERVED"))
+}
+
 func TestCode2OAuthToken(t *testing.T) {
 	resp := []byte(`{
 	"access_token": "ACCESS_TOKEN",
@@ -110,6 +116,69 @@ func TestAccessToken(t *testing.T) {
 	}, accessToken)
 }
 
+func TestWithHeader(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	// client header 与 action header 各追加一个 option
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.weixin.qq.com/cgi-bin/get_api_domain_ip?access_token=ACCESS_TOKEN", nil, gomock.Any(), gomock.Any()).Return([]byte(`{"ip_list":["127.0.0.1"]}`), nil)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client), WithHeader("User-Agent", "gochat"))
+
+	err := oa.Do(context.TODO(), "ACCESS_TOKEN", wx.NewGetAction("https://api.weixin.qq.com/cgi-bin/get_api_domain_ip", wx.WithHeader("X-Trace-Id", "TRACE_ID")))
+
+	assert.Nil(t, err)
+}
+
+func TestWithBaseURL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	gomock.InOrder(
+		client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api2.weixin.qq.com/cgi-bin/token?grant_type=client_credential&appid=APPID&secret=APPSECRET", nil).Return([]byte(`{"access_token":"ACCESS_TOKEN","expires_in":7200}`), nil),
+		client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api2.weixin.qq.com/cgi-bin/get_api_domain_ip?access_token=ACCESS_TOKEN", nil).Return([]byte(`{"ip_list":["127.0.0.1"]}`), nil),
+		// Action 指定的域名优先
+		client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://sh.api.weixin.qq.com/cgi-bin/get_api_domain_ip?access_token=ACCESS_TOKEN", nil).Return([]byte(`{"ip_list":["127.0.0.1"]}`), nil),
+	)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client), WithBaseURL("https://api2.weixin.qq.com"))
+
+	_, err := oa.AccessToken(context.TODO())
+
+	assert.Nil(t, err)
+	assert.Nil(t, oa.Do(context.TODO(), "ACCESS_TOKEN", wx.NewGetAction("/cgi-bin/get_api_domain_ip")))
+	assert.Nil(t, oa.Do(context.TODO(), "ACCESS_TOKEN", wx.NewGetAction("/cgi-bin/get_api_domain_ip", wx.WithBaseURL("https://sh.api.weixin.qq.com"))))
+}
+
+func TestWithSigner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/get_api_domain_ip?access_token=ACCESS_TOKEN&signature=SIGNATURE", []byte(`{}`), gomock.Any()).Return([]byte(`{"ip_list":["127.0.0.1"]}`), nil)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	err := oa.Do(context.TODO(), "ACCESS_TOKEN", wx.NewPostAction("https://api.weixin.qq.com/cgi-bin/get_api_domain_ip",
+		wx.WithBody(func() ([]byte, error) {
+			return []byte(`{}`), nil
+		}),
+		wx.WithSigner(func(req *wx.SignRequest) error {
+			req.URL += "&signature=SIGNATURE"
+			req.Header["X-Signature"] = "SIGNATURE"
+
+			return nil
+		}),
+	))
+
+	assert.Nil(t, err)
+}
+
 func TestVerifyEventSign(t *testing.T) {
 	oa := New("APPID", "APPSECRET", WithServerConfig("2faf43d6343a802b6073aae5b3f2f109", "jxAko083VoJ3lcPXJWzcGJ0M1tFVLgdD6qAq57GJY1U"))
 
@@ -0,0 +1,93 @@
+package offia
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/shenghui0779/gochat/event"
+	"github.com/shenghui0779/gochat/urls"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+type ResultCallbackIP struct {
+	IPList []string `json:"ip_list"`
+}
+
+// GetCallbackIP 获取微信callback IP地址（即微信推送消息的来源IP）
+// [参考](https://developers.weixin.qq.com/doc/offiaccount/Basic_Information/Get_the_WeChat_server_IP_address.html)
+func GetCallbackIP(result *ResultCallbackIP) wx.Action {
+	return wx.NewGetAction(urls.OffiaCgiBinCallbackIP,
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// Preflight 启动时预检服务器配置：本地模拟服务器地址验证（token 签名）及消息加解密（EncodingAESKey），
+// 并调用 getcallbackip 校验 access_token 可用；返回微信推送消息的来源IP列表
+func (oa *Offia) Preflight(ctx context.Context, accessToken string, options ...wx.HTTPOption) ([]string, error) {
+	if err := oa.checkServerConfig(); err != nil {
+		return nil, fmt.Errorf("preflight: %w", err)
+	}
+
+	result := new(ResultCallbackIP)
+
+	if err := oa.Do(ctx, accessToken, GetCallbackIP(result), options...); err != nil {
+		return nil, fmt.Errorf("preflight: getcallbackip: %w", err)
+	}
+
+	return result.IPList, nil
+}
+
+func (oa *Offia) checkServerConfig() error {
+	if len(oa.token) == 0 {
+		return errors.New("empty server token")
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	nonce := oa.nonce()
+
+	// 模拟服务器地址验证
+	if !oa.VerifyEventSign(event.SignWithSHA1(oa.token, timestamp, nonce), timestamp, nonce) {
+		return errors.New("server token signature mismatch")
+	}
+
+	// 明文模式无需 EncodingAESKey
+	if len(oa.aeskey) == 0 {
+		return nil
+	}
+
+	if len(oa.aeskey) != 43 {
+		return fmt.Errorf("invalid EncodingAESKey length: %d, want 43", len(oa.aeskey))
+	}
+
+	if _, err := base64.StdEncoding.DecodeString(oa.aeskey + "="); err != nil {
+		return fmt.Errorf("invalid EncodingAESKey: %w", err)
+	}
+
+	// 模拟加密消息的加解密
+	echo := []byte("<xml><Echo><![CDATA[" + nonce + "]]></Echo></xml>")
+
+	cipherText, err := event.Encrypt(oa.appid, oa.aeskey, oa.nonce(), echo)
+
+	if err != nil {
+		return fmt.Errorf("encrypt echo message: %w", err)
+	}
+
+	m, err := oa.DecryptEventMessage(base64.StdEncoding.EncodeToString(cipherText))
+
+	if err != nil {
+		return fmt.Errorf("decrypt echo message: %w", err)
+	}
+
+	if m["Echo"] != nonce {
+		return errors.New("echo message mismatch")
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package offia
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/mock"
+)
+
+func TestGetCallbackIP(t *testing.T) {
+	resp := []byte(`{"ip_list":["127.0.0.1","127.0.0.2","101.226.103.0/25"]}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.weixin.qq.com/cgi-bin/getcallbackip?access_token=ACCESS_TOKEN", nil).Return(resp, nil)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	result := new(ResultCallbackIP)
+
+	err := oa.Do(context.TODO(), "ACCESS_TOKEN", GetCallbackIP(result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultCallbackIP{
+		IPList: []string{"127.0.0.1", "127.0.0.2", "101.226.103.0/25"},
+	}, result)
+}
+
+func TestPreflight(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.weixin.qq.com/cgi-bin/getcallbackip?access_token=ACCESS_TOKEN", nil).Return([]byte(`{"ip_list":["127.0.0.1"]}`), nil)
+
+	oa := New("wx1def0e9e5891b338", "APPSECRET", WithServerConfig("2faf43d6343a802b6073aae5b3f2f109", "jxAko083VoJ3lcPXJWzcGJ0M1tFVLgdD6qAq57GJY1U"), WithMockClient(client))
+
+	ips, err := oa.Preflight(context.TODO(), "ACCESS_TOKEN")
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"127.0.0.1"}, ips)
+
+	// 配置错误时不调用接口
+	_, err = New("APPID", "APPSECRET").Preflight(context.TODO(), "ACCESS_TOKEN")
+
+	assert.EqualError(t, err, "preflight: empty server token")
+
+	_, err = New("APPID", "APPSECRET", WithServerConfig("TOKEN", "jxAko083VoJ3lcPXJWzcGJ0M1tFVLgdD6qAq57GJY")).Preflight(context.TODO(), "ACCESS_TOKEN")
+
+	assert.EqualError(t, err, "preflight: invalid EncodingAESKey length: 41, want 43")
+
+	// access_token 无效
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.weixin.qq.com/cgi-bin/getcallbackip?access_token=ACCESS_TOKEN", nil).Return([]byte(`{"errcode":40001,"errmsg":"invalid credential"}`), nil)
+
+	_, err = New("APPID", "APPSECRET", WithServerConfig("TOKEN", ""), WithMockClient(client)).Preflight(context.TODO(), "ACCESS_TOKEN")
+
+	assert.NotNil(t, err)
+}
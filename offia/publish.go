@@ -1,7 +1,10 @@
 package offia
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"time"
 
 	"github.com/shenghui0779/gochat/urls"
 	"github.com/shenghui0779/gochat/wx"
@@ -167,3 +170,86 @@ func BatchGetPublish(offset, count, nocontent int, result *ResultPublishBatchGet
 		}),
 	)
 }
+
+// 发布状态
+const (
+	PublishSuccess      = 0 // 成功
+	PublishPending      = 1 // 发布中
+	PublishOriginalFail = 2 // 原创失败
+	PublishFail         = 3 // 常规失败
+	PublishAuditFail    = 4 // 平台审核不通过
+	PublishDeleted      = 5 // 成功后用户删除所有文章
+	PublishBanned       = 6 // 成功后系统封禁所有文章
+)
+
+// PublishError 发布失败
+type PublishError struct {
+	PublishID string
+	Status    int   // 发布状态
+	FailIDX   []int // 原创审核不通过或审核失败的文章编号（第一篇为1）
+}
+
+func (e *PublishError) Error() string {
+	return fmt.Sprintf("publish %s failed, status: %d, fail_idx: %v", e.PublishID, e.Status, e.FailIDX)
+}
+
+// ArticleURLs returns the urls of published articles
+func (r *ResultPublishGet) ArticleURLs() []string {
+	if r.ArticleDetail == nil {
+		return nil
+	}
+
+	list := make([]string, 0, len(r.ArticleDetail.Item))
+
+	for _, v := range r.ArticleDetail.Item {
+		list = append(list, v.ArticleURL)
+	}
+
+	return list
+}
+
+// 发布状态轮询的退避间隔
+var (
+	publishPollInterval    = time.Second
+	publishPollMaxInterval = 30 * time.Second
+)
+
+// WaitForPublish 发布能力 - 轮询发布状态（指数退避：1s、2s、4s ... 最大30s）直至发布完成；
+// 发布失败返回 *PublishError，超时请通过 ctx 控制
+func (oa *Offia) WaitForPublish(ctx context.Context, accessToken, publishID string, options ...wx.HTTPOption) (*ResultPublishGet, error) {
+	interval := publishPollInterval
+
+	for {
+		result := new(ResultPublishGet)
+
+		if err := oa.Do(ctx, accessToken, GetPublish(publishID, result), options...); err != nil {
+			return nil, err
+		}
+
+		switch result.PublishStatus {
+		case PublishSuccess:
+			return result, nil
+		case PublishPending:
+		default:
+			return nil, &PublishError{
+				PublishID: publishID,
+				Status:    result.PublishStatus,
+				FailIDX:   result.FailIDX,
+			}
+		}
+
+		timer := time.NewTimer(interval)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		if interval *= 2; interval > publishPollMaxInterval {
+			interval = publishPollMaxInterval
+		}
+	}
+}
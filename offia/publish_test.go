@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -233,3 +234,70 @@ func TestBatchGetPublish(t *testing.T) {
 		},
 	}, result)
 }
+
+func TestWaitForPublish(t *testing.T) {
+	publishPollInterval = time.Millisecond
+
+	body := []byte(`{"publish_id":"100000001"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	gomock.InOrder(
+		client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/freepublish/get?access_token=ACCESS_TOKEN", body).Return([]byte(`{"publish_id":"100000001","publish_status":1}`), nil).Times(2),
+		client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/freepublish/get?access_token=ACCESS_TOKEN", body).Return([]byte(`{"publish_id":"100000001","publish_status":0,"article_id":"ARTICLE_ID","article_detail":{"count":1,"item":[{"idx":1,"article_url":"ARTICLE_URL"}]}}`), nil),
+	)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	result, err := oa.WaitForPublish(context.TODO(), "ACCESS_TOKEN", "100000001")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "ARTICLE_ID", result.ArticleID)
+	assert.Equal(t, []string{"ARTICLE_URL"}, result.ArticleURLs())
+}
+
+func TestWaitForPublishFail(t *testing.T) {
+	publishPollInterval = time.Millisecond
+
+	body := []byte(`{"publish_id":"100000001"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/freepublish/get?access_token=ACCESS_TOKEN", body).Return([]byte(`{"publish_id":"100000001","publish_status":2,"fail_idx":[1,2]}`), nil)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	_, err := oa.WaitForPublish(context.TODO(), "ACCESS_TOKEN", "100000001")
+
+	assert.Equal(t, &PublishError{
+		PublishID: "100000001",
+		Status:    PublishOriginalFail,
+		FailIDX:   []int{1, 2},
+	}, err)
+}
+
+func TestWaitForPublishTimeout(t *testing.T) {
+	publishPollInterval = time.Millisecond
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.Any(), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/freepublish/get?access_token=ACCESS_TOKEN", gomock.Any()).Return([]byte(`{"publish_id":"100000001","publish_status":1}`), nil).MinTimes(1)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := oa.WaitForPublish(ctx, "ACCESS_TOKEN", "100000001")
+
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
@@ -0,0 +1,130 @@
+package offia
+
+import (
+	"encoding/json"
+
+	"github.com/shenghui0779/gochat/urls"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// 消息接口的 cgi_path
+const (
+	CgiPathTemplateMsgSend  = "/cgi-bin/message/template/send"     // 模板消息
+	CgiPathSubscribeMsgSend = "/cgi-bin/message/subscribe/bizsend" // 订阅通知
+	CgiPathKFMsgSend        = "/cgi-bin/message/custom/send"       // 客服消息
+	CgiPathMassSendAll      = "/cgi-bin/message/mass/sendall"      // 群发消息（按标签）
+	CgiPathMassSend         = "/cgi-bin/message/mass/send"         // 群发消息（按OpenID列表）
+)
+
+type ParamsQuotaClear struct {
+	AppID string `json:"appid"`
+}
+
+// ClearQuota openApi管理 - 重置API调用次数（每月共10次）
+// [参考](https://developers.weixin.qq.com/doc/offiaccount/openApi/clear_quota.html)
+func ClearQuota(appid string) wx.Action {
+	params := &ParamsQuotaClear{
+		AppID: appid,
+	}
+
+	return wx.NewPostAction(urls.OffiaClearQuota,
+		wx.WithBody(func() ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+	)
+}
+
+type ParamsAPIQuota struct {
+	CgiPath string `json:"cgi_path"`
+}
+
+// APIQuota 接口每日调用额度
+type APIQuota struct {
+	DailyLimit int64 `json:"daily_limit"` // 当天该账号可调用该接口的次数
+	Used       int64 `json:"used"`        // 当天已经调用的次数
+	Remain     int64 `json:"remain"`      // 当天剩余调用次数
+}
+
+// APIRateLimit 接口频率限制
+type APIRateLimit struct {
+	CallCount     int64 `json:"call_count"`     // 周期内可调用数量，单位：次
+	RefreshSecond int64 `json:"refresh_second"` // 更新周期，单位：秒
+}
+
+type ResultAPIQuota struct {
+	Quota              *APIQuota     `json:"quota"`                // quota详情
+	RateLimit          *APIRateLimit `json:"rate_limit"`           // 普通调用频率限制
+	ComponentRateLimit *APIRateLimit `json:"component_rate_limit"` // 代调用频率限制
+}
+
+// Exhausted 当天额度是否已用完
+func (r *ResultAPIQuota) Exhausted() bool {
+	return r.Quota != nil && r.Quota.Remain <= 0
+}
+
+// GetAPIQuota openApi管理 - 查询API调用额度
+// [参考](https://developers.weixin.qq.com/doc/offiaccount/openApi/get_api_quota.html)
+func GetAPIQuota(cgiPath string, result *ResultAPIQuota) wx.Action {
+	params := &ParamsAPIQuota{
+		CgiPath: cgiPath,
+	}
+
+	return wx.NewPostAction(urls.OffiaGetAPIQuota,
+		wx.WithBody(func() ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// GetTemplateMsgQuota openApi管理 - 查询模板消息当天剩余额度
+func GetTemplateMsgQuota(result *ResultAPIQuota) wx.Action {
+	return GetAPIQuota(CgiPathTemplateMsgSend, result)
+}
+
+// GetSubscribeMsgQuota openApi管理 - 查询订阅通知当天剩余额度
+func GetSubscribeMsgQuota(result *ResultAPIQuota) wx.Action {
+	return GetAPIQuota(CgiPathSubscribeMsgSend, result)
+}
+
+// GetKFMsgQuota openApi管理 - 查询客服消息当天剩余额度
+func GetKFMsgQuota(result *ResultAPIQuota) wx.Action {
+	return GetAPIQuota(CgiPathKFMsgSend, result)
+}
+
+type ParamsRidGet struct {
+	Rid string `json:"rid"`
+}
+
+// RidRequest rid对应的请求详情
+type RidRequest struct {
+	InvokeTime   int64  `json:"invoke_time"`   // 发起请求的时间戳
+	CostInMS     int64  `json:"cost_in_ms"`    // 请求毫秒级耗时
+	RequestURL   string `json:"request_url"`   // 请求的URL参数
+	RequestBody  string `json:"request_body"`  // post请求的请求参数
+	ResponseBody string `json:"response_body"` // 接口请求返回参数
+	ClientIP     string `json:"client_ip"`     // 接口请求的客户端ip
+}
+
+type ResultRidGet struct {
+	Request *RidRequest `json:"request"`
+}
+
+// GetRid openApi管理 - 查询rid信息（接口报错返回的rid）
+// [参考](https://developers.weixin.qq.com/doc/offiaccount/openApi/get_rid_info.html)
+func GetRid(rid string, result *ResultRidGet) wx.Action {
+	params := &ParamsRidGet{
+		Rid: rid,
+	}
+
+	return wx.NewPostAction(urls.OffiaGetRid,
+		wx.WithBody(func() ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
@@ -0,0 +1,170 @@
+package offia
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/mock"
+)
+
+func TestClearQuota(t *testing.T) {
+	body := []byte(`{"appid":"APPID"}`)
+	resp := []byte(`{"errcode":0,"errmsg":"ok"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/clear_quota?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	err := oa.Do(context.TODO(), "ACCESS_TOKEN", ClearQuota("APPID"))
+
+	assert.Nil(t, err)
+}
+
+func TestGetAPIQuota(t *testing.T) {
+	body := []byte(`{"cgi_path":"/cgi-bin/message/custom/send"}`)
+	resp := []byte(`{
+	"errcode": 0,
+	"errmsg": "ok",
+	"quota": {
+		"daily_limit": 0,
+		"used": 0,
+		"remain": 0
+	},
+	"rate_limit": {
+		"call_count": 0,
+		"refresh_second": 0
+	},
+	"component_rate_limit": {
+		"call_count": 0,
+		"refresh_second": 0
+	}
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/openapi/quota/get?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	result := new(ResultAPIQuota)
+
+	err := oa.Do(context.TODO(), "ACCESS_TOKEN", GetKFMsgQuota(result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultAPIQuota{
+		Quota:              &APIQuota{},
+		RateLimit:          &APIRateLimit{},
+		ComponentRateLimit: &APIRateLimit{},
+	}, result)
+	assert.True(t, result.Exhausted())
+}
+
+func TestGetTemplateMsgQuota(t *testing.T) {
+	body := []byte(`{"cgi_path":"/cgi-bin/message/template/send"}`)
+	resp := []byte(`{
+	"errcode": 0,
+	"errmsg": "ok",
+	"quota": {
+		"daily_limit": 100000,
+		"used": 4000,
+		"remain": 96000
+	}
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/openapi/quota/get?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	result := new(ResultAPIQuota)
+
+	err := oa.Do(context.TODO(), "ACCESS_TOKEN", GetTemplateMsgQuota(result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultAPIQuota{
+		Quota: &APIQuota{
+			DailyLimit: 100000,
+			Used:       4000,
+			Remain:     96000,
+		},
+	}, result)
+	assert.False(t, result.Exhausted())
+}
+
+func TestGetSubscribeMsgQuota(t *testing.T) {
+	body := []byte(`{"cgi_path":"/cgi-bin/message/subscribe/bizsend"}`)
+	resp := []byte(`{"errcode":0,"errmsg":"ok","quota":{"daily_limit":1000,"used":1000,"remain":0}}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/openapi/quota/get?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	result := new(ResultAPIQuota)
+
+	err := oa.Do(context.TODO(), "ACCESS_TOKEN", GetSubscribeMsgQuota(result))
+
+	assert.Nil(t, err)
+	assert.True(t, result.Exhausted())
+}
+
+func TestGetRid(t *testing.T) {
+	body := []byte(`{"rid":"61725984-6126f6f9-040f19c4"}`)
+	resp := []byte(`{
+	"errcode": 0,
+	"errmsg": "ok",
+	"request": {
+		"invoke_time": 1635156704,
+		"cost_in_ms": 30,
+		"request_url": "access_token=50_Im7xxxx",
+		"request_body": "",
+		"response_body": "{\"errcode\":45009,\"errmsg\":\"reach max api daily quota limit rid: 61725984-6126f6f9-040f19c4\"}",
+		"client_ip": "113.xx.70.51"
+	}
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/openapi/rid/get?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	result := new(ResultRidGet)
+
+	err := oa.Do(context.TODO(), "ACCESS_TOKEN", GetRid("61725984-6126f6f9-040f19c4", result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultRidGet{
+		Request: &RidRequest{
+			InvokeTime:   1635156704,
+			CostInMS:     30,
+			RequestURL:   "access_token=50_Im7xxxx",
+			RequestBody:  "",
+			ResponseBody: `{"errcode":45009,"errmsg":"reach max api daily quota limit rid: 61725984-6126f6f9-040f19c4"}`,
+			ClientIP:     "113.xx.70.51",
+		},
+	}, result)
+}
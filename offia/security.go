@@ -0,0 +1,162 @@
+package offia
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/shenghui0779/gochat/urls"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// ErrCodeRiskyContent 内容含有违法违规内容
+const ErrCodeRiskyContent = 87014
+
+// MsgSecCheck 内容安全 - 检查一段文本是否含有违法违规内容（含违规内容时返回 87014 错误）
+func MsgSecCheck(content string) wx.Action {
+	return wx.NewPostAction(urls.OffiaMsgSecCheck,
+		wx.WithBody(func() ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(map[string]string{
+				"content": content,
+			})
+		}),
+	)
+}
+
+// ImageSecCheck 内容安全 - 校验一张图片是否含有违法违规内容（图片大小不超过1M，含违规内容时返回 87014 错误）
+func ImageSecCheck(filename string, data []byte) wx.Action {
+	return wx.NewPostAction(urls.OffiaImgSecCheck,
+		wx.WithUpload(func() (wx.UploadForm, error) {
+			return wx.NewUploadForm(
+				wx.WithFormFile("media", filename, func(w io.Writer) error {
+					_, err := io.Copy(w, bytes.NewReader(data))
+
+					return err
+				}),
+			), nil
+		}),
+	)
+}
+
+// ArticleViolation 图文消息的违规项
+type ArticleViolation struct {
+	Index int    // 图文在草稿中的位置，从0开始
+	Field string // 违规字段：title、author、digest、content、image
+	Value string // 违规内容（图片为图片链接）
+	Err   error  // 接口返回的错误（*wx.APIError）
+}
+
+func (v *ArticleViolation) String() string {
+	return fmt.Sprintf("article[%d].%s: %s", v.Index, v.Field, v.Err)
+}
+
+var (
+	articleImgRegexp = regexp.MustCompile(`(?i)<img[^>]+?(?:data-src|src)\s*=\s*["']([^"']+)["']`)
+	articleTagRegexp = regexp.MustCompile(`(?s)<[^>]*>`)
+)
+
+// PrecheckDraftArticles 发布前预检草稿图文：依次检测标题、作者、摘要、正文文本及正文中的图片是否含有违法违规内容，
+// 返回汇总的违规项（无违规时为空）；除 87014 外的错误（如：图片下载失败、图片过大）直接返回
+func (oa *Offia) PrecheckDraftArticles(ctx context.Context, accessToken string, articles []*DraftArticle, options ...wx.HTTPOption) ([]*ArticleViolation, error) {
+	violations := make([]*ArticleViolation, 0)
+
+	for i, article := range articles {
+		texts := []struct {
+			field string
+			value string
+		}{
+			{"title", article.Title},
+			{"author", article.Author},
+			{"digest", article.Digest},
+			{"content", articleText(article.Content)},
+		}
+
+		for _, v := range texts {
+			if len(v.value) == 0 {
+				continue
+			}
+
+			if err := oa.Do(ctx, accessToken, MsgSecCheck(v.value), options...); err != nil {
+				if !wx.IsAPIError(err, ErrCodeRiskyContent) {
+					return nil, fmt.Errorf("article[%d].%s: %w", i, v.field, err)
+				}
+
+				violations = append(violations, &ArticleViolation{
+					Index: i,
+					Field: v.field,
+					Value: v.value,
+					Err:   err,
+				})
+			}
+		}
+
+		for _, imgURL := range articleImages(article.Content) {
+			data, err := oa.client.Do(ctx, http.MethodGet, imgURL, nil, options...)
+
+			if err != nil {
+				return nil, fmt.Errorf("article[%d].image: download %s: %w", i, imgURL, err)
+			}
+
+			if err = oa.Do(ctx, accessToken, ImageSecCheck(articleImageName(imgURL), data), options...); err != nil {
+				if !wx.IsAPIError(err, ErrCodeRiskyContent) {
+					return nil, fmt.Errorf("article[%d].image: %s: %w", i, imgURL, err)
+				}
+
+				violations = append(violations, &ArticleViolation{
+					Index: i,
+					Field: "image",
+					Value: imgURL,
+					Err:   err,
+				})
+			}
+		}
+	}
+
+	return violations, nil
+}
+
+// articleText 去除正文中的 html 标签并合并空白，返回纯文本
+func articleText(content string) string {
+	return strings.Join(strings.Fields(html.UnescapeString(articleTagRegexp.ReplaceAllString(content, " "))), " ")
+}
+
+// articleImages 返回正文中的图片链接（去重）
+func articleImages(content string) []string {
+	matches := articleImgRegexp.FindAllStringSubmatch(content, -1)
+
+	images := make([]string, 0, len(matches))
+	seen := make(map[string]struct{}, len(matches))
+
+	for _, m := range matches {
+		imgURL := html.UnescapeString(m[1])
+
+		if _, ok := seen[imgURL]; ok {
+			continue
+		}
+
+		seen[imgURL] = struct{}{}
+		images = append(images, imgURL)
+	}
+
+	return images
+}
+
+func articleImageName(imgURL string) string {
+	if i := strings.IndexAny(imgURL, "?#"); i != -1 {
+		imgURL = imgURL[:i]
+	}
+
+	name := path.Base(imgURL)
+
+	if len(name) == 0 || name == "/" || name == "." || !strings.Contains(name, ".") {
+		return "image.jpg"
+	}
+
+	return name
+}
@@ -0,0 +1,104 @@
+package offia
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/mock"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+func TestMsgSecCheck(t *testing.T) {
+	body := []byte(`{"content":"hello world!"}`)
+	resp := []byte(`{"errcode":87014,"errmsg":"risky content"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/wxa/msg_sec_check?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	err := oa.Do(context.TODO(), "ACCESS_TOKEN", MsgSecCheck("hello world!"))
+
+	assert.True(t, wx.IsAPIError(err, ErrCodeRiskyContent))
+}
+
+func TestImageSecCheck(t *testing.T) {
+	resp := []byte(`{"errcode":0,"errmsg":"ok"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Upload(gomock.AssignableToTypeOf(context.TODO()), "https://api.weixin.qq.com/wxa/img_sec_check?access_token=ACCESS_TOKEN", gomock.AssignableToTypeOf(wx.NewUploadForm())).Return(resp, nil)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	err := oa.Do(context.TODO(), "ACCESS_TOKEN", ImageSecCheck("test.jpg", []byte("IMAGE")))
+
+	assert.Nil(t, err)
+}
+
+func TestPrecheckDraftArticles(t *testing.T) {
+	ok := []byte(`{"errcode":0,"errmsg":"ok"}`)
+	risky := []byte(`{"errcode":87014,"errmsg":"risky content"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	gomock.InOrder(
+		client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/wxa/msg_sec_check?access_token=ACCESS_TOKEN", []byte(`{"content":"标题"}`)).Return(ok, nil),
+		client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/wxa/msg_sec_check?access_token=ACCESS_TOKEN", []byte(`{"content":"正文 & 违规"}`)).Return(risky, nil),
+		client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://mmbiz.qpic.cn/mmbiz_jpg/a.jpg?wx_fmt=jpeg&from=appmsg", nil).Return([]byte("IMAGE_A"), nil),
+		client.EXPECT().Upload(gomock.AssignableToTypeOf(context.TODO()), "https://api.weixin.qq.com/wxa/img_sec_check?access_token=ACCESS_TOKEN", gomock.AssignableToTypeOf(wx.NewUploadForm())).Return(ok, nil),
+		client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://mmbiz.qpic.cn/mmbiz_png/b", nil).Return([]byte("IMAGE_B"), nil),
+		client.EXPECT().Upload(gomock.AssignableToTypeOf(context.TODO()), "https://api.weixin.qq.com/wxa/img_sec_check?access_token=ACCESS_TOKEN", gomock.AssignableToTypeOf(wx.NewUploadForm())).Return(risky, nil),
+	)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	violations, err := oa.PrecheckDraftArticles(context.TODO(), "ACCESS_TOKEN", []*DraftArticle{
+		{
+			Title:   "标题",
+			Content: `<p>正文 &amp; <strong>违规</strong></p><img data-src="https://mmbiz.qpic.cn/mmbiz_jpg/a.jpg?wx_fmt=jpeg&amp;from=appmsg" /><img src='https://mmbiz.qpic.cn/mmbiz_png/b'><img src="https://mmbiz.qpic.cn/mmbiz_png/b">`,
+		},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(violations))
+	assert.Equal(t, "content", violations[0].Field)
+	assert.Equal(t, "正文 & 违规", violations[0].Value)
+	assert.True(t, wx.IsAPIError(violations[0].Err, ErrCodeRiskyContent))
+	assert.Equal(t, "image", violations[1].Field)
+	assert.Equal(t, "https://mmbiz.qpic.cn/mmbiz_png/b", violations[1].Value)
+}
+
+func TestPrecheckDraftArticlesError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/wxa/msg_sec_check?access_token=ACCESS_TOKEN", gomock.Any()).Return([]byte(`{"errcode":40001,"errmsg":"invalid credential"}`), nil)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	_, err := oa.PrecheckDraftArticles(context.TODO(), "ACCESS_TOKEN", []*DraftArticle{{Title: "标题"}})
+
+	assert.True(t, wx.IsAPIError(err, 40001))
+
+	var apierr *wx.APIError
+
+	assert.True(t, errors.As(err, &apierr))
+}
@@ -0,0 +1,96 @@
+package offia
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// 用户订阅通知授权状态
+const (
+	SubscribeAccept = "accept" // 同意订阅
+	SubscribeReject = "reject" // 拒绝订阅（发送将返回 43101）
+)
+
+// SubscribeAuthStore 用户订阅通知授权状态存储（如：数据库、Redis 等）
+// 微信未提供查询授权状态的接口，需通过用户操作订阅通知弹窗及管理订阅通知的事件记录
+type SubscribeAuthStore interface {
+	// Get 获取用户对模板的授权状态，无记录时返回空字符串
+	Get(ctx context.Context, openid, templateID string) (string, error)
+
+	// Set 保存用户对模板的授权状态
+	Set(ctx context.Context, openid, templateID, status string) error
+}
+
+type memSubscribeAuthStore struct {
+	status map[string]string
+	mutex  sync.RWMutex
+}
+
+func (s *memSubscribeAuthStore) Get(ctx context.Context, openid, templateID string) (string, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.status[openid+"|"+templateID], nil
+}
+
+func (s *memSubscribeAuthStore) Set(ctx context.Context, openid, templateID, status string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.status[openid+"|"+templateID] = status
+
+	return nil
+}
+
+// NewMemSubscribeAuthStore returns an in-memory subscribe auth store (仅用于测试或单机，重启后丢失)
+func NewMemSubscribeAuthStore() SubscribeAuthStore {
+	return &memSubscribeAuthStore{
+		status: make(map[string]string),
+	}
+}
+
+// SaveSubscribeAuth 保存订阅通知事件中用户的授权状态（在事件处理中调用，msg 为 ParseMessage 的返回值；未通过 WithSubscribeAuthStore 设置存储时不保存）；
+// 支持 *SubscribeMsgPopupEvent 及 *SubscribeMsgChangeEvent，其它类型忽略
+func (oa *Offia) SaveSubscribeAuth(ctx context.Context, msg interface{}) error {
+	if oa.subauths == nil {
+		return nil
+	}
+
+	switch e := msg.(type) {
+	case *SubscribeMsgPopupEvent:
+		for _, v := range e.List {
+			if err := oa.subauths.Set(ctx, e.FromUserName, v.TemplateID, v.SubscribeStatusString); err != nil {
+				return err
+			}
+		}
+	case *SubscribeMsgChangeEvent:
+		for _, v := range e.List {
+			if err := oa.subauths.Set(ctx, e.FromUserName, v.TemplateID, v.SubscribeStatusString); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// SubscribeAuthStatus 查询用户对订阅通知模板的授权状态（accept、reject），无记录时返回空字符串；需通过 WithSubscribeAuthStore 设置存储
+func (oa *Offia) SubscribeAuthStatus(ctx context.Context, openid, templateID string) (string, error) {
+	if oa.subauths == nil {
+		return "", errors.New("subscribe auth store is nil (forgotten configure?)")
+	}
+
+	return oa.subauths.Get(ctx, openid, templateID)
+}
+
+// CanSendSubscribeMsg 用户是否可接收订阅通知（仅在用户已拒绝时返回 false，用于发送前过滤必然失败的请求）
+func (oa *Offia) CanSendSubscribeMsg(ctx context.Context, openid, templateID string) (bool, error) {
+	status, err := oa.SubscribeAuthStatus(ctx, openid, templateID)
+
+	if err != nil {
+		return false, err
+	}
+
+	return status != SubscribeReject, nil
+}
@@ -0,0 +1,54 @@
+package offia
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribeAuth(t *testing.T) {
+	oa := New("APPID", "APPSECRET", WithSubscribeAuthStore(NewMemSubscribeAuthStore()))
+
+	popup, err := ParseMessage([]byte(`<xml><ToUserName><![CDATA[gh_123456789abc]]></ToUserName><FromUserName><![CDATA[OPENID]]></FromUserName><CreateTime>1610969440</CreateTime><MsgType><![CDATA[event]]></MsgType><Event><![CDATA[subscribe_msg_popup_event]]></Event><SubscribeMsgPopupEvent><List><TemplateId><![CDATA[TEMPLATE_ID_1]]></TemplateId><SubscribeStatusString><![CDATA[accept]]></SubscribeStatusString><PopupScene>2</PopupScene></List><List><TemplateId><![CDATA[TEMPLATE_ID_2]]></TemplateId><SubscribeStatusString><![CDATA[reject]]></SubscribeStatusString><PopupScene>2</PopupScene></List></SubscribeMsgPopupEvent></xml>`))
+
+	assert.Nil(t, err)
+	assert.Nil(t, oa.SaveSubscribeAuth(context.TODO(), popup))
+
+	status, err := oa.SubscribeAuthStatus(context.TODO(), "OPENID", "TEMPLATE_ID_1")
+
+	assert.Nil(t, err)
+	assert.Equal(t, SubscribeAccept, status)
+
+	ok, err := oa.CanSendSubscribeMsg(context.TODO(), "OPENID", "TEMPLATE_ID_2")
+
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	// 用户在管理页面拒绝
+	change, err := ParseMessage([]byte(`<xml><ToUserName><![CDATA[gh_123456789abc]]></ToUserName><FromUserName><![CDATA[OPENID]]></FromUserName><CreateTime>1610969440</CreateTime><MsgType><![CDATA[event]]></MsgType><Event><![CDATA[subscribe_msg_change_event]]></Event><SubscribeMsgChangeEvent><List><TemplateId><![CDATA[TEMPLATE_ID_1]]></TemplateId><SubscribeStatusString><![CDATA[reject]]></SubscribeStatusString></List></SubscribeMsgChangeEvent></xml>`))
+
+	assert.Nil(t, err)
+	assert.Nil(t, oa.SaveSubscribeAuth(context.TODO(), change))
+
+	ok, err = oa.CanSendSubscribeMsg(context.TODO(), "OPENID", "TEMPLATE_ID_1")
+
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	// 无记录
+	ok, err = oa.CanSendSubscribeMsg(context.TODO(), "OTHER_OPENID", "TEMPLATE_ID_1")
+
+	assert.Nil(t, err)
+	assert.True(t, ok)
+}
+
+func TestSubscribeAuthWithoutStore(t *testing.T) {
+	oa := New("APPID", "APPSECRET")
+
+	assert.Nil(t, oa.SaveSubscribeAuth(context.TODO(), &SubscribeMsgChangeEvent{}))
+
+	_, err := oa.CanSendSubscribeMsg(context.TODO(), "OPENID", "TEMPLATE_ID_1")
+
+	assert.NotNil(t, err)
+}
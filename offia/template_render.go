@@ -0,0 +1,49 @@
+package offia
+
+import (
+	"regexp"
+	"strings"
+)
+
+var templPlaceholder = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_]+)\.DATA\s*\}\}`)
+
+// TemplateKeywords 返回模板内容（TemplateInfo.Content）中的关键词，按出现顺序且不重复，如：first、keyword1、remark
+func TemplateKeywords(content string) []string {
+	matches := templPlaceholder.FindAllStringSubmatch(content, -1)
+
+	keywords := make([]string, 0, len(matches))
+	seen := make(map[string]struct{}, len(matches))
+
+	for _, m := range matches {
+		if _, ok := seen[m[1]]; ok {
+			continue
+		}
+
+		seen[m[1]] = struct{}{}
+		keywords = append(keywords, m[1])
+	}
+
+	return keywords
+}
+
+// RenderTemplate 本地渲染模板消息预览：将模板内容中的 {{key.DATA}} 占位符替换为 data 中对应的值（不调用微信接口），
+// 用于发送前核对消息内容；data 未提供的关键词保留原占位符，并在 missing 中返回
+func RenderTemplate(content string, data MsgTemplData) (text string, missing []string) {
+	for _, k := range TemplateKeywords(content) {
+		if v, ok := data[k]; !ok || v == nil {
+			missing = append(missing, k)
+		}
+	}
+
+	text = templPlaceholder.ReplaceAllStringFunc(content, func(s string) string {
+		k := strings.TrimSuffix(strings.TrimSpace(strings.Trim(s, "{}")), ".DATA")
+
+		if v, ok := data[k]; ok && v != nil {
+			return v.Value
+		}
+
+		return s
+	})
+
+	return
+}
@@ -0,0 +1,28 @@
+package offia
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplateKeywords(t *testing.T) {
+	content := "{{first.DATA}}\n商品名称：{{keyword1.DATA}}\n购买时间：{{ keyword2.DATA }}\n{{remark.DATA}}{{first.DATA}}"
+
+	assert.Equal(t, []string{"first", "keyword1", "keyword2", "remark"}, TemplateKeywords(content))
+	assert.Equal(t, []string{}, TemplateKeywords("无占位符"))
+}
+
+func TestRenderTemplate(t *testing.T) {
+	content := "{{first.DATA}}\n商品名称：{{keyword1.DATA}}\n购买时间：{{ keyword2.DATA }}\n{{remark.DATA}}"
+
+	text, missing := RenderTemplate(content, MsgTemplData{
+		"first":    {Value: "恭喜你购买成功！", Color: "#173177"},
+		"keyword1": {Value: "巧克力"},
+		"keyword2": {Value: "2014年9月22日"},
+		"unused":   {Value: "未使用"},
+	})
+
+	assert.Equal(t, "恭喜你购买成功！\n商品名称：巧克力\n购买时间：2014年9月22日\n{{remark.DATA}}", text)
+	assert.Equal(t, []string{"remark"}, missing)
+}
@@ -0,0 +1,138 @@
+package offia
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// 模板消息发送状态
+const (
+	TemplateSendSuccess      = "success"              // 发送成功
+	TemplateSendUserBlock    = "failed:user block"    // 用户拒收
+	TemplateSendSystemFailed = "failed:system failed" // 其他原因失败
+)
+
+// TemplateResultStore 模板消息发送结果存储（如：数据库、Redis 等），
+// 用于关联发送时的消息id与稍后推送的模板消息发送完成事件
+type TemplateResultStore interface {
+	// Get 获取消息的发送状态，结果尚未推送时返回空字符串
+	Get(ctx context.Context, msgID int64) (string, error)
+
+	// Set 保存消息的发送状态
+	Set(ctx context.Context, msgID int64, status string) error
+
+	// Delete 删除消息的发送状态（WaitResult 获取结果后调用）
+	Delete(ctx context.Context, msgID int64) error
+}
+
+type memTemplateResultStore struct {
+	results map[int64]string
+	mutex   sync.RWMutex
+}
+
+func (s *memTemplateResultStore) Get(ctx context.Context, msgID int64) (string, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.results[msgID], nil
+}
+
+func (s *memTemplateResultStore) Set(ctx context.Context, msgID int64, status string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.results[msgID] = status
+
+	return nil
+}
+
+func (s *memTemplateResultStore) Delete(ctx context.Context, msgID int64) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.results, msgID)
+
+	return nil
+}
+
+// NewMemTemplateResultStore returns an in-memory template result store (仅用于测试或单机，重启后丢失；未经 WaitResult 读取的结果不会删除)
+func NewMemTemplateResultStore() TemplateResultStore {
+	return &memTemplateResultStore{
+		results: make(map[int64]string),
+	}
+}
+
+// TemplateSendError 模板消息发送失败
+type TemplateSendError struct {
+	MsgID  int64
+	Status string
+}
+
+func (e *TemplateSendError) Error() string {
+	return fmt.Sprintf("template msg %d failed, status: %s", e.MsgID, e.Status)
+}
+
+// SaveTemplateResult 保存模板消息发送完成事件的结果（在事件处理中调用；未通过 WithTemplateResultStore 设置存储时不保存）
+func (oa *Offia) SaveTemplateResult(ctx context.Context, e *TemplateSendJobFinishEvent) error {
+	if oa.results == nil {
+		return nil
+	}
+
+	return oa.results.Set(ctx, e.MsgID, e.Status)
+}
+
+// 发送结果轮询的退避间隔
+var (
+	templateResultPollInterval    = 200 * time.Millisecond
+	templateResultPollMaxInterval = 5 * time.Second
+)
+
+// WaitResult 等待模板消息的发送结果（消息id 由 SendTemplateMsgWithResult 返回；轮询 TemplateResultStore，指数退避：200ms、400ms ... 最大5s）；
+// 需通过 WithTemplateResultStore 设置存储；获取结果后删除存储中的记录，发送失败返回 *TemplateSendError，超时请通过 ctx 控制
+func (oa *Offia) WaitResult(ctx context.Context, msgID int64) error {
+	if oa.results == nil {
+		return errors.New("template result store is nil (forgotten configure?)")
+	}
+
+	interval := templateResultPollInterval
+
+	for {
+		status, err := oa.results.Get(ctx, msgID)
+
+		if err != nil {
+			return err
+		}
+
+		if len(status) != 0 {
+			if err = oa.results.Delete(ctx, msgID); err != nil {
+				return err
+			}
+
+			if status == TemplateSendSuccess {
+				return nil
+			}
+
+			return &TemplateSendError{
+				MsgID:  msgID,
+				Status: status,
+			}
+		}
+
+		timer := time.NewTimer(interval)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		if interval *= 2; interval > templateResultPollMaxInterval {
+			interval = templateResultPollMaxInterval
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package offia
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitResult(t *testing.T) {
+	templateResultPollInterval = time.Millisecond
+
+	store := NewMemTemplateResultStore()
+
+	oa := New("APPID", "APPSECRET", WithTemplateResultStore(store))
+
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+
+		oa.SaveTemplateResult(context.TODO(), &TemplateSendJobFinishEvent{MsgID: 200163836, Status: TemplateSendSuccess})
+		oa.SaveTemplateResult(context.TODO(), &TemplateSendJobFinishEvent{MsgID: 200163840, Status: TemplateSendUserBlock})
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	assert.Nil(t, oa.WaitResult(ctx, 200163836))
+	assert.Equal(t, &TemplateSendError{
+		MsgID:  200163840,
+		Status: TemplateSendUserBlock,
+	}, oa.WaitResult(ctx, 200163840))
+
+	// 获取结果后删除
+	status, err := store.Get(context.TODO(), 200163836)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "", status)
+}
+
+func TestWaitResultWithoutStore(t *testing.T) {
+	oa := New("APPID", "APPSECRET")
+
+	assert.Nil(t, oa.SaveTemplateResult(context.TODO(), &TemplateSendJobFinishEvent{MsgID: 200163836, Status: TemplateSendSuccess}))
+	assert.NotNil(t, oa.WaitResult(context.TODO(), 200163836))
+}
+
+func TestWaitResultTimeout(t *testing.T) {
+	templateResultPollInterval = time.Millisecond
+
+	store := NewMemTemplateResultStore()
+
+	oa := New("APPID", "APPSECRET", WithTemplateResultStore(store))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	assert.Equal(t, context.DeadlineExceeded, oa.WaitResult(ctx, 200163836))
+
+	// 共享存储中已有结果
+	assert.Nil(t, store.Set(context.TODO(), 200163836, TemplateSendSuccess))
+	assert.Nil(t, oa.WaitResult(context.TODO(), 200163836))
+}
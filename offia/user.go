@@ -1,6 +1,7 @@
 package offia
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/shenghui0779/gochat/urls"
@@ -10,6 +11,9 @@ import (
 // MaxUserListCount 关注列表的最大数目
 const MaxUserListCount = 10000
 
+// MaxChangeOpenIDCount 每次转换 openid 的最大数目
+const MaxChangeOpenIDCount = 100
+
 // SubscribeScene 关注的渠道来源
 type SubscribeScene string
 
@@ -291,6 +295,40 @@ func BatchGetUserInfo(users []*ParamsUserInfo, result *ResultBatchUserInfo) wx.A
 	)
 }
 
+// MaxBatchUserInfoCount 批量获取用户基本信息每次的最大数目
+const MaxBatchUserInfoCount = 100
+
+// BatchGetUsers 用户管理 - 批量获取用户基本信息（按每次100个自动分批，可通过 fanout 设置并发数及请求间隔），结果与 users 顺序一致
+func (oa *Offia) BatchGetUsers(ctx context.Context, accessToken string, users []*ParamsUserInfo, fanout []wx.FanOutOption, options ...wx.HTTPOption) ([]*UserInfo, error) {
+	list := make([]*UserInfo, len(users))
+
+	err := wx.FanOut(ctx, len(users), MaxBatchUserInfoCount, func(ctx context.Context, begin, end int) error {
+		result := new(ResultBatchUserInfo)
+
+		if err := oa.Do(ctx, accessToken, BatchGetUserInfo(users[begin:end], result), options...); err != nil {
+			return err
+		}
+
+		infos := make(map[string]*UserInfo, len(result.UserInfoList))
+
+		for _, v := range result.UserInfoList {
+			infos[v.OpenID] = v
+		}
+
+		for i := begin; i < end; i++ {
+			list[i] = infos[users[i].OpenID]
+		}
+
+		return nil
+	}, fanout...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
 type UserListData struct {
 	OpenID []string `json:"openid"`
 }
@@ -377,3 +415,87 @@ func BatchUnBlackUsers(openids ...string) wx.Action {
 		}),
 	)
 }
+
+type ParamsOpenIDChange struct {
+	FromAppID  string   `json:"from_appid"`
+	OpenIDList []string `json:"openid_list"`
+}
+
+type ResultOpenIDChange struct {
+	ResultList []*OpenIDChangeItem `json:"result_list"`
+}
+
+type OpenIDChangeItem struct {
+	OriOpenID string `json:"ori_openid"`
+	NewOpenID string `json:"new_openid"`
+	ErrMsg    string `json:"err_msg"` // 转换失败时返回，如：ori_openid error
+}
+
+// Failed 转换失败的记录
+func (r *ResultOpenIDChange) Failed() []*OpenIDChangeItem {
+	items := make([]*OpenIDChangeItem, 0)
+
+	for _, v := range r.ResultList {
+		if len(v.NewOpenID) == 0 || len(v.ErrMsg) != 0 {
+			items = append(items, v)
+		}
+	}
+
+	return items
+}
+
+// Mapping 转换成功的 openid 映射（原openid => 新openid）
+func (r *ResultOpenIDChange) Mapping() map[string]string {
+	m := make(map[string]string, len(r.ResultList))
+
+	for _, v := range r.ResultList {
+		if len(v.NewOpenID) != 0 && len(v.ErrMsg) == 0 {
+			m[v.OriOpenID] = v.NewOpenID
+		}
+	}
+
+	return m
+}
+
+// ChangeOpenID 用户管理 - 公众号迁移 - 转换openid（每次最多100个，fromAppID 为原帐号的appid）
+func ChangeOpenID(fromAppID string, openids []string, result *ResultOpenIDChange) wx.Action {
+	params := &ParamsOpenIDChange{
+		FromAppID:  fromAppID,
+		OpenIDList: openids,
+	}
+
+	return wx.NewPostAction(urls.OffiaChangeOpenID,
+		wx.WithBody(func() ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// ChangeOpenIDs 用户管理 - 公众号迁移 - 批量转换openid（按每次100个自动分批，合并返回各批结果）；
+// 某一批请求失败时，返回已完成批次的结果及错误
+func (oa *Offia) ChangeOpenIDs(ctx context.Context, accessToken, fromAppID string, openids []string, options ...wx.HTTPOption) (*ResultOpenIDChange, error) {
+	merged := &ResultOpenIDChange{
+		ResultList: make([]*OpenIDChangeItem, 0, len(openids)),
+	}
+
+	for begin := 0; begin < len(openids); begin += MaxChangeOpenIDCount {
+		end := begin + MaxChangeOpenIDCount
+
+		if end > len(openids) {
+			end = len(openids)
+		}
+
+		result := new(ResultOpenIDChange)
+
+		if err := oa.Do(ctx, accessToken, ChangeOpenID(fromAppID, openids[begin:end], result), options...); err != nil {
+			return merged, err
+		}
+
+		merged.ResultList = append(merged.ResultList, result.ResultList...)
+	}
+
+	return merged, nil
+}
@@ -2,6 +2,8 @@ package offia
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"testing"
 
@@ -9,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/shenghui0779/gochat/mock"
+	"github.com/shenghui0779/gochat/wx"
 )
 
 func TestCreateTag(t *testing.T) {
@@ -504,3 +507,135 @@ func TestBatchUnBlackUsers(t *testing.T) {
 
 	assert.Nil(t, err)
 }
+
+func TestChangeOpenID(t *testing.T) {
+	body := []byte(`{"from_appid":"FROM_APPID","openid_list":["OPENID1","OPENID2"]}`)
+
+	resp := []byte(`{
+	"errcode": 0,
+	"errmsg": "ok",
+	"result_list": [
+		{
+			"ori_openid": "OPENID1",
+			"new_openid": "NEW_OPENID1",
+			"err_msg": ""
+		},
+		{
+			"ori_openid": "OPENID2",
+			"err_msg": "ori_openid error"
+		}
+	]
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/changeopenid?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	result := new(ResultOpenIDChange)
+
+	err := oa.Do(context.TODO(), "ACCESS_TOKEN", ChangeOpenID("FROM_APPID", []string{"OPENID1", "OPENID2"}, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultOpenIDChange{
+		ResultList: []*OpenIDChangeItem{
+			{
+				OriOpenID: "OPENID1",
+				NewOpenID: "NEW_OPENID1",
+			},
+			{
+				OriOpenID: "OPENID2",
+				ErrMsg:    "ori_openid error",
+			},
+		},
+	}, result)
+	assert.Equal(t, map[string]string{"OPENID1": "NEW_OPENID1"}, result.Mapping())
+	assert.Equal(t, []*OpenIDChangeItem{{OriOpenID: "OPENID2", ErrMsg: "ori_openid error"}}, result.Failed())
+}
+
+func TestChangeOpenIDs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	openids := make([]string, 0, 150)
+
+	for i := 0; i < 150; i++ {
+		openids = append(openids, fmt.Sprintf("OPENID%d", i))
+	}
+
+	mockChange := func(ids []string) ([]byte, []byte) {
+		body, _ := json.Marshal(&ParamsOpenIDChange{FromAppID: "FROM_APPID", OpenIDList: ids})
+
+		result := new(ResultOpenIDChange)
+
+		for _, id := range ids {
+			result.ResultList = append(result.ResultList, &OpenIDChangeItem{OriOpenID: id, NewOpenID: "NEW_" + id})
+		}
+
+		resp, _ := json.Marshal(result)
+
+		return body, resp
+	}
+
+	body1, resp1 := mockChange(openids[:100])
+	body2, resp2 := mockChange(openids[100:])
+
+	gomock.InOrder(
+		client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/changeopenid?access_token=ACCESS_TOKEN", body1).Return(resp1, nil),
+		client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/changeopenid?access_token=ACCESS_TOKEN", body2).Return(resp2, nil),
+	)
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	result, err := oa.ChangeOpenIDs(context.TODO(), "ACCESS_TOKEN", "FROM_APPID", openids)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 150, len(result.ResultList))
+	assert.Equal(t, "NEW_OPENID149", result.Mapping()["OPENID149"])
+	assert.Equal(t, 0, len(result.Failed()))
+}
+
+func TestBatchGetUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	users := make([]*ParamsUserInfo, 0, 150)
+
+	for i := 0; i < 150; i++ {
+		users = append(users, &ParamsUserInfo{OpenID: fmt.Sprintf("OPENID_%d", i)})
+	}
+
+	for _, chunk := range [][]*ParamsUserInfo{users[:100], users[100:]} {
+		body, _ := json.Marshal(&ParamsBatchUserInfo{UserList: chunk})
+
+		result := &ResultBatchUserInfo{UserInfoList: make([]*UserInfo, 0, len(chunk))}
+
+		// 返回顺序与请求顺序不一致
+		for i := len(chunk) - 1; i >= 0; i-- {
+			result.UserInfoList = append(result.UserInfoList, &UserInfo{OpenID: chunk[i].OpenID, Subscribe: 1})
+		}
+
+		resp, _ := json.Marshal(result)
+
+		client.EXPECT().Do(gomock.Any(), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/user/info/batchget?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+	}
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client))
+
+	list, err := oa.BatchGetUsers(context.TODO(), "ACCESS_TOKEN", users, []wx.FanOutOption{wx.WithFanOutConcurrency(2)})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 150, len(list))
+
+	for i, v := range list {
+		assert.Equal(t, fmt.Sprintf("OPENID_%d", i), v.OpenID)
+	}
+}
@@ -0,0 +1,58 @@
+package offia
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// ErrNoVoiceTranscoder 语音格式不支持且未设置转码
+var ErrNoVoiceTranscoder = errors.New("voice transcoder not configured")
+
+// VoiceTranscoder 语音转码（如：基于 ffmpeg 实现），将 src 文件转码为 AMR 格式写入 dst 文件
+type VoiceTranscoder interface {
+	Transcode(ctx context.Context, src, dst string) error
+}
+
+// VoiceTranscoderFunc is an adapter to allow the use of ordinary functions as VoiceTranscoder.
+type VoiceTranscoderFunc func(ctx context.Context, src, dst string) error
+
+// Transcode calls f(ctx, src, dst).
+func (f VoiceTranscoderFunc) Transcode(ctx context.Context, src, dst string) error {
+	return f(ctx, src, dst)
+}
+
+// UploadVoice 上传临时语音素材（用于回复语音消息、客服语音消息）；
+// 语音仅支持 AMR、MP3 格式，其他格式（如：wav、m4a、silk）需设置 WithVoiceTranscoder，转码为 AMR 后自动上传，临时文件上传后删除
+func (oa *Offia) UploadVoice(ctx context.Context, accessToken, voicePath string, result *ResultMediaUpload, options ...wx.HTTPOption) error {
+	switch strings.ToLower(filepath.Ext(voicePath)) {
+	case ".amr", ".mp3":
+		return oa.Do(ctx, accessToken, UploadMedia(MediaVoice, voicePath, result), options...)
+	}
+
+	if oa.transcoder == nil {
+		return ErrNoVoiceTranscoder
+	}
+
+	f, err := os.CreateTemp("", "gochat-voice-*.amr")
+
+	if err != nil {
+		return err
+	}
+
+	dst := f.Name()
+
+	f.Close()
+
+	defer os.Remove(dst)
+
+	if err = oa.transcoder.Transcode(ctx, voicePath, dst); err != nil {
+		return err
+	}
+
+	return oa.Do(ctx, accessToken, UploadMedia(MediaVoice, dst, result), options...)
+}
@@ -0,0 +1,64 @@
+package offia
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/mock"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+func TestUploadVoice(t *testing.T) {
+	resp := []byte(`{"type":"voice","media_id":"MEDIA_ID","created_at":1606717010}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Upload(gomock.AssignableToTypeOf(context.TODO()), "https://api.weixin.qq.com/cgi-bin/media/upload?access_token=ACCESS_TOKEN&type=voice", gomock.AssignableToTypeOf(wx.NewUploadForm())).Return(resp, nil).Times(2)
+
+	var dst string
+
+	oa := New("APPID", "APPSECRET", WithMockClient(client), WithVoiceTranscoder(VoiceTranscoderFunc(func(ctx context.Context, src, out string) error {
+		assert.Equal(t, "test.wav", src)
+
+		dst = out
+
+		return os.WriteFile(out, []byte("#!AMR"), 0644)
+	})))
+
+	// AMR 无需转码
+	result := new(ResultMediaUpload)
+
+	err := oa.UploadVoice(context.TODO(), "ACCESS_TOKEN", "test.amr", result)
+
+	assert.Nil(t, err)
+	assert.Empty(t, dst)
+	assert.Equal(t, "MEDIA_ID", result.MediaID)
+
+	// 转码后上传，并删除临时文件
+	result = new(ResultMediaUpload)
+
+	err = oa.UploadVoice(context.TODO(), "ACCESS_TOKEN", "test.wav", result)
+
+	assert.Nil(t, err)
+	assert.NotEmpty(t, dst)
+	assert.Equal(t, "MEDIA_ID", result.MediaID)
+
+	_, err = os.Stat(dst)
+
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestUploadVoiceWithoutTranscoder(t *testing.T) {
+	oa := New("APPID", "APPSECRET")
+
+	err := oa.UploadVoice(context.TODO(), "ACCESS_TOKEN", "test.wav", new(ResultMediaUpload))
+
+	assert.Equal(t, ErrNoVoiceTranscoder, err)
+}
@@ -0,0 +1,317 @@
+package oplatform
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/shenghui0779/gochat/urls"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// FuncInfo 授权给开发者的权限集
+type FuncInfo struct {
+	FuncscopeCategory struct {
+		ID int `json:"id"`
+	} `json:"funcscope_category"`
+}
+
+// AuthorizationInfo 授权信息
+type AuthorizationInfo struct {
+	AuthorizerAppID        string      `json:"authorizer_appid"`         // 授权方 appid
+	AuthorizerAccessToken  string      `json:"authorizer_access_token"`  // 接口调用令牌
+	ExpiresIn              int64       `json:"expires_in"`               // authorizer_access_token 的有效期（秒）
+	AuthorizerRefreshToken string      `json:"authorizer_refresh_token"` // 刷新令牌
+	FuncInfo               []*FuncInfo `json:"func_info"`                // 授权给开发者的权限集列表
+}
+
+// ResultQueryAuth 使用授权码获取授权信息结果
+type ResultQueryAuth struct {
+	AuthorizationInfo *AuthorizationInfo `json:"authorization_info"`
+}
+
+// QueryAuth 使用授权码获取授权信息
+// [参考](https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/ThirdParty/token/authorization_info.html)
+func QueryAuth(componentAppID, authCode string, result *ResultQueryAuth) wx.Action {
+	return wx.NewPostAction(urls.OplatformQueryAuth,
+		wx.WithBody(func() ([]byte, error) {
+			return json.Marshal(map[string]string{
+				"component_appid":    componentAppID,
+				"authorization_code": authCode,
+			})
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// ResultAuthorizerToken 授权方令牌
+type ResultAuthorizerToken struct {
+	AuthorizerAccessToken  string `json:"authorizer_access_token"`
+	ExpiresIn              int64  `json:"expires_in"`
+	AuthorizerRefreshToken string `json:"authorizer_refresh_token"`
+}
+
+// RefreshAuthorizerToken 获取/刷新授权方接口调用令牌
+// [参考](https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/ThirdParty/token/api_authorizer_token.html)
+func RefreshAuthorizerToken(componentAppID, authorizerAppID, refreshToken string, result *ResultAuthorizerToken) wx.Action {
+	return wx.NewPostAction(urls.OplatformAuthorizerToken,
+		wx.WithBody(func() ([]byte, error) {
+			return json.Marshal(map[string]string{
+				"component_appid":          componentAppID,
+				"authorizer_appid":         authorizerAppID,
+				"authorizer_refresh_token": refreshToken,
+			})
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// AuthorizerInfo 授权方帐号基本信息
+type AuthorizerInfo struct {
+	NickName        string `json:"nick_name"` // 昵称
+	HeadImg         string `json:"head_img"`  // 头像
+	ServiceTypeInfo struct {
+		ID int `json:"id"`
+	} `json:"service_type_info"` // 公众号类型
+	VerifyTypeInfo struct {
+		ID int `json:"id"`
+	} `json:"verify_type_info"` // 认证类型
+	UserName      string `json:"user_name"`      // 原始ID
+	PrincipalName string `json:"principal_name"` // 主体名称
+	Alias         string `json:"alias"`          // 公众号所设置的微信号，可能为空
+	QrcodeURL     string `json:"qrcode_url"`     // 二维码图片的URL
+	Signature     string `json:"signature"`      // 帐号介绍
+	BusinessInfo  struct {
+		OpenPay   int `json:"open_pay"`
+		OpenShake int `json:"open_shake"`
+		OpenScan  int `json:"open_scan"`
+		OpenCard  int `json:"open_card"`
+		OpenStore int `json:"open_store"`
+	} `json:"business_info"` // 功能开通状况
+	MiniProgramInfo json.RawMessage `json:"MiniProgramInfo,omitempty"` // 小程序配置，仅小程序返回
+}
+
+// ResultAuthorizerInfo 授权方信息
+type ResultAuthorizerInfo struct {
+	AuthorizerInfo    *AuthorizerInfo    `json:"authorizer_info"`
+	AuthorizationInfo *AuthorizationInfo `json:"authorization_info"`
+}
+
+// GetAuthorizerInfo 获取授权方的帐号基本信息
+// [参考](https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/ThirdParty/token/api_get_authorizer_info.html)
+func GetAuthorizerInfo(componentAppID, authorizerAppID string, result *ResultAuthorizerInfo) wx.Action {
+	return wx.NewPostAction(urls.OplatformAuthorizerInfo,
+		wx.WithBody(func() ([]byte, error) {
+			return json.Marshal(map[string]string{
+				"component_appid":  componentAppID,
+				"authorizer_appid": authorizerAppID,
+			})
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// AuthorizerOption 授权方选项
+type AuthorizerOption string
+
+// 授权方选项
+const (
+	OptionLocationReport  AuthorizerOption = "location_report"  // 地理位置上报选项：0-无上报，1-进入会话时上报，2-每5s上报
+	OptionVoiceRecognize  AuthorizerOption = "voice_recognize"  // 语音识别开关选项：0-关闭语音识别，1-开启语音识别
+	OptionCustomerService AuthorizerOption = "customer_service" // 多客服开关选项：0-关闭多客服，1-开启多客服
+)
+
+// ResultAuthorizerOption 授权方选项信息
+type ResultAuthorizerOption struct {
+	AuthorizerAppID string `json:"authorizer_appid"`
+	OptionName      string `json:"option_name"`
+	OptionValue     string `json:"option_value"`
+}
+
+// GetAuthorizerOption 获取授权方选项信息
+// [参考](https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/ThirdParty/account_authorization/api_get_authorizer_option.html)
+func GetAuthorizerOption(componentAppID, authorizerAppID string, option AuthorizerOption, result *ResultAuthorizerOption) wx.Action {
+	return wx.NewPostAction(urls.OplatformGetAuthorizerOption,
+		wx.WithBody(func() ([]byte, error) {
+			return json.Marshal(map[string]string{
+				"component_appid":  componentAppID,
+				"authorizer_appid": authorizerAppID,
+				"option_name":      string(option),
+			})
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// SetAuthorizerOption 设置授权方选项信息
+// [参考](https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/ThirdParty/account_authorization/api_set_authorizer_option.html)
+func SetAuthorizerOption(componentAppID, authorizerAppID string, option AuthorizerOption, value string) wx.Action {
+	return wx.NewPostAction(urls.OplatformSetAuthorizerOption,
+		wx.WithBody(func() ([]byte, error) {
+			return json.Marshal(map[string]string{
+				"component_appid":  componentAppID,
+				"authorizer_appid": authorizerAppID,
+				"option_name":      string(option),
+				"option_value":     value,
+			})
+		}),
+	)
+}
+
+// AuthorizerStore 授权方刷新令牌（authorizer_refresh_token）存储
+// 刷新令牌仅在授权时下发一次，需持久化保存，丢失后需授权方重新授权
+type AuthorizerStore interface {
+	// Get 获取授权方的刷新令牌
+	Get(ctx context.Context, authorizerAppID string) (string, error)
+
+	// Set 保存授权方的刷新令牌
+	Set(ctx context.Context, authorizerAppID, refreshToken string) error
+
+	// Delete 删除授权方的刷新令牌（如：取消授权）
+	Delete(ctx context.Context, authorizerAppID string) error
+}
+
+type memAuthorizerStore struct {
+	tokens map[string]string
+	mutex  sync.RWMutex
+}
+
+func (s *memAuthorizerStore) Get(ctx context.Context, authorizerAppID string) (string, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	token, ok := s.tokens[authorizerAppID]
+
+	if !ok {
+		return "", errors.New("authorizer refresh token not found")
+	}
+
+	return token, nil
+}
+
+func (s *memAuthorizerStore) Set(ctx context.Context, authorizerAppID, refreshToken string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.tokens[authorizerAppID] = refreshToken
+
+	return nil
+}
+
+func (s *memAuthorizerStore) Delete(ctx context.Context, authorizerAppID string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.tokens, authorizerAppID)
+
+	return nil
+}
+
+// NewMemAuthorizerStore returns an in-memory authorizer store (仅用于测试或单机，重启后丢失)
+func NewMemAuthorizerStore() AuthorizerStore {
+	return &memAuthorizerStore{
+		tokens: make(map[string]string),
+	}
+}
+
+// Authorize 使用授权码换取授权信息，并保存授权方的刷新令牌
+func (op *Oplatform) Authorize(ctx context.Context, authCode string) (*AuthorizationInfo, error) {
+	accessToken, err := op.ComponentToken(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(ResultQueryAuth)
+
+	if err = op.Do(ctx, accessToken, QueryAuth(op.appid, authCode, result)); err != nil {
+		return nil, err
+	}
+
+	info := result.AuthorizationInfo
+
+	if info == nil {
+		return nil, errors.New("authorization_info is empty")
+	}
+
+	if err = op.store.Set(ctx, info.AuthorizerAppID, info.AuthorizerRefreshToken); err != nil {
+		return nil, err
+	}
+
+	op.authorizerTokenManager(info.AuthorizerAppID).Set(info.AuthorizerAccessToken, info.ExpiresIn)
+
+	return info, nil
+}
+
+// AuthorizerToken 获取授权方接口调用令牌（缓存，过期前使用存储的刷新令牌自动刷新）
+func (op *Oplatform) AuthorizerToken(ctx context.Context, authorizerAppID string) (string, error) {
+	return op.authorizerTokenManager(authorizerAppID).Token(ctx)
+}
+
+// Unauthorize 授权方取消授权时，删除其刷新令牌及缓存的令牌、代调用 Client
+func (op *Oplatform) Unauthorize(ctx context.Context, authorizerAppID string) error {
+	op.mutex.Lock()
+	delete(op.authmgrs, authorizerAppID)
+	delete(op.offias, authorizerAppID)
+	delete(op.minips, authorizerAppID)
+	op.mutex.Unlock()
+
+	return op.store.Delete(ctx, authorizerAppID)
+}
+
+func (op *Oplatform) authorizerTokenManager(authorizerAppID string) *wx.TokenManager {
+	op.mutex.Lock()
+	defer op.mutex.Unlock()
+
+	if mgr, ok := op.authmgrs[authorizerAppID]; ok {
+		return mgr
+	}
+
+	var options []wx.TokenOption
+
+	if op.authopts != nil {
+		options = op.authopts(authorizerAppID)
+	}
+
+	mgr := wx.NewTokenManager(func(ctx context.Context) (string, int64, error) {
+		refreshToken, err := op.store.Get(ctx, authorizerAppID)
+
+		if err != nil {
+			return "", 0, err
+		}
+
+		accessToken, err := op.ComponentToken(ctx)
+
+		if err != nil {
+			return "", 0, err
+		}
+
+		result := new(ResultAuthorizerToken)
+
+		if err = op.Do(ctx, accessToken, RefreshAuthorizerToken(op.appid, authorizerAppID, refreshToken, result)); err != nil {
+			return "", 0, err
+		}
+
+		// 刷新令牌可能发生变化，需及时更新
+		if len(result.AuthorizerRefreshToken) != 0 && result.AuthorizerRefreshToken != refreshToken {
+			if err = op.store.Set(ctx, authorizerAppID, result.AuthorizerRefreshToken); err != nil {
+				return "", 0, err
+			}
+		}
+
+		return result.AuthorizerAccessToken, result.ExpiresIn, nil
+	}, options...)
+
+	op.authmgrs[authorizerAppID] = mgr
+
+	return mgr
+}
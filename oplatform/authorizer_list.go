@@ -0,0 +1,138 @@
+package oplatform
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/shenghui0779/gochat/urls"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// AuthorizerListMaxCount 拉取授权方列表每页的最大数量
+const AuthorizerListMaxCount = 500
+
+// AuthorizerItem 已授权的帐号
+type AuthorizerItem struct {
+	AuthorizerAppID string `json:"authorizer_appid"` // 已授权的 appid
+	RefreshToken    string `json:"refresh_token"`    // 刷新令牌
+	AuthTime        int64  `json:"auth_time"`        // 授权的时间
+}
+
+// ResultAuthorizerList 已授权的帐号列表
+type ResultAuthorizerList struct {
+	TotalCount int               `json:"total_count"`
+	List       []*AuthorizerItem `json:"list"`
+}
+
+// GetAuthorizerList 拉取所有已授权的帐号信息（count 最大为 500）
+// [参考](https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/ThirdParty/Account_Authorization/api_get_authorizer_list.html)
+func GetAuthorizerList(componentAppID string, offset, count int, result *ResultAuthorizerList) wx.Action {
+	return wx.NewPostAction(urls.OplatformAuthorizerList,
+		wx.WithBody(func() ([]byte, error) {
+			return json.Marshal(map[string]interface{}{
+				"component_appid": componentAppID,
+				"offset":          offset,
+				"count":           count,
+			})
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// AuthorizerIterator 已授权帐号迭代器，按页拉取全部授权方
+//
+//	it := op.AuthorizerIterator(100)
+//	for it.Next(ctx) {
+//		item := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//	}
+type AuthorizerIterator struct {
+	op     *Oplatform
+	count  int
+	offset int
+	total  int
+	done   bool
+	items  []*AuthorizerItem
+	cur    *AuthorizerItem
+	err    error
+}
+
+// Next 移动到下一个授权方，没有更多数据或发生错误时返回 false
+func (it *AuthorizerIterator) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+
+	if len(it.items) == 0 {
+		if it.done {
+			return false
+		}
+
+		if !it.fetch(ctx) {
+			return false
+		}
+	}
+
+	it.cur = it.items[0]
+	it.items = it.items[1:]
+
+	return true
+}
+
+// Value returns the current authorizer
+func (it *AuthorizerIterator) Value() *AuthorizerItem {
+	return it.cur
+}
+
+// Total returns the total count of authorizers (available after the first Next)
+func (it *AuthorizerIterator) Total() int {
+	return it.total
+}
+
+// Err returns the error that stopped the iteration
+func (it *AuthorizerIterator) Err() error {
+	return it.err
+}
+
+func (it *AuthorizerIterator) fetch(ctx context.Context) bool {
+	accessToken, err := it.op.ComponentToken(ctx)
+
+	if err != nil {
+		it.err = err
+
+		return false
+	}
+
+	result := new(ResultAuthorizerList)
+
+	if err = it.op.Do(ctx, accessToken, GetAuthorizerList(it.op.appid, it.offset, it.count, result)); err != nil {
+		it.err = err
+
+		return false
+	}
+
+	it.total = result.TotalCount
+	it.offset += len(result.List)
+	it.items = result.List
+
+	if len(result.List) < it.count || it.offset >= it.total {
+		it.done = true
+	}
+
+	return len(it.items) != 0
+}
+
+// AuthorizerIterator returns an iterator over all authorized accounts (count 为每页数量，最大 500)
+func (op *Oplatform) AuthorizerIterator(count int) *AuthorizerIterator {
+	if count <= 0 || count > AuthorizerListMaxCount {
+		count = AuthorizerListMaxCount
+	}
+
+	return &AuthorizerIterator{
+		op:    op,
+		count: count,
+	}
+}
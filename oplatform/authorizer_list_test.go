@@ -0,0 +1,88 @@
+package oplatform
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/mock"
+)
+
+func TestGetAuthorizerList(t *testing.T) {
+	body := []byte(`{"component_appid":"COMPONENT_APPID","count":100,"offset":0}`)
+	resp := []byte(`{"total_count":33,"list":[{"authorizer_appid":"wxaaaaaaaaaaaaaaaa","refresh_token":"REFRESH_TOKEN","auth_time":1558000607}]}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/component/api_get_authorizer_list?component_access_token=COMPONENT_ACCESS_TOKEN", body).Return(resp, nil)
+
+	op := New("COMPONENT_APPID", "COMPONENT_APPSECRET", WithMockClient(client))
+
+	result := new(ResultAuthorizerList)
+
+	err := op.Do(context.TODO(), "COMPONENT_ACCESS_TOKEN", GetAuthorizerList(op.AppID(), 0, 100, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultAuthorizerList{
+		TotalCount: 33,
+		List: []*AuthorizerItem{
+			{
+				AuthorizerAppID: "wxaaaaaaaaaaaaaaaa",
+				RefreshToken:    "REFRESH_TOKEN",
+				AuthTime:        1558000607,
+			},
+		},
+	}, result)
+}
+
+func TestAuthorizerIterator(t *testing.T) {
+	listURL := "https://api.weixin.qq.com/cgi-bin/component/api_get_authorizer_list?component_access_token=COMPONENT_ACCESS_TOKEN"
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	gomock.InOrder(
+		client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/component/api_component_token", gomock.Any()).Return([]byte(`{"component_access_token":"COMPONENT_ACCESS_TOKEN","expires_in":7200}`), nil),
+		client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, listURL, []byte(`{"component_appid":"COMPONENT_APPID","count":2,"offset":0}`)).Return([]byte(`{"total_count":3,"list":[{"authorizer_appid":"APPID_1"},{"authorizer_appid":"APPID_2"}]}`), nil),
+		client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, listURL, []byte(`{"component_appid":"COMPONENT_APPID","count":2,"offset":2}`)).Return([]byte(`{"total_count":3,"list":[{"authorizer_appid":"APPID_3"}]}`), nil),
+	)
+
+	op := New("COMPONENT_APPID", "COMPONENT_APPSECRET", WithMockClient(client), WithVerifyTicket("TICKET"))
+
+	it := op.AuthorizerIterator(2)
+
+	appids := make([]string, 0)
+
+	for it.Next(context.TODO()) {
+		appids = append(appids, it.Value().AuthorizerAppID)
+	}
+
+	assert.Nil(t, it.Err())
+	assert.Equal(t, 3, it.Total())
+	assert.Equal(t, []string{"APPID_1", "APPID_2", "APPID_3"}, appids)
+}
+
+func TestAuthorizerIteratorError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/component/api_component_token", gomock.Any()).Return(nil, errors.New("network error"))
+
+	op := New("COMPONENT_APPID", "COMPONENT_APPSECRET", WithMockClient(client), WithVerifyTicket("TICKET"))
+
+	it := op.AuthorizerIterator(0)
+
+	assert.False(t, it.Next(context.TODO()))
+	assert.NotNil(t, it.Err())
+}
@@ -0,0 +1,190 @@
+package oplatform
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/mock"
+)
+
+func TestQueryAuth(t *testing.T) {
+	body := []byte(`{"authorization_code":"AUTH_CODE","component_appid":"COMPONENT_APPID"}`)
+	resp := []byte(`{"authorization_info":{"authorizer_appid":"wxf8b4f85f3a794e77","authorizer_access_token":"QXjUqNqfYVH0yBE1iI_7vuN_9gQbpjfK7hYwJ3P7xOa88a89-Aga5x1NMYJyB8G2yKt1KCl0nPC3W9GJzw0Zzq_dBxc8pxIGUNi_bFes0qM","expires_in":7200,"authorizer_refresh_token":"dTo-YCXPL4llX-u1W1pPpnp8Hgm4wpJtlR6iV0doKdY","func_info":[{"funcscope_category":{"id":1}},{"funcscope_category":{"id":2}}]}}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/component/api_query_auth?component_access_token=COMPONENT_ACCESS_TOKEN", body).Return(resp, nil)
+
+	op := New("COMPONENT_APPID", "COMPONENT_APPSECRET", WithMockClient(client))
+
+	result := new(ResultQueryAuth)
+
+	err := op.Do(context.TODO(), "COMPONENT_ACCESS_TOKEN", QueryAuth(op.AppID(), "AUTH_CODE", result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, "wxf8b4f85f3a794e77", result.AuthorizationInfo.AuthorizerAppID)
+	assert.Equal(t, "dTo-YCXPL4llX-u1W1pPpnp8Hgm4wpJtlR6iV0doKdY", result.AuthorizationInfo.AuthorizerRefreshToken)
+	assert.Equal(t, int64(7200), result.AuthorizationInfo.ExpiresIn)
+	assert.Equal(t, 2, len(result.AuthorizationInfo.FuncInfo))
+}
+
+func TestRefreshAuthorizerToken(t *testing.T) {
+	body := []byte(`{"authorizer_appid":"AUTHORIZER_APPID","authorizer_refresh_token":"REFRESH_TOKEN","component_appid":"COMPONENT_APPID"}`)
+	resp := []byte(`{"authorizer_access_token":"AUTHORIZER_ACCESS_TOKEN","expires_in":7200,"authorizer_refresh_token":"REFRESH_TOKEN"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/component/api_authorizer_token?component_access_token=COMPONENT_ACCESS_TOKEN", body).Return(resp, nil)
+
+	op := New("COMPONENT_APPID", "COMPONENT_APPSECRET", WithMockClient(client))
+
+	result := new(ResultAuthorizerToken)
+
+	err := op.Do(context.TODO(), "COMPONENT_ACCESS_TOKEN", RefreshAuthorizerToken(op.AppID(), "AUTHORIZER_APPID", "REFRESH_TOKEN", result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultAuthorizerToken{
+		AuthorizerAccessToken:  "AUTHORIZER_ACCESS_TOKEN",
+		ExpiresIn:              7200,
+		AuthorizerRefreshToken: "REFRESH_TOKEN",
+	}, result)
+}
+
+func TestGetAuthorizerInfo(t *testing.T) {
+	body := []byte(`{"authorizer_appid":"AUTHORIZER_APPID","component_appid":"COMPONENT_APPID"}`)
+	resp := []byte(`{"authorizer_info":{"nick_name":"微信SDK Demo Special","head_img":"http://wx.qlogo.cn/mmopen/GPy","service_type_info":{"id":2},"verify_type_info":{"id":0},"user_name":"gh_eb5e3a772040","principal_name":"腾讯计算机系统有限公司","alias":"paytest01","qrcode_url":"URL","business_info":{"open_pay":1,"open_shake":0,"open_scan":0,"open_card":0,"open_store":0}},"authorization_info":{"authorizer_appid":"wxf8b4f85f3a794e77","func_info":[{"funcscope_category":{"id":1}}]}}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/component/api_get_authorizer_info?component_access_token=COMPONENT_ACCESS_TOKEN", body).Return(resp, nil)
+
+	op := New("COMPONENT_APPID", "COMPONENT_APPSECRET", WithMockClient(client))
+
+	result := new(ResultAuthorizerInfo)
+
+	err := op.Do(context.TODO(), "COMPONENT_ACCESS_TOKEN", GetAuthorizerInfo(op.AppID(), "AUTHORIZER_APPID", result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, "微信SDK Demo Special", result.AuthorizerInfo.NickName)
+	assert.Equal(t, 2, result.AuthorizerInfo.ServiceTypeInfo.ID)
+	assert.Equal(t, 1, result.AuthorizerInfo.BusinessInfo.OpenPay)
+	assert.Equal(t, "wxf8b4f85f3a794e77", result.AuthorizationInfo.AuthorizerAppID)
+}
+
+func TestGetAuthorizerOption(t *testing.T) {
+	body := []byte(`{"authorizer_appid":"AUTHORIZER_APPID","component_appid":"COMPONENT_APPID","option_name":"voice_recognize"}`)
+	resp := []byte(`{"authorizer_appid":"AUTHORIZER_APPID","option_name":"voice_recognize","option_value":"1"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/component/api_get_authorizer_option?component_access_token=COMPONENT_ACCESS_TOKEN", body).Return(resp, nil)
+
+	op := New("COMPONENT_APPID", "COMPONENT_APPSECRET", WithMockClient(client))
+
+	result := new(ResultAuthorizerOption)
+
+	err := op.Do(context.TODO(), "COMPONENT_ACCESS_TOKEN", GetAuthorizerOption(op.AppID(), "AUTHORIZER_APPID", OptionVoiceRecognize, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultAuthorizerOption{
+		AuthorizerAppID: "AUTHORIZER_APPID",
+		OptionName:      "voice_recognize",
+		OptionValue:     "1",
+	}, result)
+}
+
+func TestSetAuthorizerOption(t *testing.T) {
+	body := []byte(`{"authorizer_appid":"AUTHORIZER_APPID","component_appid":"COMPONENT_APPID","option_name":"voice_recognize","option_value":"1"}`)
+	resp := []byte(`{"errcode":0,"errmsg":"ok"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/component/api_set_authorizer_option?component_access_token=COMPONENT_ACCESS_TOKEN", body).Return(resp, nil)
+
+	op := New("COMPONENT_APPID", "COMPONENT_APPSECRET", WithMockClient(client))
+
+	err := op.Do(context.TODO(), "COMPONENT_ACCESS_TOKEN", SetAuthorizerOption(op.AppID(), "AUTHORIZER_APPID", OptionVoiceRecognize, "1"))
+
+	assert.Nil(t, err)
+}
+
+func TestAuthorizerToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/component/api_component_token", gomock.Any()).Return([]byte(`{"component_access_token":"COMPONENT_ACCESS_TOKEN","expires_in":7200}`), nil)
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/component/api_authorizer_token?component_access_token=COMPONENT_ACCESS_TOKEN", []byte(`{"authorizer_appid":"AUTHORIZER_APPID","authorizer_refresh_token":"REFRESH_TOKEN","component_appid":"COMPONENT_APPID"}`)).Return([]byte(`{"authorizer_access_token":"AUTHORIZER_ACCESS_TOKEN","expires_in":7200,"authorizer_refresh_token":"NEW_REFRESH_TOKEN"}`), nil).Times(1)
+
+	store := NewMemAuthorizerStore()
+
+	op := New("COMPONENT_APPID", "COMPONENT_APPSECRET", WithMockClient(client), WithVerifyTicket("TICKET"), WithAuthorizerStore(store))
+
+	// 未保存刷新令牌
+	_, err := op.AuthorizerToken(context.TODO(), "UNKNOWN_APPID")
+	assert.NotNil(t, err)
+
+	assert.Nil(t, store.Set(context.TODO(), "AUTHORIZER_APPID", "REFRESH_TOKEN"))
+
+	for i := 0; i < 2; i++ {
+		token, err := op.AuthorizerToken(context.TODO(), "AUTHORIZER_APPID")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "AUTHORIZER_ACCESS_TOKEN", token)
+	}
+
+	// 刷新令牌已更新
+	refreshToken, err := store.Get(context.TODO(), "AUTHORIZER_APPID")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "NEW_REFRESH_TOKEN", refreshToken)
+
+	// 取消授权
+	assert.Nil(t, op.Unauthorize(context.TODO(), "AUTHORIZER_APPID"))
+
+	_, err = store.Get(context.TODO(), "AUTHORIZER_APPID")
+	assert.NotNil(t, err)
+}
+
+func TestAuthorize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/component/api_component_token", gomock.Any()).Return([]byte(`{"component_access_token":"COMPONENT_ACCESS_TOKEN","expires_in":7200}`), nil)
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/component/api_query_auth?component_access_token=COMPONENT_ACCESS_TOKEN", gomock.Any()).Return([]byte(`{"authorization_info":{"authorizer_appid":"AUTHORIZER_APPID","authorizer_access_token":"AUTHORIZER_ACCESS_TOKEN","expires_in":7200,"authorizer_refresh_token":"REFRESH_TOKEN"}}`), nil)
+
+	op := New("COMPONENT_APPID", "COMPONENT_APPSECRET", WithMockClient(client), WithVerifyTicket("TICKET"))
+
+	info, err := op.Authorize(context.TODO(), "AUTH_CODE")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "AUTHORIZER_APPID", info.AuthorizerAppID)
+
+	// 直接使用授权时下发的令牌
+	token, err := op.AuthorizerToken(context.TODO(), "AUTHORIZER_APPID")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "AUTHORIZER_ACCESS_TOKEN", token)
+}
@@ -0,0 +1,99 @@
+package oplatform
+
+import (
+	"encoding/json"
+
+	"github.com/shenghui0779/gochat/urls"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// 以下接口需使用授权小程序的 authorizer_access_token 调用（如：op.MinipClient(appid).Do）
+
+// 改名审核状态（见 QueryNickname 与 NicknameAuditEvent）
+const (
+	NicknameAuditing = 1 // 审核中
+	NicknameRejected = 2 // 审核失败
+	NicknameApproved = 3 // 审核成功
+)
+
+// ParamsNicknameSet 设置名称参数（证明材料均为通过 minip.UploadTempMedia 上传的 media_id）
+type ParamsNicknameSet struct {
+	NickName          string `json:"nick_name"`                      // 名称（昵称）
+	IDCard            string `json:"id_card,omitempty"`              // 身份证照片（个人号必填）
+	License           string `json:"license,omitempty"`              // 组织机构代码证或营业执照（组织号必填）
+	NamingOtherStuff1 string `json:"naming_other_stuff_1,omitempty"` // 其他证明材料
+	NamingOtherStuff2 string `json:"naming_other_stuff_2,omitempty"` // 其他证明材料
+	NamingOtherStuff3 string `json:"naming_other_stuff_3,omitempty"` // 其他证明材料
+	NamingOtherStuff4 string `json:"naming_other_stuff_4,omitempty"` // 其他证明材料
+	NamingOtherStuff5 string `json:"naming_other_stuff_5,omitempty"` // 其他证明材料
+}
+
+// ResultNicknameSet 设置名称结果
+type ResultNicknameSet struct {
+	Wording string `json:"wording"`  // 材料说明
+	AuditID int64  `json:"audit_id"` // 审核单 ID（需要审核时返回，审核结果以 wxa_nickname_audit 事件推送）
+}
+
+// SetNickname 设置小程序名称（名称需要审核时返回 audit_id，可通过 QueryNickname 查询审核状态）
+func SetNickname(params *ParamsNicknameSet, result *ResultNicknameSet) wx.Action {
+	return wx.NewPostAction(urls.OplatformWxaSetNickname,
+		wx.WithBody(func() ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// ResultNicknameQuery 改名审核状态
+type ResultNicknameQuery struct {
+	NickName   string `json:"nickname"`    // 审核昵称
+	AuditStat  int    `json:"audit_stat"`  // 审核状态，1：审核中，2：审核失败，3：审核成功
+	FailReason string `json:"fail_reason"` // 失败原因
+	CreateTime int64  `json:"create_time"` // 审核提交时间
+	AuditTime  int64  `json:"audit_time"`  // 审核完成时间
+}
+
+// QueryNickname 查询改名审核状态
+func QueryNickname(auditID int64, result *ResultNicknameQuery) wx.Action {
+	return wx.NewPostAction(urls.OplatformWxaQueryNickname,
+		wx.WithBody(func() ([]byte, error) {
+			return json.Marshal(map[string]int64{
+				"audit_id": auditID,
+			})
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// ParamsHeadImageModify 修改头像参数（裁剪区域为图片的比例坐标，不裁剪时为 (0, 0) - (1, 1)）
+type ParamsHeadImageModify struct {
+	HeadImgMediaID string  `json:"head_img_media_id"` // 头像素材 media_id
+	X1             float64 `json:"x1"`                // 裁剪框左上角 x 坐标（取值范围：[0, 1]）
+	Y1             float64 `json:"y1"`                // 裁剪框左上角 y 坐标（取值范围：[0, 1]）
+	X2             float64 `json:"x2"`                // 裁剪框右下角 x 坐标（取值范围：[0, 1]）
+	Y2             float64 `json:"y2"`                // 裁剪框右下角 y 坐标（取值范围：[0, 1]）
+}
+
+// ModifyHeadImage 修改小程序头像
+func ModifyHeadImage(params *ParamsHeadImageModify) wx.Action {
+	return wx.NewPostAction(urls.OplatformModifyHeadImage,
+		wx.WithBody(func() ([]byte, error) {
+			return json.Marshal(params)
+		}),
+	)
+}
+
+// ModifySignature 修改小程序简介（4-120字）
+func ModifySignature(signature string) wx.Action {
+	return wx.NewPostAction(urls.OplatformModifySignature,
+		wx.WithBody(func() ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(map[string]string{
+				"signature": signature,
+			})
+		}),
+	)
+}
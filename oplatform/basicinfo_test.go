@@ -0,0 +1,112 @@
+package oplatform
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/minip"
+	"github.com/shenghui0779/gochat/mock"
+)
+
+func TestSetNickname(t *testing.T) {
+	body := []byte(`{"nick_name":"公司名称","license":"MEDIA_ID","naming_other_stuff_1":"STUFF_MEDIA_ID"}`)
+	resp := []byte(`{"errcode":0,"errmsg":"ok","wording":"","audit_id":12345}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/wxa/setnickname?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	mp := minip.New("APPID", "", minip.WithMockClient(client))
+
+	result := new(ResultNicknameSet)
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", SetNickname(&ParamsNicknameSet{
+		NickName:          "公司名称",
+		License:           "MEDIA_ID",
+		NamingOtherStuff1: "STUFF_MEDIA_ID",
+	}, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultNicknameSet{AuditID: 12345}, result)
+}
+
+func TestQueryNickname(t *testing.T) {
+	body := []byte(`{"audit_id":12345}`)
+	resp := []byte(`{
+	"errcode": 0,
+	"errmsg": "ok",
+	"nickname": "公司名称",
+	"audit_stat": 3,
+	"fail_reason": "",
+	"create_time": 1524300000,
+	"audit_time": 1524400000
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/wxa/api_wxa_querynickname?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	mp := minip.New("APPID", "", minip.WithMockClient(client))
+
+	result := new(ResultNicknameQuery)
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", QueryNickname(12345, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultNicknameQuery{
+		NickName:   "公司名称",
+		AuditStat:  NicknameApproved,
+		CreateTime: 1524300000,
+		AuditTime:  1524400000,
+	}, result)
+}
+
+func TestModifyHeadImage(t *testing.T) {
+	body := []byte(`{"head_img_media_id":"MEDIA_ID","x1":0,"y1":0,"x2":0.7596899,"y2":0.5}`)
+	resp := []byte(`{"errcode":0,"errmsg":"ok"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/account/modifyheadimage?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	mp := minip.New("APPID", "", minip.WithMockClient(client))
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", ModifyHeadImage(&ParamsHeadImageModify{
+		HeadImgMediaID: "MEDIA_ID",
+		X2:             0.7596899,
+		Y2:             0.5,
+	}))
+
+	assert.Nil(t, err)
+}
+
+func TestModifySignature(t *testing.T) {
+	body := []byte(`{"signature":"这是一个简介"}`)
+	resp := []byte(`{"errcode":0,"errmsg":"ok"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/account/modifysignature?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	mp := minip.New("APPID", "", minip.WithMockClient(client))
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", ModifySignature("这是一个简介"))
+
+	assert.Nil(t, err)
+}
@@ -0,0 +1,49 @@
+package oplatform
+
+import (
+	"github.com/shenghui0779/gochat/urls"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// 以下接口需使用试用小程序的 authorizer_access_token 调用（如：op.MinipClient(appid).Do）
+
+// 企业代码类型
+const (
+	CodeTypeUSCC            = 1 // 统一社会信用代码（18位）
+	CodeTypeOrganization    = 2 // 组织机构代码（9位xxxxxxxx-x）
+	CodeTypeBusinessLicense = 3 // 营业执照注册号（15位）
+)
+
+// BetaWeappVerifyInfo 试用小程序转正所需的企业信息
+type BetaWeappVerifyInfo struct {
+	EnterpriseName     string `json:"enterprise_name"`                // 企业名（需与工商部门登记信息一致）
+	Code               string `json:"code"`                           // 企业代码
+	CodeType           int    `json:"code_type"`                      // 企业代码类型，1：统一社会信用代码，2：组织机构代码，3：营业执照注册号
+	LegalPersonaWechat string `json:"legal_persona_wechat"`           // 法人微信号
+	LegalPersonaName   string `json:"legal_persona_name"`             // 法人姓名（绑定银行卡）
+	LegalPersonaIDCard string `json:"legal_persona_idcard,omitempty"` // 法人身份证号
+	ComponentPhone     string `json:"component_phone,omitempty"`      // 第三方联系电话
+}
+
+// VerifyBetaWeapp 试用小程序快速认证（转正）；
+// 法人需在微信中完成身份验证，转正结果以 notify_third_fastverifybetaapp 推送至授权事件接收URL（见 ComponentEvent）
+func VerifyBetaWeapp(info *BetaWeappVerifyInfo) wx.Action {
+	return wx.NewPostAction(urls.OplatformWxaVerifyBetaWeapp,
+		wx.WithBody(func() ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(map[string]*BetaWeappVerifyInfo{
+				"verify_info": info,
+			})
+		}),
+	)
+}
+
+// SetBetaWeappNickname 修改试用小程序名称
+func SetBetaWeappNickname(name string) wx.Action {
+	return wx.NewPostAction(urls.OplatformWxaSetBetaWeappNickname,
+		wx.WithBody(func() ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(map[string]string{
+				"name": name,
+			})
+		}),
+	)
+}
@@ -0,0 +1,56 @@
+package oplatform
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/minip"
+	"github.com/shenghui0779/gochat/mock"
+)
+
+func TestVerifyBetaWeapp(t *testing.T) {
+	body := []byte(`{"verify_info":{"enterprise_name":"xxx企业","code":"111111111111111111","code_type":1,"legal_persona_wechat":"wechatid","legal_persona_name":"张三","component_phone":"1234567"}}`)
+	resp := []byte(`{"errcode":0,"errmsg":"ok"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/wxa/verifybetaweapp?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	mp := minip.New("APPID", "", minip.WithMockClient(client))
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", VerifyBetaWeapp(&BetaWeappVerifyInfo{
+		EnterpriseName:     "xxx企业",
+		Code:               "111111111111111111",
+		CodeType:           CodeTypeUSCC,
+		LegalPersonaWechat: "wechatid",
+		LegalPersonaName:   "张三",
+		ComponentPhone:     "1234567",
+	}))
+
+	assert.Nil(t, err)
+}
+
+func TestSetBetaWeappNickname(t *testing.T) {
+	body := []byte(`{"name":"xxx小程序"}`)
+	resp := []byte(`{"errcode":0,"errmsg":"ok"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/wxa/setbetaweappnickname?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	mp := minip.New("APPID", "", minip.WithMockClient(client))
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", SetBetaWeappNickname("xxx小程序"))
+
+	assert.Nil(t, err)
+}
@@ -0,0 +1,80 @@
+package oplatform
+
+import (
+	"encoding/json"
+
+	"github.com/shenghui0779/gochat/urls"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// 以下接口需使用授权小程序的 authorizer_access_token 调用（如：op.MinipClient(appid).Do）
+
+// 类目审核状态
+const (
+	CategoryAuditing = 1 // 审核中
+	CategoryRejected = 2 // 审核不通过
+	CategoryApproved = 3 // 审核通过
+)
+
+// Category 已设置的类目
+type Category struct {
+	First       int64  `json:"first"`        // 一级类目 ID
+	FirstName   string `json:"first_name"`   // 一级类目名称
+	Second      int64  `json:"second"`       // 二级类目 ID
+	SecondName  string `json:"second_name"`  // 二级类目名称
+	AuditStatus int    `json:"audit_status"` // 审核状态，1：审核中，2：审核不通过，3：审核通过
+	AuditReason string `json:"audit_reason"` // 审核不通过的原因
+}
+
+// ResultCategoryGet 已设置的类目
+type ResultCategoryGet struct {
+	Categories    []*Category `json:"categories"`
+	Limit         int         `json:"limit"`          // 一个更改周期内可以添加类目的次数
+	Quota         int         `json:"quota"`          // 本更改周期内还可以添加类目的次数
+	CategoryLimit int         `json:"category_limit"` // 最多可以设置的类目数量
+}
+
+// GetCategory 获取已设置的所有类目
+func GetCategory(result *ResultCategoryGet) wx.Action {
+	return wx.NewGetAction(urls.OplatformWxopenGetCategory,
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// CategoryCert 类目资质
+type CategoryCert struct {
+	Key   string `json:"key"`   // 资质名称
+	Value string `json:"value"` // 资质图片（media_id）
+}
+
+// CategoryAdd 待添加的类目
+type CategoryAdd struct {
+	First      int64           `json:"first"`                // 一级类目 ID
+	Second     int64           `json:"second"`               // 二级类目 ID
+	Certicates []*CategoryCert `json:"certicates,omitempty"` // 资质信息（字段名与微信接口保持一致）
+}
+
+// AddCategory 添加类目（需审核的类目，审核结果以 wxa_category_audit 事件推送）
+func AddCategory(categories ...*CategoryAdd) wx.Action {
+	return wx.NewPostAction(urls.OplatformWxopenAddCategory,
+		wx.WithBody(func() ([]byte, error) {
+			return json.Marshal(map[string][]*CategoryAdd{
+				"categories": categories,
+			})
+		}),
+	)
+}
+
+// DeleteCategory 删除类目
+func DeleteCategory(first, second int64) wx.Action {
+	return wx.NewPostAction(urls.OplatformWxopenDelCategory,
+		wx.WithBody(func() ([]byte, error) {
+			return json.Marshal(map[string]int64{
+				"first":  first,
+				"second": second,
+			})
+		}),
+	)
+}
@@ -0,0 +1,110 @@
+package oplatform
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/minip"
+	"github.com/shenghui0779/gochat/mock"
+)
+
+func TestGetCategory(t *testing.T) {
+	resp := []byte(`{
+	"errcode": 0,
+	"errmsg": "ok",
+	"categories": [
+		{
+			"first": 226,
+			"first_name": "教育",
+			"second": 1063,
+			"second_name": "在线视频课程",
+			"audit_status": 1,
+			"audit_reason": ""
+		}
+	],
+	"limit": 5,
+	"quota": 4,
+	"category_limit": 5
+}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.weixin.qq.com/cgi-bin/wxopen/getcategory?access_token=ACCESS_TOKEN", nil).Return(resp, nil)
+
+	mp := minip.New("APPID", "", minip.WithMockClient(client))
+
+	result := new(ResultCategoryGet)
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", GetCategory(result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultCategoryGet{
+		Categories: []*Category{
+			{
+				First:       226,
+				FirstName:   "教育",
+				Second:      1063,
+				SecondName:  "在线视频课程",
+				AuditStatus: CategoryAuditing,
+			},
+		},
+		Limit:         5,
+		Quota:         4,
+		CategoryLimit: 5,
+	}, result)
+}
+
+func TestAddCategory(t *testing.T) {
+	body := []byte(`{"categories":[{"first":226,"second":1063,"certicates":[{"key":"办学许可证","value":"MEDIA_ID"}]},{"first":304,"second":1064}]}`)
+	resp := []byte(`{"errcode":0,"errmsg":"ok"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/wxopen/addcategory?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	mp := minip.New("APPID", "", minip.WithMockClient(client))
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", AddCategory(
+		&CategoryAdd{
+			First:  226,
+			Second: 1063,
+			Certicates: []*CategoryCert{
+				{Key: "办学许可证", Value: "MEDIA_ID"},
+			},
+		},
+		&CategoryAdd{
+			First:  304,
+			Second: 1064,
+		},
+	))
+
+	assert.Nil(t, err)
+}
+
+func TestDeleteCategory(t *testing.T) {
+	body := []byte(`{"first":226,"second":1063}`)
+	resp := []byte(`{"errcode":0,"errmsg":"ok"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/wxopen/deletecategory?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	mp := minip.New("APPID", "", minip.WithMockClient(client))
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", DeleteCategory(226, 1063))
+
+	assert.Nil(t, err)
+}
@@ -0,0 +1,243 @@
+package oplatform
+
+import (
+	"encoding/json"
+
+	"github.com/shenghui0779/gochat/urls"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// 以下接口需使用授权小程序的 authorizer_access_token 调用（如：op.MinipClient(appid).Do）
+
+// ParamsCodeCommit 上传代码参数
+type ParamsCodeCommit struct {
+	TemplateID  int64  `json:"template_id"`  // 代码库中的代码模板 ID
+	ExtJSON     string `json:"ext_json"`     // 第三方自定义的配置（JSON字符串）
+	UserVersion string `json:"user_version"` // 代码版本号，开发者可自定义（长度不要超过 64 个字符）
+	UserDesc    string `json:"user_desc"`    // 代码描述，开发者可自定义
+}
+
+// CommitCode 上传小程序代码
+// [参考](https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/code/commit.html)
+func CommitCode(params *ParamsCodeCommit) wx.Action {
+	return wx.NewPostAction(urls.OplatformWxaCommit,
+		wx.WithBody(func() ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+	)
+}
+
+// QRCode 体验版二维码
+type QRCode struct {
+	Buffer []byte
+}
+
+// GetTrialQRCode 获取体验版二维码（path 为指定页面路径，可为空）
+// [参考](https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/code/get_qrcode.html)
+func GetTrialQRCode(path string, qrcode *QRCode) wx.Action {
+	options := []wx.ActionOption{
+		wx.WithDecode(func(b []byte) error {
+			qrcode.Buffer = make([]byte, len(b))
+			copy(qrcode.Buffer, b)
+
+			return nil
+		}),
+	}
+
+	if len(path) != 0 {
+		options = append(options, wx.WithQuery("path", path))
+	}
+
+	return wx.NewGetAction(urls.OplatformWxaGetQRCode, options...)
+}
+
+// ResultCodePage 已上传代码的页面列表
+type ResultCodePage struct {
+	PageList []string `json:"page_list"`
+}
+
+// GetCodePage 获取已上传的代码的页面列表
+// [参考](https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/code/get_page.html)
+func GetCodePage(result *ResultCodePage) wx.Action {
+	return wx.NewGetAction(urls.OplatformWxaGetPage,
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// AuditCategory 审核类目
+type AuditCategory struct {
+	FirstClass  string `json:"first_class"`
+	SecondClass string `json:"second_class"`
+	ThirdClass  string `json:"third_class,omitempty"`
+	FirstID     int64  `json:"first_id"`
+	SecondID    int64  `json:"second_id"`
+	ThirdID     int64  `json:"third_id,omitempty"`
+}
+
+// ResultAuditCategory 可填写的类目信息
+type ResultAuditCategory struct {
+	CategoryList []*AuditCategory `json:"category_list"`
+}
+
+// GetAuditCategory 获取审核时可填写的类目信息
+// [参考](https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/category/get_category.html)
+func GetAuditCategory(result *ResultAuditCategory) wx.Action {
+	return wx.NewGetAction(urls.OplatformWxaGetCategory,
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// AuditItem 审核项
+type AuditItem struct {
+	Address     string `json:"address,omitempty"`      // 小程序的页面，可通过 get_page 获取
+	Tag         string `json:"tag,omitempty"`          // 小程序的标签，用空格分隔，标签至多 10 个，标签长度至多 20
+	FirstClass  string `json:"first_class,omitempty"`  // 一级类目名称
+	SecondClass string `json:"second_class,omitempty"` // 二级类目名称
+	ThirdClass  string `json:"third_class,omitempty"`  // 三级类目名称
+	FirstID     int64  `json:"first_id,omitempty"`     // 一级类目的 ID
+	SecondID    int64  `json:"second_id,omitempty"`    // 二级类目的 ID
+	ThirdID     int64  `json:"third_id,omitempty"`     // 三级类目的 ID
+	Title       string `json:"title,omitempty"`        // 小程序页面的标题，标题长度至多 32
+}
+
+// ParamsAuditSubmit 提交审核参数
+type ParamsAuditSubmit struct {
+	ItemList      []*AuditItem `json:"item_list,omitempty"`      // 审核项列表（选填，至多填写 5 项）
+	PreviewInfo   interface{}  `json:"preview_info,omitempty"`   // 预览信息（小程序页面截图和操作录屏）
+	VersionDesc   string       `json:"version_desc,omitempty"`   // 小程序版本说明和功能解释
+	FeedbackInfo  string       `json:"feedback_info,omitempty"`  // 反馈内容，至多 200 字
+	FeedbackStuff string       `json:"feedback_stuff,omitempty"` // 用 | 分割的 media_id 列表，至多 5 张图片
+	UGCDeclare    interface{}  `json:"ugc_declare,omitempty"`    // 用户生成内容场景（UGC）信息安全声明
+}
+
+// ResultAuditSubmit 提交审核结果
+type ResultAuditSubmit struct {
+	AuditID int64 `json:"auditid"`
+}
+
+// SubmitAudit 提交审核
+// [参考](https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/code/submit_audit.html)
+func SubmitAudit(params *ParamsAuditSubmit, result *ResultAuditSubmit) wx.Action {
+	return wx.NewPostAction(urls.OplatformWxaSubmitAudit,
+		wx.WithBody(func() ([]byte, error) {
+			return wx.MarshalNoEscapeHTML(params)
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// AuditStatus 审核状态
+type AuditStatus int
+
+// 审核状态
+const (
+	AuditSuccess  AuditStatus = 0 // 审核成功
+	AuditRejected AuditStatus = 1 // 审核被拒绝
+	AuditPending  AuditStatus = 2 // 审核中
+	AuditUndone   AuditStatus = 3 // 已撤回
+	AuditDelaying AuditStatus = 4 // 审核延后
+)
+
+// ResultAuditStatus 审核状态
+type ResultAuditStatus struct {
+	AuditID         int64       `json:"auditid,omitempty"` // 最新的审核 ID（仅 get_latest_auditstatus 返回）
+	Status          AuditStatus `json:"status"`            // 审核状态
+	Reason          string      `json:"reason"`            // 当审核被拒绝时，返回的拒绝原因
+	ScreenShot      string      `json:"screenshot"`        // 当审核被拒绝时，会返回审核失败的小程序截图示例，用 | 分隔的 media_id 的列表
+	UserVersion     string      `json:"user_version,omitempty"`
+	UserDesc        string      `json:"user_desc,omitempty"`
+	SubmitAuditTime int64       `json:"submit_audit_time,omitempty"`
+}
+
+// GetAuditStatus 查询指定发布审核单的审核状态
+// [参考](https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/code/get_auditstatus.html)
+func GetAuditStatus(auditID int64, result *ResultAuditStatus) wx.Action {
+	return wx.NewPostAction(urls.OplatformWxaGetAuditStatus,
+		wx.WithBody(func() ([]byte, error) {
+			return json.Marshal(map[string]int64{
+				"auditid": auditID,
+			})
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// GetLatestAuditStatus 查询最新一次提交的审核状态
+// [参考](https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/code/get_latest_auditstatus.html)
+func GetLatestAuditStatus(result *ResultAuditStatus) wx.Action {
+	return wx.NewGetAction(urls.OplatformWxaGetLatestAuditStatus,
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// UndoCodeAudit 小程序审核撤回（单个帐号每天审核撤回次数最多不超过 5 次）
+// [参考](https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/code/undocodeaudit.html)
+func UndoCodeAudit() wx.Action {
+	return wx.NewGetAction(urls.OplatformWxaUndoCodeAudit)
+}
+
+// ReleaseCode 发布已通过审核的小程序
+// [参考](https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/code/release.html)
+func ReleaseCode() wx.Action {
+	return wx.NewPostAction(urls.OplatformWxaRelease,
+		wx.WithBody(func() ([]byte, error) {
+			return []byte("{}"), nil
+		}),
+	)
+}
+
+// RevertCodeRelease 版本回退（仅支持回退到上一个线上版本）
+// [参考](https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/code/revertcoderelease.html)
+func RevertCodeRelease() wx.Action {
+	return wx.NewGetAction(urls.OplatformWxaRevertCodeRelease)
+}
+
+// GrayRelease 分阶段发布（grayPercentage 为灰度的百分比，1 ~ 100 的整数）
+// [参考](https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/code/grayrelease.html)
+func GrayRelease(grayPercentage int) wx.Action {
+	return wx.NewPostAction(urls.OplatformWxaGrayRelease,
+		wx.WithBody(func() ([]byte, error) {
+			return json.Marshal(map[string]int{
+				"gray_percentage": grayPercentage,
+			})
+		}),
+	)
+}
+
+// GrayReleasePlan 分阶段发布详情
+type GrayReleasePlan struct {
+	Status          int   `json:"status"`           // 0:初始状态 1:执行中 2:暂停中 3:执行完毕 4:被删除
+	CreateTimestamp int64 `json:"create_timestamp"` // 分阶段发布计划的创建时间
+	GrayPercentage  int   `json:"gray_percentage"`  // 当前的灰度比例
+}
+
+// ResultGrayReleasePlan 分阶段发布详情
+type ResultGrayReleasePlan struct {
+	GrayReleasePlan *GrayReleasePlan `json:"gray_release_plan"`
+}
+
+// GetGrayReleasePlan 查询当前分阶段发布详情
+// [参考](https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/code/getgrayreleaseplan.html)
+func GetGrayReleasePlan(result *ResultGrayReleasePlan) wx.Action {
+	return wx.NewGetAction(urls.OplatformWxaGetGrayReleasePlan,
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// RevertGrayRelease 取消分阶段发布
+// [参考](https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/code/revertgrayrelease.html)
+func RevertGrayRelease() wx.Action {
+	return wx.NewGetAction(urls.OplatformWxaRevertGrayRelease)
+}
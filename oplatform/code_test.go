@@ -0,0 +1,295 @@
+package oplatform
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/minip"
+	"github.com/shenghui0779/gochat/mock"
+)
+
+func TestCommitCode(t *testing.T) {
+	body := []byte(`{"template_id":0,"ext_json":"{\"extAppid\":\"\",\"ext\":{\"attr1\":\"value1\"}}","user_version":"V1.0","user_desc":"test"}`)
+	resp := []byte(`{"errcode":0,"errmsg":"ok"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/wxa/commit?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	mp := minip.New("APPID", "", minip.WithMockClient(client))
+
+	params := &ParamsCodeCommit{
+		TemplateID:  0,
+		ExtJSON:     `{"extAppid":"","ext":{"attr1":"value1"}}`,
+		UserVersion: "V1.0",
+		UserDesc:    "test",
+	}
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", CommitCode(params))
+
+	assert.Nil(t, err)
+}
+
+func TestGetTrialQRCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.weixin.qq.com/wxa/get_qrcode?access_token=ACCESS_TOKEN&path=page%2Findex%3Faction%3D1", nil).Return([]byte("BUFFER"), nil)
+
+	mp := minip.New("APPID", "", minip.WithMockClient(client))
+
+	qrcode := new(QRCode)
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", GetTrialQRCode("page/index?action=1", qrcode))
+
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("BUFFER"), qrcode.Buffer)
+}
+
+func TestGetCodePage(t *testing.T) {
+	resp := []byte(`{"errcode":0,"errmsg":"ok","page_list":["index/index","page/list"]}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.weixin.qq.com/wxa/get_page?access_token=ACCESS_TOKEN", nil).Return(resp, nil)
+
+	mp := minip.New("APPID", "", minip.WithMockClient(client))
+
+	result := new(ResultCodePage)
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", GetCodePage(result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultCodePage{PageList: []string{"index/index", "page/list"}}, result)
+}
+
+func TestGetAuditCategory(t *testing.T) {
+	resp := []byte(`{"errcode":0,"errmsg":"ok","category_list":[{"first_class":"工具","second_class":"备忘录","first_id":1,"second_id":2}]}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.weixin.qq.com/wxa/get_category?access_token=ACCESS_TOKEN", nil).Return(resp, nil)
+
+	mp := minip.New("APPID", "", minip.WithMockClient(client))
+
+	result := new(ResultAuditCategory)
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", GetAuditCategory(result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultAuditCategory{
+		CategoryList: []*AuditCategory{
+			{
+				FirstClass:  "工具",
+				SecondClass: "备忘录",
+				FirstID:     1,
+				SecondID:    2,
+			},
+		},
+	}, result)
+}
+
+func TestSubmitAudit(t *testing.T) {
+	body := []byte(`{"item_list":[{"address":"index","tag":"学习 生活","first_class":"文娱","second_class":"资讯","first_id":1,"second_id":2,"title":"首页"}],"version_desc":"blablabla"}`)
+	resp := []byte(`{"errcode":0,"errmsg":"ok","auditid":1234567}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/wxa/submit_audit?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	mp := minip.New("APPID", "", minip.WithMockClient(client))
+
+	params := &ParamsAuditSubmit{
+		ItemList: []*AuditItem{
+			{
+				Address:     "index",
+				Tag:         "学习 生活",
+				FirstClass:  "文娱",
+				SecondClass: "资讯",
+				FirstID:     1,
+				SecondID:    2,
+				Title:       "首页",
+			},
+		},
+		VersionDesc: "blablabla",
+	}
+
+	result := new(ResultAuditSubmit)
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", SubmitAudit(params, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultAuditSubmit{AuditID: 1234567}, result)
+}
+
+func TestGetAuditStatus(t *testing.T) {
+	body := []byte(`{"auditid":1234567}`)
+	resp := []byte(`{"errcode":0,"errmsg":"ok","status":1,"reason":"帐号信息不合规范","screenshot":"xx|yy|zz"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/wxa/get_auditstatus?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	mp := minip.New("APPID", "", minip.WithMockClient(client))
+
+	result := new(ResultAuditStatus)
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", GetAuditStatus(1234567, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultAuditStatus{
+		Status:     AuditRejected,
+		Reason:     "帐号信息不合规范",
+		ScreenShot: "xx|yy|zz",
+	}, result)
+}
+
+func TestGetLatestAuditStatus(t *testing.T) {
+	resp := []byte(`{"errcode":0,"errmsg":"ok","auditid":1234567,"status":1,"reason":"帐号信息不合规范","ScreenShot":"xx|yy|zz","user_version":"V1.0","user_desc":"test","submit_audit_time":1640000000}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.weixin.qq.com/wxa/get_latest_auditstatus?access_token=ACCESS_TOKEN", nil).Return(resp, nil)
+
+	mp := minip.New("APPID", "", minip.WithMockClient(client))
+
+	result := new(ResultAuditStatus)
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", GetLatestAuditStatus(result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultAuditStatus{
+		AuditID:         1234567,
+		Status:          AuditRejected,
+		Reason:          "帐号信息不合规范",
+		ScreenShot:      "xx|yy|zz",
+		UserVersion:     "V1.0",
+		UserDesc:        "test",
+		SubmitAuditTime: 1640000000,
+	}, result)
+}
+
+func TestUndoCodeAudit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.weixin.qq.com/wxa/undocodeaudit?access_token=ACCESS_TOKEN", nil).Return([]byte(`{"errcode":0,"errmsg":"ok"}`), nil)
+
+	mp := minip.New("APPID", "", minip.WithMockClient(client))
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", UndoCodeAudit())
+
+	assert.Nil(t, err)
+}
+
+func TestReleaseCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/wxa/release?access_token=ACCESS_TOKEN", []byte("{}")).Return([]byte(`{"errcode":0,"errmsg":"ok"}`), nil)
+
+	mp := minip.New("APPID", "", minip.WithMockClient(client))
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", ReleaseCode())
+
+	assert.Nil(t, err)
+}
+
+func TestRevertCodeRelease(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.weixin.qq.com/wxa/revertcoderelease?access_token=ACCESS_TOKEN", nil).Return([]byte(`{"errcode":0,"errmsg":"ok"}`), nil)
+
+	mp := minip.New("APPID", "", minip.WithMockClient(client))
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", RevertCodeRelease())
+
+	assert.Nil(t, err)
+}
+
+func TestGrayRelease(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/wxa/grayrelease?access_token=ACCESS_TOKEN", []byte(`{"gray_percentage":10}`)).Return([]byte(`{"errcode":0,"errmsg":"ok"}`), nil)
+
+	mp := minip.New("APPID", "", minip.WithMockClient(client))
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", GrayRelease(10))
+
+	assert.Nil(t, err)
+}
+
+func TestGetGrayReleasePlan(t *testing.T) {
+	resp := []byte(`{"errcode":0,"errmsg":"ok","gray_release_plan":{"status":1,"create_timestamp":1526137068,"gray_percentage":60}}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.weixin.qq.com/wxa/getgrayreleaseplan?access_token=ACCESS_TOKEN", nil).Return(resp, nil)
+
+	mp := minip.New("APPID", "", minip.WithMockClient(client))
+
+	result := new(ResultGrayReleasePlan)
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", GetGrayReleasePlan(result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultGrayReleasePlan{
+		GrayReleasePlan: &GrayReleasePlan{
+			Status:          1,
+			CreateTimestamp: 1526137068,
+			GrayPercentage:  60,
+		},
+	}, result)
+}
+
+func TestRevertGrayRelease(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.weixin.qq.com/wxa/revertgrayrelease?access_token=ACCESS_TOKEN", nil).Return([]byte(`{"errcode":0,"errmsg":"ok"}`), nil)
+
+	mp := minip.New("APPID", "", minip.WithMockClient(client))
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", RevertGrayRelease())
+
+	assert.Nil(t, err)
+}
@@ -0,0 +1,45 @@
+package oplatform
+
+import (
+	"encoding/json"
+
+	"github.com/shenghui0779/gochat/urls"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// AuthType 授权账号类型
+type AuthType int
+
+// 第三方平台支持的授权账号类型
+const (
+	AuthOffia AuthType = 1 // 商户点击链接后，手机端仅展示公众号
+	AuthMinip AuthType = 2 // 商户点击链接后，手机端仅展示小程序
+	AuthBoth  AuthType = 3 // 商户点击链接后，手机端展示公众号和小程序
+)
+
+// ComponentAccessToken 第三方平台令牌
+type ComponentAccessToken struct {
+	Token     string `json:"component_access_token"`
+	ExpiresIn int64  `json:"expires_in"`
+}
+
+// ResultPreAuthCode 预授权码
+type ResultPreAuthCode struct {
+	PreAuthCode string `json:"pre_auth_code"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// CreatePreAuthCode 获取预授权码
+// [参考](https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/ThirdParty/token/pre_auth_code.html)
+func CreatePreAuthCode(componentAppID string, result *ResultPreAuthCode) wx.Action {
+	return wx.NewPostAction(urls.OplatformPreAuthCode,
+		wx.WithBody(func() ([]byte, error) {
+			return json.Marshal(map[string]string{
+				"component_appid": componentAppID,
+			})
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
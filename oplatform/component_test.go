@@ -0,0 +1,36 @@
+package oplatform
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/mock"
+)
+
+func TestCreatePreAuthCode(t *testing.T) {
+	body := []byte(`{"component_appid":"COMPONENT_APPID"}`)
+	resp := []byte(`{"pre_auth_code":"Cx_Dk6qiBE0Dmx4EmlT3oRfArPvwSQ-oa3NL_fwHM7VI08r52wazoZX2Rhpz1dEw","expires_in":600}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/component/api_create_preauthcode?component_access_token=COMPONENT_ACCESS_TOKEN", body).Return(resp, nil)
+
+	op := New("COMPONENT_APPID", "COMPONENT_APPSECRET", WithMockClient(client))
+
+	result := new(ResultPreAuthCode)
+
+	err := op.Do(context.TODO(), "COMPONENT_ACCESS_TOKEN", CreatePreAuthCode(op.AppID(), result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultPreAuthCode{
+		PreAuthCode: "Cx_Dk6qiBE0Dmx4EmlT3oRfArPvwSQ-oa3NL_fwHM7VI08r52wazoZX2Rhpz1dEw",
+		ExpiresIn:   600,
+	}, result)
+}
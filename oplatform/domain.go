@@ -0,0 +1,117 @@
+package oplatform
+
+import (
+	"encoding/json"
+
+	"github.com/shenghui0779/gochat/urls"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// DomainAction 域名操作类型
+type DomainAction string
+
+// 域名操作类型
+const (
+	DomainAdd    DomainAction = "add"    // 添加
+	DomainDelete DomainAction = "delete" // 删除
+	DomainSet    DomainAction = "set"    // 覆盖
+	DomainGet    DomainAction = "get"    // 获取
+)
+
+// ServerDomain 服务器域名
+type ServerDomain struct {
+	RequestDomain   []string `json:"requestdomain,omitempty"`   // request 合法域名
+	WsRequestDomain []string `json:"wsrequestdomain,omitempty"` // socket 合法域名
+	UploadDomain    []string `json:"uploaddomain,omitempty"`    // uploadFile 合法域名
+	DownloadDomain  []string `json:"downloaddomain,omitempty"`  // downloadFile 合法域名
+	UDPDomain       []string `json:"udpdomain,omitempty"`       // udp 合法域名
+	TCPDomain       []string `json:"tcpdomain,omitempty"`       // tcp 合法域名
+}
+
+// ParamsServerDomain 设置服务器域名参数
+type ParamsServerDomain struct {
+	Action DomainAction `json:"action"`
+	ServerDomain
+}
+
+// ResultServerDomain 服务器域名配置
+type ResultServerDomain struct {
+	ServerDomain
+	InvalidRequestDomain   []string `json:"invalid_requestdomain,omitempty"`   // 无效的 request 合法域名（仅 modify_domain_directly 返回）
+	InvalidWsRequestDomain []string `json:"invalid_wsrequestdomain,omitempty"` // 无效的 socket 合法域名
+	InvalidUploadDomain    []string `json:"invalid_uploaddomain,omitempty"`    // 无效的 uploadFile 合法域名
+	InvalidDownloadDomain  []string `json:"invalid_downloaddomain,omitempty"`  // 无效的 downloadFile 合法域名
+	InvalidUDPDomain       []string `json:"invalid_udpdomain,omitempty"`       // 无效的 udp 合法域名
+	InvalidTCPDomain       []string `json:"invalid_tcpdomain,omitempty"`       // 无效的 tcp 合法域名
+	NoICPDomain            []string `json:"no_icp_domain,omitempty"`           // 没有经过icp备案的域名
+}
+
+// ModifyDomain 设置服务器域名（需先将域名登记到第三方平台的小程序服务器域名中）
+// [参考](https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/Mini_Program_Basic_Info/Server_Address_Configuration.html)
+func ModifyDomain(params *ParamsServerDomain, result *ResultServerDomain) wx.Action {
+	return wx.NewPostAction(urls.OplatformWxaModifyDomain,
+		wx.WithBody(func() ([]byte, error) {
+			return json.Marshal(params)
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// ModifyDomainDirectly 快速配置服务器域名（无需先将域名登记到第三方平台）
+// [参考](https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/Mini_Program_Basic_Info/modify_domain_directly.html)
+func ModifyDomainDirectly(params *ParamsServerDomain, result *ResultServerDomain) wx.Action {
+	return wx.NewPostAction(urls.OplatformWxaModifyDomainDirectly,
+		wx.WithBody(func() ([]byte, error) {
+			return json.Marshal(params)
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// ResultWebviewDomain 业务域名配置
+type ResultWebviewDomain struct {
+	WebviewDomain []string `json:"webviewdomain"`
+}
+
+// SetWebviewDomain 设置业务域名（需先将域名登记到第三方平台的小程序业务域名中）
+// action 为空时，默认将第三方平台登记的业务域名全部添加到该小程序
+// [参考](https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/Mini_Program_Basic_Info/setwebviewdomain.html)
+func SetWebviewDomain(action DomainAction, domains []string, result *ResultWebviewDomain) wx.Action {
+	return wx.NewPostAction(urls.OplatformWxaSetWebviewDomain,
+		wx.WithBody(func() ([]byte, error) {
+			return json.Marshal(newWebviewDomainParams(action, domains))
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// SetWebviewDomainDirectly 快速配置业务域名（无需先将域名登记到第三方平台）
+// [参考](https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/Mini_Program_Basic_Info/setwebviewdomain_directly.html)
+func SetWebviewDomainDirectly(action DomainAction, domains []string, result *ResultWebviewDomain) wx.Action {
+	return wx.NewPostAction(urls.OplatformWxaSetWebviewDomainDirectly,
+		wx.WithBody(func() ([]byte, error) {
+			return json.Marshal(newWebviewDomainParams(action, domains))
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+type paramsWebviewDomain struct {
+	Action        DomainAction `json:"action,omitempty"`
+	WebviewDomain []string     `json:"webviewdomain,omitempty"`
+}
+
+func newWebviewDomainParams(action DomainAction, domains []string) *paramsWebviewDomain {
+	return &paramsWebviewDomain{
+		Action:        action,
+		WebviewDomain: domains,
+	}
+}
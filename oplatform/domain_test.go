@@ -0,0 +1,113 @@
+package oplatform
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/minip"
+	"github.com/shenghui0779/gochat/mock"
+)
+
+func TestModifyDomain(t *testing.T) {
+	body := []byte(`{"action":"add","requestdomain":["https://www.qq.com"],"wsrequestdomain":["wss://www.qq.com"],"uploaddomain":["https://www.qq.com"],"downloaddomain":["https://www.qq.com"]}`)
+	resp := []byte(`{"errcode":0,"errmsg":"ok","requestdomain":["https://www.qq.com"],"wsrequestdomain":["wss://www.qq.com"],"uploaddomain":["https://www.qq.com"],"downloaddomain":["https://www.qq.com"]}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/wxa/modify_domain?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	mp := minip.New("APPID", "", minip.WithMockClient(client))
+
+	params := &ParamsServerDomain{
+		Action: DomainAdd,
+		ServerDomain: ServerDomain{
+			RequestDomain:   []string{"https://www.qq.com"},
+			WsRequestDomain: []string{"wss://www.qq.com"},
+			UploadDomain:    []string{"https://www.qq.com"},
+			DownloadDomain:  []string{"https://www.qq.com"},
+		},
+	}
+
+	result := new(ResultServerDomain)
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", ModifyDomain(params, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultServerDomain{ServerDomain: params.ServerDomain}, result)
+}
+
+func TestModifyDomainDirectly(t *testing.T) {
+	body := []byte(`{"action":"get"}`)
+	resp := []byte(`{"errcode":0,"errmsg":"ok","requestdomain":["https://www.qq.com"],"invalid_requestdomain":["https://www.invalid.com"],"no_icp_domain":["https://www.noicp.com"]}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/wxa/modify_domain_directly?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	mp := minip.New("APPID", "", minip.WithMockClient(client))
+
+	result := new(ResultServerDomain)
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", ModifyDomainDirectly(&ParamsServerDomain{Action: DomainGet}, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultServerDomain{
+		ServerDomain: ServerDomain{
+			RequestDomain: []string{"https://www.qq.com"},
+		},
+		InvalidRequestDomain: []string{"https://www.invalid.com"},
+		NoICPDomain:          []string{"https://www.noicp.com"},
+	}, result)
+}
+
+func TestSetWebviewDomain(t *testing.T) {
+	body := []byte(`{"action":"add","webviewdomain":["https://www.qq.com"]}`)
+	resp := []byte(`{"errcode":0,"errmsg":"ok","webviewdomain":["https://www.qq.com"]}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/wxa/setwebviewdomain?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	mp := minip.New("APPID", "", minip.WithMockClient(client))
+
+	result := new(ResultWebviewDomain)
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", SetWebviewDomain(DomainAdd, []string{"https://www.qq.com"}, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultWebviewDomain{WebviewDomain: []string{"https://www.qq.com"}}, result)
+}
+
+func TestSetWebviewDomainDirectly(t *testing.T) {
+	body := []byte(`{"action":"get"}`)
+	resp := []byte(`{"errcode":0,"errmsg":"ok","webviewdomain":["https://www.qq.com"]}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/wxa/setwebviewdomain_directly?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	mp := minip.New("APPID", "", minip.WithMockClient(client))
+
+	result := new(ResultWebviewDomain)
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", SetWebviewDomainDirectly(DomainGet, nil, result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultWebviewDomain{WebviewDomain: []string{"https://www.qq.com"}}, result)
+}
@@ -0,0 +1,170 @@
+package oplatform
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/shenghui0779/gochat/event"
+)
+
+// InfoType 第三方平台推送的消息类型
+type InfoType string
+
+// 第三方平台支持的推送消息类型
+const (
+	InfoComponentVerifyTicket InfoType = "component_verify_ticket" // 验证票据
+	InfoAuthorized            InfoType = "authorized"              // 授权成功
+	InfoUpdateAuthorized      InfoType = "updateauthorized"        // 授权更新
+	InfoUnauthorized          InfoType = "unauthorized"            // 取消授权
+
+	InfoFastRegisterBetaApp InfoType = "notify_third_fastregisterbetaapp" // 试用小程序创建结果
+	InfoFastVerifyBetaApp   InfoType = "notify_third_fastverifybetaapp"   // 试用小程序转正结果
+)
+
+// FastRegisterInfo 快速创建/转正小程序时提交的信息
+type FastRegisterInfo struct {
+	UniqueID           string `xml:"unique_id"`            // 任务 ID（试用小程序）
+	Name               string `xml:"name"`                 // 企业名称或小程序名称
+	Code               string `xml:"code"`                 // 企业代码
+	CodeType           int    `xml:"code_type"`            // 企业代码类型
+	LegalPersonaWechat string `xml:"legal_persona_wechat"` // 法人微信号
+	LegalPersonaName   string `xml:"legal_persona_name"`   // 法人姓名
+	ComponentPhone     string `xml:"component_phone"`      // 第三方联系电话
+}
+
+// ComponentEvent 第三方平台授权事件（验证票据、授权变更通知）
+// [参考](https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/Before_Develop/authorize_event.html)
+type ComponentEvent struct {
+	XMLName                      xml.Name `xml:"xml"`
+	AppID                        string   `xml:"AppId"`                        // 第三方平台 appid
+	CreateTime                   int64    `xml:"CreateTime"`                   // 时间戳
+	InfoType                     InfoType `xml:"InfoType"`                     // 通知类型
+	ComponentVerifyTicket        string   `xml:"ComponentVerifyTicket"`        // 验证票据
+	AuthorizerAppID              string   `xml:"AuthorizerAppid"`              // 公众号或小程序的 appid
+	AuthorizationCode            string   `xml:"AuthorizationCode"`            // 授权码，可用于获取授权信息
+	AuthorizationCodeExpiredTime int64    `xml:"AuthorizationCodeExpiredTime"` // 授权码过期时间
+	PreAuthCode                  string   `xml:"PreAuthCode"`                  // 预授权码
+
+	// 以下为试用小程序创建/转正结果通知
+	RegisterAppID string            `xml:"appid"`  // 小程序 appid
+	Status        int               `xml:"status"` // 状态，0 表示成功
+	Msg           string            `xml:"msg"`    // 状态说明
+	Info          *FastRegisterInfo `xml:"info"`   // 提交的信息
+}
+
+// ComponentEventMessage 第三方平台推送的加密消息
+type ComponentEventMessage struct {
+	XMLName xml.Name `xml:"xml"`
+	AppID   string   `xml:"AppId"`
+	Encrypt string   `xml:"Encrypt"`
+}
+
+// VerifyEventSign 验证消息事件签名
+// 验证消息来自微信服务器，使用：msg_signature、timestamp、nonce、msg_encrypt
+func (op *Oplatform) VerifyEventSign(signature string, items ...string) bool {
+	signStr := event.SignWithSHA1(op.token, items...)
+
+	return signStr == signature
+}
+
+// DecryptComponentEvent 对授权事件消息进行解密
+func (op *Oplatform) DecryptComponentEvent(encrypt string) (*ComponentEvent, error) {
+	b, err := event.Decrypt(op.appid, op.aeskey, encrypt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	e := new(ComponentEvent)
+
+	if err = xml.Unmarshal(b, e); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// ParseComponentEvent 验证签名并解析授权事件；
+// 收到 component_verify_ticket 时自动更新（并持久化）票据，收到 unauthorized 时自动删除授权方的刷新令牌
+func (op *Oplatform) ParseComponentEvent(ctx context.Context, signature, timestamp, nonce string, body []byte) (*ComponentEvent, error) {
+	msg := new(ComponentEventMessage)
+
+	if err := xml.Unmarshal(body, msg); err != nil {
+		return nil, err
+	}
+
+	if !op.VerifyEventSign(signature, timestamp, nonce, msg.Encrypt) {
+		return nil, errors.New("component event signature verified fail")
+	}
+
+	b, err := op.DecryptEvent(msg.Encrypt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return op.HandleComponentEvent(ctx, b)
+}
+
+// HandleComponentEvent 解析已验证签名并解密的授权事件报文（如：由 DecryptEvent 解密）；
+// 收到 component_verify_ticket 时自动更新（并持久化）票据，收到 unauthorized 时自动删除授权方的刷新令牌
+func (op *Oplatform) HandleComponentEvent(ctx context.Context, b []byte) (*ComponentEvent, error) {
+	e := new(ComponentEvent)
+
+	if err := xml.Unmarshal(b, e); err != nil {
+		return nil, err
+	}
+
+	switch e.InfoType {
+	case InfoComponentVerifyTicket:
+		if err := op.saveVerifyTicket(ctx, e.ComponentVerifyTicket); err != nil {
+			return nil, err
+		}
+	case InfoUnauthorized:
+		if err := op.Unauthorize(ctx, e.AuthorizerAppID); err != nil {
+			return nil, err
+		}
+	}
+
+	return e, nil
+}
+
+// ComponentEventHandler 处理授权事件（component_verify_ticket 已自动处理，可不关注）
+type ComponentEventHandler func(ctx context.Context, e *ComponentEvent) error
+
+// ComponentEventServer returns an http.Handler for the 授权事件接收URL
+// 处理成功后返回 success，否则返回 HTTP 400/500
+func (op *Oplatform) ComponentEventServer(handler ComponentEventHandler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		query := r.URL.Query()
+
+		e, err := op.ParseComponentEvent(r.Context(), query.Get("msg_signature"), query.Get("timestamp"), query.Get("nonce"), body)
+
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		if handler != nil {
+			if err = handler(r.Context(), e); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+
+				return
+			}
+		}
+
+		w.Write([]byte("success"))
+	})
+}
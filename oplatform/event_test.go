@@ -0,0 +1,123 @@
+package oplatform
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/event"
+)
+
+const (
+	testEventToken  = "2faf43d6343a802b6073aae5b3f2f109"
+	testEventAESKey = "jxAko083VoJ3lcPXJWzcGJ0M1tFVLgdD6qAq57GJY1U"
+)
+
+func mockComponentEvent(t *testing.T, plain string) (string, []byte) {
+	cipher, err := event.Encrypt("COMPONENT_APPID", testEventAESKey, "1234567890123456", []byte(plain))
+
+	assert.Nil(t, err)
+
+	encrypt := base64.StdEncoding.EncodeToString(cipher)
+	signature := event.SignWithSHA1(testEventToken, "1606902086", "1246833592", encrypt)
+
+	return signature, []byte(fmt.Sprintf("<xml><AppId><![CDATA[COMPONENT_APPID]]></AppId><Encrypt><![CDATA[%s]]></Encrypt></xml>", encrypt))
+}
+
+func TestParseComponentEvent(t *testing.T) {
+	op := New("COMPONENT_APPID", "COMPONENT_APPSECRET", WithServerConfig(testEventToken, testEventAESKey))
+
+	signature, body := mockComponentEvent(t, `<xml><AppId>COMPONENT_APPID</AppId><CreateTime>1413192605</CreateTime><InfoType>component_verify_ticket</InfoType><ComponentVerifyTicket>TICKET</ComponentVerifyTicket></xml>`)
+
+	e, err := op.ParseComponentEvent(context.TODO(), signature, "1606902086", "1246833592", body)
+
+	assert.Nil(t, err)
+	assert.Equal(t, InfoComponentVerifyTicket, e.InfoType)
+	assert.Equal(t, int64(1413192605), e.CreateTime)
+	assert.Equal(t, "TICKET", op.VerifyTicket())
+
+	// 签名错误
+	_, err = op.ParseComponentEvent(context.TODO(), "SIGNATURE", "1606902086", "1246833592", body)
+
+	assert.NotNil(t, err)
+}
+
+func TestParseFastVerifyBetaAppEvent(t *testing.T) {
+	op := New("COMPONENT_APPID", "COMPONENT_APPSECRET", WithServerConfig(testEventToken, testEventAESKey))
+
+	signature, body := mockComponentEvent(t, `<xml><AppId><![CDATA[COMPONENT_APPID]]></AppId><CreateTime>1535442403</CreateTime><InfoType><![CDATA[notify_third_fastverifybetaapp]]></InfoType><appid>BETA_APPID</appid><status>0</status><msg>OK</msg><info><unique_id><![CDATA[UNIQUE_ID]]></unique_id><name><![CDATA[xxx企业]]></name><code><![CDATA[111111111111111111]]></code><code_type>1</code_type><legal_persona_wechat><![CDATA[wechatid]]></legal_persona_wechat><legal_persona_name><![CDATA[张三]]></legal_persona_name><component_phone><![CDATA[1234567]]></component_phone></info></xml>`)
+
+	e, err := op.ParseComponentEvent(context.TODO(), signature, "1606902086", "1246833592", body)
+
+	assert.Nil(t, err)
+	assert.Equal(t, InfoFastVerifyBetaApp, e.InfoType)
+	assert.Equal(t, "BETA_APPID", e.RegisterAppID)
+	assert.Equal(t, 0, e.Status)
+	assert.Equal(t, "OK", e.Msg)
+	assert.Equal(t, &FastRegisterInfo{
+		UniqueID:           "UNIQUE_ID",
+		Name:               "xxx企业",
+		Code:               "111111111111111111",
+		CodeType:           CodeTypeUSCC,
+		LegalPersonaWechat: "wechatid",
+		LegalPersonaName:   "张三",
+		ComponentPhone:     "1234567",
+	}, e.Info)
+}
+
+func TestParseUnauthorizedEvent(t *testing.T) {
+	store := NewMemAuthorizerStore()
+
+	assert.Nil(t, store.Set(context.TODO(), "AUTHORIZER_APPID", "REFRESH_TOKEN"))
+
+	op := New("COMPONENT_APPID", "COMPONENT_APPSECRET", WithServerConfig(testEventToken, testEventAESKey), WithAuthorizerStore(store))
+
+	signature, body := mockComponentEvent(t, `<xml><AppId>COMPONENT_APPID</AppId><CreateTime>1413192760</CreateTime><InfoType>unauthorized</InfoType><AuthorizerAppid>AUTHORIZER_APPID</AuthorizerAppid></xml>`)
+
+	e, err := op.ParseComponentEvent(context.TODO(), signature, "1606902086", "1246833592", body)
+
+	assert.Nil(t, err)
+	assert.Equal(t, InfoUnauthorized, e.InfoType)
+
+	_, err = store.Get(context.TODO(), "AUTHORIZER_APPID")
+
+	assert.NotNil(t, err)
+}
+
+func TestComponentEventServer(t *testing.T) {
+	op := New("COMPONENT_APPID", "COMPONENT_APPSECRET", WithServerConfig(testEventToken, testEventAESKey))
+
+	signature, body := mockComponentEvent(t, `<xml><AppId>COMPONENT_APPID</AppId><CreateTime>1413192760</CreateTime><InfoType>authorized</InfoType><AuthorizerAppid>AUTHORIZER_APPID</AuthorizerAppid><AuthorizationCode>AUTH_CODE</AuthorizationCode><AuthorizationCodeExpiredTime>1413196360</AuthorizationCodeExpiredTime><PreAuthCode>PRE_AUTH_CODE</PreAuthCode></xml>`)
+
+	var received *ComponentEvent
+
+	srv := op.ComponentEventServer(func(ctx context.Context, e *ComponentEvent) error {
+		received = e
+
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/component?msg_signature="+signature+"&timestamp=1606902086&nonce=1246833592", strings.NewReader(string(body)))
+
+	srv.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "success", w.Body.String())
+	assert.Equal(t, &ComponentEvent{
+		XMLName:                      received.XMLName,
+		AppID:                        "COMPONENT_APPID",
+		CreateTime:                   1413192760,
+		InfoType:                     InfoAuthorized,
+		AuthorizerAppID:              "AUTHORIZER_APPID",
+		AuthorizationCode:            "AUTH_CODE",
+		AuthorizationCodeExpiredTime: 1413196360,
+		PreAuthCode:                  "PRE_AUTH_CODE",
+	}, received)
+}
@@ -0,0 +1,209 @@
+package oplatform
+
+import (
+	"encoding/xml"
+	"strings"
+
+	"github.com/shenghui0779/gochat/event"
+)
+
+// 小程序代码审核事件
+const (
+	EventWeappAuditSuccess event.EventType = "weapp_audit_success" // 审核通过
+	EventWeappAuditFail    event.EventType = "weapp_audit_fail"    // 审核不通过
+	EventWeappAuditDelay   event.EventType = "weapp_audit_delay"   // 审核延后
+)
+
+// 小程序基础信息审核事件
+const (
+	EventWxaNicknameAudit event.EventType = "wxa_nickname_audit" // 名称审核结果
+	EventWxaCategoryAudit event.EventType = "wxa_category_audit" // 类目审核结果
+)
+
+// MessageHeader 消息公共字段
+type MessageHeader struct {
+	XMLName      xml.Name      `xml:"xml"`
+	ToUserName   string        `xml:"ToUserName"`   // 授权方的原始ID
+	FromUserName string        `xml:"FromUserName"` // 发送方帐号（openid）
+	CreateTime   int64         `xml:"CreateTime"`   // 消息创建时间
+	MsgType      event.MsgType `xml:"MsgType"`      // 消息类型
+}
+
+// Header returns the message header
+func (h *MessageHeader) Header() *MessageHeader {
+	return h
+}
+
+// TextMessage 文本消息
+type TextMessage struct {
+	MessageHeader
+	MsgID   int64  `xml:"MsgId"`
+	Content string `xml:"Content"`
+}
+
+// ImageMessage 图片消息
+type ImageMessage struct {
+	MessageHeader
+	MsgID   int64  `xml:"MsgId"`
+	PicURL  string `xml:"PicUrl"`
+	MediaID string `xml:"MediaId"`
+}
+
+// VoiceMessage 语音消息
+type VoiceMessage struct {
+	MessageHeader
+	MsgID       int64  `xml:"MsgId"`
+	MediaID     string `xml:"MediaId"`
+	Format      string `xml:"Format"`
+	Recognition string `xml:"Recognition"` // 语音识别结果（开启语音识别后返回）
+}
+
+// VideoMessage 视频/小视频消息
+type VideoMessage struct {
+	MessageHeader
+	MsgID        int64  `xml:"MsgId"`
+	MediaID      string `xml:"MediaId"`
+	ThumbMediaID string `xml:"ThumbMediaId"`
+}
+
+// LocationMessage 地理位置消息
+type LocationMessage struct {
+	MessageHeader
+	MsgID     int64   `xml:"MsgId"`
+	LocationX float64 `xml:"Location_X"`
+	LocationY float64 `xml:"Location_Y"`
+	Scale     int     `xml:"Scale"`
+	Label     string  `xml:"Label"`
+}
+
+// LinkMessage 链接消息
+type LinkMessage struct {
+	MessageHeader
+	MsgID       int64  `xml:"MsgId"`
+	Title       string `xml:"Title"`
+	Description string `xml:"Description"`
+	URL         string `xml:"Url"`
+}
+
+// EventMessage 事件推送（关注、扫码、上报地理位置、菜单等）
+type EventMessage struct {
+	MessageHeader
+	Event     string  `xml:"Event"`     // 事件类型（原始大小写）
+	EventKey  string  `xml:"EventKey"`  // 事件KEY值
+	Ticket    string  `xml:"Ticket"`    // 二维码的ticket
+	Latitude  float64 `xml:"Latitude"`  // 地理位置纬度
+	Longitude float64 `xml:"Longitude"` // 地理位置经度
+	Precision float64 `xml:"Precision"` // 地理位置精度
+}
+
+// EventType returns the lower-cased event type
+func (e *EventMessage) EventType() event.EventType {
+	return event.EventType(strings.ToLower(e.Event))
+}
+
+// WeappAuditEvent 小程序代码审核结果事件
+// [参考](https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/code/audit_event.html)
+type WeappAuditEvent struct {
+	EventMessage
+	SuccTime   int64  `xml:"SuccTime"`   // 审核成功时的时间戳
+	FailTime   int64  `xml:"FailTime"`   // 审核不通过时的时间戳
+	DelayTime  int64  `xml:"DelayTime"`  // 审核延后时的时间戳
+	Reason     string `xml:"Reason"`     // 审核不通过/延后的原因
+	ScreenShot string `xml:"ScreenShot"` // 审核不通过的截图示例，用 | 分隔的 media_id 的列表
+}
+
+// NicknameAuditEvent 小程序名称审核结果事件
+type NicknameAuditEvent struct {
+	EventMessage
+	Ret      int    `xml:"ret"`      // 审核结果，2：失败，3：成功
+	NickName string `xml:"nickname"` // 需要更改的昵称
+	Reason   string `xml:"reason"`   // 审核失败的驳回原因
+}
+
+// CategoryAuditEvent 小程序类目审核结果事件
+type CategoryAuditEvent struct {
+	EventMessage
+	Ret    int    `xml:"ret"`    // 审核结果，2：不通过，3：通过
+	First  int64  `xml:"first"`  // 一级类目 ID
+	Second int64  `xml:"second"` // 二级类目 ID
+	Reason string `xml:"reason"` // 审核不通过的原因
+}
+
+// UnknownMessage 未定义类型的消息，保留原始报文
+type UnknownMessage struct {
+	MessageHeader
+	Event string `xml:"Event"`
+	Raw   []byte `xml:"-"`
+}
+
+type messageKind struct {
+	MessageHeader
+	Event    string   `xml:"Event"`
+	InfoType InfoType `xml:"InfoType"`
+}
+
+// ParseMessage 将解密后的消息解析为具体类型，返回值为以下类型之一：
+// *ComponentEvent、*TextMessage、*ImageMessage、*VoiceMessage、*VideoMessage、*LocationMessage、
+// *LinkMessage、*WeappAuditEvent、*NicknameAuditEvent、*CategoryAuditEvent、*EventMessage、*UnknownMessage
+func ParseMessage(b []byte) (interface{}, error) {
+	kind := new(messageKind)
+
+	if err := xml.Unmarshal(b, kind); err != nil {
+		return nil, err
+	}
+
+	var msg interface{}
+
+	if len(kind.InfoType) != 0 {
+		msg = new(ComponentEvent)
+	} else {
+		switch kind.MsgType {
+		case event.MsgText:
+			msg = new(TextMessage)
+		case event.MsgImage:
+			msg = new(ImageMessage)
+		case event.MsgVoice:
+			msg = new(VoiceMessage)
+		case event.MsgVideo, event.MsgShortVideo:
+			msg = new(VideoMessage)
+		case event.MsgLocation:
+			msg = new(LocationMessage)
+		case event.MsgLink:
+			msg = new(LinkMessage)
+		case event.MsgEvent:
+			switch event.EventType(strings.ToLower(kind.Event)) {
+			case EventWeappAuditSuccess, EventWeappAuditFail, EventWeappAuditDelay:
+				msg = new(WeappAuditEvent)
+			case EventWxaNicknameAudit:
+				msg = new(NicknameAuditEvent)
+			case EventWxaCategoryAudit:
+				msg = new(CategoryAuditEvent)
+			default:
+				msg = new(EventMessage)
+			}
+		default:
+			return &UnknownMessage{
+				MessageHeader: kind.MessageHeader,
+				Event:         kind.Event,
+				Raw:           b,
+			}, nil
+		}
+	}
+
+	if err := xml.Unmarshal(b, msg); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// DecryptMessage 对授权方的消息事件进行解密，并解析为具体类型（参考 ParseMessage）
+func (op *Oplatform) DecryptMessage(encrypt string) (interface{}, error) {
+	b, err := event.Decrypt(op.appid, op.aeskey, encrypt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseMessage(b)
+}
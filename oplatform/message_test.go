@@ -0,0 +1,138 @@
+package oplatform
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/event"
+)
+
+func TestParseMessage(t *testing.T) {
+	msg, err := ParseMessage([]byte(`<xml><ToUserName><![CDATA[gh_3ad31c0ba9b5]]></ToUserName><FromUserName><![CDATA[OPENID]]></FromUserName><CreateTime>1606902602</CreateTime><MsgType><![CDATA[text]]></MsgType><Content><![CDATA[ILoveGochat]]></Content><MsgId>10086</MsgId></xml>`))
+
+	assert.Nil(t, err)
+
+	text, ok := msg.(*TextMessage)
+
+	assert.True(t, ok)
+	assert.Equal(t, "gh_3ad31c0ba9b5", text.ToUserName)
+	assert.Equal(t, "OPENID", text.FromUserName)
+	assert.Equal(t, int64(1606902602), text.CreateTime)
+	assert.Equal(t, event.MsgText, text.MsgType)
+	assert.Equal(t, int64(10086), text.MsgID)
+	assert.Equal(t, "ILoveGochat", text.Content)
+
+	msg, err = ParseMessage([]byte(`<xml><ToUserName><![CDATA[gh_3ad31c0ba9b5]]></ToUserName><FromUserName><![CDATA[OPENID]]></FromUserName><CreateTime>1606902602</CreateTime><MsgType><![CDATA[location]]></MsgType><Location_X>23.134521</Location_X><Location_Y>113.358803</Location_Y><Scale>20</Scale><Label><![CDATA[位置信息]]></Label><MsgId>10086</MsgId></xml>`))
+
+	assert.Nil(t, err)
+
+	location, ok := msg.(*LocationMessage)
+
+	assert.True(t, ok)
+	assert.Equal(t, 23.134521, location.LocationX)
+	assert.Equal(t, 113.358803, location.LocationY)
+	assert.Equal(t, 20, location.Scale)
+	assert.Equal(t, "位置信息", location.Label)
+}
+
+func TestParseEventMessage(t *testing.T) {
+	msg, err := ParseMessage([]byte(`<xml><ToUserName><![CDATA[gh_3ad31c0ba9b5]]></ToUserName><FromUserName><![CDATA[OPENID]]></FromUserName><CreateTime>1606902602</CreateTime><MsgType><![CDATA[event]]></MsgType><Event><![CDATA[SCAN]]></Event><EventKey><![CDATA[SCENE_VALUE]]></EventKey><Ticket><![CDATA[TICKET]]></Ticket></xml>`))
+
+	assert.Nil(t, err)
+
+	e, ok := msg.(*EventMessage)
+
+	assert.True(t, ok)
+	assert.Equal(t, event.EventScan, e.EventType())
+	assert.Equal(t, "SCENE_VALUE", e.EventKey)
+	assert.Equal(t, "TICKET", e.Ticket)
+}
+
+func TestParseWeappAuditEvent(t *testing.T) {
+	msg, err := ParseMessage([]byte(`<xml><ToUserName><![CDATA[gh_fb9688c2a4b2]]></ToUserName><FromUserName><![CDATA[od1P50M-fNQI5Gcq-trm4a7apsU8]]></FromUserName><CreateTime>1488856591</CreateTime><MsgType><![CDATA[event]]></MsgType><Event><![CDATA[weapp_audit_fail]]></Event><Reason><![CDATA[1:账号信息不符合规范:<br>(1):包含色情因素<br>2:服务类目"金融业-保险_"与你提交代码审核时设置的功能页面内容不一致:<br>(1):功能页面设置的部分标签不属于所选的服务类目范围。<br>]]></Reason><FailTime>1488856591</FailTime><ScreenShot>xxx|yyy|zzz</ScreenShot></xml>`))
+
+	assert.Nil(t, err)
+
+	e, ok := msg.(*WeappAuditEvent)
+
+	assert.True(t, ok)
+	assert.Equal(t, EventWeappAuditFail, e.EventType())
+	assert.Equal(t, "gh_fb9688c2a4b2", e.ToUserName)
+	assert.Equal(t, int64(1488856591), e.FailTime)
+	assert.Equal(t, "xxx|yyy|zzz", e.ScreenShot)
+	assert.Contains(t, e.Reason, "包含色情因素")
+}
+
+func TestParseNicknameAuditEvent(t *testing.T) {
+	msg, err := ParseMessage([]byte(`<xml><ToUserName><![CDATA[gh_fxxxxxxxa4b2]]></ToUserName><FromUserName><![CDATA[odxxxxM-xxxxxxxx-trm4a7apsU8]]></FromUserName><CreateTime>1488800000</CreateTime><MsgType><![CDATA[event]]></MsgType><Event><![CDATA[wxa_nickname_audit]]></Event><ret>2</ret><nickname><![CDATA[昵称]]></nickname><reason><![CDATA[驳回原因]]></reason></xml>`))
+
+	assert.Nil(t, err)
+
+	e, ok := msg.(*NicknameAuditEvent)
+
+	assert.True(t, ok)
+	assert.Equal(t, EventWxaNicknameAudit, e.EventType())
+	assert.Equal(t, NicknameRejected, e.Ret)
+	assert.Equal(t, "昵称", e.NickName)
+	assert.Equal(t, "驳回原因", e.Reason)
+}
+
+func TestParseCategoryAuditEvent(t *testing.T) {
+	msg, err := ParseMessage([]byte(`<xml><ToUserName><![CDATA[gh_fxxxxxxxa4b2]]></ToUserName><FromUserName><![CDATA[odxxxxM-xxxxxxxx-trm4a7apsU8]]></FromUserName><CreateTime>1488800000</CreateTime><MsgType><![CDATA[event]]></MsgType><Event><![CDATA[wxa_category_audit]]></Event><ret>3</ret><first>1</first><second>2</second><reason><![CDATA[]]></reason></xml>`))
+
+	assert.Nil(t, err)
+
+	e, ok := msg.(*CategoryAuditEvent)
+
+	assert.True(t, ok)
+	assert.Equal(t, EventWxaCategoryAudit, e.EventType())
+	assert.Equal(t, CategoryApproved, e.Ret)
+	assert.Equal(t, int64(1), e.First)
+	assert.Equal(t, int64(2), e.Second)
+}
+
+func TestParseComponentEventMessage(t *testing.T) {
+	msg, err := ParseMessage([]byte(`<xml><AppId>COMPONENT_APPID</AppId><CreateTime>1413192605</CreateTime><InfoType>component_verify_ticket</InfoType><ComponentVerifyTicket>TICKET</ComponentVerifyTicket></xml>`))
+
+	assert.Nil(t, err)
+
+	e, ok := msg.(*ComponentEvent)
+
+	assert.True(t, ok)
+	assert.Equal(t, InfoComponentVerifyTicket, e.InfoType)
+	assert.Equal(t, "TICKET", e.ComponentVerifyTicket)
+}
+
+func TestParseUnknownMessage(t *testing.T) {
+	b := []byte(`<xml><ToUserName><![CDATA[gh_3ad31c0ba9b5]]></ToUserName><MsgType><![CDATA[unknown]]></MsgType></xml>`)
+
+	msg, err := ParseMessage(b)
+
+	assert.Nil(t, err)
+
+	unknown, ok := msg.(*UnknownMessage)
+
+	assert.True(t, ok)
+	assert.Equal(t, event.MsgType("unknown"), unknown.MsgType)
+	assert.Equal(t, b, unknown.Raw)
+}
+
+func TestDecryptMessage(t *testing.T) {
+	op := New("COMPONENT_APPID", "COMPONENT_APPSECRET", WithServerConfig(testEventToken, testEventAESKey))
+
+	cipher, err := event.Encrypt("COMPONENT_APPID", testEventAESKey, "1234567890123456", []byte(`<xml><ToUserName><![CDATA[gh_3ad31c0ba9b5]]></ToUserName><FromUserName><![CDATA[OPENID]]></FromUserName><CreateTime>1606902602</CreateTime><MsgType><![CDATA[image]]></MsgType><PicUrl><![CDATA[PIC_URL]]></PicUrl><MediaId><![CDATA[MEDIA_ID]]></MediaId><MsgId>10086</MsgId></xml>`))
+
+	assert.Nil(t, err)
+
+	msg, err := op.DecryptMessage(base64.StdEncoding.EncodeToString(cipher))
+
+	assert.Nil(t, err)
+
+	image, ok := msg.(*ImageMessage)
+
+	assert.True(t, ok)
+	assert.Equal(t, "PIC_URL", image.PicURL)
+	assert.Equal(t, "MEDIA_ID", image.MediaID)
+}
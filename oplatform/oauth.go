@@ -0,0 +1,78 @@
+package oplatform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/shenghui0779/gochat/offia"
+	"github.com/shenghui0779/gochat/urls"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// OAuth2URL 代公众号发起网页授权URL（请使用 URLEncode 对 redirectURL 进行处理）
+// [参考](https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/Before_Develop/Official_Accounts/official_account_website_authorization.html)
+func (op *Oplatform) OAuth2URL(authorizerAppID string, scope offia.AuthScope, redirectURL, state string) string {
+	return fmt.Sprintf("%s?appid=%s&redirect_uri=%s&response_type=code&scope=%s&state=%s&component_appid=%s#wechat_redirect", urls.Oauth2Authorize, authorizerAppID, redirectURL, scope, state, op.appid)
+}
+
+// Code2OAuthToken 代公众号通过 code 换取网页授权 access_token
+func (op *Oplatform) Code2OAuthToken(ctx context.Context, authorizerAppID, code string, options ...wx.HTTPOption) (*offia.OAuthToken, error) {
+	accessToken, err := op.ComponentToken(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return op.oauthToken(ctx, fmt.Sprintf("%s?appid=%s&code=%s&grant_type=authorization_code&component_appid=%s&component_access_token=%s", urls.OplatformSnsComponentAccessToken, authorizerAppID, code, op.appid, url.QueryEscape(accessToken)), options...)
+}
+
+// RefreshOAuthToken 代公众号刷新网页授权 access_token
+func (op *Oplatform) RefreshOAuthToken(ctx context.Context, authorizerAppID, refreshToken string, options ...wx.HTTPOption) (*offia.OAuthToken, error) {
+	accessToken, err := op.ComponentToken(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return op.oauthToken(ctx, fmt.Sprintf("%s?appid=%s&grant_type=refresh_token&component_appid=%s&component_access_token=%s&refresh_token=%s", urls.OplatformSnsComponentRefreshToken, authorizerAppID, op.appid, url.QueryEscape(accessToken), refreshToken), options...)
+}
+
+func (op *Oplatform) oauthToken(ctx context.Context, reqURL string, options ...wx.HTTPOption) (*offia.OAuthToken, error) {
+	options = wx.PrependHTTPHeaders(options, op.headers)
+
+	resp, err := op.client.Do(ctx, http.MethodGet, op.endpoint(reqURL), nil, options...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	r := gjson.ParseBytes(resp)
+
+	if code := r.Get("errcode").Int(); code != 0 {
+		return nil, wx.NewAPIError(code, r.Get("errmsg").String())
+	}
+
+	token := new(offia.OAuthToken)
+
+	if err = json.Unmarshal(resp, token); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// ApiTicket 获取授权公众号的 api ticket（如：jsapi_ticket），签名请使用 c.Offia().JSApiSign
+func (c *OffiaClient) ApiTicket(ctx context.Context, ticketType offia.TicketType, options ...wx.HTTPOption) (*offia.ResultApiTicket, error) {
+	result := new(offia.ResultApiTicket)
+
+	if err := c.Do(ctx, offia.GetApiTicket(ticketType, result), options...); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
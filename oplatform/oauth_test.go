@@ -0,0 +1,86 @@
+package oplatform
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/mock"
+	"github.com/shenghui0779/gochat/offia"
+)
+
+func TestOAuth2URL(t *testing.T) {
+	op := New("COMPONENT_APPID", "COMPONENT_APPSECRET")
+
+	assert.Equal(t, "https://open.weixin.qq.com/connect/oauth2/authorize?appid=AUTHORIZER_APPID&redirect_uri=RedirectURL&response_type=code&scope=snsapi_base&state=STATE&component_appid=COMPONENT_APPID#wechat_redirect", op.OAuth2URL("AUTHORIZER_APPID", offia.ScopeSnsapiBase, "RedirectURL", "STATE"))
+}
+
+func TestCode2OAuthToken(t *testing.T) {
+	resp := []byte(`{"access_token":"ACCESS_TOKEN","expires_in":7200,"refresh_token":"REFRESH_TOKEN","openid":"OPENID","scope":"snsapi_base"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/component/api_component_token", gomock.Any()).Return([]byte(`{"component_access_token":"COMPONENT_ACCESS_TOKEN","expires_in":7200}`), nil)
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.weixin.qq.com/sns/oauth2/component/access_token?appid=AUTHORIZER_APPID&code=CODE&grant_type=authorization_code&component_appid=COMPONENT_APPID&component_access_token=COMPONENT_ACCESS_TOKEN", nil).Return(resp, nil)
+
+	op := New("COMPONENT_APPID", "COMPONENT_APPSECRET", WithMockClient(client), WithVerifyTicket("TICKET"))
+
+	token, err := op.Code2OAuthToken(context.TODO(), "AUTHORIZER_APPID", "CODE")
+
+	assert.Nil(t, err)
+	assert.Equal(t, &offia.OAuthToken{
+		AccessToken:  "ACCESS_TOKEN",
+		RefreshToken: "REFRESH_TOKEN",
+		ExpiresIn:    7200,
+		OpenID:       "OPENID",
+		Scope:        "snsapi_base",
+	}, token)
+}
+
+func TestRefreshOAuthToken(t *testing.T) {
+	resp := []byte(`{"access_token":"ACCESS_TOKEN","expires_in":7200,"refresh_token":"REFRESH_TOKEN","openid":"OPENID","scope":"snsapi_base"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/component/api_component_token", gomock.Any()).Return([]byte(`{"component_access_token":"COMPONENT_ACCESS_TOKEN","expires_in":7200}`), nil)
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.weixin.qq.com/sns/oauth2/component/refresh_token?appid=AUTHORIZER_APPID&grant_type=refresh_token&component_appid=COMPONENT_APPID&component_access_token=COMPONENT_ACCESS_TOKEN&refresh_token=REFRESH_TOKEN", nil).Return(resp, nil)
+
+	op := New("COMPONENT_APPID", "COMPONENT_APPSECRET", WithMockClient(client), WithVerifyTicket("TICKET"))
+
+	token, err := op.RefreshOAuthToken(context.TODO(), "AUTHORIZER_APPID", "REFRESH_TOKEN")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "ACCESS_TOKEN", token.AccessToken)
+}
+
+func TestApiTicket(t *testing.T) {
+	resp := []byte(`{"errcode":0,"errmsg":"ok","ticket":"bxLdikRXVbTPdHSM05e5u5sUoXNKd8-41ZO3MhKoyN5OfkWITDGgnr2fwJ0m9E8NYzWKVZvdVtaUgWvsdshFKA","expires_in":7200}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.weixin.qq.com/cgi-bin/ticket/getticket?access_token=AUTHORIZER_ACCESS_TOKEN&type=jsapi", nil).Return(resp, nil)
+
+	op := New("COMPONENT_APPID", "COMPONENT_APPSECRET", WithMockClient(client))
+
+	op.authorizerTokenManager("AUTHORIZER_APPID").Set("AUTHORIZER_ACCESS_TOKEN", 7200)
+
+	ticket, err := op.OffiaClient("AUTHORIZER_APPID").ApiTicket(context.TODO(), offia.JSAPITicket)
+
+	assert.Nil(t, err)
+	assert.Equal(t, &offia.ResultApiTicket{
+		Ticket:    "bxLdikRXVbTPdHSM05e5u5sUoXNKd8-41ZO3MhKoyN5OfkWITDGgnr2fwJ0m9E8NYzWKVZvdVtaUgWvsdshFKA",
+		ExpiresIn: 7200,
+	}, ticket)
+}
@@ -0,0 +1,69 @@
+package oplatform
+
+import (
+	"encoding/json"
+
+	"github.com/shenghui0779/gochat/urls"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// ResultOpenAccount 开放平台帐号
+type ResultOpenAccount struct {
+	OpenAppID string `json:"open_appid"`
+}
+
+// CreateOpenAccount 创建开放平台帐号并绑定公众号/小程序（appid 为授权方 appid）
+// [参考](https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/account/create.html)
+func CreateOpenAccount(appid string, result *ResultOpenAccount) wx.Action {
+	return wx.NewPostAction(urls.OplatformOpenCreate,
+		wx.WithBody(func() ([]byte, error) {
+			return json.Marshal(map[string]string{
+				"appid": appid,
+			})
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// BindOpenAccount 将公众号/小程序绑定到开放平台帐号下
+// [参考](https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/account/bind.html)
+func BindOpenAccount(appid, openAppID string) wx.Action {
+	return wx.NewPostAction(urls.OplatformOpenBind,
+		wx.WithBody(func() ([]byte, error) {
+			return json.Marshal(map[string]string{
+				"appid":      appid,
+				"open_appid": openAppID,
+			})
+		}),
+	)
+}
+
+// UnbindOpenAccount 将公众号/小程序从开放平台帐号下解绑
+// [参考](https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/account/unbind.html)
+func UnbindOpenAccount(appid, openAppID string) wx.Action {
+	return wx.NewPostAction(urls.OplatformOpenUnbind,
+		wx.WithBody(func() ([]byte, error) {
+			return json.Marshal(map[string]string{
+				"appid":      appid,
+				"open_appid": openAppID,
+			})
+		}),
+	)
+}
+
+// GetOpenAccount 获取公众号/小程序所绑定的开放平台帐号
+// [参考](https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/account/get.html)
+func GetOpenAccount(appid string, result *ResultOpenAccount) wx.Action {
+	return wx.NewPostAction(urls.OplatformOpenGet,
+		wx.WithBody(func() ([]byte, error) {
+			return json.Marshal(map[string]string{
+				"appid": appid,
+			})
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
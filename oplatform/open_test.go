@@ -0,0 +1,91 @@
+package oplatform
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/mock"
+	"github.com/shenghui0779/gochat/offia"
+)
+
+func TestCreateOpenAccount(t *testing.T) {
+	body := []byte(`{"appid":"AUTHORIZER_APPID"}`)
+	resp := []byte(`{"errcode":0,"errmsg":"ok","open_appid":"OPEN_APPID"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/open/create?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	oa := offia.New("AUTHORIZER_APPID", "", offia.WithMockClient(client))
+
+	result := new(ResultOpenAccount)
+
+	err := oa.Do(context.TODO(), "ACCESS_TOKEN", CreateOpenAccount("AUTHORIZER_APPID", result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultOpenAccount{OpenAppID: "OPEN_APPID"}, result)
+}
+
+func TestBindOpenAccount(t *testing.T) {
+	body := []byte(`{"appid":"AUTHORIZER_APPID","open_appid":"OPEN_APPID"}`)
+	resp := []byte(`{"errcode":0,"errmsg":"ok"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/open/bind?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	oa := offia.New("AUTHORIZER_APPID", "", offia.WithMockClient(client))
+
+	err := oa.Do(context.TODO(), "ACCESS_TOKEN", BindOpenAccount("AUTHORIZER_APPID", "OPEN_APPID"))
+
+	assert.Nil(t, err)
+}
+
+func TestUnbindOpenAccount(t *testing.T) {
+	body := []byte(`{"appid":"AUTHORIZER_APPID","open_appid":"OPEN_APPID"}`)
+	resp := []byte(`{"errcode":0,"errmsg":"ok"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/open/unbind?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	oa := offia.New("AUTHORIZER_APPID", "", offia.WithMockClient(client))
+
+	err := oa.Do(context.TODO(), "ACCESS_TOKEN", UnbindOpenAccount("AUTHORIZER_APPID", "OPEN_APPID"))
+
+	assert.Nil(t, err)
+}
+
+func TestGetOpenAccount(t *testing.T) {
+	body := []byte(`{"appid":"AUTHORIZER_APPID"}`)
+	resp := []byte(`{"errcode":0,"errmsg":"ok","open_appid":"OPEN_APPID"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/open/get?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	oa := offia.New("AUTHORIZER_APPID", "", offia.WithMockClient(client))
+
+	result := new(ResultOpenAccount)
+
+	err := oa.Do(context.TODO(), "ACCESS_TOKEN", GetOpenAccount("AUTHORIZER_APPID", result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultOpenAccount{OpenAppID: "OPEN_APPID"}, result)
+}
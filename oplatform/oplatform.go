@@ -0,0 +1,328 @@
+package oplatform
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/shenghui0779/gochat/urls"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// Oplatform 微信开放平台（第三方平台）
+type Oplatform struct {
+	appid     string
+	appsecret string
+	token     string
+	aeskey    string
+	ticket    string
+	tickets   TicketStore
+	mutex     sync.RWMutex
+	tokenmgr  *wx.TokenManager
+	store     AuthorizerStore
+	authmgrs  map[string]*wx.TokenManager
+	offias    map[string]*OffiaClient
+	minips    map[string]*MinipClient
+	tokenopts []wx.TokenOption
+	authopts  func(authorizerAppID string) []wx.TokenOption
+	nonce     func() string
+	client    wx.HTTPClient
+	baseURL   string
+	dials     []wx.DialOption
+	headers   map[string]string
+	lenient   bool
+}
+
+// AppID returns component appid
+func (op *Oplatform) AppID() string {
+	return op.appid
+}
+
+// AppSecret returns component app secret
+func (op *Oplatform) AppSecret() string {
+	return op.appsecret
+}
+
+// SetVerifyTicket 设置 component_verify_ticket（微信服务器每隔10分钟推送一次）
+func (op *Oplatform) SetVerifyTicket(ticket string) {
+	op.mutex.Lock()
+	defer op.mutex.Unlock()
+
+	op.ticket = ticket
+}
+
+// VerifyTicket returns component_verify_ticket
+func (op *Oplatform) VerifyTicket() string {
+	op.mutex.RLock()
+	defer op.mutex.RUnlock()
+
+	return op.ticket
+}
+
+// ComponentAccessToken 获取令牌（component_access_token）
+// [参考](https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/ThirdParty/token/component_access_token.html)
+func (op *Oplatform) ComponentAccessToken(ctx context.Context, verifyTicket string, options ...wx.HTTPOption) (*ComponentAccessToken, error) {
+	options = wx.PrependHTTPHeaders(options, op.headers)
+
+	body, err := json.Marshal(map[string]string{
+		"component_appid":         op.appid,
+		"component_appsecret":     op.appsecret,
+		"component_verify_ticket": verifyTicket,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := op.client.Do(ctx, http.MethodPost, op.endpoint(urls.OplatformComponentToken), body, options...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	r := gjson.ParseBytes(resp)
+
+	if code := r.Get("errcode").Int(); code != 0 {
+		return nil, wx.NewAPIError(code, r.Get("errmsg").String())
+	}
+
+	token := new(ComponentAccessToken)
+
+	if err = json.Unmarshal(resp, token); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// ComponentToken 获取令牌（缓存，过期前使用最新的 component_verify_ticket 自动刷新）
+func (op *Oplatform) ComponentToken(ctx context.Context) (string, error) {
+	return op.tokenmgr.Token(ctx)
+}
+
+// ComponentTokenManager returns the component_access_token manager
+func (op *Oplatform) ComponentTokenManager() *wx.TokenManager {
+	return op.tokenmgr
+}
+
+// endpoint returns the request url with base url applied
+func (op *Oplatform) endpoint(reqURL string) string {
+	return wx.JoinURL(op.baseURL, reqURL)
+}
+
+// Do exec action
+func (op *Oplatform) Do(ctx context.Context, componentAccessToken string, action wx.Action, options ...wx.HTTPOption) error {
+	options = wx.PrependHTTPHeaders(options, op.headers, wx.ActionHeaders(action))
+
+	body, err := action.Body()
+
+	if err != nil {
+		return err
+	}
+
+	reqURL := op.endpoint(action.URL())
+
+	if strings.Contains(reqURL, "?") {
+		reqURL = fmt.Sprintf("%s&component_access_token=%s", reqURL, url.QueryEscape(componentAccessToken))
+	} else {
+		reqURL = fmt.Sprintf("%s?component_access_token=%s", reqURL, url.QueryEscape(componentAccessToken))
+	}
+
+	if reqURL, options, err = wx.SignAction(action, reqURL, body, options); err != nil {
+		return err
+	}
+
+	resp, err := op.client.Do(ctx, action.Method(), reqURL, body, options...)
+
+	if err != nil {
+		return err
+	}
+
+	r := gjson.ParseBytes(resp)
+
+	if code := r.Get("errcode").Int(); code != 0 {
+		return wx.NewAPIError(code, r.Get("errmsg").String())
+	}
+
+	if op.lenient {
+		return wx.DecodeLenient(resp, action.Decode)
+	}
+
+	return action.Decode(resp)
+}
+
+// AuthURL 生成PC端授权链接（请使用 URLEncode 对 redirectURL 进行处理）
+// bizAppID 为指定授权唯一的小程序或公众号，可为空
+// [参考](https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/operation/thirdparty/Before_Develop/Authorization_Process_Technical_Description.html)
+func (op *Oplatform) AuthURL(preAuthCode, redirectURL string, authType AuthType, bizAppID string) string {
+	authURL := fmt.Sprintf("%s?component_appid=%s&pre_auth_code=%s&redirect_uri=%s&auth_type=%d", urls.OplatformComponentLoginPage, op.appid, preAuthCode, redirectURL, authType)
+
+	if len(bizAppID) != 0 {
+		authURL = fmt.Sprintf("%s&biz_appid=%s", authURL, bizAppID)
+	}
+
+	return authURL
+}
+
+// MobileAuthURL 生成移动端授权链接（需在微信客户端内打开，请使用 URLEncode 对 redirectURL 进行处理）
+// bizAppID 为指定授权唯一的小程序或公众号，可为空
+func (op *Oplatform) MobileAuthURL(preAuthCode, redirectURL string, authType AuthType, bizAppID string) string {
+	authURL := fmt.Sprintf("%s?action=bindcomponent&no_scan=1&component_appid=%s&pre_auth_code=%s&redirect_uri=%s&auth_type=%d", urls.OplatformComponentBindComponent, op.appid, preAuthCode, redirectURL, authType)
+
+	if len(bizAppID) != 0 {
+		authURL = fmt.Sprintf("%s&biz_appid=%s", authURL, bizAppID)
+	}
+
+	return authURL + "#wechat_redirect"
+}
+
+// Option 开放平台配置项
+type Option func(op *Oplatform)
+
+// WithServerConfig 设置消息与事件接收配置
+// [参考](https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/Before_Develop/message_push.html)
+func WithServerConfig(token, aeskey string) Option {
+	return func(op *Oplatform) {
+		op.token = token
+		op.aeskey = aeskey
+	}
+}
+
+// WithVerifyTicket 设置初始 component_verify_ticket（如：从缓存中加载）
+func WithVerifyTicket(ticket string) Option {
+	return func(op *Oplatform) {
+		op.ticket = ticket
+	}
+}
+
+// WithTicketStore 设置 component_verify_ticket 存储（多实例部署或重启后无需等待微信重新推送）
+func WithTicketStore(store TicketStore) Option {
+	return func(op *Oplatform) {
+		op.tickets = store
+	}
+}
+
+// WithAuthorizerStore 设置授权方刷新令牌存储（默认内存存储）
+func WithAuthorizerStore(store AuthorizerStore) Option {
+	return func(op *Oplatform) {
+		op.store = store
+	}
+}
+
+// WithComponentTokenOptions 设置 component_access_token 管理的配置项（如：wx.WithTokenStore）
+func WithComponentTokenOptions(options ...wx.TokenOption) Option {
+	return func(op *Oplatform) {
+		op.tokenopts = options
+	}
+}
+
+// WithAuthorizerTokenOptions 设置授权方 authorizer_access_token 管理的配置项，按授权方返回
+// （如：wx.WithTokenStore 需为每个授权方指定不同的 key）
+func WithAuthorizerTokenOptions(f func(authorizerAppID string) []wx.TokenOption) Option {
+	return func(op *Oplatform) {
+		op.authopts = f
+	}
+}
+
+// WithNonce 设置 Nonce（加密随机串）
+func WithNonce(f func() string) Option {
+	return func(op *Oplatform) {
+		op.nonce = f
+	}
+}
+
+// WithClient 设置 HTTP Client
+func WithClient(c *http.Client) Option {
+	return func(op *Oplatform) {
+		op.client = wx.NewHTTPClient(c)
+	}
+}
+
+// WithHeader 设置请求 header（如：User-Agent、链路追踪 header），作用于所有请求
+func WithHeader(key, value string) Option {
+	return func(op *Oplatform) {
+		op.headers[key] = value
+	}
+}
+
+// WithDialOptions 设置 HTTP Client 的连接配置（如：强制 IPv4、自定义 DNS 解析），
+// 在所有配置项之后作用于默认或 WithClient 设置的 Client，与配置顺序无关；Client 的 Transport 不是 *http.Transport 时不生效
+func WithDialOptions(options ...wx.DialOption) Option {
+	return func(op *Oplatform) {
+		op.dials = options
+	}
+}
+
+// WithLenientDecode 宽松解析返回结果，兼容数字与数字字符串等类型不一致的字段
+func WithLenientDecode() Option {
+	return func(op *Oplatform) {
+		op.lenient = true
+	}
+}
+
+// WithBaseURL 设置接口域名（默认：https://api.weixin.qq.com），可用于切换备用域名（如：https://api2.weixin.qq.com），代授权方调用的公众号、小程序客户端同样生效
+func WithBaseURL(base string) Option {
+	return func(op *Oplatform) {
+		op.baseURL = base
+	}
+}
+
+// WithMockClient 设置 Mock Client
+func WithMockClient(c wx.HTTPClient) Option {
+	return func(op *Oplatform) {
+		op.client = c
+	}
+}
+
+// New returns new Oplatform
+func New(appid, appsecret string, options ...Option) *Oplatform {
+	op := &Oplatform{
+		appid:     appid,
+		appsecret: appsecret,
+		nonce: func() string {
+			return wx.Nonce(16)
+		},
+		store:    NewMemAuthorizerStore(),
+		authmgrs: make(map[string]*wx.TokenManager),
+		offias:   make(map[string]*OffiaClient),
+		minips:   make(map[string]*MinipClient),
+		client:   wx.NewDefaultClient(),
+		baseURL:  wx.BaseURLAPI,
+		headers:  make(map[string]string),
+	}
+
+	for _, f := range options {
+		f(op)
+	}
+
+	op.client = wx.ApplyDialOptions(op.client, op.dials...)
+
+	op.tokenmgr = wx.NewTokenManager(func(ctx context.Context) (string, int64, error) {
+		ticket, err := op.loadVerifyTicket(ctx)
+
+		if err != nil {
+			return "", 0, err
+		}
+
+		if len(ticket) == 0 {
+			return "", 0, errors.New("component_verify_ticket is empty (forgotten set?)")
+		}
+
+		token, err := op.ComponentAccessToken(ctx, ticket)
+
+		if err != nil {
+			return "", 0, err
+		}
+
+		return token.Token, token.ExpiresIn, nil
+	}, op.tokenopts...)
+
+	return op
+}
@@ -0,0 +1,75 @@
+package oplatform
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/mock"
+)
+
+func TestComponentAccessToken(t *testing.T) {
+	body := []byte(`{"component_appid":"COMPONENT_APPID","component_appsecret":"COMPONENT_APPSECRET","component_verify_ticket":"TICKET"}`)
+	resp := []byte(`{"component_access_token":"61W3mEpU66027wgNZ_MhGHNQDHnFATkDa9-2llqrMBjUwxRSNPbVsMmyD-yq8wZETSoE5NQgecigDrSHkPtIYA","expires_in":7200}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/component/api_component_token", body).Return(resp, nil)
+
+	op := New("COMPONENT_APPID", "COMPONENT_APPSECRET", WithMockClient(client))
+
+	token, err := op.ComponentAccessToken(context.TODO(), "TICKET")
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ComponentAccessToken{
+		Token:     "61W3mEpU66027wgNZ_MhGHNQDHnFATkDa9-2llqrMBjUwxRSNPbVsMmyD-yq8wZETSoE5NQgecigDrSHkPtIYA",
+		ExpiresIn: 7200,
+	}, token)
+}
+
+func TestComponentToken(t *testing.T) {
+	body := []byte(`{"component_appid":"COMPONENT_APPID","component_appsecret":"COMPONENT_APPSECRET","component_verify_ticket":"TICKET"}`)
+	resp := []byte(`{"component_access_token":"COMPONENT_ACCESS_TOKEN","expires_in":7200}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	// 缓存有效期内仅请求一次
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/component/api_component_token", body).Return(resp, nil).Times(1)
+
+	op := New("COMPONENT_APPID", "COMPONENT_APPSECRET", WithMockClient(client))
+
+	// 未设置 component_verify_ticket
+	_, err := op.ComponentToken(context.TODO())
+	assert.NotNil(t, err)
+
+	op.SetVerifyTicket("TICKET")
+
+	for i := 0; i < 2; i++ {
+		token, err := op.ComponentToken(context.TODO())
+
+		assert.Nil(t, err)
+		assert.Equal(t, "COMPONENT_ACCESS_TOKEN", token)
+	}
+}
+
+func TestAuthURL(t *testing.T) {
+	op := New("COMPONENT_APPID", "COMPONENT_APPSECRET")
+
+	assert.Equal(t, "https://mp.weixin.qq.com/cgi-bin/componentloginpage?component_appid=COMPONENT_APPID&pre_auth_code=PRE_AUTH_CODE&redirect_uri=REDIRECT_URI&auth_type=3", op.AuthURL("PRE_AUTH_CODE", "REDIRECT_URI", AuthBoth, ""))
+	assert.Equal(t, "https://mp.weixin.qq.com/cgi-bin/componentloginpage?component_appid=COMPONENT_APPID&pre_auth_code=PRE_AUTH_CODE&redirect_uri=REDIRECT_URI&auth_type=2&biz_appid=BIZ_APPID", op.AuthURL("PRE_AUTH_CODE", "REDIRECT_URI", AuthMinip, "BIZ_APPID"))
+}
+
+func TestMobileAuthURL(t *testing.T) {
+	op := New("COMPONENT_APPID", "COMPONENT_APPSECRET")
+
+	assert.Equal(t, "https://open.weixin.qq.com/wxaopen/safe/bindcomponent?action=bindcomponent&no_scan=1&component_appid=COMPONENT_APPID&pre_auth_code=PRE_AUTH_CODE&redirect_uri=REDIRECT_URI&auth_type=1#wechat_redirect", op.MobileAuthURL("PRE_AUTH_CODE", "REDIRECT_URI", AuthOffia, ""))
+}
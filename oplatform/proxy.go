@@ -0,0 +1,153 @@
+package oplatform
+
+import (
+	"context"
+
+	"github.com/shenghui0779/gochat/minip"
+	"github.com/shenghui0779/gochat/offia"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// OffiaClient 代授权公众号调用接口（使用第三方平台管理的 authorizer_access_token）
+type OffiaClient struct {
+	op    *Oplatform
+	appid string
+	oa    *offia.Offia
+}
+
+// AppID returns authorizer appid
+func (c *OffiaClient) AppID() string {
+	return c.appid
+}
+
+// Offia returns the underlying offia client
+func (c *OffiaClient) Offia() *offia.Offia {
+	return c.oa
+}
+
+// AccessToken returns authorizer_access_token
+func (c *OffiaClient) AccessToken(ctx context.Context) (string, error) {
+	return c.op.AuthorizerToken(ctx, c.appid)
+}
+
+// Do exec action
+func (c *OffiaClient) Do(ctx context.Context, action wx.Action, options ...wx.HTTPOption) error {
+	accessToken, err := c.AccessToken(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	return c.oa.Do(ctx, accessToken, action, options...)
+}
+
+// OffiaClient returns a client for the authorized offia (按授权方缓存，取消授权时删除)
+func (op *Oplatform) OffiaClient(authorizerAppID string) *OffiaClient {
+	op.mutex.Lock()
+	defer op.mutex.Unlock()
+
+	if c, ok := op.offias[authorizerAppID]; ok {
+		return c
+	}
+
+	c := &OffiaClient{
+		op:    op,
+		appid: authorizerAppID,
+		oa:    offia.New(authorizerAppID, "", op.offiaOptions()...),
+	}
+
+	op.offias[authorizerAppID] = c
+
+	return c
+}
+
+// MinipClient 代授权小程序调用接口（使用第三方平台管理的 authorizer_access_token）
+type MinipClient struct {
+	op    *Oplatform
+	appid string
+	mp    *minip.Minip
+}
+
+// AppID returns authorizer appid
+func (c *MinipClient) AppID() string {
+	return c.appid
+}
+
+// Minip returns the underlying minip client
+func (c *MinipClient) Minip() *minip.Minip {
+	return c.mp
+}
+
+// AccessToken returns authorizer_access_token
+func (c *MinipClient) AccessToken(ctx context.Context) (string, error) {
+	return c.op.AuthorizerToken(ctx, c.appid)
+}
+
+// Do exec action
+func (c *MinipClient) Do(ctx context.Context, action wx.Action, options ...wx.HTTPOption) error {
+	accessToken, err := c.AccessToken(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	return c.mp.Do(ctx, accessToken, action, options...)
+}
+
+// MinipClient returns a client for the authorized minip (按授权方缓存，取消授权时删除)
+func (op *Oplatform) MinipClient(authorizerAppID string) *MinipClient {
+	op.mutex.Lock()
+	defer op.mutex.Unlock()
+
+	if c, ok := op.minips[authorizerAppID]; ok {
+		return c
+	}
+
+	c := &MinipClient{
+		op:    op,
+		appid: authorizerAppID,
+		mp:    minip.New(authorizerAppID, "", op.minipOptions()...),
+	}
+
+	op.minips[authorizerAppID] = c
+
+	return c
+}
+
+// offiaOptions 代调用公众号沿用第三方平台的配置（HTTP Client 已应用 WithDialOptions，共享连接池，无需重复设置）
+func (op *Oplatform) offiaOptions() []offia.Option {
+	options := []offia.Option{
+		offia.WithNonce(op.nonce),
+		offia.WithHTTPClient(op.client),
+		offia.WithBaseURL(op.baseURL),
+	}
+
+	for k, v := range op.headers {
+		options = append(options, offia.WithHeader(k, v))
+	}
+
+	if op.lenient {
+		options = append(options, offia.WithLenientDecode())
+	}
+
+	return options
+}
+
+// minipOptions 代调用小程序沿用第三方平台的配置（HTTP Client 已应用 WithDialOptions，共享连接池，无需重复设置）
+func (op *Oplatform) minipOptions() []minip.Option {
+	options := []minip.Option{
+		minip.WithNonce(op.nonce),
+		minip.WithHTTPClient(op.client),
+		minip.WithBaseURL(op.baseURL),
+	}
+
+	for k, v := range op.headers {
+		options = append(options, minip.WithHeader(k, v))
+	}
+
+	if op.lenient {
+		options = append(options, minip.WithLenientDecode())
+	}
+
+	return options
+}
@@ -0,0 +1,111 @@
+package oplatform
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/minip"
+	"github.com/shenghui0779/gochat/mock"
+	"github.com/shenghui0779/gochat/offia"
+)
+
+func TestOffiaClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.weixin.qq.com/cgi-bin/menu/delete?access_token=AUTHORIZER_ACCESS_TOKEN", nil).Return([]byte(`{"errcode":0,"errmsg":"ok"}`), nil)
+
+	op := New("COMPONENT_APPID", "COMPONENT_APPSECRET", WithMockClient(client))
+
+	op.authorizerTokenManager("AUTHORIZER_APPID").Set("AUTHORIZER_ACCESS_TOKEN", 7200)
+
+	oa := op.OffiaClient("AUTHORIZER_APPID")
+
+	assert.Equal(t, "AUTHORIZER_APPID", oa.AppID())
+	assert.Equal(t, "AUTHORIZER_APPID", oa.Offia().AppID())
+
+	err := oa.Do(context.TODO(), offia.DeleteMenu())
+
+	assert.Nil(t, err)
+
+	// 按授权方缓存，取消授权后重建
+	assert.Same(t, oa, op.OffiaClient("AUTHORIZER_APPID"))
+	assert.Nil(t, op.Unauthorize(context.TODO(), "AUTHORIZER_APPID"))
+	assert.NotSame(t, oa, op.OffiaClient("AUTHORIZER_APPID"))
+}
+
+func TestMinipClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/wxa/plugin?access_token=AUTHORIZER_ACCESS_TOKEN", []byte(`{"action":"unbind","plugin_appid":"APPID"}`)).Return([]byte(`{"errcode":0,"errmsg":"ok"}`), nil)
+
+	op := New("COMPONENT_APPID", "COMPONENT_APPSECRET", WithMockClient(client))
+
+	op.authorizerTokenManager("AUTHORIZER_APPID").Set("AUTHORIZER_ACCESS_TOKEN", 7200)
+
+	mp := op.MinipClient("AUTHORIZER_APPID")
+
+	assert.Equal(t, "AUTHORIZER_APPID", mp.AppID())
+
+	err := mp.Do(context.TODO(), minip.UnbindPlugin("APPID"))
+
+	assert.Nil(t, err)
+
+	// 未授权（无刷新令牌）
+	err = op.MinipClient("UNKNOWN_APPID").Do(context.TODO(), minip.UnbindPlugin("APPID"))
+
+	assert.NotNil(t, err)
+}
+
+func TestMinipClientOptions(t *testing.T) {
+	body := []byte(`{"begin_date":"20170313","end_date":"20170313"}`)
+	resp := []byte(`{"list":[{"ref_date":"20170313","session_cnt":"142549","visit_pv":472351,"stay_time_uv":"8.9"}]}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	// 沿用第三方平台的域名、请求 header 及宽松解析
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api2.weixin.qq.com/datacube/getweanalysisappiddailyvisittrend?access_token=AUTHORIZER_ACCESS_TOKEN", body, gomock.Any()).Return(resp, nil)
+
+	op := New("COMPONENT_APPID", "COMPONENT_APPSECRET", WithMockClient(client), WithBaseURL("https://api2.weixin.qq.com"), WithHeader("User-Agent", "gochat"), WithLenientDecode())
+
+	op.authorizerTokenManager("AUTHORIZER_APPID").Set("AUTHORIZER_ACCESS_TOKEN", 7200)
+
+	result := new(minip.ResultDailyVisitTrend)
+
+	err := op.MinipClient("AUTHORIZER_APPID").Do(context.TODO(), minip.GetDailyVisitTrend("20170313", result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(142549), result.List[0].SessionCnt)
+}
+
+func TestOffiaClientLenientDecode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodGet, "https://api.weixin.qq.com/cgi-bin/menu/get?access_token=AUTHORIZER_ACCESS_TOKEN", nil).Return([]byte(`{"menu":{"button":[],"menuid":"208396938"}}`), nil)
+
+	op := New("COMPONENT_APPID", "COMPONENT_APPSECRET", WithMockClient(client), WithLenientDecode())
+
+	op.authorizerTokenManager("AUTHORIZER_APPID").Set("AUTHORIZER_ACCESS_TOKEN", 7200)
+
+	result := new(offia.ResultMenuGet)
+
+	err := op.OffiaClient("AUTHORIZER_APPID").Do(context.TODO(), offia.GetMenu(result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(208396938), result.Menu.MenuID)
+}
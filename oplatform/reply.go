@@ -0,0 +1,81 @@
+package oplatform
+
+import (
+	"encoding/base64"
+
+	"github.com/shenghui0779/gochat/event"
+	"github.com/shenghui0779/gochat/offia"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// 代授权方接收消息与被动回复：消息使用第三方平台的 token、aeskey 及 component_appid 加解密
+
+// DecryptEventMessage 对授权方的消息事件进行解密
+func (op *Oplatform) DecryptEventMessage(encrypt string) (wx.WXML, error) {
+	b, err := op.DecryptEvent(encrypt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return wx.ParseXML2Map(b)
+}
+
+// DecryptEvent 事件消息解密，返回解密后的原始报文（XML），可用于消息存档或重新解析
+func (op *Oplatform) DecryptEvent(encrypt string) ([]byte, error) {
+	return event.Decrypt(op.appid, op.aeskey, encrypt)
+}
+
+// Reply 代授权方回复消息（originID 为授权方的原始ID，即消息中的 ToUserName）
+// [参考](https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/Before_Develop/Message_Encryption/Message_encryption_and_decryption.html)
+func (op *Oplatform) Reply(originID, openid string, reply event.Reply) (*event.ReplyMessage, error) {
+	body, err := reply.Bytes(originID, openid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// 消息加密
+	cipherText, err := event.Encrypt(op.appid, op.aeskey, op.nonce(), body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return event.BuildReply(op.token, op.nonce(), base64.StdEncoding.EncodeToString(cipherText)), nil
+}
+
+// ReplyText 回复文本消息
+func ReplyText(content string) event.Reply {
+	return offia.ReplyText(content)
+}
+
+// ReplyImage 回复图片消息
+func ReplyImage(mediaID string) event.Reply {
+	return offia.ReplyImage(mediaID)
+}
+
+// ReplyVoice 回复语音消息
+func ReplyVoice(mediaID string) event.Reply {
+	return offia.ReplyVoice(mediaID)
+}
+
+// ReplyVideo 回复视频消息
+func ReplyVideo(mediaID, title, description string) event.Reply {
+	return offia.ReplyVideo(mediaID, title, description)
+}
+
+// ReplyMusic 回复音乐消息
+func ReplyMusic(music *offia.XMLMusic) event.Reply {
+	return offia.ReplyMusic(music)
+}
+
+// ReplyNews 回复图文消息
+func ReplyNews(articles ...*offia.XMLNewsArticle) event.Reply {
+	return offia.ReplyNews(articles...)
+}
+
+// TransferToKF 消息转发到客服
+func TransferToKF(kfAccount ...string) event.Reply {
+	return offia.TransferToKF(kfAccount...)
+}
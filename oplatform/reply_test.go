@@ -0,0 +1,69 @@
+package oplatform
+
+import (
+	"encoding/xml"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/event"
+	"github.com/shenghui0779/gochat/offia"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+func TestDecryptEventMessage(t *testing.T) {
+	op := New("COMPONENT_APPID", "COMPONENT_APPSECRET", WithServerConfig(testEventToken, testEventAESKey))
+
+	_, body := mockComponentEvent(t, `<xml><ToUserName><![CDATA[gh_3ad31c0ba9b5]]></ToUserName><FromUserName><![CDATA[OPENID]]></FromUserName><CreateTime>1606902602</CreateTime><MsgType><![CDATA[text]]></MsgType><Content><![CDATA[ILoveGochat]]></Content><MsgId>10086</MsgId></xml>`)
+
+	msg := new(ComponentEventMessage)
+
+	assert.Nil(t, xml.Unmarshal(body, msg))
+
+	m, err := op.DecryptEventMessage(msg.Encrypt)
+
+	assert.Nil(t, err)
+	assert.Equal(t, wx.WXML{
+		"ToUserName":   "gh_3ad31c0ba9b5",
+		"FromUserName": "OPENID",
+		"CreateTime":   "1606902602",
+		"MsgType":      "text",
+		"Content":      "ILoveGochat",
+		"MsgId":        "10086",
+	}, m)
+}
+
+func TestReply(t *testing.T) {
+	op := New("COMPONENT_APPID", "COMPONENT_APPSECRET", WithServerConfig(testEventToken, testEventAESKey), WithNonce(func() string {
+		return "1234567890123456"
+	}))
+
+	replies := map[string]event.Reply{
+		"text":  ReplyText("hello"),
+		"image": ReplyImage("MEDIA_ID"),
+		"voice": ReplyVoice("MEDIA_ID"),
+		"video": ReplyVideo("MEDIA_ID", "TITLE", "DESCRIPTION"),
+		"music": ReplyMusic(&offia.XMLMusic{Title: "TITLE", MusicURL: "MUSIC_URL"}),
+		"news": ReplyNews(&offia.XMLNewsArticle{
+			Title:  "TITLE",
+			URL:    "URL",
+			PicURL: "PIC_URL",
+		}),
+		"transfer_customer_service": TransferToKF("test1@test"),
+	}
+
+	for msgType, reply := range replies {
+		msg, err := op.Reply("gh_3ad31c0ba9b5", "OPENID", reply)
+
+		assert.Nil(t, err)
+		assert.Equal(t, string(msg.MsgSignature), event.SignWithSHA1(testEventToken, strconv.FormatInt(msg.TimeStamp, 10), string(msg.Nonce), string(msg.Encrypt)))
+
+		m, err := op.DecryptEventMessage(string(msg.Encrypt))
+
+		assert.Nil(t, err)
+		assert.Equal(t, "gh_3ad31c0ba9b5", m["FromUserName"])
+		assert.Equal(t, "OPENID", m["ToUserName"])
+		assert.Equal(t, msgType, m["MsgType"])
+	}
+}
@@ -0,0 +1,73 @@
+package oplatform
+
+import (
+	"encoding/json"
+
+	"github.com/shenghui0779/gochat/urls"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// ResultTesterBind 绑定体验者结果
+type ResultTesterBind struct {
+	UserStr string `json:"userstr"` // 人员对应的唯一字符串
+}
+
+// BindTester 绑定微信用户为体验者（体验版二维码请使用 GetTrialQRCode 获取）
+// [参考](https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/Mini_Program_AdminManagement/Admin.html)
+func BindTester(wechatID string, result *ResultTesterBind) wx.Action {
+	return wx.NewPostAction(urls.OplatformWxaBindTester,
+		wx.WithBody(func() ([]byte, error) {
+			return json.Marshal(map[string]string{
+				"wechatid": wechatID,
+			})
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
+
+// UnbindTester 解除绑定体验者（wechatID 和 userStr 二选一）
+// [参考](https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/Mini_Program_AdminManagement/unbind_tester.html)
+func UnbindTester(wechatID, userStr string) wx.Action {
+	return wx.NewPostAction(urls.OplatformWxaUnbindTester,
+		wx.WithBody(func() ([]byte, error) {
+			params := make(map[string]string)
+
+			if len(wechatID) != 0 {
+				params["wechatid"] = wechatID
+			}
+
+			if len(userStr) != 0 {
+				params["userstr"] = userStr
+			}
+
+			return json.Marshal(params)
+		}),
+	)
+}
+
+// Tester 体验者
+type Tester struct {
+	UserStr string `json:"userstr"`
+}
+
+// ResultTesterList 体验者列表
+type ResultTesterList struct {
+	Members []*Tester `json:"members"`
+}
+
+// ListTester 获取体验者列表
+// [参考](https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/Mini_Program_AdminManagement/memberauth.html)
+func ListTester(result *ResultTesterList) wx.Action {
+	return wx.NewPostAction(urls.OplatformWxaMemberAuth,
+		wx.WithBody(func() ([]byte, error) {
+			return json.Marshal(map[string]string{
+				"action": "get_experiencer",
+			})
+		}),
+		wx.WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}),
+	)
+}
@@ -0,0 +1,78 @@
+package oplatform
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/minip"
+	"github.com/shenghui0779/gochat/mock"
+)
+
+func TestBindTester(t *testing.T) {
+	body := []byte(`{"wechatid":"testid"}`)
+	resp := []byte(`{"errcode":0,"errmsg":"ok","userstr":"xxxxxxxxx"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/wxa/bind_tester?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	mp := minip.New("APPID", "", minip.WithMockClient(client))
+
+	result := new(ResultTesterBind)
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", BindTester("testid", result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultTesterBind{UserStr: "xxxxxxxxx"}, result)
+}
+
+func TestUnbindTester(t *testing.T) {
+	body := []byte(`{"userstr":"xxxxxxxxx"}`)
+	resp := []byte(`{"errcode":0,"errmsg":"ok"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/wxa/unbind_tester?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	mp := minip.New("APPID", "", minip.WithMockClient(client))
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", UnbindTester("", "xxxxxxxxx"))
+
+	assert.Nil(t, err)
+}
+
+func TestListTester(t *testing.T) {
+	body := []byte(`{"action":"get_experiencer"}`)
+	resp := []byte(`{"errcode":0,"errmsg":"ok","members":[{"userstr":"xxxxxxxx"},{"userstr":"yyyyyyyy"}]}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/wxa/memberauth?access_token=ACCESS_TOKEN", body).Return(resp, nil)
+
+	mp := minip.New("APPID", "", minip.WithMockClient(client))
+
+	result := new(ResultTesterList)
+
+	err := mp.Do(context.TODO(), "ACCESS_TOKEN", ListTester(result))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ResultTesterList{
+		Members: []*Tester{
+			{UserStr: "xxxxxxxx"},
+			{UserStr: "yyyyyyyy"},
+		},
+	}, result)
+}
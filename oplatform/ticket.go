@@ -0,0 +1,122 @@
+package oplatform
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/shenghui0779/gochat/urls"
+	"github.com/shenghui0779/gochat/wx"
+)
+
+// TicketStore component_verify_ticket 存储（如：数据库、Redis 等）
+// 票据由微信服务器每隔10分钟推送一次，有效期为12小时
+type TicketStore interface {
+	// Get 获取票据，不存在时返回空字符串
+	Get(ctx context.Context) (string, error)
+
+	// Set 保存票据
+	Set(ctx context.Context, ticket string) error
+}
+
+type memTicketStore struct {
+	ticket string
+	mutex  sync.RWMutex
+}
+
+func (s *memTicketStore) Get(ctx context.Context) (string, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.ticket, nil
+}
+
+func (s *memTicketStore) Set(ctx context.Context, ticket string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.ticket = ticket
+
+	return nil
+}
+
+// NewMemTicketStore returns an in-memory ticket store (仅用于测试或单机，重启后丢失)
+func NewMemTicketStore() TicketStore {
+	return new(memTicketStore)
+}
+
+func (op *Oplatform) loadVerifyTicket(ctx context.Context) (string, error) {
+	if op.tickets != nil {
+		ticket, err := op.tickets.Get(ctx)
+
+		if err != nil {
+			return "", err
+		}
+
+		if len(ticket) != 0 {
+			op.SetVerifyTicket(ticket)
+
+			return ticket, nil
+		}
+	}
+
+	return op.VerifyTicket(), nil
+}
+
+func (op *Oplatform) saveVerifyTicket(ctx context.Context, ticket string) error {
+	op.SetVerifyTicket(ticket)
+
+	if op.tickets != nil {
+		return op.tickets.Set(ctx, ticket)
+	}
+
+	return nil
+}
+
+// StartPushTicket 启动票据推送服务（调用后微信服务器立即推送 component_verify_ticket）
+// [参考](https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/ThirdParty/token/component_verify_ticket_service.html)
+func (op *Oplatform) StartPushTicket(ctx context.Context, options ...wx.HTTPOption) error {
+	options = wx.PrependHTTPHeaders(options, op.headers)
+
+	body, err := json.Marshal(map[string]string{
+		"component_appid":  op.appid,
+		"component_secret": op.appsecret,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	resp, err := op.client.Do(ctx, http.MethodPost, op.endpoint(urls.OplatformStartPushTicket), body, options...)
+
+	if err != nil {
+		return err
+	}
+
+	r := gjson.ParseBytes(resp)
+
+	if code := r.Get("errcode").Int(); code != 0 {
+		return wx.NewAPIError(code, r.Get("errmsg").String())
+	}
+
+	return nil
+}
+
+// BootstrapVerifyTicket 启动时检查票据，内存及存储中均无票据时启动票据推送服务（如：首次部署）；
+// 票据通过授权事件异步推送，由 ParseComponentEvent 自动保存
+func (op *Oplatform) BootstrapVerifyTicket(ctx context.Context, options ...wx.HTTPOption) error {
+	ticket, err := op.loadVerifyTicket(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	if len(ticket) != 0 {
+		return nil
+	}
+
+	return op.StartPushTicket(ctx, options...)
+}
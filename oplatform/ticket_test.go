@@ -0,0 +1,84 @@
+package oplatform
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shenghui0779/gochat/mock"
+)
+
+func TestStartPushTicket(t *testing.T) {
+	body := []byte(`{"component_appid":"COMPONENT_APPID","component_secret":"COMPONENT_APPSECRET"}`)
+	resp := []byte(`{"errcode":0,"errmsg":"ok"}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/component/api_start_push_ticket", body).Return(resp, nil)
+
+	op := New("COMPONENT_APPID", "COMPONENT_APPSECRET", WithMockClient(client))
+
+	assert.Nil(t, op.StartPushTicket(context.TODO()))
+}
+
+func TestBootstrapVerifyTicket(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	// 仅在无票据时启动推送
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/component/api_start_push_ticket", gomock.Any()).Return([]byte(`{"errcode":0,"errmsg":"ok"}`), nil).Times(1)
+
+	store := NewMemTicketStore()
+
+	op := New("COMPONENT_APPID", "COMPONENT_APPSECRET", WithServerConfig(testEventToken, testEventAESKey), WithMockClient(client), WithTicketStore(store))
+
+	assert.Nil(t, op.BootstrapVerifyTicket(context.TODO()))
+
+	signature, body := mockComponentEvent(t, `<xml><AppId>COMPONENT_APPID</AppId><CreateTime>1413192605</CreateTime><InfoType>component_verify_ticket</InfoType><ComponentVerifyTicket>TICKET</ComponentVerifyTicket></xml>`)
+
+	_, err := op.ParseComponentEvent(context.TODO(), signature, "1606902086", "1246833592", body)
+
+	assert.Nil(t, err)
+
+	ticket, err := store.Get(context.TODO())
+
+	assert.Nil(t, err)
+	assert.Equal(t, "TICKET", ticket)
+
+	// 新实例从存储中加载票据
+	op2 := New("COMPONENT_APPID", "COMPONENT_APPSECRET", WithMockClient(client), WithTicketStore(store))
+
+	assert.Nil(t, op2.BootstrapVerifyTicket(context.TODO()))
+	assert.Equal(t, "TICKET", op2.VerifyTicket())
+}
+
+func TestComponentTokenWithTicketStore(t *testing.T) {
+	body := []byte(`{"component_appid":"COMPONENT_APPID","component_appsecret":"COMPONENT_APPSECRET","component_verify_ticket":"TICKET"}`)
+	resp := []byte(`{"component_access_token":"COMPONENT_ACCESS_TOKEN","expires_in":7200}`)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.AssignableToTypeOf(context.TODO()), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/component/api_component_token", body).Return(resp, nil)
+
+	store := NewMemTicketStore()
+
+	assert.Nil(t, store.Set(context.TODO(), "TICKET"))
+
+	op := New("COMPONENT_APPID", "COMPONENT_APPSECRET", WithMockClient(client), WithTicketStore(store))
+
+	token, err := op.ComponentToken(context.TODO())
+
+	assert.Nil(t, err)
+	assert.Equal(t, "COMPONENT_ACCESS_TOKEN", token)
+}
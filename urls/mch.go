@@ -2,64 +2,159 @@ package urls
 
 const (
 	MchRSAPublicKey     = "https://fraud.mch.weixin.qq.com/risk/getpublickey"
-	MchToolsShortURL    = "https://api.mch.weixin.qq.com/tools/shorturl"
-	MchAuthCodeToOpenID = "https://api.mch.weixin.qq.com/tools/authcodetoopenid"
+	MchToolsShortURL    = "/tools/shorturl"
+	MchAuthCodeToOpenID = "/tools/authcodetoopenid"
 )
 
 // order
 const (
-	MchOrderUnify   = "https://api.mch.weixin.qq.com/pay/unifiedorder"   // 统一下单
-	MchOrderQuery   = "https://api.mch.weixin.qq.com/pay/orderquery"     // 订单查询
-	MchOrderClose   = "https://api.mch.weixin.qq.com/pay/closeorder"     // 订单关闭
-	MchOrderReverse = "https://api.mch.weixin.qq.com/secapi/pay/reverse" // 撤销订单
+	MchOrderUnify   = "/pay/unifiedorder"   // 统一下单
+	MchOrderQuery   = "/pay/orderquery"     // 订单查询
+	MchOrderClose   = "/pay/closeorder"     // 订单关闭
+	MchOrderReverse = "/secapi/pay/reverse" // 撤销订单
 )
 
 // micro
 const (
-	MchMicroPay = "https://api.mch.weixin.qq.com/pay/micropay"
+	MchMicroPay = "/pay/micropay"
+)
+
+// deposit
+const (
+	MchDepositMicroPay   = "/deposit/micropay"   // 押金支付（付款码）
+	MchDepositFacePay    = "/deposit/facepay"    // 押金支付（人脸）
+	MchDepositOrderQuery = "/deposit/orderquery" // 押金订单查询
+	MchDepositReverse    = "/deposit/reverse"    // 押金撤销
+)
+
+// facepay
+const (
+	MchFacePayAuthInfo = "https://payapp.weixin.qq.com/face/get_wxpayface_authinfo" // 获取调用凭证
+	MchFacePay         = "/pay/facepay"                                             // 人脸支付
+	MchFacePayQuery    = "/pay/facepayquery"                                        // 人脸支付订单查询
+	MchFacePayReverse  = "/secapi/pay/facepayreverse"                               // 人脸支付撤销订单
 )
 
 // refund
 const (
-	MchRefundApply = "https://api.mch.weixin.qq.com/secapi/pay/refund" // 申请退款
-	MchRefundQuery = "https://api.mch.weixin.qq.com/pay/refundquery"   // 退款查询
+	MchRefundApply = "/secapi/pay/refund" // 申请退款
+	MchRefundQuery = "/pay/refundquery"   // 退款查询
 )
 
 // pappay
 const (
-	MchPappayAPPEntrust     = "https://api.mch.weixin.qq.com/papay/preentrustweb"  // APP纯签约
-	MchPappayOAEntrust      = "https://api.mch.weixin.qq.com/papay/entrustweb"     // 公众号纯签约
-	MchPappayH5Entrust      = "https://api.mch.weixin.qq.com/papay/h5entrustweb"   // H5纯签约
-	MchPappayContractOrder  = "https://api.mch.weixin.qq.com/pay/contractorder"    // 支付中签约
-	MchPappayContractQuery  = "https://api.mch.weixin.qq.com/papay/querycontract"  // 签约查询
-	MchPappayContractDelete = "https://api.mch.weixin.qq.com/papay/deletecontract" // 申请解约
-	MchPappayApply          = "https://api.mch.weixin.qq.com/pay/pappayapply"      // 申请扣款
-	MchPappayOrderQuery     = "https://api.mch.weixin.qq.com/pay/paporderquery"    // 扣款查询
+	MchPappayAPPEntrust     = "/papay/preentrustweb"  // APP纯签约
+	MchPappayOAEntrust      = "/papay/entrustweb"     // 公众号纯签约
+	MchPappayH5Entrust      = "/papay/h5entrustweb"   // H5纯签约
+	MchPappayContractOrder  = "/pay/contractorder"    // 支付中签约
+	MchPappayContractQuery  = "/papay/querycontract"  // 签约查询
+	MchPappayContractDelete = "/papay/deletecontract" // 申请解约
+	MchPappayApply          = "/pay/pappayapply"      // 申请扣款
+	MchPappayOrderQuery     = "/pay/paporderquery"    // 扣款查询
 )
 
 // transfer
 const (
-	MchTransferToBalance          = "https://api.mch.weixin.qq.com/mmpaymkttransfers/promotion/transfers"             // 企业付款到零钱
-	MchTransferBalanceOrderQuery  = "https://api.mch.weixin.qq.com/mmpaymkttransfers/gettransferinfo"                 // 企业付款到零钱订单查询
-	MchTransferToBankCard         = "https://api.mch.weixin.qq.com/mmpaysptrans/pay_bank"                             // 企业付款到银行卡
-	MchTransferBankCardOrderQuery = "https://api.mch.weixin.qq.com/mmpaysptrans/query_bank"                           // 企业付款到银行卡订单查询
-	MchTransferToPocket           = "https://api.mch.weixin.qq.com/mmpaymkttransfers/promotion/paywwsptrans2pocket"   // 企业向员工付款
-	MchTransferPocketOrderQuery   = "https://api.mch.weixin.qq.com/mmpaymkttransfers/promotion/querywwsptrans2pocket" // 企业向员工付款订单查询
+	MchTransferToBalance          = "/mmpaymkttransfers/promotion/transfers"             // 企业付款到零钱
+	MchTransferBalanceOrderQuery  = "/mmpaymkttransfers/gettransferinfo"                 // 企业付款到零钱订单查询
+	MchTransferToBankCard         = "/mmpaysptrans/pay_bank"                             // 企业付款到银行卡
+	MchTransferBankCardOrderQuery = "/mmpaysptrans/query_bank"                           // 企业付款到银行卡订单查询
+	MchTransferToPocket           = "/mmpaymkttransfers/promotion/paywwsptrans2pocket"   // 企业向员工付款
+	MchTransferPocketOrderQuery   = "/mmpaymkttransfers/promotion/querywwsptrans2pocket" // 企业向员工付款订单查询
 )
 
 // redpack
 const (
-	MchRedpackNormal    = "https://api.mch.weixin.qq.com/mmpaymkttransfers/sendredpack"        // 普通红包
-	MchRedpackGroup     = "https://api.mch.weixin.qq.com/mmpaymkttransfers/sendgroupredpack"   // 裂变红包
-	MchRedpackMinip     = "https://api.mch.weixin.qq.com/mmpaymkttransfers/sendminiprogramhb"  // 小程序红包
-	MchRedpackQuery     = "https://api.mch.weixin.qq.com/mmpaymkttransfers/gethbinfo"          // 红包查询
-	MchRedpackCorp      = "https://api.mch.weixin.qq.com/mmpaymkttransfers/sendworkwxredpack"  // 企业红包
-	MchRedpackCorpQuery = "https://api.mch.weixin.qq.com/mmpaymkttransfers/queryworkwxredpack" // 企业红包查询
+	MchRedpackNormal    = "/mmpaymkttransfers/sendredpack"        // 普通红包
+	MchRedpackGroup     = "/mmpaymkttransfers/sendgroupredpack"   // 裂变红包
+	MchRedpackMinip     = "/mmpaymkttransfers/sendminiprogramhb"  // 小程序红包
+	MchRedpackQuery     = "/mmpaymkttransfers/gethbinfo"          // 红包查询
+	MchRedpackCorp      = "/mmpaymkttransfers/sendworkwxredpack"  // 企业红包
+	MchRedpackCorpQuery = "/mmpaymkttransfers/queryworkwxredpack" // 企业红包查询
 )
 
 // other
 const (
-	MchDownloadBill      = "https://api.mch.weixin.qq.com/pay/downloadbill"                // 下载交易账单
-	MchDownloadFundFlow  = "https://api.mch.weixin.qq.com/pay/downloadfundflow"            // 下载资金账单
-	MchBatchQueryComment = "https://api.mch.weixin.qq.com/billcommentsp/batchquerycomment" // 拉取订单评价数据
+	MchDownloadBill      = "/pay/downloadbill"                // 下载交易账单
+	MchDownloadFundFlow  = "/pay/downloadfundflow"            // 下载资金账单
+	MchBatchQueryComment = "/billcommentsp/batchquerycomment" // 拉取订单评价数据
+)
+
+// v3 transfer
+const (
+	MchV3TransferBatches           = "/v3/transfer/batches"                    // 发起商家转账
+	MchV3TransferBatchByBatchID    = "/v3/transfer/batches/batch-id"           // 通过微信批次单号查询
+	MchV3TransferBatchByOutBatchNO = "/v3/transfer/batches/out-batch-no"       // 通过商家批次单号查询
+	MchV3TransferBillReceipt       = "/v3/transfer/bill-receipt"               // 转账电子回单申请受理/查询
+	MchV3TransferDetailReceipt     = "/v3/transfer-detail/electronic-receipts" // 转账明细电子回单受理/查询
+)
+
+// v3 media
+const (
+	MchV3MediaImageUpload = "/v3/merchant/media/upload"       // 图片上传
+	MchV3MediaVideoUpload = "/v3/merchant/media/video_upload" // 视频上传
+)
+
+// v3 ecommerce
+const (
+	MchV3EcommerceApplyments               = "/v3/ecommerce/applyments"                  // 二级商户进件
+	MchV3EcommerceApplymentByOutRequest    = "/v3/ecommerce/applyments/out-request-no"   // 通过业务申请编号查询申请状态
+	MchV3EcommerceSubMerchants             = "/v3/apply4sub/sub_merchants"               // 二级商户结算账户修改/查询
+	MchV3EcommerceFundBalance              = "/v3/ecommerce/fund/balance"                // 查询二级商户账户实时余额
+	MchV3EcommerceProfitSharingOrders      = "/v3/ecommerce/profitsharing/orders"        // 请求分账/查询分账结果
+	MchV3EcommerceProfitSharingFinish      = "/v3/ecommerce/profitsharing/finish-order"  // 完结分账
+	MchV3EcommerceProfitSharingAddReceiver = "/v3/ecommerce/profitsharing/receivers/add" // 添加分账接收方
+)
+
+// v3 transactions
+const (
+	MchV3TransactionsJSAPI               = "/v3/pay/transactions/jsapi"                // JSAPI/小程序下单
+	MchV3TransactionsAPP                 = "/v3/pay/transactions/app"                  // APP下单
+	MchV3TransactionsH5                  = "/v3/pay/transactions/h5"                   // H5下单
+	MchV3TransactionsNative              = "/v3/pay/transactions/native"               // Native下单
+	MchV3TransactionsByID                = "/v3/pay/transactions/id"                   // 微信支付订单号查询
+	MchV3TransactionsByOutTradeNO        = "/v3/pay/transactions/out-trade-no"         // 商户订单号查询/关闭订单
+	MchV3PartnerTransactionsJSAPI        = "/v3/pay/partner/transactions/jsapi"        // 服务商 - JSAPI/小程序下单
+	MchV3PartnerTransactionsAPP          = "/v3/pay/partner/transactions/app"          // 服务商 - APP下单
+	MchV3PartnerTransactionsH5           = "/v3/pay/partner/transactions/h5"           // 服务商 - H5下单
+	MchV3PartnerTransactionsNative       = "/v3/pay/partner/transactions/native"       // 服务商 - Native下单
+	MchV3PartnerTransactionsByID         = "/v3/pay/partner/transactions/id"           // 服务商 - 微信支付订单号查询
+	MchV3PartnerTransactionsByOutTradeNO = "/v3/pay/partner/transactions/out-trade-no" // 服务商 - 商户订单号查询/关闭订单
+	MchV3RefundDomestic                  = "/v3/refund/domestic/refunds"               // 申请退款/查询单笔退款
+)
+
+// v3 businesscircle
+const (
+	MchV3BusinessCirclePointsNotify       = "/v3/businesscircle/points/notify"       // 商圈积分同步
+	MchV3BusinessCircleUserAuthorizations = "/v3/businesscircle/user-authorizations" // 商圈积分授权查询
+)
+
+// v3 vehicle parking
+const (
+	MchV3ParkingServicesFind            = "/v3/vehicle/parking/services/find"     // 查询车牌服务开通信息
+	MchV3Parkings                       = "/v3/vehicle/parking/parkings"          // 创建停车入场
+	MchV3ParkingTransactions            = "/v3/vehicle/transactions/parking"      // 扣费受理
+	MchV3ParkingTransactionByOutTradeNO = "/v3/vehicle/transactions/out-trade-no" // 查询订单
+)
+
+// v3 complaint
+const (
+	MchV3Complaints             = "/v3/merchant-service/complaints-v2"           // 查询投诉单列表/详情/协商历史，回复/反馈处理完成
+	MchV3ComplaintNotifications = "/v3/merchant-service/complaint-notifications" // 创建/查询/更新/删除投诉通知回调地址
+)
+
+// v3 marketing favor
+const (
+	MchV3FavorCouponStocks = "/v3/marketing/favor/coupon-stocks" // 创建代金券批次
+	MchV3FavorStocks       = "/v3/marketing/favor/stocks"        // 激活/暂停/重启/查询代金券批次
+	MchV3FavorUsers        = "/v3/marketing/favor/users"         // 发放/查询代金券
+)
+
+// v3 goldplan
+const (
+	MchV3GoldPlanChangeStatus           = "/v3/goldplan/merchants/changegoldplanstatus"            // 点金计划管理
+	MchV3GoldPlanChangeCustomPageStatus = "/v3/goldplan/merchants/changecustompagestatus"          // 商家小票管理
+	MchV3GoldPlanSetAdvertisingFilter   = "/v3/goldplan/merchants/set-advertising-industry-filter" // 同业过滤标签管理
+	MchV3GoldPlanOpenAdvertisingShow    = "/v3/goldplan/merchants/open-advertising-show"           // 开通广告展示
+	MchV3GoldPlanCloseAdvertisingShow   = "/v3/goldplan/merchants/close-advertising-show"          // 关闭广告展示
 )
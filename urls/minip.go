@@ -2,83 +2,129 @@ package urls
 
 // auth
 const (
-	MinipAccessToken        = "https://api.weixin.qq.com/cgi-bin/token"
-	MinipCode2Session       = "https://api.weixin.qq.com/sns/jscode2session"
-	MinipPhoneNumber        = "https://api.weixin.qq.com/wxa/business/getuserphonenumber"
-	MinipEncryptedDataCheck = "https://api.weixin.qq.com/wxa/business/checkencryptedmsg"
-	MinipPaidUnion          = "https://api.weixin.qq.com/wxa/getpaidunionid"
+	MinipAccessToken        = "/cgi-bin/token"
+	MinipCode2Session       = "/sns/jscode2session"
+	MinipPhoneNumber        = "/wxa/business/getuserphonenumber"
+	MinipEncryptedDataCheck = "/wxa/business/checkencryptedmsg"
+	MinipPaidUnion          = "/wxa/getpaidunionid"
+	MinipCheckSession       = "/wxa/checksession"
+	MinipResetSessionKey    = "/wxa/resetusersessionkey"
+)
+
+// analysis
+const (
+	MinipAnalysisDailySummaryTrend = "/datacube/getweanalysisappiddailysummarytrend"
+	MinipAnalysisDailyVisitTrend   = "/datacube/getweanalysisappiddailyvisittrend"
 )
 
 // message
 const (
-	MinipUniformMsgSend   = "https://api.weixin.qq.com/cgi-bin/message/wxopen/template/uniform_send"
-	MinipSubscribeMsgSend = "https://api.weixin.qq.com/cgi-bin/message/subscribe/send"
-	MinipKFMsgSend        = "https://api.weixin.qq.com/cgi-bin/message/custom/send"
-	MinipKFTypingSend     = "https://api.weixin.qq.com/cgi-bin/message/custom/typing"
+	MinipUniformMsgSend   = "/cgi-bin/message/wxopen/template/uniform_send"
+	MinipSubscribeMsgSend = "/cgi-bin/message/subscribe/send"
+	MinipKFMsgSend        = "/cgi-bin/message/custom/send"
+	MinipKFTypingSend     = "/cgi-bin/message/custom/typing"
 )
 
 // qrcode
 const (
-	MinipQRCodeCreate     = "https://api.weixin.qq.com/cgi-bin/wxaapp/createwxaqrcode"
-	MinipQRCodeGet        = "https://api.weixin.qq.com/wxa/getwxacode"
-	MinipQRCodeGetUnlimit = "https://api.weixin.qq.com/wxa/getwxacodeunlimit"
+	MinipQRCodeCreate     = "/cgi-bin/wxaapp/createwxaqrcode"
+	MinipQRCodeGet        = "/wxa/getwxacode"
+	MinipQRCodeGetUnlimit = "/wxa/getwxacodeunlimit"
 )
 
 // media
 const (
-	MinipMediaUpload = "https://api.weixin.qq.com/cgi-bin/media/upload"
-	MinipMediaGet    = "https://api.weixin.qq.com/cgi-bin/media/get"
+	MinipMediaUpload = "/cgi-bin/media/upload"
+	MinipMediaGet    = "/cgi-bin/media/get"
 )
 
 // plugin
 const (
-	MinipPluginManage    = "https://api.weixin.qq.com/wxa/plugin"
-	MinipPluginDevManage = "https://api.weixin.qq.com/wxa/devplugin"
+	MinipPluginManage    = "/wxa/plugin"
+	MinipPluginDevManage = "/wxa/devplugin"
 )
 
 // security
 const (
-	MinipImageSecCheck   = "https://api.weixin.qq.com/wxa/img_sec_check"
-	MinipMediaCheckAsync = "https://api.weixin.qq.com/wxa/media_check_async"
-	MinipMsgSecCheck     = "https://api.weixin.qq.com/wxa/msg_sec_check"
+	MinipImageSecCheck   = "/wxa/img_sec_check"
+	MinipMediaCheckAsync = "/wxa/media_check_async"
+	MinipMsgSecCheck     = "/wxa/msg_sec_check"
 )
 
 // image
 const (
-	MinipAICrop          = "https://api.weixin.qq.com/cv/img/aicrop"
-	MinipScanQRCode      = "https://api.weixin.qq.com/cv/img/qrcode"
-	MinipSuperreSolution = "https://api.weixin.qq.com/cv/img/superresolution"
+	MinipAICrop          = "/cv/img/aicrop"
+	MinipScanQRCode      = "/cv/img/qrcode"
+	MinipSuperreSolution = "/cv/img/superresolution"
 )
 
 // ocr
 const (
-	MinipOCRIDCard          = "https://api.weixin.qq.com/cv/ocr/idcard"
-	MinipOCRBankCard        = "https://api.weixin.qq.com/cv/ocr/bankcard"
-	MinipOCRPlateNumber     = "https://api.weixin.qq.com/cv/ocr/platenum"
-	MinipOCRDriverLicense   = "https://api.weixin.qq.com/cv/ocr/drivinglicense"
-	MinipOCRVehicleLicense  = "https://api.weixin.qq.com/cv/ocr/driving"
-	MinipOCRBusinessLicense = "https://api.weixin.qq.com/cv/ocr/bizlicense"
-	MinipOCRComm            = "https://api.weixin.qq.com/cv/ocr/comm"
+	MinipOCRIDCard          = "/cv/ocr/idcard"
+	MinipOCRBankCard        = "/cv/ocr/bankcard"
+	MinipOCRPlateNumber     = "/cv/ocr/platenum"
+	MinipOCRDriverLicense   = "/cv/ocr/drivinglicense"
+	MinipOCRVehicleLicense  = "/cv/ocr/driving"
+	MinipOCRBusinessLicense = "/cv/ocr/bizlicense"
+	MinipOCRComm            = "/cv/ocr/comm"
 )
 
 // subscribe
 const (
-	MinipSubscribeAddTemplate            = "https://api.weixin.qq.com/wxaapi/newtmpl/addtemplate"
-	MinipSubscribeDeleteTemplate         = "https://api.weixin.qq.com/wxaapi/newtmpl/deltemplate"
-	MinipSubscribeGetCategory            = "https://api.weixin.qq.com/wxaapi/newtmpl/getcategory"
-	MinipSubscribeGetPubTemplateKeyWords = "https://api.weixin.qq.com/wxaapi/newtmpl/getpubtemplatekeywords"
-	MinipSubscribeGetPubTemplateTitles   = "https://api.weixin.qq.com/wxaapi/newtmpl/getpubtemplatetitles"
-	MinipSubscribeGetTemplateList        = "https://api.weixin.qq.com/wxaapi/newtmpl/gettemplate"
+	MinipSubscribeAddTemplate            = "/wxaapi/newtmpl/addtemplate"
+	MinipSubscribeDeleteTemplate         = "/wxaapi/newtmpl/deltemplate"
+	MinipSubscribeGetCategory            = "/wxaapi/newtmpl/getcategory"
+	MinipSubscribeGetPubTemplateKeyWords = "/wxaapi/newtmpl/getpubtemplatekeywords"
+	MinipSubscribeGetPubTemplateTitles   = "/wxaapi/newtmpl/getpubtemplatetitles"
+	MinipSubscribeGetTemplateList        = "/wxaapi/newtmpl/gettemplate"
 )
 
 // other
 const (
-	MinipInvokeService   = "https://api.weixin.qq.com/wxa/servicemarket"
-	MinipSoterVerify     = "https://api.weixin.qq.com/cgi-bin/soter/verify_signature"
-	MinipShortLink       = "https://api.weixin.qq.com/wxa/genwxashortlink"
-	MinipUserRiskRank    = "https://api.weixin.qq.com/wxa/getuserriskrank"
-	MinipGenerateScheme  = "https://api.weixin.qq.com/wxa/generatescheme"
-	MinipQueryScheme     = "https://api.weixin.qq.com/wxa/queryscheme"
-	MinipGenerateURLLink = "https://api.weixin.qq.com/wxa/generate_urllink"
-	MinipQueryURLLink    = "https://api.weixin.qq.com/wxa/query_urllink"
+	MinipInvokeService   = "/wxa/servicemarket"
+	MinipSoterVerify     = "/cgi-bin/soter/verify_signature"
+	MinipShortLink       = "/wxa/genwxashortlink"
+	MinipUserRiskRank    = "/wxa/getuserriskrank"
+	MinipGenerateScheme  = "/wxa/generatescheme"
+	MinipQueryScheme     = "/wxa/queryscheme"
+	MinipGenerateURLLink = "/wxa/generate_urllink"
+	MinipQueryURLLink    = "/wxa/query_urllink"
+)
+
+// nearby
+const (
+	MinipNearbyAddPOI    = "/wxa/addnearbypoi"
+	MinipNearbyDeletePOI = "/wxa/delnearbypoi"
+)
+
+// ad
+const (
+	MinipPublisherStat = "/publisher/stat"
+)
+
+// express delivery
+const (
+	MinipExpressPreviewTemplate = "/cgi-bin/express/delivery/template/preview"
+	MinipExpressUpdateBusiness  = "/cgi-bin/express/delivery/service/business/update"
+	MinipExpressUpdatePath      = "/cgi-bin/express/delivery/path/update"
+	MinipExpressGetContact      = "/cgi-bin/express/delivery/contact/get"
+)
+
+// express business
+const (
+	MinipExpressBusinessAddOrder       = "/cgi-bin/express/business/order/add"
+	MinipExpressBusinessGetOrder       = "/cgi-bin/express/business/order/get"
+	MinipExpressBusinessCancelOrder    = "/cgi-bin/express/business/order/cancel"
+	MinipExpressBusinessGetPath        = "/cgi-bin/express/business/path/get"
+	MinipExpressBusinessGetAllDelivery = "/cgi-bin/express/business/delivery/getall"
+	MinipExpressBusinessGetQuota       = "/cgi-bin/express/business/quota/get"
+	MinipExpressBusinessBindAccount    = "/cgi-bin/express/business/account/bind"
+	MinipExpressBusinessGetAllAccount  = "/cgi-bin/express/business/account/getall"
+)
+
+// privacy
+const (
+	MinipGetPrivacySetting    = "/cgi-bin/component/getprivacysetting"
+	MinipSetPrivacySetting    = "/cgi-bin/component/setprivacysetting"
+	MinipUploadPrivacyExtFile = "/cgi-bin/component/uploadprivacyextfile"
 )
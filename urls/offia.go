@@ -8,144 +8,188 @@ const (
 
 // cgi-bin
 const (
-	OffiaCgiBinAccessToken = "https://api.weixin.qq.com/cgi-bin/token"
-	OffiaCgiBinTicket      = "https://api.weixin.qq.com/cgi-bin/ticket/getticket"
+	OffiaCgiBinAccessToken = "/cgi-bin/token"
+	OffiaCgiBinTicket      = "/cgi-bin/ticket/getticket"
+	OffiaCgiBinCallbackIP  = "/cgi-bin/getcallbackip"
 )
 
 // menu
 const (
-	OffiaMenuCreate            = "https://api.weixin.qq.com/cgi-bin/menu/create"
-	OffiaGetCurSelfMenuInfo    = "https://api.weixin.qq.com/cgi-bin/get_current_selfmenu_info"
-	OffiaMenuAddConditional    = "https://api.weixin.qq.com/cgi-bin/menu/addconditional"
-	OffiaMenuTryMatch          = "https://api.weixin.qq.com/cgi-bin/menu/trymatch"
-	OffiaMenuGet               = "https://api.weixin.qq.com/cgi-bin/menu/get"
-	OffiaMenuDelete            = "https://api.weixin.qq.com/cgi-bin/menu/delete"
-	OffiaMenuDeleteConditional = "https://api.weixin.qq.com/cgi-bin/menu/delconditional"
+	OffiaMenuCreate            = "/cgi-bin/menu/create"
+	OffiaGetCurSelfMenuInfo    = "/cgi-bin/get_current_selfmenu_info"
+	OffiaMenuAddConditional    = "/cgi-bin/menu/addconditional"
+	OffiaMenuTryMatch          = "/cgi-bin/menu/trymatch"
+	OffiaMenuGet               = "/cgi-bin/menu/get"
+	OffiaMenuDelete            = "/cgi-bin/menu/delete"
+	OffiaMenuDeleteConditional = "/cgi-bin/menu/delconditional"
 )
 
 // sns
 const (
-	OffiaSnsCode2Token         = "https://api.weixin.qq.com/sns/oauth2/access_token"
-	OffiaSnsCheckAccessToken   = "https://api.weixin.qq.com/sns/auth"
-	OffiaSnsRefreshAccessToken = "https://api.weixin.qq.com/sns/oauth2/refresh_token"
-	OffiaSnsUserInfo           = "https://api.weixin.qq.com/sns/userinfo"
+	OffiaSnsCode2Token         = "/sns/oauth2/access_token"
+	OffiaSnsCheckAccessToken   = "/sns/auth"
+	OffiaSnsRefreshAccessToken = "/sns/oauth2/refresh_token"
+	OffiaSnsUserInfo           = "/sns/userinfo"
 )
 
 // user
 const (
-	OffiaTagCreate        = "https://api.weixin.qq.com/cgi-bin/tags/create"
-	OffiaTagUpdate        = "https://api.weixin.qq.com/cgi-bin/tags/update"
-	OffiaTagGet           = "https://api.weixin.qq.com/cgi-bin/tags/get"
-	OffiaTagDelete        = "https://api.weixin.qq.com/cgi-bin/tags/delete"
-	OffiaTagUserGet       = "https://api.weixin.qq.com/cgi-bin/user/tag/get"
-	OffiaBatchTagging     = "https://api.weixin.qq.com/cgi-bin/tags/members/batchtagging"
-	OffiaBatchUnTagging   = "https://api.weixin.qq.com/cgi-bin/tags/members/batchuntagging"
-	OffiaTagGetIDList     = "https://api.weixin.qq.com/cgi-bin/tags/getidlist"
-	OffiaUserGet          = "https://api.weixin.qq.com/cgi-bin/user/info"
-	OffiaUserBatchGet     = "https://api.weixin.qq.com/cgi-bin/user/info/batchget"
-	OffiaUserList         = "https://api.weixin.qq.com/cgi-bin/user/get"
-	OffiaBlackListGet     = "https://api.weixin.qq.com/cgi-bin/tags/members/getblacklist"
-	OffiaBatchBlackList   = "https://api.weixin.qq.com/cgi-bin/tags/members/batchblacklist"
-	OffiaBatchUnBlackList = "https://api.weixin.qq.com/cgi-bin/tags/members/batchunblacklist"
-	OffiaUserRemarkSet    = "https://api.weixin.qq.com/cgi-bin/user/info/updateremark"
+	OffiaTagCreate        = "/cgi-bin/tags/create"
+	OffiaTagUpdate        = "/cgi-bin/tags/update"
+	OffiaTagGet           = "/cgi-bin/tags/get"
+	OffiaTagDelete        = "/cgi-bin/tags/delete"
+	OffiaTagUserGet       = "/cgi-bin/user/tag/get"
+	OffiaBatchTagging     = "/cgi-bin/tags/members/batchtagging"
+	OffiaBatchUnTagging   = "/cgi-bin/tags/members/batchuntagging"
+	OffiaTagGetIDList     = "/cgi-bin/tags/getidlist"
+	OffiaUserGet          = "/cgi-bin/user/info"
+	OffiaUserBatchGet     = "/cgi-bin/user/info/batchget"
+	OffiaUserList         = "/cgi-bin/user/get"
+	OffiaBlackListGet     = "/cgi-bin/tags/members/getblacklist"
+	OffiaBatchBlackList   = "/cgi-bin/tags/members/batchblacklist"
+	OffiaBatchUnBlackList = "/cgi-bin/tags/members/batchunblacklist"
+	OffiaUserRemarkSet    = "/cgi-bin/user/info/updateremark"
+	OffiaChangeOpenID     = "/cgi-bin/changeopenid"
 )
 
 // message
 const (
-	OffiaSetIndustry           = "https://api.weixin.qq.com/cgi-bin/template/api_set_industry"
-	OffiaGetIndustry           = "https://api.weixin.qq.com/cgi-bin/template/get_industry"
-	OffiaTemplateAdd           = "https://api.weixin.qq.com/cgi-bin/template/api_add_template"
-	OffiaGetAllPrivateTemplate = "https://api.weixin.qq.com/cgi-bin/template/get_all_private_template"
-	OffiaDelPrivateTemplate    = "https://api.weixin.qq.com/cgi-bin/template/del_private_template"
-	OffiaTemplateMsgSend       = "https://api.weixin.qq.com/cgi-bin/message/template/send"
-	OffiaTemplateSubscribe     = "https://api.weixin.qq.com/cgi-bin/message/template/subscribe"
+	OffiaSetIndustry           = "/cgi-bin/template/api_set_industry"
+	OffiaGetIndustry           = "/cgi-bin/template/get_industry"
+	OffiaTemplateAdd           = "/cgi-bin/template/api_add_template"
+	OffiaGetAllPrivateTemplate = "/cgi-bin/template/get_all_private_template"
+	OffiaDelPrivateTemplate    = "/cgi-bin/template/del_private_template"
+	OffiaTemplateMsgSend       = "/cgi-bin/message/template/send"
+	OffiaTemplateSubscribe     = "/cgi-bin/message/template/subscribe"
 )
 
 // popularize
 const (
-	OffiaQRCodeCreate     = "https://api.weixin.qq.com/cgi-bin/qrcode/create"
+	OffiaQRCodeCreate     = "/cgi-bin/qrcode/create"
 	OffiaQRCodeShow       = "https://mp.weixin.qq.com/cgi-bin/showqrcode"
-	OffiaShortURLGenerate = "https://api.weixin.qq.com/cgi-bin/shorturl"
+	OffiaShortURLGenerate = "/cgi-bin/shorturl"
 )
 
 // media
 const (
-	OffiaMediaUpload      = "https://api.weixin.qq.com/cgi-bin/media/upload"
-	OffiaMediaGet         = "https://api.weixin.qq.com/cgi-bin/media/get"
-	OffiaNewsAdd          = "https://api.weixin.qq.com/cgi-bin/material/add_news"
-	OffiaNewsUpdate       = "https://api.weixin.qq.com/cgi-bin/material/update_news"
-	OffiaNewsImgUpload    = "https://api.weixin.qq.com/cgi-bin/media/uploadimg"
-	OffiaMaterialAdd      = "https://api.weixin.qq.com/cgi-bin/material/add_material"
-	OffiaMaterialDelete   = "https://api.weixin.qq.com/cgi-bin/material/del_material"
-	OffiaMaterialGet      = "https://api.weixin.qq.com/cgi-bin/material/get_material"
-	OffiaMaterialCountGet = "https://api.weixin.qq.com/cgi-bin/material/get_materialcount"
-	OffiaMaterialBatchGet = "https://api.weixin.qq.com/cgi-bin/material/batchget_material"
+	OffiaMediaUpload      = "/cgi-bin/media/upload"
+	OffiaMediaGet         = "/cgi-bin/media/get"
+	OffiaMediaGetJSSDK    = "/cgi-bin/media/get/jssdk"
+	OffiaNewsAdd          = "/cgi-bin/material/add_news"
+	OffiaNewsUpdate       = "/cgi-bin/material/update_news"
+	OffiaNewsImgUpload    = "/cgi-bin/media/uploadimg"
+	OffiaMaterialAdd      = "/cgi-bin/material/add_material"
+	OffiaMaterialDelete   = "/cgi-bin/material/del_material"
+	OffiaMaterialGet      = "/cgi-bin/material/get_material"
+	OffiaMaterialCountGet = "/cgi-bin/material/get_materialcount"
+	OffiaMaterialBatchGet = "/cgi-bin/material/batchget_material"
 )
 
 // image
 const (
-	OffiaAICrop          = "https://api.weixin.qq.com/cv/img/aicrop"
-	OffiaScanQRCode      = "https://api.weixin.qq.com/cv/img/qrcode"
-	OffiaSuperreSolution = "https://api.weixin.qq.com/cv/img/superresolution"
+	OffiaAICrop          = "/cv/img/aicrop"
+	OffiaScanQRCode      = "/cv/img/qrcode"
+	OffiaSuperreSolution = "/cv/img/superresolution"
 )
 
 // ocr
 const (
-	OffiaOCRIDCard          = "https://api.weixin.qq.com/cv/ocr/idcard"
-	OffiaOCRBankCard        = "https://api.weixin.qq.com/cv/ocr/bankcard"
-	OffiaOCRPlateNumber     = "https://api.weixin.qq.com/cv/ocr/platenum"
-	OffiaOCRDriverLicense   = "https://api.weixin.qq.com/cv/ocr/drivinglicense"
-	OffiaOCRVehicleLicense  = "https://api.weixin.qq.com/cv/ocr/driving"
-	OffiaOCRBusinessLicense = "https://api.weixin.qq.com/cv/ocr/bizlicense"
-	OffiaOCRComm            = "https://api.weixin.qq.com/cv/ocr/comm"
+	OffiaOCRIDCard          = "/cv/ocr/idcard"
+	OffiaOCRBankCard        = "/cv/ocr/bankcard"
+	OffiaOCRPlateNumber     = "/cv/ocr/platenum"
+	OffiaOCRDriverLicense   = "/cv/ocr/drivinglicense"
+	OffiaOCRVehicleLicense  = "/cv/ocr/driving"
+	OffiaOCRBusinessLicense = "/cv/ocr/bizlicense"
+	OffiaOCRComm            = "/cv/ocr/comm"
 )
 
 // KF
 const (
-	OffiaKFAccountList   = "https://api.weixin.qq.com/cgi-bin/customservice/getkflist"
-	OffiaKFOnlineList    = "https://api.weixin.qq.com/cgi-bin/customservice/getonlinekflist"
-	OffiaKFAccountAdd    = "https://api.weixin.qq.com/customservice/kfaccount/add"
-	OffiaKFInvite        = "https://api.weixin.qq.com/customservice/kfaccount/inviteworker"
-	OffiaKFAccountUpdate = "https://api.weixin.qq.com/customservice/kfaccount/update"
-	OffiaKFAvatarUpload  = "https://api.weixin.qq.com/customservice/kfaccount/uploadheadimg"
-	OffiaKFDelete        = "https://api.weixin.qq.com/customservice/kfaccount/del"
-	OffiaKFSessionCreate = "https://api.weixin.qq.com/customservice/kfsession/create"
-	OffiaKFSessionClose  = "https://api.weixin.qq.com/customservice/kfsession/close"
-	OffiaKFSessionGet    = "https://api.weixin.qq.com/customservice/kfsession/getsession"
-	OffiaKFSessionList   = "https://api.weixin.qq.com/customservice/kfsession/getsessionlist"
-	OffiaKFWaitCase      = "https://api.weixin.qq.com/customservice/kfsession/getwaitcase"
-	OffiaKFMsgRecordList = "https://api.weixin.qq.com/customservice/msgrecord/getmsglist"
-	OffiaKFMsgSend       = "https://api.weixin.qq.com/cgi-bin/message/custom/send"
-	OffiaSetTyping       = "https://api.weixin.qq.com/cgi-bin/message/custom/typing"
+	OffiaKFAccountList   = "/cgi-bin/customservice/getkflist"
+	OffiaKFOnlineList    = "/cgi-bin/customservice/getonlinekflist"
+	OffiaKFAccountAdd    = "/customservice/kfaccount/add"
+	OffiaKFInvite        = "/customservice/kfaccount/inviteworker"
+	OffiaKFAccountUpdate = "/customservice/kfaccount/update"
+	OffiaKFAvatarUpload  = "/customservice/kfaccount/uploadheadimg"
+	OffiaKFDelete        = "/customservice/kfaccount/del"
+	OffiaKFSessionCreate = "/customservice/kfsession/create"
+	OffiaKFSessionClose  = "/customservice/kfsession/close"
+	OffiaKFSessionGet    = "/customservice/kfsession/getsession"
+	OffiaKFSessionList   = "/customservice/kfsession/getsessionlist"
+	OffiaKFWaitCase      = "/customservice/kfsession/getwaitcase"
+	OffiaKFMsgRecordList = "/customservice/msgrecord/getmsglist"
+	OffiaKFMsgSend       = "/cgi-bin/message/custom/send"
+	OffiaSetTyping       = "/cgi-bin/message/custom/typing"
 )
 
 // subscribe
 const (
-	OffiaSubscribeAddTemplate            = "https://api.weixin.qq.com/wxaapi/newtmpl/addtemplate"
-	OffiaSubscribeDeleteTemplate         = "https://api.weixin.qq.com/wxaapi/newtmpl/deltemplate"
-	OffiaSubscribeGetCategory            = "https://api.weixin.qq.com/wxaapi/newtmpl/getcategory"
-	OffiaSubscribeGetPubTemplateKeywords = "https://api.weixin.qq.com/wxaapi/newtmpl/getpubtemplatekeywords"
-	OffiaSubscribeGetPubTemplateTitles   = "https://api.weixin.qq.com/wxaapi/newtmpl/getpubtemplatetitles"
-	OffiaSubscribeGetTemplateList        = "https://api.weixin.qq.com/wxaapi/newtmpl/gettemplate"
-	OffiaSubscribeMsgBizSend             = "https://api.weixin.qq.com/cgi-bin/message/subscribe/bizsend"
+	OffiaSubscribeAddTemplate            = "/wxaapi/newtmpl/addtemplate"
+	OffiaSubscribeDeleteTemplate         = "/wxaapi/newtmpl/deltemplate"
+	OffiaSubscribeGetCategory            = "/wxaapi/newtmpl/getcategory"
+	OffiaSubscribeGetPubTemplateKeywords = "/wxaapi/newtmpl/getpubtemplatekeywords"
+	OffiaSubscribeGetPubTemplateTitles   = "/wxaapi/newtmpl/getpubtemplatetitles"
+	OffiaSubscribeGetTemplateList        = "/wxaapi/newtmpl/gettemplate"
+	OffiaSubscribeMsgBizSend             = "/cgi-bin/message/subscribe/bizsend"
 )
 
 // draft
 const (
-	OffiaDraftAdd      = "https://api.weixin.qq.com/cgi-bin/draft/add"
-	OffiaDraftGet      = "https://api.weixin.qq.com/cgi-bin/draft/get"
-	OffiaDraftDelete   = "https://api.weixin.qq.com/cgi-bin/draft/delete"
-	OffiaDraftUpdate   = "https://api.weixin.qq.com/cgi-bin/draft/update"
-	OffiaDraftCount    = "https://api.weixin.qq.com/cgi-bin/draft/count"
-	OffiaDraftBatchGet = "https://api.weixin.qq.com/cgi-bin/draft/batchget"
-	OffiaDraftSwitch   = "https://api.weixin.qq.com/cgi-bin/draft/switch"
+	OffiaDraftAdd      = "/cgi-bin/draft/add"
+	OffiaDraftGet      = "/cgi-bin/draft/get"
+	OffiaDraftDelete   = "/cgi-bin/draft/delete"
+	OffiaDraftUpdate   = "/cgi-bin/draft/update"
+	OffiaDraftCount    = "/cgi-bin/draft/count"
+	OffiaDraftBatchGet = "/cgi-bin/draft/batchget"
+	OffiaDraftSwitch   = "/cgi-bin/draft/switch"
 )
 
 // publish
 const (
-	OffiaPublishSubmit     = "https://api.weixin.qq.com/cgi-bin/freepublish/submit"
-	OffiaPublishGet        = "https://api.weixin.qq.com/cgi-bin/freepublish/get"
-	OffiaPublishDelete     = "https://api.weixin.qq.com/cgi-bin/freepublish/delete"
-	OffiaPublishGetArticle = "https://api.weixin.qq.com/cgi-bin/freepublish/getarticle"
-	OffiaPublishBatchGet   = "https://api.weixin.qq.com/cgi-bin/freepublish/batchget"
+	OffiaPublishSubmit     = "/cgi-bin/freepublish/submit"
+	OffiaPublishGet        = "/cgi-bin/freepublish/get"
+	OffiaPublishDelete     = "/cgi-bin/freepublish/delete"
+	OffiaPublishGetArticle = "/cgi-bin/freepublish/getarticle"
+	OffiaPublishBatchGet   = "/cgi-bin/freepublish/batchget"
+)
+
+// mass
+const (
+	OffiaMassSendAll = "/cgi-bin/message/mass/sendall"
+	OffiaMassGet     = "/cgi-bin/message/mass/get"
+)
+
+// openapi
+const (
+	OffiaClearQuota  = "/cgi-bin/clear_quota"
+	OffiaGetAPIQuota = "/cgi-bin/openapi/quota/get"
+	OffiaGetRid      = "/cgi-bin/openapi/rid/get"
+)
+
+// invoice
+const (
+	OffiaInvoiceSetBizAttr          = "/card/invoice/setbizattr"
+	OffiaInvoiceGetAuthURL          = "/card/invoice/getauthurl"
+	OffiaInvoiceGetAuthData         = "/card/invoice/getauthdata"
+	OffiaInvoiceInsert              = "/card/invoice/insert"
+	OffiaInvoiceReimburseGetInfo    = "/card/invoice/reimburse/getinvoiceinfo"
+	OffiaInvoiceReimburseUpdateStat = "/card/invoice/reimburse/updateinvoicestatus"
+)
+
+// security
+const (
+	OffiaMsgSecCheck = "/wxa/msg_sec_check"
+	OffiaImgSecCheck = "/wxa/img_sec_check"
+)
+
+// comment
+const (
+	OffiaCommentOpen        = "/cgi-bin/comment/open"
+	OffiaCommentClose       = "/cgi-bin/comment/close"
+	OffiaCommentList        = "/cgi-bin/comment/list"
+	OffiaCommentMarkElect   = "/cgi-bin/comment/markelect"
+	OffiaCommentUnmarkElect = "/cgi-bin/comment/unmarkelect"
+	OffiaCommentDelete      = "/cgi-bin/comment/delete"
+	OffiaCommentReplyAdd    = "/cgi-bin/comment/reply/add"
+	OffiaCommentReplyDelete = "/cgi-bin/comment/reply/delete"
 )
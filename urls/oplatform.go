@@ -1,39 +1,103 @@
-/*
-@Time : 2021/8/16 5:09 下午
-@Author : 21
-@File : consts
-@Software: GoLand
-*/
 package urls
 
-const  (
-	BaseUrl = "https://mp.weixin.qq.com"
+// component
+const (
+	OplatformComponentToken         = "/cgi-bin/component/api_component_token"                // 获取令牌
+	OplatformPreAuthCode            = "/cgi-bin/component/api_create_preauthcode"             // 获取预授权码
+	OplatformQueryAuth              = "/cgi-bin/component/api_query_auth"                     // 使用授权码获取授权信息
+	OplatformAuthorizerInfo         = "/cgi-bin/component/api_get_authorizer_info"            // 获取授权方的帐号基本信息
+	OplatformAuthorizerToken        = "/cgi-bin/component/api_authorizer_token"               // 获取/刷新接口调用令牌
+	OplatformComponentLoginPage     = "https://mp.weixin.qq.com/cgi-bin/componentloginpage"   // PC端授权页
+	OplatformComponentBindComponent = "https://open.weixin.qq.com/wxaopen/safe/bindcomponent" // 移动端授权链接
+	OplatformStartPushTicket        = "/cgi-bin/component/api_start_push_ticket"              // 启动票据推送服务
 )
 
+// Deprecated: 请使用 Oplatform 前缀的常量
 const (
-	// 获取令牌
-	ComponentApiComponentTokenUrl = "https://api.weixin.qq.com/cgi-bin/component/api_component_token"
-	// 获取预授权码
-	ComponentApiCreatePreAuthCode = "https://api.weixin.qq.com/cgi-bin/component/api_create_preauthcode"
-	// 使用授权码获取授权信息
-	ComponentApiQueryAuthUrl = "https://api.weixin.qq.com/cgi-bin/component/api_query_auth"
-	// 获取授权方的帐号基本信息
-	ComponentApiGetAuthorizerInfoUrl = "https://api.weixin.qq.com/cgi-bin/component/api_get_authorizer_info"
-	// 获取/刷新接口调用令牌
-	ComponentApiGetAuthorizerTokenUrl = "https://api.weixin.qq.com/cgi-bin/component/api_authorizer_token"
+	BaseUrl                           = "https://mp.weixin.qq.com"
+	ComponentApiComponentTokenUrl     = OplatformComponentToken
+	ComponentApiCreatePreAuthCode     = OplatformPreAuthCode
+	ComponentApiQueryAuthUrl          = OplatformQueryAuth
+	ComponentApiGetAuthorizerInfoUrl  = OplatformAuthorizerInfo
+	ComponentApiGetAuthorizerTokenUrl = OplatformAuthorizerToken
+	WxopenWxamplinkUrl                = "/cgi-bin/wxopen/wxamplink"    // 关联小程序
+	WxopenWxamplinkGetUrl             = "/cgi-bin/wxopen/wxamplinkget" // 获取公众号关联的小程序
+	OaMediaUpload                     = "/cgi-bin/media/upload"        // 图文消息内的图片获取URL
+	OaAddMaterial                     = "/cgi-bin/media/add_material"  // 图文永久素材
 )
 
-const   (
-	//关联小程序
-	WxopenWxamplinkUrl = "https://api.weixin.qq.com/cgi-bin/wxopen/wxamplink"
-	//获取公众号关联的小程序
-	WxopenWxamplinkGetUrl = "https://api.weixin.qq.com/cgi-bin/wxopen/wxamplinkget"
+// authorizer
+const (
+	OplatformGetAuthorizerOption = "/cgi-bin/component/api_get_authorizer_option" // 获取授权方选项信息
+	OplatformSetAuthorizerOption = "/cgi-bin/component/api_set_authorizer_option" // 设置授权方选项信息
 )
 
-const  (
-	// 图文消息内的图片获取URL
-	OaMediaUpload = "https://api.weixin.qq.com/cgi-bin/media/upload"
-	// 图文永久素材
-	OaAddMaterial = "https://api.weixin.qq.com/cgi-bin/media/add_material"
+// code management
+const (
+	OplatformWxaCommit               = "/wxa/commit"                 // 上传小程序代码
+	OplatformWxaGetQRCode            = "/wxa/get_qrcode"             // 获取体验版二维码
+	OplatformWxaGetPage              = "/wxa/get_page"               // 获取已上传的代码的页面列表
+	OplatformWxaGetCategory          = "/wxa/get_category"           // 获取审核时可填写的类目信息
+	OplatformWxaSubmitAudit          = "/wxa/submit_audit"           // 提交审核
+	OplatformWxaGetAuditStatus       = "/wxa/get_auditstatus"        // 查询指定版本的审核状态
+	OplatformWxaGetLatestAuditStatus = "/wxa/get_latest_auditstatus" // 查询最新一次提交的审核状态
+	OplatformWxaUndoCodeAudit        = "/wxa/undocodeaudit"          // 小程序审核撤回
+	OplatformWxaRelease              = "/wxa/release"                // 发布已通过审核的小程序
+	OplatformWxaRevertCodeRelease    = "/wxa/revertcoderelease"      // 版本回退
+	OplatformWxaGrayRelease          = "/wxa/grayrelease"            // 分阶段发布
+	OplatformWxaGetGrayReleasePlan   = "/wxa/getgrayreleaseplan"     // 查询当前分阶段发布详情
+	OplatformWxaRevertGrayRelease    = "/wxa/revertgrayrelease"      // 取消分阶段发布
+)
+
+// domain
+const (
+	OplatformWxaModifyDomain             = "/wxa/modify_domain"             // 设置服务器域名
+	OplatformWxaModifyDomainDirectly     = "/wxa/modify_domain_directly"    // 快速配置小程序服务器域名
+	OplatformWxaSetWebviewDomain         = "/wxa/setwebviewdomain"          // 设置业务域名
+	OplatformWxaSetWebviewDomainDirectly = "/wxa/setwebviewdomain_directly" // 快速配置小程序业务域名
+)
+
+// tester
+const (
+	OplatformWxaBindTester   = "/wxa/bind_tester"   // 绑定体验者
+	OplatformWxaUnbindTester = "/wxa/unbind_tester" // 解除绑定体验者
+	OplatformWxaMemberAuth   = "/wxa/memberauth"    // 获取体验者列表
+)
+
+// basic info
+const (
+	OplatformWxaSetNickname   = "/wxa/setnickname"                 // 设置名称
+	OplatformWxaQueryNickname = "/wxa/api_wxa_querynickname"       // 查询改名审核状态
+	OplatformModifyHeadImage  = "/cgi-bin/account/modifyheadimage" // 修改头像
+	OplatformModifySignature  = "/cgi-bin/account/modifysignature" // 修改简介
+)
 
-)
\ No newline at end of file
+// category
+const (
+	OplatformWxopenGetCategory = "/cgi-bin/wxopen/getcategory"    // 获取已设置的所有类目
+	OplatformWxopenAddCategory = "/cgi-bin/wxopen/addcategory"    // 添加类目
+	OplatformWxopenDelCategory = "/cgi-bin/wxopen/deletecategory" // 删除类目
+)
+
+// beta weapp
+const (
+	OplatformWxaVerifyBetaWeapp      = "/wxa/verifybetaweapp"      // 试用小程序快速认证
+	OplatformWxaSetBetaWeappNickname = "/wxa/setbetaweappnickname" // 修改试用小程序名称
+)
+
+// open account
+const (
+	OplatformOpenCreate = "/cgi-bin/open/create" // 创建开放平台帐号并绑定公众号/小程序
+	OplatformOpenBind   = "/cgi-bin/open/bind"   // 将公众号/小程序绑定到开放平台帐号下
+	OplatformOpenUnbind = "/cgi-bin/open/unbind" // 将公众号/小程序从开放平台帐号下解绑
+	OplatformOpenGet    = "/cgi-bin/open/get"    // 获取公众号/小程序所绑定的开放平台帐号
+)
+
+// authorizer list
+const OplatformAuthorizerList = "/cgi-bin/component/api_get_authorizer_list" // 拉取所有已授权的帐号信息
+
+// component oauth
+const (
+	OplatformSnsComponentAccessToken  = "/sns/oauth2/component/access_token"  // 代公众号通过 code 换取 access_token
+	OplatformSnsComponentRefreshToken = "/sns/oauth2/component/refresh_token" // 代公众号刷新 access_token
+)
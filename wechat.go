@@ -3,8 +3,11 @@ package gochat
 import (
 	"github.com/shenghui0779/gochat/corp"
 	"github.com/shenghui0779/gochat/mch"
+	"github.com/shenghui0779/gochat/mchv3"
 	"github.com/shenghui0779/gochat/minip"
 	"github.com/shenghui0779/gochat/offia"
+	"github.com/shenghui0779/gochat/oplatform"
+	"github.com/shenghui0779/gochat/wx"
 )
 
 // NewMch 微信商户
@@ -12,6 +15,11 @@ func NewMch(mchid, apikey string, options ...mch.Option) *mch.Mch {
 	return mch.New(mchid, apikey, options...)
 }
 
+// NewMchV3 微信商户（支付v3）
+func NewMchV3(mchid, apikey, serialno string, prvkey *wx.PrivateKey, options ...mchv3.Option) *mchv3.Mch {
+	return mchv3.New(mchid, apikey, serialno, prvkey, options...)
+}
+
 // NewOffia 微信公众号
 func NewOffia(appid, appsecret string, options ...offia.Option) *offia.Offia {
 	return offia.New(appid, appsecret, options...)
@@ -22,6 +30,11 @@ func NewMinip(appid, appsecret string, options ...minip.Option) *minip.Minip {
 	return minip.New(appid, appsecret, options...)
 }
 
+// NewOplatform 微信开放平台（第三方平台）
+func NewOplatform(appid, appsecret string, options ...oplatform.Option) *oplatform.Oplatform {
+	return oplatform.New(appid, appsecret, options...)
+}
+
 // NewCorp 企业微信
 func NewCorp(corpid string, options ...corp.Option) *corp.Corp {
 	return corp.New(corpid, options...)
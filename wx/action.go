@@ -4,14 +4,18 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 )
 
 // Action is the interface that handle wechat api
+//
+// Action 构造后不可变，同一个 Action 可在多个 goroutine 中并发执行（如：多个应用共用同一个 Action）；
+// 注意：Decode 会写入构造时传入的 result，并发执行带 result 的 Action 时请为每次调用分别构造
 type Action interface {
 	// Method returns action method
 	Method() string
 
-	// URL returns request url
+	// URL returns request url (不修改 Action 自身)
 	URL(accessToken ...string) string
 
 	// WXML returns body for xml request
@@ -33,14 +37,82 @@ type Action interface {
 	IsTLS() bool
 }
 
+// HeaderAction is the optional interface implemented by actions that specify request headers
+type HeaderAction interface {
+	// Headers returns the headers for the request (只读，不可修改)
+	Headers() map[string]string
+}
+
+// SignerAction is the optional interface implemented by actions that sign the request
+type SignerAction interface {
+	// Signer returns the signer for the request
+	Signer() Signer
+}
+
+// ActionHeaders returns the headers of action, nil if action does not implement HeaderAction
+func ActionHeaders(action Action) map[string]string {
+	if a, ok := action.(HeaderAction); ok {
+		return a.Headers()
+	}
+
+	return nil
+}
+
+// SignRequest the request to sign, signer can modify the url and set headers
+type SignRequest struct {
+	Method string
+	URL    string
+	Body   []byte // 上传请求时为 nil
+	Header map[string]string
+}
+
+// Signer signs the request (如：附加签名 query 参数或签名 header)
+type Signer func(req *SignRequest) error
+
+// SignAction signs the request with the signer of action, returns the signed url and options.
+// 签名 header 优先级最高；action 未实现 SignerAction 或未设置 Signer 时原样返回
+func SignAction(action Action, reqURL string, body []byte, options []HTTPOption) (string, []HTTPOption, error) {
+	sa, ok := action.(SignerAction)
+
+	if !ok {
+		return reqURL, options, nil
+	}
+
+	signer := sa.Signer()
+
+	if signer == nil {
+		return reqURL, options, nil
+	}
+
+	req := &SignRequest{
+		Method: action.Method(),
+		URL:    reqURL,
+		Body:   body,
+		Header: make(map[string]string),
+	}
+
+	if err := signer(req); err != nil {
+		return "", nil, err
+	}
+
+	if len(req.Header) != 0 {
+		options = append(options, WithHTTPHeaders(req.Header))
+	}
+
+	return req.URL, options, nil
+}
+
 type action struct {
 	method     string
 	reqURL     string
+	baseURL    string
 	query      url.Values
 	wxml       func(mchid, apikey, nonce string) (WXML, error)
 	body       func() ([]byte, error)
 	uploadform func() (UploadForm, error)
 	decode     func(b []byte) error
+	headers    map[string]string
+	signer     Signer
 	upload     bool
 	tls        bool
 }
@@ -50,15 +122,60 @@ func (a *action) Method() string {
 }
 
 func (a *action) URL(accessToken ...string) string {
+	reqURL := a.endpoint()
+
+	if len(accessToken) == 0 && len(a.query) == 0 {
+		return reqURL
+	}
+
+	// 复制 query，保证并发调用时 action 不被修改
+	query := make(url.Values, len(a.query)+1)
+
+	for k, v := range a.query {
+		query[k] = v
+	}
+
 	if len(accessToken) != 0 {
-		a.query.Set("access_token", accessToken[0])
+		query.Set("access_token", accessToken[0])
 	}
 
-	if len(a.query) == 0 {
+	return fmt.Sprintf("%s?%s", reqURL, query.Encode())
+}
+
+// endpoint returns the request url with base url applied
+func (a *action) endpoint() string {
+	if len(a.baseURL) == 0 {
 		return a.reqURL
 	}
 
-	return fmt.Sprintf("%s?%s", a.reqURL, a.query.Encode())
+	path := a.reqURL
+
+	if u, err := url.Parse(a.reqURL); err == nil && len(u.Host) != 0 {
+		path = u.RequestURI()
+	}
+
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	return JoinURL(a.baseURL, path)
+}
+
+// 微信接口默认域名，各产品客户端可通过 WithBaseURL 切换（如：备用域名 https://api2.weixin.qq.com）
+const (
+	BaseURLAPI    = "https://api.weixin.qq.com"     // 公众号、小程序、第三方平台
+	BaseURLQYAPI  = "https://qyapi.weixin.qq.com"   // 企业微信
+	BaseURLMchAPI = "https://api.mch.weixin.qq.com" // 微信支付
+)
+
+// JoinURL 请求地址为路径（如：/cgi-bin/token）时拼接在 base（协议与域名）之后；
+// 完整地址（如：Action 已通过 WithBaseURL 指定域名）原样返回
+func JoinURL(base, reqURL string) string {
+	if !strings.HasPrefix(reqURL, "/") {
+		return reqURL
+	}
+
+	return strings.TrimSuffix(base, "/") + reqURL
 }
 
 func (a *action) WXML(mchid, apikey, nonce string) (WXML, error) {
@@ -101,6 +218,14 @@ func (a *action) IsTLS() bool {
 	return a.tls
 }
 
+func (a *action) Headers() map[string]string {
+	return a.headers
+}
+
+func (a *action) Signer() Signer {
+	return a.signer
+}
+
 // ActionOption configures how we set up the action
 type ActionOption func(a *action)
 
@@ -111,6 +236,14 @@ func WithQuery(key, value string) ActionOption {
 	}
 }
 
+// WithBaseURL sets base url for action (如：https://api2.weixin.qq.com).
+// 替换请求地址的协议与域名，请求地址为路径（如：/cgi-bin/token）时拼接在 base 之后，优先于客户端设置的接口域名
+func WithBaseURL(base string) ActionOption {
+	return func(a *action) {
+		a.baseURL = strings.TrimSuffix(base, "/")
+	}
+}
+
 // WithBody sets post body for action.
 func WithBody(f func() ([]byte, error)) ActionOption {
 	return func(a *action) {
@@ -140,6 +273,20 @@ func WithDecode(f func(b []byte) error) ActionOption {
 	}
 }
 
+// WithHeader sets header for action (如：User-Agent、链路追踪 header).
+func WithHeader(key, value string) ActionOption {
+	return func(a *action) {
+		a.headers[key] = value
+	}
+}
+
+// WithSigner sets signer for action.
+func WithSigner(s Signer) ActionOption {
+	return func(a *action) {
+		a.signer = s
+	}
+}
+
 // WithTLS sets request with tls for action.
 func WithTLS() ActionOption {
 	return func(a *action) {
@@ -150,9 +297,10 @@ func WithTLS() ActionOption {
 // NewAction returns a new action
 func NewAction(method string, reqURL string, options ...ActionOption) Action {
 	a := &action{
-		method: method,
-		reqURL: reqURL,
-		query:  url.Values{},
+		method:  method,
+		reqURL:  reqURL,
+		query:   url.Values{},
+		headers: make(map[string]string),
 	}
 
 	for _, f := range options {
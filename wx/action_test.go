@@ -0,0 +1,142 @@
+package wx
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignAction(t *testing.T) {
+	action := NewPostAction("https://api.weixin.qq.com/xpay/query_user_balance",
+		WithBody(func() ([]byte, error) {
+			return []byte(`{"openid":"OPENID"}`), nil
+		}),
+		WithSigner(func(req *SignRequest) error {
+			req.URL += "&pay_sig=" + HMacSHA256("requestUri&"+string(req.Body), "APPKEY")
+			req.Header["X-Signature"] = req.Method
+
+			return nil
+		}),
+	)
+
+	body, err := action.Body()
+
+	assert.Nil(t, err)
+
+	options := []HTTPOption{WithHTTPClose()}
+
+	reqURL, options, err := SignAction(action, action.URL("ACCESS_TOKEN"), body, options)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "https://api.weixin.qq.com/xpay/query_user_balance?access_token=ACCESS_TOKEN&pay_sig="+HMacSHA256(`requestUri&{"openid":"OPENID"}`, "APPKEY"), reqURL)
+	assert.Equal(t, 2, len(options))
+
+	setting := &httpSetting{headers: make(map[string]string)}
+
+	for _, f := range options {
+		f(setting)
+	}
+
+	assert.Equal(t, http.MethodPost, setting.headers["X-Signature"])
+
+	// 未设置 Signer
+	reqURL, options, err = SignAction(NewGetAction("https://api.weixin.qq.com/cgi-bin/get_api_domain_ip"), "URL", nil, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "URL", reqURL)
+	assert.Nil(t, options)
+
+	// 签名失败
+	_, _, err = SignAction(NewGetAction("URL", WithSigner(func(req *SignRequest) error {
+		return errors.New("sign failed")
+	})), "URL", nil, nil)
+
+	assert.EqualError(t, err, "sign failed")
+}
+
+// plainAction 外部实现的 Action（未实现 HeaderAction、SignerAction）
+type plainAction struct{}
+
+func (a *plainAction) Method() string                                 { return http.MethodGet }
+func (a *plainAction) URL(accessToken ...string) string               { return "URL" }
+func (a *plainAction) WXML(mchid, apikey, nonce string) (WXML, error) { return nil, nil }
+func (a *plainAction) Body() ([]byte, error)                          { return nil, nil }
+func (a *plainAction) UploadForm() (UploadForm, error)                { return nil, nil }
+func (a *plainAction) Decode(b []byte) error                          { return nil }
+func (a *plainAction) IsUpload() bool                                 { return false }
+func (a *plainAction) IsTLS() bool                                    { return false }
+
+func TestOptionalActionInterfaces(t *testing.T) {
+	var action Action = new(plainAction)
+
+	assert.Nil(t, ActionHeaders(action))
+
+	reqURL, options, err := SignAction(action, "URL", nil, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "URL", reqURL)
+	assert.Nil(t, options)
+
+	assert.Equal(t, map[string]string{"X-Trace-Id": "TRACE"}, ActionHeaders(NewGetAction("URL", WithHeader("X-Trace-Id", "TRACE"))))
+}
+
+func TestActionConcurrentURL(t *testing.T) {
+	action := NewGetAction("https://api.weixin.qq.com/cgi-bin/user/info",
+		WithQuery("openid", "OPENID"),
+		WithQuery("lang", "zh_CN"),
+	)
+
+	var wg sync.WaitGroup
+
+	urls := make([]string, 20)
+
+	for i := range urls {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			urls[i] = action.URL(fmt.Sprintf("ACCESS_TOKEN_%d", i))
+		}(i)
+	}
+
+	wg.Wait()
+
+	for i, v := range urls {
+		assert.Equal(t, fmt.Sprintf("https://api.weixin.qq.com/cgi-bin/user/info?access_token=ACCESS_TOKEN_%d&lang=zh_CN&openid=OPENID", i), v)
+	}
+
+	// access_token 不会残留在 action 中
+	assert.Equal(t, "https://api.weixin.qq.com/cgi-bin/user/info?lang=zh_CN&openid=OPENID", action.URL())
+	assert.Equal(t, "https://api.weixin.qq.com/cgi-bin/token", NewGetAction("https://api.weixin.qq.com/cgi-bin/token").URL())
+}
+
+func TestWithBaseURL(t *testing.T) {
+	// 替换域名
+	action := NewGetAction("https://api.weixin.qq.com/cgi-bin/user/info",
+		WithBaseURL("https://api2.weixin.qq.com/"),
+		WithQuery("openid", "OPENID"),
+	)
+
+	assert.Equal(t, "https://api2.weixin.qq.com/cgi-bin/user/info?openid=OPENID", action.URL())
+	assert.Equal(t, "https://api2.weixin.qq.com/cgi-bin/user/info?access_token=ACCESS_TOKEN&openid=OPENID", action.URL("ACCESS_TOKEN"))
+
+	// 路径拼接
+	assert.Equal(t, "https://sh.api.weixin.qq.com/cgi-bin/token", NewGetAction("/cgi-bin/token", WithBaseURL("https://sh.api.weixin.qq.com")).URL())
+	assert.Equal(t, "https://sh.api.weixin.qq.com/cgi-bin/token", NewGetAction("cgi-bin/token", WithBaseURL("https://sh.api.weixin.qq.com")).URL())
+
+	// 未设置时原样返回
+	assert.Equal(t, "https://api.weixin.qq.com/cgi-bin/token", NewGetAction("https://api.weixin.qq.com/cgi-bin/token").URL())
+}
+
+func TestJoinURL(t *testing.T) {
+	assert.Equal(t, "https://api.weixin.qq.com/cgi-bin/token", JoinURL(BaseURLAPI, "/cgi-bin/token"))
+	assert.Equal(t, "https://api2.weixin.qq.com/cgi-bin/token", JoinURL("https://api2.weixin.qq.com/", "/cgi-bin/token"))
+
+	// 完整地址原样返回
+	assert.Equal(t, "https://open.weixin.qq.com/connect/oauth2/authorize", JoinURL(BaseURLAPI, "https://open.weixin.qq.com/connect/oauth2/authorize"))
+}
@@ -0,0 +1,73 @@
+package wx
+
+import (
+	"context"
+	"fmt"
+)
+
+// ChainStep 构建链式调用的下一个 Action，执行到该步骤时才调用，可使用之前 Action 的结果
+type ChainStep func(ctx context.Context) (Action, error)
+
+// ChainError 链式调用中某一步骤的错误
+type ChainError struct {
+	Step int // 出错的步骤（从0开始）
+	Err  error
+}
+
+func (e *ChainError) Error() string {
+	return fmt.Sprintf("chain step %d: %s", e.Step, e.Err.Error())
+}
+
+func (e *ChainError) Unwrap() error {
+	return e.Err
+}
+
+// Chain 依次执行一组 Action（如：先上传素材，再使用返回的 media_id 发送消息），任一步骤出错即终止
+type Chain struct {
+	do    func(ctx context.Context, action Action) error
+	steps []ChainStep
+}
+
+// Then 添加 Action
+func (c *Chain) Then(action Action) *Chain {
+	return c.ThenFunc(func(ctx context.Context) (Action, error) {
+		return action, nil
+	})
+}
+
+// ThenFunc 添加依赖之前结果的 Action
+func (c *Chain) ThenFunc(step ChainStep) *Chain {
+	c.steps = append(c.steps, step)
+
+	return c
+}
+
+// Run 执行链式调用，出错时返回 *ChainError
+func (c *Chain) Run(ctx context.Context) error {
+	for i, step := range c.steps {
+		if err := ctx.Err(); err != nil {
+			return &ChainError{Step: i, Err: err}
+		}
+
+		action, err := step(ctx)
+
+		if err != nil {
+			return &ChainError{Step: i, Err: err}
+		}
+
+		if err = c.do(ctx, action); err != nil {
+			return &ChainError{Step: i, Err: err}
+		}
+	}
+
+	return nil
+}
+
+// NewChain returns new action chain, do 为执行 Action 的方法，如：
+//
+//	wx.NewChain(func(ctx context.Context, action wx.Action) error {
+//		return oa.Do(ctx, accessToken, action)
+//	})
+func NewChain(do func(ctx context.Context, action Action) error) *Chain {
+	return &Chain{do: do}
+}
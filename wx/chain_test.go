@@ -0,0 +1,92 @@
+package wx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockChainResult struct {
+	MediaID string `json:"media_id"`
+}
+
+func TestChain(t *testing.T) {
+	responses := map[string][]byte{
+		"https://api.weixin.qq.com/cgi-bin/media/upload":        []byte(`{"media_id":"MEDIA_ID"}`),
+		"https://api.weixin.qq.com/cgi-bin/message/custom/send": []byte(`{"errcode":0,"errmsg":"ok"}`),
+	}
+
+	body := ""
+
+	chain := NewChain(func(ctx context.Context, action Action) error {
+		b, err := action.Body()
+
+		if err != nil {
+			return err
+		}
+
+		if len(b) != 0 {
+			body = string(b)
+		}
+
+		return action.Decode(responses[action.URL()])
+	})
+
+	result := new(mockChainResult)
+
+	err := chain.
+		Then(NewPostAction("https://api.weixin.qq.com/cgi-bin/media/upload", WithDecode(func(b []byte) error {
+			return json.Unmarshal(b, result)
+		}))).
+		ThenFunc(func(ctx context.Context) (Action, error) {
+			return NewPostAction("https://api.weixin.qq.com/cgi-bin/message/custom/send", WithBody(func() ([]byte, error) {
+				return []byte(`{"media_id":"` + result.MediaID + `"}`), nil
+			})), nil
+		}).
+		Run(context.TODO())
+
+	assert.Nil(t, err)
+	assert.Equal(t, `{"media_id":"MEDIA_ID"}`, body)
+}
+
+func TestChainError(t *testing.T) {
+	count := 0
+
+	chain := NewChain(func(ctx context.Context, action Action) error {
+		count++
+
+		if count == 2 {
+			return errors.New("40007|invalid media_id")
+		}
+
+		return nil
+	})
+
+	for i := 0; i < 3; i++ {
+		chain.Then(NewGetAction("https://api.weixin.qq.com"))
+	}
+
+	err := chain.Run(context.TODO())
+
+	assert.Equal(t, 2, count)
+
+	var cerr *ChainError
+
+	assert.True(t, errors.As(err, &cerr))
+	assert.Equal(t, 1, cerr.Step)
+	assert.Equal(t, "40007|invalid media_id", cerr.Err.Error())
+
+	// 取消
+	ctx, cancel := context.WithCancel(context.Background())
+
+	cancel()
+
+	err = NewChain(func(ctx context.Context, action Action) error {
+		return nil
+	}).Then(NewGetAction("https://api.weixin.qq.com")).Run(ctx)
+
+	assert.True(t, errors.Is(err, context.Canceled))
+}
@@ -0,0 +1,84 @@
+package wx
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// 返回码说明表由 errcodes.json 生成（errcode_gen.go），新增返回码请修改 errcodes.json 后执行 go generate
+
+//go:generate go run ../internal/errcodegen -schema errcodes.json -package wx -output errcode_gen.go
+
+// ErrCodeDesc 返回码说明
+type ErrCodeDesc struct {
+	ZH string // 中文说明
+	EN string // 英文说明
+}
+
+// 返回码说明的语言
+const (
+	LangZH = "zh" // 中文
+	LangEN = "en" // 英文
+)
+
+// LookupErrCode 查询微信全局返回码说明
+func LookupErrCode(code int64) (*ErrCodeDesc, bool) {
+	desc, ok := errcodes[code]
+
+	return desc, ok
+}
+
+// APIError 微信接口返回的错误（errcode 不为0）
+type APIError struct {
+	Code int64
+	Msg  string
+}
+
+// NewAPIError returns new api error
+func NewAPIError(code int64, msg string) *APIError {
+	return &APIError{
+		Code: code,
+		Msg:  msg,
+	}
+}
+
+// Error 格式为 errcode|errmsg，已知返回码附带中文说明，如：40001|invalid credential（获取 access_token 时 AppSecret 错误，或者 access_token 无效）
+func (e *APIError) Error() string {
+	if desc, ok := errcodes[e.Code]; ok {
+		return fmt.Sprintf("%d|%s（%s）", e.Code, e.Msg, desc.ZH)
+	}
+
+	return fmt.Sprintf("%d|%s", e.Code, e.Msg)
+}
+
+// Desc 返回码说明，未知返回码时为空
+func (e *APIError) Desc() *ErrCodeDesc {
+	if desc, ok := errcodes[e.Code]; ok {
+		return desc
+	}
+
+	return new(ErrCodeDesc)
+}
+
+// Description 指定语言的返回码说明（LangZH、LangEN，兼容 zh_CN、en-US 等格式），其他语言返回中文说明，未知返回码时为空
+func (e *APIError) Description(lang string) string {
+	desc := e.Desc()
+
+	if strings.HasPrefix(strings.ToLower(lang), LangEN) {
+		return desc.EN
+	}
+
+	return desc.ZH
+}
+
+// IsAPIError 判断 err 是否为指定返回码的接口错误（支持 wrap 后的错误）
+func IsAPIError(err error, code int64) bool {
+	var apierr *APIError
+
+	if errors.As(err, &apierr) {
+		return apierr.Code == code
+	}
+
+	return false
+}
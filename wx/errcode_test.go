@@ -0,0 +1,46 @@
+package wx
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIError(t *testing.T) {
+	err := NewAPIError(40001, "invalid credential")
+
+	assert.Equal(t, "40001|invalid credential（获取 access_token 时 AppSecret 错误，或者 access_token 无效）", err.Error())
+	assert.Equal(t, "invalid credential, AppSecret is wrong or access_token is invalid", err.Desc().EN)
+	assert.Equal(t, "invalid credential, AppSecret is wrong or access_token is invalid", err.Description(LangEN))
+	assert.Equal(t, "invalid credential, AppSecret is wrong or access_token is invalid", err.Description("en-US"))
+	assert.Equal(t, "获取 access_token 时 AppSecret 错误，或者 access_token 无效", err.Description(LangZH))
+	assert.Equal(t, "获取 access_token 时 AppSecret 错误，或者 access_token 无效", err.Description("zh_CN"))
+
+	// 未知返回码
+	err = NewAPIError(99999999, "unknown error")
+
+	assert.Equal(t, "99999999|unknown error", err.Error())
+	assert.Equal(t, &ErrCodeDesc{}, err.Desc())
+	assert.Equal(t, "", err.Description(LangEN))
+}
+
+func TestIsAPIError(t *testing.T) {
+	err := fmt.Errorf("step 1: %w", NewAPIError(45009, "reach max api daily quota limit"))
+
+	assert.True(t, IsAPIError(err, 45009))
+	assert.False(t, IsAPIError(err, 45011))
+	assert.False(t, IsAPIError(errors.New("45009|reach max api daily quota limit"), 45009))
+}
+
+func TestLookupErrCode(t *testing.T) {
+	desc, ok := LookupErrCode(-1)
+
+	assert.True(t, ok)
+	assert.Equal(t, "系统繁忙，此时请开发者稍候再试", desc.ZH)
+
+	_, ok = LookupErrCode(0)
+
+	assert.False(t, ok)
+}
@@ -0,0 +1,118 @@
+package wx
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type fanOutSetting struct {
+	concurrency int
+	interval    time.Duration
+}
+
+// FanOutOption 分批并发配置项
+type FanOutOption func(s *fanOutSetting)
+
+// WithFanOutConcurrency 设置最大并发数（默认：1）
+func WithFanOutConcurrency(n int) FanOutOption {
+	return func(s *fanOutSetting) {
+		if n > 0 {
+			s.concurrency = n
+		}
+	}
+}
+
+// WithFanOutInterval 设置相邻两批请求的最小发起间隔，用于限制请求频率（默认：不限制）
+func WithFanOutInterval(d time.Duration) FanOutOption {
+	return func(s *fanOutSetting) {
+		s.interval = d
+	}
+}
+
+// FanOut 将 total 个元素按每批 size 个分批，并发执行 f 处理区间 [begin, end)；
+// 调用方按区间写入预分配的结果切片即可保持原有顺序；任一批返回错误则取消其余批次并返回首个错误
+func FanOut(ctx context.Context, total, size int, f func(ctx context.Context, begin, end int) error, options ...FanOutOption) error {
+	if total <= 0 {
+		return nil
+	}
+
+	if size <= 0 {
+		size = total
+	}
+
+	setting := &fanOutSetting{
+		concurrency: 1,
+	}
+
+	for _, fn := range options {
+		fn(setting)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg    sync.WaitGroup
+		once  sync.Once
+		ferr  error
+		slots = make(chan struct{}, setting.concurrency)
+		last  time.Time
+	)
+
+loop:
+	for begin := 0; begin < total; begin += size {
+		end := begin + size
+
+		if end > total {
+			end = total
+		}
+
+		if wait := setting.interval - time.Since(last); setting.interval > 0 && !last.IsZero() && wait > 0 {
+			select {
+			case <-time.After(wait):
+			case <-ctx.Done():
+				break loop
+			}
+		}
+
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			break loop
+		}
+
+		// 获取并发槽位时其他批次可能已失败
+		if ctx.Err() != nil {
+			<-slots
+
+			break
+		}
+
+		last = time.Now()
+
+		wg.Add(1)
+
+		go func(begin, end int) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+
+			if err := f(ctx, begin, end); err != nil {
+				once.Do(func() {
+					ferr = err
+					cancel()
+				})
+			}
+		}(begin, end)
+	}
+
+	wg.Wait()
+
+	if ferr != nil {
+		return ferr
+	}
+
+	return ctx.Err()
+}
@@ -0,0 +1,87 @@
+package wx
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFanOut(t *testing.T) {
+	items := make([]int, 250)
+	results := make([]int, len(items))
+
+	for i := range items {
+		items[i] = i
+	}
+
+	var (
+		running int32
+		maxRun  int32
+		batches int32
+	)
+
+	err := FanOut(context.TODO(), len(items), 100, func(ctx context.Context, begin, end int) error {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+
+		for {
+			m := atomic.LoadInt32(&maxRun)
+
+			if n <= m || atomic.CompareAndSwapInt32(&maxRun, m, n) {
+				break
+			}
+		}
+
+		atomic.AddInt32(&batches, 1)
+
+		time.Sleep(10 * time.Millisecond)
+
+		for i := begin; i < end; i++ {
+			results[i] = items[i] * 2
+		}
+
+		return nil
+	}, WithFanOutConcurrency(2))
+
+	assert.Nil(t, err)
+	assert.Equal(t, int32(3), batches)
+	assert.LessOrEqual(t, maxRun, int32(2))
+	assert.Equal(t, 498, results[249])
+	assert.Equal(t, 200, results[100])
+}
+
+func TestFanOutInterval(t *testing.T) {
+	starts := make([]time.Time, 3)
+
+	err := FanOut(context.TODO(), 3, 1, func(ctx context.Context, begin, end int) error {
+		starts[begin] = time.Now()
+
+		return nil
+	}, WithFanOutConcurrency(3), WithFanOutInterval(20*time.Millisecond))
+
+	assert.Nil(t, err)
+	assert.GreaterOrEqual(t, int64(starts[2].Sub(starts[0])), int64(40*time.Millisecond))
+}
+
+func TestFanOutError(t *testing.T) {
+	var batches int32
+
+	err := FanOut(context.TODO(), 10, 1, func(ctx context.Context, begin, end int) error {
+		atomic.AddInt32(&batches, 1)
+
+		if begin == 1 {
+			return errors.New("batch failed")
+		}
+
+		return nil
+	})
+
+	assert.EqualError(t, err, "batch failed")
+	assert.Equal(t, int32(2), batches)
+
+	assert.Nil(t, FanOut(context.TODO(), 0, 100, nil))
+}
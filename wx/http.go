@@ -11,15 +11,23 @@ import (
 	"mime/multipart"
 	"net"
 	"net/http"
+	"net/textproto"
 	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
 // httpSetting http request setting
 type httpSetting struct {
-	headers map[string]string
-	cookies []*http.Cookie
-	close   bool
+	headers   map[string]string
+	cookies   []*http.Cookie
+	close     bool
+	progress  UploadProgress
+	transport http.RoundTripper
+	header    func(header http.Header)
 }
 
 // HTTPOption configures how we set up the http request.
@@ -32,6 +40,32 @@ func WithHTTPHeader(key, value string) HTTPOption {
 	}
 }
 
+// WithHTTPHeaders specifies the headers to http request.
+func WithHTTPHeaders(headers map[string]string) HTTPOption {
+	return func(s *httpSetting) {
+		for k, v := range headers {
+			s.headers[k] = v
+		}
+	}
+}
+
+// PrependHTTPHeaders prepends the non-empty headers to options, so that headers specified by options take precedence.
+func PrependHTTPHeaders(options []HTTPOption, headers ...map[string]string) []HTTPOption {
+	prepends := make([]HTTPOption, 0, len(headers)+len(options))
+
+	for _, h := range headers {
+		if len(h) != 0 {
+			prepends = append(prepends, WithHTTPHeaders(h))
+		}
+	}
+
+	if len(prepends) == 0 {
+		return options
+	}
+
+	return append(prepends, options...)
+}
+
 // WithHTTPCookies specifies the cookies to http request.
 func WithHTTPCookies(cookies ...*http.Cookie) HTTPOption {
 	return func(s *httpSetting) {
@@ -48,6 +82,56 @@ func WithHTTPClose() HTTPOption {
 	}
 }
 
+// UploadProgress 上传进度回调，sent 为已发送的字节数，total 为请求 body 的总字节数
+type UploadProgress func(sent, total int64)
+
+// WithUploadProgress specifies the progress callback for upload request (如：大视频上传展示进度，或长时间无进度时取消 context).
+// The callback is invoked in the goroutine writing the request body, which may be different from the caller's.
+func WithUploadProgress(f UploadProgress) HTTPOption {
+	return func(s *httpSetting) {
+		s.progress = f
+	}
+}
+
+// RoundTripFunc is an adapter to allow the use of ordinary functions as http.RoundTripper.
+type RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req).
+func (f RoundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// WithRoundTripper specifies the transport for this request only, replacing the client's transport
+// (用于测试：在传输层拦截请求，校验最终发送的请求字节，如 multipart 及签名后的 body).
+func WithRoundTripper(rt http.RoundTripper) HTTPOption {
+	return func(s *httpSetting) {
+		s.transport = rt
+	}
+}
+
+// WithHTTPResponseHeader specifies the callback to receive the response header (如：微信支付v3 应答签名验证).
+// The callback is invoked once the response is received, before the body is read.
+func WithHTTPResponseHeader(f func(header http.Header)) HTTPOption {
+	return func(s *httpSetting) {
+		s.header = f
+	}
+}
+
+// maxErrorBody 非 2xx 应答最多读取的 body 大小
+const maxErrorBody = 64 << 10 // 64kb
+
+// HTTPStatusError is returned when the response status code >= 400, the body is kept for decoding the error detail
+// (如：微信支付v3 以 4xx 返回 {"code":"...","message":"..."}).
+type HTTPStatusError struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("unexpected status %d", e.StatusCode)
+}
+
 // UploadForm is the interface for http upload.
 type UploadForm interface {
 	// Write writes fields to multipart writer
@@ -64,8 +148,26 @@ type formfile struct {
 }
 
 type uploadform struct {
-	formfiles  []*formfile
-	formfields map[string]string
+	formfiles    []*formfile
+	formfields   map[string]string
+	contenttypes map[string]string
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func (f *uploadform) createFormFile(w *multipart.Writer, v *formfile) (io.Writer, error) {
+	contentType, ok := f.contenttypes[v.fieldname]
+
+	if !ok {
+		return w.CreateFormFile(v.fieldname, v.filename)
+	}
+
+	h := make(textproto.MIMEHeader)
+
+	h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, quoteEscaper.Replace(v.fieldname), quoteEscaper.Replace(v.filename)))
+	h.Set("Content-Type", contentType)
+
+	return w.CreatePart(h)
 }
 
 func (f *uploadform) Write(w *multipart.Writer) error {
@@ -73,20 +175,29 @@ func (f *uploadform) Write(w *multipart.Writer) error {
 		return errors.New("empty file field")
 	}
 
-	for _, v := range f.formfiles {
-		part, err := w.CreateFormFile(v.fieldname, v.filename)
+	// 普通字段（如：v3 媒体上传的 meta）先于文件写入，按字段名排序
+	names := make([]string, 0, len(f.formfields))
 
-		if err != nil {
+	for name := range f.formfields {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := w.WriteField(name, f.formfields[name]); err != nil {
 			return err
 		}
+	}
 
-		if err = v.filefunc(part); err != nil {
+	for _, v := range f.formfiles {
+		part, err := f.createFormFile(w, v)
+
+		if err != nil {
 			return err
 		}
-	}
 
-	for name, value := range f.formfields {
-		if err := w.WriteField(name, value); err != nil {
+		if err = v.filefunc(part); err != nil {
 			return err
 		}
 	}
@@ -108,6 +219,14 @@ func WithFormFile(fieldname, filename string, fn FormFileFunc) UploadField {
 	}
 }
 
+// WithFormFileContentType specifies the part Content-Type of the file field (默认：application/octet-stream),
+// 部分接口要求与文件类型一致，如：image/jpeg.
+func WithFormFileContentType(fieldname, contentType string) UploadField {
+	return func(u *uploadform) {
+		u.contenttypes[fieldname] = contentType
+	}
+}
+
 // WithFormField specifies the form field to upload from.
 func WithFormField(fieldname, fieldvalue string) UploadField {
 	return func(u *uploadform) {
@@ -118,8 +237,9 @@ func WithFormField(fieldname, fieldvalue string) UploadField {
 // NewUploadForm returns an upload form
 func NewUploadForm(fields ...UploadField) UploadForm {
 	form := &uploadform{
-		formfiles:  make([]*formfile, 0),
-		formfields: make(map[string]string),
+		formfiles:    make([]*formfile, 0),
+		formfields:   make(map[string]string),
+		contenttypes: make(map[string]string),
 	}
 
 	for _, f := range fields {
@@ -151,6 +271,10 @@ func (c *httpclient) Do(ctx context.Context, method, reqURL string, body []byte,
 		return nil, err
 	}
 
+	return c.do(ctx, req, options...)
+}
+
+func (c *httpclient) do(ctx context.Context, req *http.Request, options ...HTTPOption) ([]byte, error) {
 	setting := new(httpSetting)
 
 	if len(options) != 0 {
@@ -179,7 +303,16 @@ func (c *httpclient) Do(ctx context.Context, method, reqURL string, body []byte,
 		req.Close = true
 	}
 
-	resp, err := c.client.Do(req)
+	client := c.client
+
+	if setting.transport != nil {
+		cli := *c.client
+		cli.Transport = setting.transport
+
+		client = &cli
+	}
+
+	resp, err := client.Do(req)
 
 	if err != nil {
 		// If the context has been canceled, the context's error is probably more useful.
@@ -194,32 +327,143 @@ func (c *httpclient) Do(ctx context.Context, method, reqURL string, body []byte,
 
 	defer resp.Body.Close()
 
+	if setting.header != nil {
+		setting.header(resp.Header)
+	}
+
 	if resp.StatusCode >= http.StatusBadRequest {
+		b, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+
 		io.Copy(ioutil.Discard, resp.Body)
 
-		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+		return nil, &HTTPStatusError{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header,
+			Body:       b,
+		}
 	}
 
 	return ioutil.ReadAll(resp.Body)
 }
 
 func (c *httpclient) Upload(ctx context.Context, reqURL string, form UploadForm, options ...HTTPOption) ([]byte, error) {
-	buf := bytes.NewBuffer(make([]byte, 0, 20<<10)) // 20kb
+	buf := uploadBufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+
+	// buffer 由 Upload 及 Transport 共同持有，两者均释放后放回 pool
+	refs := int32(2)
+	body := &uploadBody{buf: buf, refs: &refs}
+	body.Reader = bytes.NewReader(buf.Bytes())
+
+	defer body.release()
+
 	w := multipart.NewWriter(buf)
 
 	if err := form.Write(w); err != nil {
+		body.Close()
+
 		return nil, err
 	}
 
-	options = append(options, WithHTTPHeader("Content-Type", w.FormDataContentType()))
-
 	// Don't forget to close the multipart writer.
 	// If you don't close it, your request will be missing the terminating boundary.
 	if err := w.Close(); err != nil {
+		body.Close()
+
 		return nil, err
 	}
 
-	return c.Do(ctx, http.MethodPost, reqURL, buf.Bytes(), options...)
+	body.Reader.Reset(buf.Bytes())
+
+	if len(options) != 0 {
+		setting := &httpSetting{headers: make(map[string]string)}
+
+		for _, f := range options {
+			f(setting)
+		}
+
+		body.progress = setting.progress
+		body.total = int64(buf.Len())
+	}
+
+	// body 由 Transport 负责关闭（可能在其他 goroutine 中，且晚于 do 返回）
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL, body)
+
+	if err != nil {
+		body.Close()
+
+		return nil, err
+	}
+
+	req.ContentLength = int64(buf.Len())
+
+	// 307/308 重定向或重试时，Transport 通过 GetBody 重新发送 body
+	req.GetBody = body.reopen
+
+	options = append(options, WithHTTPHeader("Content-Type", w.FormDataContentType()))
+
+	return c.do(ctx, req, options...)
+}
+
+// maxPooledUploadBuffer 超过该大小的 buffer 不放回 pool，避免长期占用内存
+const maxPooledUploadBuffer = 32 << 20 // 32mb
+
+var uploadBufferPool = sync.Pool{
+	New: func() interface{} {
+		return bytes.NewBuffer(make([]byte, 0, 20<<10)) // 20kb
+	},
+}
+
+// uploadBody 上传请求 body，复用 pool 中的 buffer 构建 multipart 数据，避免每次上传重新分配及扩容
+type uploadBody struct {
+	*bytes.Reader
+	buf      *bytes.Buffer
+	refs     *int32 // 同一 buffer 的所有 body 共享引用计数
+	once     sync.Once
+	progress UploadProgress
+	sent     int64
+	total    int64
+}
+
+func (b *uploadBody) Read(p []byte) (int, error) {
+	n, err := b.Reader.Read(p)
+
+	if n > 0 && b.progress != nil {
+		b.sent += int64(n)
+		b.progress(b.sent, b.total)
+	}
+
+	return n, err
+}
+
+func (b *uploadBody) Close() error {
+	b.once.Do(b.release)
+
+	return nil
+}
+
+// reopen returns a new body reading the same buffer from the start, which holds a reference of the buffer
+func (b *uploadBody) reopen() (io.ReadCloser, error) {
+	atomic.AddInt32(b.refs, 1)
+
+	return &uploadBody{
+		Reader:   bytes.NewReader(b.buf.Bytes()),
+		buf:      b.buf,
+		refs:     b.refs,
+		progress: b.progress,
+		total:    b.total,
+	}, nil
+}
+
+// release 释放对 buffer 的引用，全部释放后放回 pool
+func (b *uploadBody) release() {
+	if atomic.AddInt32(b.refs, -1) != 0 {
+		return
+	}
+
+	if b.buf.Cap() <= maxPooledUploadBuffer {
+		uploadBufferPool.Put(b.buf)
+	}
 }
 
 // NewHTTPClient returns a new http client
@@ -229,16 +473,84 @@ func NewHTTPClient(client *http.Client) HTTPClient {
 	}
 }
 
+// dialSetting dial setting
+type dialSetting struct {
+	network string
+	dialer  *net.Dialer
+}
+
+// DialOption configures how we dial the wechat hosts.
+type DialOption func(s *dialSetting)
+
+// WithIPv4Only specifies only dial the IPv4 address (双栈网络下强制使用 IPv4).
+func WithIPv4Only() DialOption {
+	return func(s *dialSetting) {
+		s.network = "tcp4"
+	}
+}
+
+// WithIPv6Only specifies only dial the IPv6 address.
+func WithIPv6Only() DialOption {
+	return func(s *dialSetting) {
+		s.network = "tcp6"
+	}
+}
+
+// WithResolver specifies the resolver to lookup the wechat hosts.
+func WithResolver(r *net.Resolver) DialOption {
+	return func(s *dialSetting) {
+		s.dialer.Resolver = r
+	}
+}
+
+// WithDNSServer specifies the dns server (如：内网 DNS 10.0.0.2:53) to lookup the wechat hosts.
+func WithDNSServer(addr string) DialOption {
+	return func(s *dialSetting) {
+		s.dialer.Resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				var d net.Dialer
+
+				return d.DialContext(ctx, network, addr)
+			},
+		}
+	}
+}
+
+// NewDialContext returns a dial function for http.Transport
+func NewDialContext(options ...DialOption) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	setting := &dialSetting{
+		dialer: &net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 60 * time.Second,
+		},
+	}
+
+	for _, f := range options {
+		f(setting)
+	}
+
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if len(setting.network) != 0 && strings.HasPrefix(network, "tcp") {
+			network = setting.network
+		}
+
+		return setting.dialer.DialContext(ctx, network, addr)
+	}
+}
+
 // NewDefaultClient returns a default http client
 func NewDefaultClient(certs ...tls.Certificate) HTTPClient {
+	return NewDialClient(nil, certs...)
+}
+
+// NewDialClient returns a default http client with the dial options
+func NewDialClient(options []DialOption, certs ...tls.Certificate) HTTPClient {
 	return &httpclient{
 		client: &http.Client{
 			Transport: &http.Transport{
-				Proxy: http.ProxyFromEnvironment,
-				DialContext: (&net.Dialer{
-					Timeout:   30 * time.Second,
-					KeepAlive: 60 * time.Second,
-				}).DialContext,
+				Proxy:       http.ProxyFromEnvironment,
+				DialContext: NewDialContext(options...),
 				TLSClientConfig: &tls.Config{
 					Certificates:       certs,
 					InsecureSkipVerify: true,
@@ -254,6 +566,35 @@ func NewDefaultClient(certs ...tls.Certificate) HTTPClient {
 	}
 }
 
+// ApplyDialOptions applies the dial options to the transport of client (须为 NewHTTPClient、NewDialClient 等创建的 HTTPClient),
+// 返回新的 HTTPClient，不修改原 client 及其 Transport；
+// Transport 为 nil 时基于 http.DefaultTransport，Transport 不是 *http.Transport（如：自定义 RoundTripper）或 client 为 Mock 时原样返回
+func ApplyDialOptions(client HTTPClient, options ...DialOption) HTTPClient {
+	c, ok := client.(*httpclient)
+
+	if !ok || len(options) == 0 {
+		return client
+	}
+
+	var transport *http.Transport
+
+	switch rt := c.client.Transport.(type) {
+	case nil:
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		transport = rt.Clone()
+	default:
+		return client
+	}
+
+	transport.DialContext = NewDialContext(options...)
+
+	hc := *c.client
+	hc.Transport = transport
+
+	return &httpclient{client: &hc}
+}
+
 // defaultHTTPClient default http client
 var defaultHTTPClient = NewDefaultClient()
 
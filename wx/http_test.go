@@ -0,0 +1,410 @@
+package wx
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpload(t *testing.T) {
+	content := bytes.Repeat([]byte("gochat"), 10<<10)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Greater(t, r.ContentLength, int64(len(content)))
+
+		file, header, err := r.FormFile("media")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "test.jpg", header.Filename)
+
+		b, err := ioutil.ReadAll(file)
+
+		assert.Nil(t, err)
+		assert.Equal(t, content, b)
+		assert.Equal(t, "TITLE", r.FormValue("title"))
+
+		w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+	}))
+	defer srv.Close()
+
+	client := NewHTTPClient(srv.Client())
+
+	// 多次上传，验证 pool 中复用的 buffer 不会残留上一次的数据
+	for i := 0; i < 3; i++ {
+		resp, err := client.Upload(context.TODO(), srv.URL, NewUploadForm(
+			WithFormFile("media", "test.jpg", func(w io.Writer) error {
+				_, err := w.Write(content)
+
+				return err
+			}),
+			WithFormField("title", "TITLE"),
+		))
+
+		assert.Nil(t, err)
+		assert.Equal(t, []byte(`{"errcode":0,"errmsg":"ok"}`), resp)
+	}
+}
+
+func TestUploadRedirect(t *testing.T) {
+	content := bytes.Repeat([]byte("gochat"), 10<<10)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/upload", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/media/upload", http.StatusTemporaryRedirect)
+	})
+
+	mux.HandleFunc("/media/upload", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		file, _, err := r.FormFile("media")
+
+		assert.Nil(t, err)
+
+		b, err := ioutil.ReadAll(file)
+
+		assert.Nil(t, err)
+		assert.Equal(t, content, b)
+
+		w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := NewHTTPClient(srv.Client())
+
+	// 307 重定向时重新发送完整的 body
+	for i := 0; i < 3; i++ {
+		resp, err := client.Upload(context.TODO(), srv.URL+"/upload", NewUploadForm(
+			WithFormFile("media", "test.jpg", func(w io.Writer) error {
+				_, err := w.Write(content)
+
+				return err
+			}),
+		))
+
+		assert.Nil(t, err)
+		assert.Equal(t, []byte(`{"errcode":0,"errmsg":"ok"}`), resp)
+	}
+}
+
+func TestUploadProgress(t *testing.T) {
+	content := bytes.Repeat([]byte("gochat"), 100<<10)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(ioutil.Discard, r.Body)
+
+		w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+	}))
+	defer srv.Close()
+
+	client := NewHTTPClient(srv.Client())
+
+	var (
+		calls int
+		last  int64
+		total int64
+	)
+
+	_, err := client.Upload(context.TODO(), srv.URL, NewUploadForm(
+		WithFormFile("media", "test.mp4", func(w io.Writer) error {
+			_, err := w.Write(content)
+
+			return err
+		}),
+	), WithUploadProgress(func(sent, n int64) {
+		assert.Greater(t, sent, last)
+
+		calls++
+		last = sent
+		total = n
+	}))
+
+	assert.Nil(t, err)
+	assert.Greater(t, calls, 1)
+	assert.Greater(t, total, int64(len(content)))
+	assert.Equal(t, total, last)
+}
+
+func TestWithRoundTripper(t *testing.T) {
+	client := NewHTTPClient(&http.Client{
+		Transport: RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return nil, errors.New("default transport should not be used")
+		}),
+	})
+
+	var (
+		method string
+		header http.Header
+		body   []byte
+	)
+
+	rt := RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		method = req.Method
+		header = req.Header.Clone()
+
+		b, err := ioutil.ReadAll(req.Body)
+
+		if err != nil {
+			return nil, err
+		}
+
+		body = b
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     make(http.Header),
+			Body:       ioutil.NopCloser(strings.NewReader(`{"errcode":0,"errmsg":"ok"}`)),
+			Request:    req,
+		}, nil
+	})
+
+	resp, err := client.Do(context.TODO(), http.MethodPost, "https://api.weixin.qq.com/test", []byte(`{"openid":"OPENID"}`), WithHTTPHeader("Content-Type", "application/json"), WithRoundTripper(rt))
+
+	assert.Nil(t, err)
+	assert.Equal(t, []byte(`{"errcode":0,"errmsg":"ok"}`), resp)
+	assert.Equal(t, http.MethodPost, method)
+	assert.Equal(t, "application/json", header.Get("Content-Type"))
+	assert.Equal(t, []byte(`{"openid":"OPENID"}`), body)
+
+	_, err = client.Upload(context.TODO(), "https://api.weixin.qq.com/upload", NewUploadForm(
+		WithFormFile("media", "test.jpg", func(w io.Writer) error {
+			_, err := w.Write([]byte("gochat"))
+
+			return err
+		}),
+		WithFormField("description", "test"),
+	), WithRoundTripper(rt))
+
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(header.Get("Content-Type"), "multipart/form-data; boundary="))
+	assert.Contains(t, string(body), `name="media"; filename="test.jpg"`)
+	assert.Contains(t, string(body), `name="description"`)
+
+	// 未指定时使用客户端默认的 transport
+	_, err = client.Do(context.TODO(), http.MethodGet, "https://api.weixin.qq.com/test", nil)
+
+	assert.NotNil(t, err)
+}
+
+func TestHTTPStatusError(t *testing.T) {
+	client := NewHTTPClient(&http.Client{
+		Transport: RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+			header := make(http.Header)
+			header.Set("Request-ID", "REQUEST_ID")
+
+			return &http.Response{
+				StatusCode: http.StatusBadRequest,
+				Header:     header,
+				Body:       ioutil.NopCloser(strings.NewReader(`{"code":"PARAM_ERROR","message":"参数错误"}`)),
+				Request:    req,
+			}, nil
+		}),
+	})
+
+	var header http.Header
+
+	_, err := client.Do(context.TODO(), http.MethodGet, "https://api.mch.weixin.qq.com/v3/test", nil, WithHTTPResponseHeader(func(h http.Header) {
+		header = h
+	}))
+
+	var statusErr *HTTPStatusError
+
+	assert.True(t, errors.As(err, &statusErr))
+	assert.Equal(t, "unexpected status 400", err.Error())
+	assert.Equal(t, http.StatusBadRequest, statusErr.StatusCode)
+	assert.Equal(t, []byte(`{"code":"PARAM_ERROR","message":"参数错误"}`), statusErr.Body)
+	assert.Equal(t, "REQUEST_ID", header.Get("Request-ID"))
+}
+
+func TestUploadFormContentType(t *testing.T) {
+	form := NewUploadForm(
+		WithFormFile("media", "test.jpg", func(w io.Writer) error {
+			_, err := w.Write([]byte("IMAGE"))
+
+			return err
+		}),
+		WithFormFile("thumb", "thumb.jpg", func(w io.Writer) error {
+			_, err := w.Write([]byte("THUMB"))
+
+			return err
+		}),
+		WithFormFileContentType("media", "image/jpeg"),
+	)
+
+	var buf bytes.Buffer
+
+	w := multipart.NewWriter(&buf)
+
+	assert.Nil(t, form.Write(w))
+	assert.Nil(t, w.Close())
+
+	r := multipart.NewReader(&buf, w.Boundary())
+
+	part, err := r.NextPart()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "media", part.FormName())
+	assert.Equal(t, "test.jpg", part.FileName())
+	assert.Equal(t, "image/jpeg", part.Header.Get("Content-Type"))
+
+	part, err = r.NextPart()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "thumb", part.FormName())
+	assert.Equal(t, "application/octet-stream", part.Header.Get("Content-Type"))
+}
+
+func TestUploadEmptyForm(t *testing.T) {
+	client := NewHTTPClient(http.DefaultClient)
+
+	_, err := client.Upload(context.TODO(), "http://127.0.0.1", NewUploadForm())
+
+	assert.EqualError(t, err, "empty file field")
+}
+
+func TestPrependHTTPHeaders(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "gochat/test", r.Header.Get("User-Agent"))
+		assert.Equal(t, "TRACE_ACTION", r.Header.Get("X-Trace-Id"))
+		assert.Equal(t, "REQUEST", r.Header.Get("X-Request-Id"))
+
+		w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+	}))
+	defer srv.Close()
+
+	client := NewHTTPClient(srv.Client())
+
+	action := NewGetAction(srv.URL, WithHeader("X-Trace-Id", "TRACE_ACTION"))
+
+	assert.Equal(t, map[string]string{"X-Trace-Id": "TRACE_ACTION"}, ActionHeaders(action))
+
+	options := PrependHTTPHeaders([]HTTPOption{WithHTTPHeader("X-Request-Id", "REQUEST")},
+		map[string]string{"User-Agent": "gochat/test", "X-Trace-Id": "TRACE_CLIENT", "X-Request-Id": "CLIENT"},
+		ActionHeaders(action),
+	)
+
+	resp, err := client.Do(context.TODO(), http.MethodGet, action.URL(), nil, options...)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []byte(`{"errcode":0,"errmsg":"ok"}`), resp)
+
+	// 无 header 时不追加 option
+	options = []HTTPOption{WithHTTPClose()}
+
+	assert.Equal(t, 1, len(PrependHTTPHeaders(options, nil, map[string]string{})))
+}
+
+func TestDialClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+	}))
+	defer srv.Close()
+
+	client := NewDialClient([]DialOption{WithIPv4Only()})
+
+	resp, err := client.Do(context.TODO(), http.MethodGet, srv.URL, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []byte(`{"errcode":0,"errmsg":"ok"}`), resp)
+
+	// 强制 IPv6 时无法连接 IPv4 地址
+	client = NewDialClient([]DialOption{WithIPv6Only()})
+
+	_, err = client.Do(context.TODO(), http.MethodGet, srv.URL, nil)
+
+	assert.NotNil(t, err)
+}
+
+func TestApplyDialOptions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+	}))
+	defer srv.Close()
+
+	hc := &http.Client{Timeout: 5 * time.Second}
+
+	// 作用于自定义 Client 的 Transport，保留 Client 的其他配置，且不修改原 Client
+	client := ApplyDialOptions(NewHTTPClient(hc), WithIPv6Only())
+
+	_, err := client.Do(context.TODO(), http.MethodGet, srv.URL, nil)
+
+	assert.NotNil(t, err)
+	assert.Nil(t, hc.Transport)
+	assert.Equal(t, 5*time.Second, client.(*httpclient).client.Timeout)
+
+	client = ApplyDialOptions(NewHTTPClient(hc), WithIPv4Only())
+
+	resp, err := client.Do(context.TODO(), http.MethodGet, srv.URL, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []byte(`{"errcode":0,"errmsg":"ok"}`), resp)
+
+	// 自定义 RoundTripper 时不生效
+	custom := NewHTTPClient(&http.Client{Transport: RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return nil, errors.New("custom")
+	})})
+
+	assert.Same(t, custom, ApplyDialOptions(custom, WithIPv4Only()))
+}
+
+func TestDialResolver(t *testing.T) {
+	var dnsNetwork string
+
+	dial := NewDialContext(WithIPv4Only(), WithResolver(&net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			dnsNetwork = network
+
+			return nil, errors.New("dns unavailable")
+		},
+	}))
+
+	_, err := dial(context.TODO(), "tcp", "api.gochat.test:443")
+
+	assert.NotNil(t, err)
+	assert.True(t, strings.Contains(err.Error(), "dns unavailable"))
+	assert.NotEmpty(t, dnsNetwork)
+}
+
+func BenchmarkUpload10MB(b *testing.B) {
+	content := bytes.Repeat([]byte{'x'}, 10<<20)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(ioutil.Discard, r.Body)
+
+		w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+	}))
+	defer srv.Close()
+
+	client := NewHTTPClient(srv.Client())
+
+	form := NewUploadForm(WithFormFile("media", "test.mp4", func(w io.Writer) error {
+		_, err := io.Copy(w, bytes.NewReader(content))
+
+		return err
+	}))
+
+	b.SetBytes(int64(len(content)))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := client.Upload(context.TODO(), srv.URL, form); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package wx
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Int64 兼容数字与数字字符串（空字符串及 null 视为0）的整数
+type Int64 int64
+
+// UnmarshalJSON implements json.Unmarshaler
+func (i *Int64) UnmarshalJSON(b []byte) error {
+	s := unquoteNumber(b)
+
+	if len(s) == 0 {
+		*i = 0
+
+		return nil
+	}
+
+	v, err := strconv.ParseInt(s, 10, 64)
+
+	if err != nil {
+		return err
+	}
+
+	*i = Int64(v)
+
+	return nil
+}
+
+// Float64 兼容数字与数字字符串（空字符串及 null 视为0）的浮点数
+type Float64 float64
+
+// UnmarshalJSON implements json.Unmarshaler
+func (f *Float64) UnmarshalJSON(b []byte) error {
+	s := unquoteNumber(b)
+
+	if len(s) == 0 {
+		*f = 0
+
+		return nil
+	}
+
+	v, err := strconv.ParseFloat(s, 64)
+
+	if err != nil {
+		return err
+	}
+
+	*f = Float64(v)
+
+	return nil
+}
+
+// Bool 兼容 true/false、1/0 及其字符串形式的布尔值
+type Bool bool
+
+// UnmarshalJSON implements json.Unmarshaler
+func (v *Bool) UnmarshalJSON(b []byte) error {
+	s := unquoteNumber(b)
+
+	if len(s) == 0 {
+		*v = false
+
+		return nil
+	}
+
+	ok, err := strconv.ParseBool(s)
+
+	if err != nil {
+		return err
+	}
+
+	*v = Bool(ok)
+
+	return nil
+}
+
+func unquoteNumber(b []byte) string {
+	s := strings.TrimSpace(string(b))
+
+	if s == "null" {
+		return ""
+	}
+
+	return strings.TrimSpace(strings.Trim(s, `"`))
+}
+
+// DecodeLenient 宽松解析：decode 因数字与字符串类型不一致（如：数字返回为字符串）失败时，
+// 将报错字段转换为期望的类型后重试
+func DecodeLenient(b []byte, decode func(b []byte) error) error {
+	err := decode(b)
+
+	// 每次修正一个字段，最多重试32次
+	for i := 0; i < 32 && err != nil; i++ {
+		var typeErr *json.UnmarshalTypeError
+
+		if !errors.As(err, &typeErr) || len(typeErr.Field) == 0 {
+			return err
+		}
+
+		fixed, ferr := coerceField(b, typeErr)
+
+		if ferr != nil || bytes.Equal(fixed, b) {
+			return err
+		}
+
+		b = fixed
+		err = decode(b)
+	}
+
+	return err
+}
+
+func coerceField(b []byte, typeErr *json.UnmarshalTypeError) ([]byte, error) {
+	var root interface{}
+
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+
+	if err := d.Decode(&root); err != nil {
+		return nil, err
+	}
+
+	kind := typeErr.Type.Kind()
+
+	if kind == reflect.Ptr {
+		kind = typeErr.Type.Elem().Kind()
+	}
+
+	root = walkField(root, strings.Split(typeErr.Field, "."), func(v interface{}) interface{} {
+		return coerceValue(v, kind)
+	})
+
+	return MarshalNoEscapeHTML(root)
+}
+
+// walkField 按字段路径替换值（路径中不含数组下标时作用于数组的所有元素）
+func walkField(node interface{}, path []string, f func(v interface{}) interface{}) interface{} {
+	switch v := node.(type) {
+	case []interface{}:
+		if len(path) != 0 {
+			if i, err := strconv.Atoi(path[0]); err == nil {
+				if i >= 0 && i < len(v) {
+					v[i] = walkField(v[i], path[1:], f)
+				}
+
+				return v
+			}
+		}
+
+		for i := range v {
+			v[i] = walkField(v[i], path, f)
+		}
+
+		return v
+	case map[string]interface{}:
+		if len(path) == 0 {
+			return f(v)
+		}
+
+		if child, ok := v[path[0]]; ok {
+			v[path[0]] = walkField(child, path[1:], f)
+		}
+
+		return v
+	default:
+		if len(path) == 0 {
+			return f(v)
+		}
+
+		return v
+	}
+}
+
+func coerceValue(v interface{}, kind reflect.Kind) interface{} {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		switch x := v.(type) {
+		case string:
+			if s := strings.TrimSpace(x); len(s) == 0 {
+				return json.Number("0")
+			} else if _, err := strconv.ParseFloat(s, 64); err == nil {
+				return json.Number(s)
+			}
+		case bool:
+			if x {
+				return json.Number("1")
+			}
+
+			return json.Number("0")
+		}
+	case reflect.String:
+		switch x := v.(type) {
+		case json.Number:
+			return x.String()
+		case bool:
+			return strconv.FormatBool(x)
+		}
+	case reflect.Bool:
+		switch x := v.(type) {
+		case json.Number:
+			return x.String() != "0"
+		case string:
+			if ok, err := strconv.ParseBool(strings.TrimSpace(x)); err == nil {
+				return ok
+			}
+		}
+	}
+
+	return v
+}
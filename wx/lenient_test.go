@@ -0,0 +1,79 @@
+package wx
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLenientTypes(t *testing.T) {
+	type result struct {
+		Count  Int64   `json:"count"`
+		Amount Float64 `json:"amount"`
+		IsOK   Bool    `json:"is_ok"`
+	}
+
+	for _, s := range []string{
+		`{"count":10,"amount":1.5,"is_ok":true}`,
+		`{"count":"10","amount":"1.5","is_ok":"true"}`,
+		`{"count":"10","amount":"1.5","is_ok":1}`,
+	} {
+		r := new(result)
+
+		assert.Nil(t, json.Unmarshal([]byte(s), r), s)
+		assert.Equal(t, &result{Count: 10, Amount: 1.5, IsOK: true}, r, s)
+	}
+
+	r := new(result)
+
+	assert.Nil(t, json.Unmarshal([]byte(`{"count":"","amount":null,"is_ok":""}`), r))
+	assert.Equal(t, &result{}, r)
+
+	assert.NotNil(t, json.Unmarshal([]byte(`{"count":"abc"}`), r))
+}
+
+func TestDecodeLenient(t *testing.T) {
+	type item struct {
+		RefDate string `json:"ref_date"`
+		VisitPV int64  `json:"visit_pv"`
+	}
+
+	type result struct {
+		Total int     `json:"total"`
+		Rate  float64 `json:"rate"`
+		Open  bool    `json:"open"`
+		ID    string  `json:"id"`
+		List  []*item `json:"list"`
+	}
+
+	r := new(result)
+
+	decode := func(b []byte) error {
+		return json.Unmarshal(b, r)
+	}
+
+	// 严格解析失败
+	b := []byte(`{"total":"2","rate":"0.5","open":"1","id":10086,"list":[{"ref_date":20170313,"visit_pv":"100"},{"ref_date":"20170314","visit_pv":""}]}`)
+
+	assert.NotNil(t, decode(b))
+
+	r = new(result)
+
+	assert.Nil(t, DecodeLenient(b, decode))
+	assert.Equal(t, &result{
+		Total: 2,
+		Rate:  0.5,
+		Open:  true,
+		ID:    "10086",
+		List: []*item{
+			{RefDate: "20170313", VisitPV: 100},
+			{RefDate: "20170314", VisitPV: 0},
+		},
+	}, r)
+
+	// 无法转换
+	r = new(result)
+
+	assert.NotNil(t, DecodeLenient([]byte(`{"total":"abc"}`), decode))
+}
@@ -0,0 +1,75 @@
+package wx
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"time"
+)
+
+var (
+	// ErrStateInvalid state 格式错误或签名不匹配
+	ErrStateInvalid = errors.New("oauth state invalid")
+
+	// ErrStateExpired state 已过期
+	ErrStateExpired = errors.New("oauth state expired")
+)
+
+// StateManager 网页授权 state 参数管理（HMAC-SHA256 签名，带有效期），用于防止 CSRF 攻击；
+// 生成的 state 仅含 0-9a-f，长度为 58 字节，满足微信 state 参数的要求（a-zA-Z0-9，最多128字节）
+type StateManager struct {
+	secret []byte
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+// Generate 生成 state，bind 为与当前用户会话绑定的值（如：session id），回调时需使用相同的值验证
+func (m *StateManager) Generate(bind string) string {
+	payload := strconv.FormatInt(m.now().Add(m.ttl).Unix(), 10) + Nonce(16)
+
+	return payload + m.sign(payload, bind)
+}
+
+// Verify 验证网页授权回调的 state
+func (m *StateManager) Verify(state, bind string) error {
+	if len(state) != 58 {
+		return ErrStateInvalid
+	}
+
+	payload, signature := state[:26], state[26:]
+
+	if !hmac.Equal([]byte(signature), []byte(m.sign(payload, bind))) {
+		return ErrStateInvalid
+	}
+
+	expireAt, err := strconv.ParseInt(payload[:10], 10, 64)
+
+	if err != nil {
+		return ErrStateInvalid
+	}
+
+	if m.now().Unix() > expireAt {
+		return ErrStateExpired
+	}
+
+	return nil
+}
+
+func (m *StateManager) sign(payload, bind string) string {
+	mac := hmac.New(sha256.New, m.secret)
+
+	mac.Write([]byte(payload + "|" + bind))
+
+	return hex.EncodeToString(mac.Sum(nil))[:32]
+}
+
+// NewStateManager returns new oauth state manager (ttl 为 state 有效期，建议：10分钟)
+func NewStateManager(secret string, ttl time.Duration) *StateManager {
+	return &StateManager{
+		secret: []byte(secret),
+		ttl:    ttl,
+		now:    time.Now,
+	}
+}
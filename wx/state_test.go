@@ -0,0 +1,36 @@
+package wx
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateManager(t *testing.T) {
+	m := NewStateManager("STATE_SECRET", 10*time.Minute)
+
+	state := m.Generate("SESSION_ID")
+
+	assert.Len(t, state, 58)
+	assert.Regexp(t, regexp.MustCompile(`^[a-zA-Z0-9]+$`), state)
+	assert.Nil(t, m.Verify(state, "SESSION_ID"))
+
+	// 会话不匹配
+	assert.Equal(t, ErrStateInvalid, m.Verify(state, "OTHER_SESSION_ID"))
+
+	// 篡改
+	assert.Equal(t, ErrStateInvalid, m.Verify("2"+state[1:], "SESSION_ID"))
+	assert.Equal(t, ErrStateInvalid, m.Verify(state[:57], "SESSION_ID"))
+
+	// 密钥不匹配
+	assert.Equal(t, ErrStateInvalid, NewStateManager("OTHER_SECRET", 10*time.Minute).Verify(state, "SESSION_ID"))
+
+	// 过期
+	m.now = func() time.Time {
+		return time.Now().Add(11 * time.Minute)
+	}
+
+	assert.Equal(t, ErrStateExpired, m.Verify(state, "SESSION_ID"))
+}
@@ -0,0 +1,145 @@
+package wx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// ErrStubRouteNotFound 桩客户端未配置请求对应的路由
+var ErrStubRouteNotFound = errors.New("stub route not found")
+
+// StubRequest 桩客户端收到的请求
+type StubRequest struct {
+	Method string
+	URL    string
+	Body   []byte
+	Form   UploadForm // 上传请求的表单
+	Header map[string]string
+}
+
+// StubHandler 根据请求返回响应
+type StubHandler func(req *StubRequest) ([]byte, error)
+
+// StubClient 基于路由表的轻量级 HTTPClient 桩实现，用于测试（无需 gomock）；
+// 路由为「method + url」，url 优先完全匹配，其次忽略 query 匹配；
+// 同一路由多次配置时按顺序依次响应，最后一个响应可重复使用；上传请求的 method 为 POST
+type StubClient struct {
+	mutex    sync.Mutex
+	routes   map[string][]StubHandler
+	requests []*StubRequest
+}
+
+// NewStubClient returns new StubClient
+func NewStubClient() *StubClient {
+	return &StubClient{
+		routes: make(map[string][]StubHandler),
+	}
+}
+
+// Handle 配置路由的响应处理函数
+func (c *StubClient) Handle(method, reqURL string, f StubHandler) *StubClient {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	key := stubRouteKey(method, reqURL)
+
+	c.routes[key] = append(c.routes[key], f)
+
+	return c
+}
+
+// Reply 配置路由的响应内容
+func (c *StubClient) Reply(method, reqURL string, body []byte) *StubClient {
+	return c.Handle(method, reqURL, func(req *StubRequest) ([]byte, error) {
+		return body, nil
+	})
+}
+
+// ReplyError 配置路由返回错误
+func (c *StubClient) ReplyError(method, reqURL string, err error) *StubClient {
+	return c.Handle(method, reqURL, func(req *StubRequest) ([]byte, error) {
+		return nil, err
+	})
+}
+
+// Requests 返回已收到的请求（按请求顺序）
+func (c *StubClient) Requests() []*StubRequest {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	requests := make([]*StubRequest, len(c.requests))
+
+	copy(requests, c.requests)
+
+	return requests
+}
+
+func (c *StubClient) Do(ctx context.Context, method, reqURL string, body []byte, options ...HTTPOption) ([]byte, error) {
+	return c.serve(ctx, &StubRequest{
+		Method: method,
+		URL:    reqURL,
+		Body:   body,
+	}, options...)
+}
+
+func (c *StubClient) Upload(ctx context.Context, reqURL string, form UploadForm, options ...HTTPOption) ([]byte, error) {
+	return c.serve(ctx, &StubRequest{
+		Method: http.MethodPost,
+		URL:    reqURL,
+		Form:   form,
+	}, options...)
+}
+
+func (c *StubClient) serve(ctx context.Context, req *StubRequest, options ...HTTPOption) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	setting := &httpSetting{
+		headers: make(map[string]string),
+	}
+
+	for _, f := range options {
+		f(setting)
+	}
+
+	req.Header = setting.headers
+
+	c.mutex.Lock()
+
+	c.requests = append(c.requests, req)
+
+	key := stubRouteKey(req.Method, req.URL)
+	handlers, ok := c.routes[key]
+
+	if !ok {
+		if i := strings.Index(req.URL, "?"); i != -1 {
+			key = stubRouteKey(req.Method, req.URL[:i])
+			handlers, ok = c.routes[key]
+		}
+	}
+
+	if !ok {
+		c.mutex.Unlock()
+
+		return nil, fmt.Errorf("%w: %s %s", ErrStubRouteNotFound, req.Method, req.URL)
+	}
+
+	f := handlers[0]
+
+	if len(handlers) > 1 {
+		c.routes[key] = handlers[1:]
+	}
+
+	c.mutex.Unlock()
+
+	return f(req)
+}
+
+func stubRouteKey(method, reqURL string) string {
+	return strings.ToUpper(method) + " " + reqURL
+}
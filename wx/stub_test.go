@@ -0,0 +1,68 @@
+package wx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStubClient(t *testing.T) {
+	client := NewStubClient().
+		Reply(http.MethodGet, "https://api.weixin.qq.com/cgi-bin/token", []byte(`{"access_token":"ACCESS_TOKEN"}`)).
+		Reply(http.MethodPost, "https://api.weixin.qq.com/cgi-bin/menu/create?access_token=ACCESS_TOKEN", []byte(`{"errcode":40001}`)).
+		Reply(http.MethodPost, "https://api.weixin.qq.com/cgi-bin/menu/create?access_token=ACCESS_TOKEN", []byte(`{"errcode":0}`)).
+		ReplyError(http.MethodPost, "https://api.weixin.qq.com/cgi-bin/media/upload", errors.New("upload failed"))
+
+	var _ HTTPClient = client
+
+	// 忽略 query 匹配
+	b, err := client.Do(context.TODO(), http.MethodGet, "https://api.weixin.qq.com/cgi-bin/token?appid=APPID", nil, WithHTTPHeader("X-Test", "1"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, []byte(`{"access_token":"ACCESS_TOKEN"}`), b)
+
+	// 按顺序响应，最后一个重复使用
+	for _, v := range []string{`{"errcode":40001}`, `{"errcode":0}`, `{"errcode":0}`} {
+		b, err = client.Do(context.TODO(), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/menu/create?access_token=ACCESS_TOKEN", []byte(`{}`))
+
+		assert.Nil(t, err)
+		assert.Equal(t, []byte(v), b)
+	}
+
+	_, err = client.Upload(context.TODO(), "https://api.weixin.qq.com/cgi-bin/media/upload?access_token=ACCESS_TOKEN", NewUploadForm())
+
+	assert.Equal(t, errors.New("upload failed"), err)
+
+	_, err = client.Do(context.TODO(), http.MethodGet, "https://api.weixin.qq.com/cgi-bin/user/get", nil)
+
+	assert.True(t, errors.Is(err, ErrStubRouteNotFound))
+
+	requests := client.Requests()
+
+	assert.Equal(t, 6, len(requests))
+	assert.Equal(t, map[string]string{"X-Test": "1"}, requests[0].Header)
+	assert.Equal(t, []byte(`{}`), requests[1].Body)
+	assert.Equal(t, http.MethodPost, requests[4].Method)
+	assert.NotNil(t, requests[4].Form)
+}
+
+func TestStubClientHandle(t *testing.T) {
+	client := NewStubClient().Handle(http.MethodPost, "https://api.weixin.qq.com/cgi-bin/message/custom/send", func(req *StubRequest) ([]byte, error) {
+		return req.Body, nil
+	})
+
+	b, err := client.Do(context.TODO(), http.MethodPost, "https://api.weixin.qq.com/cgi-bin/message/custom/send?access_token=ACCESS_TOKEN", []byte(`{"touser":"OPENID"}`))
+
+	assert.Nil(t, err)
+	assert.Equal(t, []byte(`{"touser":"OPENID"}`), b)
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+
+	_, err = client.Do(ctx, http.MethodPost, "https://api.weixin.qq.com/cgi-bin/message/custom/send", nil)
+
+	assert.Equal(t, context.Canceled, err)
+}
@@ -0,0 +1,516 @@
+package wx
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// TokenFetcher 获取凭证（access_token、component_access_token、jsapi_ticket 等），返回凭证及有效期（秒）
+type TokenFetcher func(ctx context.Context) (token string, expiresIn int64, err error)
+
+// TokenEvent 凭证刷新事件，Err 不为 nil 表示刷新失败；
+// StoreErr 不为 nil 表示凭证已刷新，但保存至外部存储失败（仍返回新凭证）
+type TokenEvent struct {
+	Token    string
+	ExpireAt time.Time
+	Err      error
+	StoreErr error
+}
+
+// TokenHook 凭证刷新回调（在持有刷新锁时调用，不可再调用 TokenManager 的 Token、Refresh 方法）
+type TokenHook func(ctx context.Context, e *TokenEvent)
+
+// ErrTokenRefreshPreempted 请求剩余时间不足以完成刷新，已返回缓存的（可能即将过期的）凭证
+var ErrTokenRefreshPreempted = errors.New("token refresh preempted by context deadline")
+
+// TokenSnapshot 凭证快照
+type TokenSnapshot struct {
+	Token    string `json:"token"`
+	ExpireAt int64  `json:"expire_at"` // 过期时间（Unix时间戳）
+}
+
+// TokenStore 凭证的外部存储
+type TokenStore interface {
+	// Get 获取凭证，不存在时返回 nil
+	Get(ctx context.Context, key string) (*TokenSnapshot, error)
+
+	// Set 保存凭证
+	Set(ctx context.Context, key string, snapshot *TokenSnapshot) error
+}
+
+type memTokenStore struct {
+	snapshots map[string]*TokenSnapshot
+	mutex     sync.RWMutex
+}
+
+func (s *memTokenStore) Get(ctx context.Context, key string) (*TokenSnapshot, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.snapshots[key], nil
+}
+
+func (s *memTokenStore) Set(ctx context.Context, key string, snapshot *TokenSnapshot) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.snapshots[key] = snapshot
+
+	return nil
+}
+
+// NewMemTokenStore returns an in-memory token store (仅用于测试或单机)
+func NewMemTokenStore() TokenStore {
+	return &memTokenStore{
+		snapshots: make(map[string]*TokenSnapshot),
+	}
+}
+
+// TokenManager 凭证管理，缓存凭证并在过期前刷新
+type TokenManager struct {
+	fetcher  TokenFetcher
+	store    TokenStore
+	key      string
+	ahead    time.Duration
+	jitter   time.Duration
+	retry    time.Duration
+	hook     TokenHook
+	stale    TokenHook
+	rtt      time.Duration
+	elapsed  time.Duration
+	token    string
+	expireAt time.Time
+	mutex    sync.RWMutex  // 保护缓存的凭证
+	sem      chan struct{} // 刷新锁，串行化加载及刷新，等待时可按 ctx 放弃
+}
+
+// Token 获取凭证，若缓存凭证即将过期则刷新；
+// 其他请求正在刷新且 ctx 剩余时间不足以等待时，返回缓存的未过期凭证
+func (m *TokenManager) Token(ctx context.Context) (string, error) {
+	if token, ok := m.validToken(); ok {
+		return token, nil
+	}
+
+	token, locked, err := m.acquire(ctx)
+
+	if !locked {
+		return token, err
+	}
+
+	defer m.unlock()
+
+	// 等待期间凭证可能已由其他请求刷新
+	if token, ok := m.validToken(); ok {
+		return token, nil
+	}
+
+	// 其他实例可能已刷新凭证，优先从外部存储加载
+	if err = m.load(ctx); err != nil {
+		return "", err
+	}
+
+	if token, ok := m.validToken(); ok {
+		return token, nil
+	}
+
+	if d, ok := m.preemptAfter(ctx); ok && d < 0 {
+		if token, ok := m.staleToken(ctx, ErrTokenRefreshPreempted); ok {
+			return token, nil
+		}
+	}
+
+	return m.refresh(ctx)
+}
+
+// Refresh 强制刷新凭证
+func (m *TokenManager) Refresh(ctx context.Context) (string, error) {
+	if err := m.lock(ctx); err != nil {
+		return "", err
+	}
+
+	defer m.unlock()
+
+	return m.refresh(ctx)
+}
+
+// Set 设置凭证（如：从外部缓存加载）
+func (m *TokenManager) Set(token string, expiresIn int64) {
+	m.update(token, time.Now().Add(time.Duration(expiresIn)*time.Second))
+}
+
+// Export 导出当前缓存的凭证（无缓存时返回 nil），用于新实例预热
+func (m *TokenManager) Export() *TokenSnapshot {
+	token, expireAt := m.cached()
+
+	if len(token) == 0 {
+		return nil
+	}
+
+	return &TokenSnapshot{
+		Token:    token,
+		ExpireAt: expireAt.Unix(),
+	}
+}
+
+// Import 导入凭证（如：滚动发布时由旧实例导出），凭证已过期则返回错误
+func (m *TokenManager) Import(snapshot *TokenSnapshot) error {
+	if snapshot == nil || len(snapshot.Token) == 0 {
+		return errors.New("empty token")
+	}
+
+	expireAt := time.Unix(snapshot.ExpireAt, 0)
+
+	if !time.Now().Before(expireAt) {
+		return errors.New("token expired")
+	}
+
+	m.update(snapshot.Token, expireAt)
+
+	return nil
+}
+
+// Start 后台定时刷新凭证（在过期前 ahead + 随机 jitter 时刷新，刷新间隔不小于 retry，失败后按 retry 间隔重试），
+// 适用于无法接受按需刷新延迟的服务；阻塞直至 ctx 取消，一般使用：go mgr.Start(ctx)
+func (m *TokenManager) Start(ctx context.Context) {
+	failed := false
+
+	for {
+		timer := time.NewTimer(m.nextRefresh(ctx, failed))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return
+		case <-timer.C:
+		}
+
+		failed = m.renew(ctx) != nil
+	}
+}
+
+func (m *TokenManager) nextRefresh(ctx context.Context, failed bool) time.Duration {
+	if failed {
+		return m.retry
+	}
+
+	if token, _ := m.cached(); len(token) == 0 {
+		// ctx 已取消，由 Start 退出
+		if err := m.lock(ctx); err != nil {
+			return m.retry
+		}
+
+		err := m.load(ctx)
+
+		m.unlock()
+
+		// 外部存储不可用时按 retry 间隔重试，避免频繁请求
+		if err != nil {
+			return m.retry
+		}
+
+		// 尚无凭证，立即刷新
+		if token, _ = m.cached(); len(token) == 0 {
+			return 0
+		}
+	}
+
+	_, expireAt := m.cached()
+
+	d := time.Until(expireAt) - m.ahead
+
+	if m.jitter > 0 {
+		d -= time.Duration(rand.Int63n(int64(m.jitter)))
+	}
+
+	// 凭证有效期不大于提前刷新时间时（如：expires_in 过短），避免频繁刷新
+	if d < m.retry {
+		return m.retry
+	}
+
+	return d
+}
+
+// renew 后台刷新：若外部存储中的凭证已由其他实例刷新则直接使用，否则刷新
+func (m *TokenManager) renew(ctx context.Context) error {
+	if err := m.lock(ctx); err != nil {
+		return err
+	}
+
+	defer m.unlock()
+
+	_, expireAt := m.cached()
+
+	if err := m.load(ctx); err != nil {
+		return err
+	}
+
+	if _, latest := m.cached(); latest.After(expireAt) {
+		if _, ok := m.validToken(); ok {
+			return nil
+		}
+	}
+
+	_, err := m.refresh(ctx)
+
+	return err
+}
+
+// lock 获取刷新锁，ctx 取消时放弃等待
+func (m *TokenManager) lock(ctx context.Context) error {
+	select {
+	case m.sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (m *TokenManager) unlock() {
+	<-m.sem
+}
+
+// acquire 获取刷新锁（locked 为 true）；锁被占用且 ctx 剩余时间不足以完成刷新或 ctx 已取消时，
+// 放弃等待并返回缓存的未过期凭证，无可用凭证时继续等待直至 ctx 取消
+func (m *TokenManager) acquire(ctx context.Context) (token string, locked bool, err error) {
+	select {
+	case m.sem <- struct{}{}:
+		return "", true, nil
+	default:
+	}
+
+	var preempt <-chan time.Time
+
+	if d, ok := m.preemptAfter(ctx); ok {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+
+		preempt = timer.C
+	}
+
+	for {
+		select {
+		case m.sem <- struct{}{}:
+			return "", true, nil
+		case <-preempt:
+			if token, ok := m.staleToken(ctx, ErrTokenRefreshPreempted); ok {
+				return token, false, nil
+			}
+
+			preempt = nil
+		case <-ctx.Done():
+			if token, ok := m.staleToken(ctx, ctx.Err()); ok {
+				return token, false, nil
+			}
+
+			return "", false, ctx.Err()
+		}
+	}
+}
+
+// preemptAfter 返回放弃刷新前 ctx 的剩余时间（取配置的耗时与上次刷新耗时的较大值），未启用或 ctx 无截止时间时 ok 为 false
+func (m *TokenManager) preemptAfter(ctx context.Context) (d time.Duration, ok bool) {
+	if m.rtt <= 0 {
+		return 0, false
+	}
+
+	deadline, ok := ctx.Deadline()
+
+	if !ok {
+		return 0, false
+	}
+
+	rtt := m.rtt
+
+	m.mutex.RLock()
+
+	if m.elapsed > rtt {
+		rtt = m.elapsed
+	}
+
+	m.mutex.RUnlock()
+
+	return time.Until(deadline) - rtt, true
+}
+
+// staleToken 返回缓存的未过期（可能即将过期的）凭证，并调用 warn 告警
+func (m *TokenManager) staleToken(ctx context.Context, err error) (string, bool) {
+	token, expireAt := m.cached()
+
+	if len(token) == 0 || !time.Now().Before(expireAt) {
+		return "", false
+	}
+
+	if m.stale != nil {
+		m.stale(ctx, &TokenEvent{
+			Token:    token,
+			ExpireAt: expireAt,
+			Err:      err,
+		})
+	}
+
+	return token, true
+}
+
+func (m *TokenManager) cached() (string, time.Time) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.token, m.expireAt
+}
+
+func (m *TokenManager) update(token string, expireAt time.Time) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.token = token
+	m.expireAt = expireAt
+}
+
+// validToken 返回缓存的凭证，ok 表示凭证无需刷新
+func (m *TokenManager) validToken() (string, bool) {
+	token, expireAt := m.cached()
+
+	return token, len(token) != 0 && time.Now().Add(m.ahead).Before(expireAt)
+}
+
+// load 从外部存储加载凭证（仅当其比本地缓存的凭证更晚过期时）
+func (m *TokenManager) load(ctx context.Context) error {
+	if m.store == nil {
+		return nil
+	}
+
+	snapshot, err := m.store.Get(ctx, m.key)
+
+	if err != nil {
+		return err
+	}
+
+	if _, expireAt := m.cached(); snapshot == nil || len(snapshot.Token) == 0 || snapshot.ExpireAt <= expireAt.Unix() {
+		return nil
+	}
+
+	m.update(snapshot.Token, time.Unix(snapshot.ExpireAt, 0))
+
+	return nil
+}
+
+func (m *TokenManager) refresh(ctx context.Context) (string, error) {
+	start := time.Now()
+
+	token, expiresIn, err := m.fetcher(ctx)
+
+	if err == nil && len(token) == 0 {
+		err = errors.New("empty token")
+	}
+
+	if err != nil {
+		m.emit(ctx, &TokenEvent{Err: err})
+
+		return "", err
+	}
+
+	expireAt := time.Now().Add(time.Duration(expiresIn) * time.Second)
+
+	m.mutex.Lock()
+
+	m.token = token
+	m.expireAt = expireAt
+	m.elapsed = time.Since(start)
+
+	m.mutex.Unlock()
+
+	e := &TokenEvent{
+		Token:    token,
+		ExpireAt: expireAt,
+	}
+
+	// 微信已使旧凭证失效，保存失败时仍返回新凭证，错误通过回调通知
+	if m.store != nil {
+		e.StoreErr = m.store.Set(ctx, m.key, &TokenSnapshot{
+			Token:    token,
+			ExpireAt: expireAt.Unix(),
+		})
+	}
+
+	m.emit(ctx, e)
+
+	return token, nil
+}
+
+func (m *TokenManager) emit(ctx context.Context, e *TokenEvent) {
+	if m.hook != nil {
+		m.hook(ctx, e)
+	}
+}
+
+// TokenOption 凭证管理配置项
+type TokenOption func(m *TokenManager)
+
+// WithRefreshAhead 设置提前刷新时间，默认：5分钟
+func WithRefreshAhead(d time.Duration) TokenOption {
+	return func(m *TokenManager) {
+		m.ahead = d
+	}
+}
+
+// WithTokenStore 设置凭证的外部存储（如：Redis），多实例共享凭证，
+// 新部署的实例优先使用已存储的凭证，避免刷新导致旧实例的凭证失效
+func WithTokenStore(store TokenStore, key string) TokenOption {
+	return func(m *TokenManager) {
+		m.store = store
+		m.key = key
+	}
+}
+
+// WithRefreshJitter 设置后台刷新的随机提前量（避免多个凭证同时刷新），默认：30秒
+func WithRefreshJitter(d time.Duration) TokenOption {
+	return func(m *TokenManager) {
+		m.jitter = d
+	}
+}
+
+// WithRetryInterval 设置后台刷新失败后的重试间隔，默认：10秒（d <= 0 时忽略）
+func WithRetryInterval(d time.Duration) TokenOption {
+	return func(m *TokenManager) {
+		if d > 0 {
+			m.retry = d
+		}
+	}
+}
+
+// WithTokenHook 设置凭证刷新回调（如：记录日志、上报监控、同步至外部缓存）
+func WithTokenHook(hook TokenHook) TokenOption {
+	return func(m *TokenManager) {
+		m.hook = hook
+	}
+}
+
+// WithStaleOnDeadline 设置按需刷新的预估耗时 rtt：当请求 ctx 的剩余时间小于 rtt（或上次刷新的实际耗时）且缓存凭证尚未过期时，
+// 不再阻塞刷新，直接返回缓存的凭证，并调用 warn 告警（TokenEvent.Err 为 ErrTokenRefreshPreempted）；默认不启用
+func WithStaleOnDeadline(rtt time.Duration, warn TokenHook) TokenOption {
+	return func(m *TokenManager) {
+		m.rtt = rtt
+		m.stale = warn
+	}
+}
+
+// NewTokenManager returns new token manager
+func NewTokenManager(fetcher TokenFetcher, options ...TokenOption) *TokenManager {
+	m := &TokenManager{
+		fetcher: fetcher,
+		sem:     make(chan struct{}, 1),
+		ahead:   5 * time.Minute,
+		jitter:  30 * time.Second,
+		retry:   10 * time.Second,
+	}
+
+	for _, f := range options {
+		f(m)
+	}
+
+	return m
+}
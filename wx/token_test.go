@@ -0,0 +1,373 @@
+package wx
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenManager(t *testing.T) {
+	count := 0
+
+	m := NewTokenManager(func(ctx context.Context) (string, int64, error) {
+		count++
+
+		return "TOKEN_" + strconv.Itoa(count), 7200, nil
+	})
+
+	token, err := m.Token(context.TODO())
+
+	assert.Nil(t, err)
+	assert.Equal(t, "TOKEN_1", token)
+
+	// 命中缓存
+	token, err = m.Token(context.TODO())
+
+	assert.Nil(t, err)
+	assert.Equal(t, "TOKEN_1", token)
+
+	// 强制刷新
+	token, err = m.Refresh(context.TODO())
+
+	assert.Nil(t, err)
+	assert.Equal(t, "TOKEN_2", token)
+
+	// 即将过期
+	m.Set("TOKEN_EXPIRING", 60)
+
+	token, err = m.Token(context.TODO())
+
+	assert.Nil(t, err)
+	assert.Equal(t, "TOKEN_3", token)
+}
+
+func TestTokenManagerError(t *testing.T) {
+	m := NewTokenManager(func(ctx context.Context) (string, int64, error) {
+		return "", 0, errors.New("40001|invalid credential")
+	})
+
+	_, err := m.Token(context.TODO())
+
+	assert.NotNil(t, err)
+}
+
+func TestTokenManagerStaleOnDeadline(t *testing.T) {
+	count := 0
+
+	var events []*TokenEvent
+
+	m := NewTokenManager(func(ctx context.Context) (string, int64, error) {
+		count++
+
+		return "TOKEN_" + strconv.Itoa(count), 7200, nil
+	}, WithStaleOnDeadline(time.Second, func(ctx context.Context, e *TokenEvent) {
+		events = append(events, e)
+	}))
+
+	// 即将过期
+	m.Set("TOKEN_EXPIRING", 60)
+
+	// 剩余时间不足，返回缓存凭证
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	token, err := m.Token(ctx)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "TOKEN_EXPIRING", token)
+	assert.Equal(t, 0, count)
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, "TOKEN_EXPIRING", events[0].Token)
+	assert.True(t, errors.Is(events[0].Err, ErrTokenRefreshPreempted))
+
+	// 无 deadline，正常刷新
+	token, err = m.Token(context.TODO())
+
+	assert.Nil(t, err)
+	assert.Equal(t, "TOKEN_1", token)
+	assert.Equal(t, 1, len(events))
+
+	// 缓存凭证已过期，即使剩余时间不足也需刷新
+	m.Set("TOKEN_EXPIRED", 0)
+
+	token, err = m.Token(ctx)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "TOKEN_2", token)
+	assert.Equal(t, 1, len(events))
+}
+
+func TestTokenManagerStaleWhileRefreshing(t *testing.T) {
+	fetching := make(chan struct{})
+	release := make(chan struct{})
+
+	m := NewTokenManager(func(ctx context.Context) (string, int64, error) {
+		close(fetching)
+		<-release
+
+		return "TOKEN_NEW", 7200, nil
+	})
+
+	// 即将过期
+	m.Set("TOKEN_EXPIRING", 60)
+
+	done := make(chan string)
+
+	go func() {
+		token, _ := m.Token(context.TODO())
+
+		done <- token
+	}()
+
+	<-fetching
+
+	// 刷新阻塞中，短 deadline 的请求返回缓存凭证
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	token, err := m.Token(ctx)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "TOKEN_EXPIRING", token)
+
+	close(release)
+
+	assert.Equal(t, "TOKEN_NEW", <-done)
+
+	// 无可用缓存凭证时，返回 ctx 错误
+	m.Set("TOKEN_EXPIRED", 0)
+
+	assert.Nil(t, m.lock(context.TODO()))
+
+	_, err = m.Token(ctx)
+
+	m.unlock()
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestTokenManagerStart(t *testing.T) {
+	var mutex sync.Mutex
+
+	count := 0
+	events := make([]*TokenEvent, 0)
+
+	m := NewTokenManager(func(ctx context.Context) (string, int64, error) {
+		count++
+
+		if count == 2 {
+			return "", 0, errors.New("-1|system error")
+		}
+
+		return "TOKEN_" + strconv.Itoa(count), 1, nil
+	},
+		WithRefreshAhead(900*time.Millisecond),
+		WithRefreshJitter(0),
+		WithRetryInterval(10*time.Millisecond),
+		WithTokenHook(func(ctx context.Context, e *TokenEvent) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			events = append(events, e)
+		}),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan struct{})
+
+	go func() {
+		m.Start(ctx)
+		close(done)
+	}()
+
+	time.Sleep(250 * time.Millisecond)
+
+	cancel()
+	<-done
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	// 首次刷新成功 -> 第二次刷新失败 -> 重试成功
+	assert.GreaterOrEqual(t, len(events), 3)
+	assert.Equal(t, "TOKEN_1", events[0].Token)
+	assert.Nil(t, events[0].Err)
+	assert.NotNil(t, events[1].Err)
+	assert.Equal(t, "TOKEN_3", events[2].Token)
+	assert.Nil(t, events[2].Err)
+
+	token, err := m.Token(context.TODO())
+
+	assert.Nil(t, err)
+	assert.NotEmpty(t, token)
+}
+
+func TestTokenManagerNextRefresh(t *testing.T) {
+	m := NewTokenManager(func(ctx context.Context) (string, int64, error) {
+		return "TOKEN", 7200, nil
+	}, WithRefreshAhead(5*time.Minute), WithRefreshJitter(0), WithRetryInterval(10*time.Second))
+
+	m.Set("TOKEN", 7200)
+
+	d := m.nextRefresh(context.TODO(), false)
+
+	assert.True(t, d > 115*time.Minute-time.Second && d <= 115*time.Minute)
+
+	// expires_in 不大于提前刷新时间，不会立即刷新
+	m.Set("TOKEN", 300)
+
+	assert.Equal(t, 10*time.Second, m.nextRefresh(context.TODO(), false))
+
+	m.Set("TOKEN", 0)
+
+	assert.Equal(t, 10*time.Second, m.nextRefresh(context.TODO(), false))
+}
+
+func TestTokenManagerNextRefreshFailed(t *testing.T) {
+	// 重试间隔不能为 0，否则失败后立即重试
+	m := NewTokenManager(func(ctx context.Context) (string, int64, error) {
+		return "", 0, errors.New("fetch failed")
+	}, WithRetryInterval(0), WithRetryInterval(-time.Second))
+
+	assert.Equal(t, 10*time.Second, m.nextRefresh(context.TODO(), true))
+
+	// 外部存储不可用
+	m = NewTokenManager(func(ctx context.Context) (string, int64, error) {
+		return "TOKEN", 7200, nil
+	}, WithTokenStore(new(errTokenStore), "APPID"), WithRetryInterval(time.Second))
+
+	assert.Equal(t, time.Second, m.nextRefresh(context.TODO(), false))
+
+	// 尚无凭证时立即刷新
+	m = NewTokenManager(func(ctx context.Context) (string, int64, error) {
+		return "TOKEN", 7200, nil
+	}, WithTokenStore(NewMemTokenStore(), "APPID"))
+
+	assert.Equal(t, time.Duration(0), m.nextRefresh(context.TODO(), false))
+}
+
+func TestTokenManagerExport(t *testing.T) {
+	m := NewTokenManager(func(ctx context.Context) (string, int64, error) {
+		return "TOKEN", 7200, nil
+	})
+
+	assert.Nil(t, m.Export())
+
+	_, err := m.Token(context.TODO())
+
+	assert.Nil(t, err)
+
+	snapshot := m.Export()
+
+	assert.Equal(t, "TOKEN", snapshot.Token)
+
+	// 新实例导入，无需刷新
+	n := NewTokenManager(func(ctx context.Context) (string, int64, error) {
+		return "", 0, errors.New("should not refresh")
+	})
+
+	assert.Nil(t, n.Import(snapshot))
+
+	token, err := n.Token(context.TODO())
+
+	assert.Nil(t, err)
+	assert.Equal(t, "TOKEN", token)
+
+	// 已过期
+	assert.NotNil(t, n.Import(&TokenSnapshot{Token: "EXPIRED", ExpireAt: time.Now().Unix() - 1}))
+	assert.NotNil(t, n.Import(nil))
+}
+
+func TestTokenManagerStore(t *testing.T) {
+	store := NewMemTokenStore()
+
+	count := 0
+
+	fetcher := func(ctx context.Context) (string, int64, error) {
+		count++
+
+		return "TOKEN_" + strconv.Itoa(count), 7200, nil
+	}
+
+	old := NewTokenManager(fetcher, WithTokenStore(store, "APPID"))
+
+	token, err := old.Token(context.TODO())
+
+	assert.Nil(t, err)
+	assert.Equal(t, "TOKEN_1", token)
+
+	// 新实例从存储加载，不会刷新
+	n := NewTokenManager(fetcher, WithTokenStore(store, "APPID"))
+
+	token, err = n.Token(context.TODO())
+
+	assert.Nil(t, err)
+	assert.Equal(t, "TOKEN_1", token)
+	assert.Equal(t, 1, count)
+
+	// 新实例刷新后，旧实例的缓存即将过期时从存储加载
+	token, err = n.Refresh(context.TODO())
+
+	assert.Nil(t, err)
+	assert.Equal(t, "TOKEN_2", token)
+
+	old.Set("TOKEN_1", 60)
+
+	token, err = old.Token(context.TODO())
+
+	assert.Nil(t, err)
+	assert.Equal(t, "TOKEN_2", token)
+	assert.Equal(t, 2, count)
+
+	// 后台刷新：存储中的凭证更新则直接使用
+	old.Set("TOKEN_1", 60)
+
+	assert.Nil(t, old.renew(context.TODO()))
+	assert.Equal(t, "TOKEN_2", old.Export().Token)
+	assert.Equal(t, 2, count)
+}
+
+type failTokenStore struct{}
+
+func (s *failTokenStore) Get(ctx context.Context, key string) (*TokenSnapshot, error) {
+	return nil, nil
+}
+
+func (s *failTokenStore) Set(ctx context.Context, key string, snapshot *TokenSnapshot) error {
+	return errors.New("store unavailable")
+}
+
+func TestTokenManagerStoreSetFail(t *testing.T) {
+	var event *TokenEvent
+
+	m := NewTokenManager(func(ctx context.Context) (string, int64, error) {
+		return "TOKEN", 7200, nil
+	}, WithTokenStore(new(failTokenStore), "APPID"), WithTokenHook(func(ctx context.Context, e *TokenEvent) {
+		event = e
+	}))
+
+	// 保存失败仍返回新凭证
+	token, err := m.Token(context.TODO())
+
+	assert.Nil(t, err)
+	assert.Equal(t, "TOKEN", token)
+	assert.Equal(t, "TOKEN", event.Token)
+	assert.Nil(t, event.Err)
+	assert.NotNil(t, event.StoreErr)
+}
+
+type errTokenStore struct{}
+
+func (s *errTokenStore) Get(ctx context.Context, key string) (*TokenSnapshot, error) {
+	return nil, errors.New("store unavailable")
+}
+
+func (s *errTokenStore) Set(ctx context.Context, key string, snapshot *TokenSnapshot) error {
+	return errors.New("store unavailable")
+}
@@ -12,6 +12,7 @@ import (
 	"encoding/json"
 	"encoding/pem"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -270,6 +271,24 @@ func MarshalNoEscapeHTML(v interface{}) ([]byte, error) {
 	return b, nil
 }
 
+// ErrAppIDMismatch 返回或解密的数据中的 appid 与当前配置不一致（多租户部署时凭证混用）
+var ErrAppIDMismatch = errors.New("appid mismatch")
+
+// CheckAppID 校验数据中的 appid 是否属于 allowed，appid 为空时不校验
+func CheckAppID(appid string, allowed ...string) error {
+	if len(appid) == 0 {
+		return nil
+	}
+
+	for _, v := range allowed {
+		if v == appid {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w, want: %s, got: %s", ErrAppIDMismatch, strings.Join(allowed, ","), appid)
+}
+
 // LoadCertFromPfxFile 通过pfx(p12)证书文件生成TLS证书
 func LoadCertFromPfxFile(pfxfile, mchid string) (tls.Certificate, error) {
 	fail := func(err error) (tls.Certificate, error) { return tls.Certificate{}, err }
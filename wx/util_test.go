@@ -1,6 +1,7 @@
 package wx
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -86,3 +87,13 @@ func TestMarshalNoEscapeHTML(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, `{"action":"long2short","long_url":"http://wap.koudaitong.com/v2/showcase/goods?alias=128wi9shh&spm=h56083&redirect_count=1"}`, string(b))
 }
+
+func TestCheckAppID(t *testing.T) {
+	assert.Nil(t, CheckAppID("", "APPID"))
+	assert.Nil(t, CheckAppID("APPID", "OTHER_APPID", "APPID"))
+
+	err := CheckAppID("OTHER_APPID", "APPID")
+
+	assert.True(t, errors.Is(err, ErrAppIDMismatch))
+	assert.Equal(t, "appid mismatch, want: APPID, got: OTHER_APPID", err.Error())
+}
@@ -0,0 +1,268 @@
+package wx
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// wxml 标签：`wxml:"name[,omitempty]"`；
+// 切片字段的标签使用 $n 表示下标（如：`wxml:"coupon_id_$n"`），按 0、1、2... 依次读取直至不存在
+
+type wxmlField struct {
+	name      string
+	omitempty bool
+	value     reflect.Value
+}
+
+// alloc 为 true 时（解析），为 nil 的匿名结构体指针自动创建；否则（转换）跳过
+func wxmlFields(v reflect.Value, alloc bool) []*wxmlField {
+	fields := make([]*wxmlField, 0)
+
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+
+		if len(sf.PkgPath) != 0 && !sf.Anonymous {
+			continue
+		}
+
+		fv := v.Field(i)
+
+		tag, ok := sf.Tag.Lookup("wxml")
+
+		// 匿名结构体（无标签）展开
+		if !ok && sf.Anonymous {
+			switch {
+			case fv.Kind() == reflect.Struct:
+				fields = append(fields, wxmlFields(fv, alloc)...)
+			case fv.Kind() == reflect.Ptr && fv.Type().Elem().Kind() == reflect.Struct:
+				if fv.IsNil() {
+					if !alloc || !fv.CanSet() {
+						continue
+					}
+
+					fv.Set(reflect.New(fv.Type().Elem()))
+				}
+
+				fields = append(fields, wxmlFields(fv.Elem(), alloc)...)
+			}
+
+			continue
+		}
+
+		if !ok || tag == "-" {
+			continue
+		}
+
+		name, opts := tag, ""
+
+		if i := strings.Index(tag, ","); i >= 0 {
+			name, opts = tag[:i], tag[i+1:]
+		}
+
+		fields = append(fields, &wxmlField{
+			name:      name,
+			omitempty: opts == "omitempty",
+			value:     fv,
+		})
+	}
+
+	return fields
+}
+
+// UnmarshalWXML 将 WXML 解析至结构体（v 为结构体指针），字段通过 wxml 标签映射，
+// 支持 string、bool、整数、浮点数、它们的指针及切片
+func UnmarshalWXML(m WXML, v interface{}) error {
+	rv := reflect.ValueOf(v)
+
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("wxml: v must be a non-nil pointer to struct")
+	}
+
+	for _, f := range wxmlFields(rv.Elem(), true) {
+		if f.value.Kind() == reflect.Slice && f.value.Type().Elem().Kind() != reflect.Uint8 {
+			slice := reflect.MakeSlice(f.value.Type(), 0, 0)
+
+			for n := 0; ; n++ {
+				s, ok := m[strings.Replace(f.name, "$n", strconv.Itoa(n), 1)]
+
+				if !ok || (n > 0 && !strings.Contains(f.name, "$n")) {
+					break
+				}
+
+				elem := reflect.New(f.value.Type().Elem()).Elem()
+
+				if err := setWXMLValue(elem, s); err != nil {
+					return fmt.Errorf("wxml: field %s: %w", f.name, err)
+				}
+
+				slice = reflect.Append(slice, elem)
+			}
+
+			if slice.Len() != 0 {
+				f.value.Set(slice)
+			}
+
+			continue
+		}
+
+		s, ok := m[f.name]
+
+		if !ok {
+			continue
+		}
+
+		if err := setWXMLValue(f.value, s); err != nil {
+			return fmt.Errorf("wxml: field %s: %w", f.name, err)
+		}
+	}
+
+	return nil
+}
+
+func setWXMLValue(v reflect.Value, s string) error {
+	if v.Kind() == reflect.Ptr {
+		ptr := reflect.New(v.Type().Elem())
+
+		if err := setWXMLValue(ptr.Elem(), s); err != nil {
+			return err
+		}
+
+		v.Set(ptr)
+
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		switch strings.ToUpper(s) {
+		case "Y", "YES", "1", "TRUE":
+			v.SetBool(true)
+		case "", "N", "NO", "0", "FALSE":
+			v.SetBool(false)
+		default:
+			return fmt.Errorf("invalid bool %q", s)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if len(s) == 0 {
+			return nil
+		}
+
+		i, err := strconv.ParseInt(s, 10, v.Type().Bits())
+
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if len(s) == 0 {
+			return nil
+		}
+
+		i, err := strconv.ParseUint(s, 10, v.Type().Bits())
+
+		if err != nil {
+			return err
+		}
+
+		v.SetUint(i)
+	case reflect.Float32, reflect.Float64:
+		if len(s) == 0 {
+			return nil
+		}
+
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+
+		if err != nil {
+			return err
+		}
+
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+
+	return nil
+}
+
+// MarshalWXML 将结构体（或其指针）转换为 WXML，字段通过 wxml 标签映射，omitempty 时忽略零值；
+// bool 转换为 Y/N，与 UnmarshalWXML 对称
+func MarshalWXML(v interface{}) (WXML, error) {
+	rv := reflect.ValueOf(v)
+
+	if rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return nil, errors.New("wxml: v must be a struct or pointer to struct")
+	}
+
+	m := WXML{}
+
+	for _, f := range wxmlFields(rv, false) {
+		if f.omitempty && f.value.IsZero() {
+			continue
+		}
+
+		if f.value.Kind() == reflect.Slice && f.value.Type().Elem().Kind() != reflect.Uint8 {
+			for n := 0; n < f.value.Len(); n++ {
+				s, err := formatWXMLValue(f.value.Index(n))
+
+				if err != nil {
+					return nil, fmt.Errorf("wxml: field %s: %w", f.name, err)
+				}
+
+				m[strings.Replace(f.name, "$n", strconv.Itoa(n), 1)] = s
+			}
+
+			continue
+		}
+
+		s, err := formatWXMLValue(f.value)
+
+		if err != nil {
+			return nil, fmt.Errorf("wxml: field %s: %w", f.name, err)
+		}
+
+		m[f.name] = s
+	}
+
+	return m, nil
+}
+
+func formatWXMLValue(v reflect.Value) (string, error) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "", nil
+		}
+
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		if v.Bool() {
+			return "Y", nil
+		}
+
+		return "N", nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits()), nil
+	}
+
+	return "", fmt.Errorf("unsupported type %s", v.Type())
+}
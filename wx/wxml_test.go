@@ -0,0 +1,131 @@
+package wx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type wxmlCommon struct {
+	AppID string `wxml:"appid"`
+	MchID string `wxml:"mch_id"`
+}
+
+type wxmlResult struct {
+	wxmlCommon
+	OutTradeNO  string   `wxml:"out_trade_no"`
+	TotalFee    int      `wxml:"total_fee"`
+	Rate        float64  `wxml:"rate,omitempty"`
+	IsSubscribe bool     `wxml:"is_subscribe"`
+	Attach      *string  `wxml:"attach,omitempty"`
+	CouponIDs   []string `wxml:"coupon_id_$n,omitempty"`
+	CouponFees  []int64  `wxml:"coupon_fee_$n,omitempty"`
+	Ignored     string   `wxml:"-"`
+	NoTag       string
+}
+
+type WXMLSettle struct {
+	ProfitSharing bool `wxml:"profit_sharing"`
+}
+
+type wxmlOrder struct {
+	*WXMLSettle
+	OutTradeNO string `wxml:"out_trade_no"`
+}
+
+func TestUnmarshalWXML(t *testing.T) {
+	attach := "ATTACH"
+
+	m := WXML{
+		"appid":        "wx2421b1c4370ec43b",
+		"mch_id":       "10000100",
+		"out_trade_no": "1415659990",
+		"total_fee":    "100",
+		"is_subscribe": "Y",
+		"attach":       "ATTACH",
+		"coupon_id_0":  "10000",
+		"coupon_id_1":  "10001",
+		"coupon_fee_0": "10",
+		"coupon_fee_1": "20",
+		"NoTag":        "NO_TAG",
+	}
+
+	result := new(wxmlResult)
+
+	assert.Nil(t, UnmarshalWXML(m, result))
+	assert.Equal(t, &wxmlResult{
+		wxmlCommon: wxmlCommon{
+			AppID: "wx2421b1c4370ec43b",
+			MchID: "10000100",
+		},
+		OutTradeNO:  "1415659990",
+		TotalFee:    100,
+		IsSubscribe: true,
+		Attach:      &attach,
+		CouponIDs:   []string{"10000", "10001"},
+		CouponFees:  []int64{10, 20},
+	}, result)
+
+	// 类型错误
+	assert.NotNil(t, UnmarshalWXML(WXML{"total_fee": "1.5"}, new(wxmlResult)))
+	assert.NotNil(t, UnmarshalWXML(m, wxmlResult{}))
+}
+
+func TestMarshalWXML(t *testing.T) {
+	attach := "ATTACH"
+
+	m, err := MarshalWXML(&wxmlResult{
+		wxmlCommon: wxmlCommon{
+			AppID: "wx2421b1c4370ec43b",
+			MchID: "10000100",
+		},
+		OutTradeNO: "1415659990",
+		TotalFee:   100,
+		Attach:     &attach,
+		CouponIDs:  []string{"10000", "10001"},
+		Ignored:    "IGNORED",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, WXML{
+		"appid":        "wx2421b1c4370ec43b",
+		"mch_id":       "10000100",
+		"out_trade_no": "1415659990",
+		"total_fee":    "100",
+		"is_subscribe": "N",
+		"attach":       "ATTACH",
+		"coupon_id_0":  "10000",
+		"coupon_id_1":  "10001",
+	}, m)
+
+	_, err = MarshalWXML("string")
+
+	assert.NotNil(t, err)
+}
+
+func TestWXMLRoundTrip(t *testing.T) {
+	m, err := MarshalWXML(&wxmlOrder{
+		WXMLSettle: &WXMLSettle{ProfitSharing: true},
+		OutTradeNO: "1415659990",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, WXML{
+		"profit_sharing": "Y",
+		"out_trade_no":   "1415659990",
+	}, m)
+
+	result := new(wxmlOrder)
+
+	assert.Nil(t, UnmarshalWXML(m, result))
+	assert.Equal(t, &wxmlOrder{
+		WXMLSettle: &WXMLSettle{ProfitSharing: true},
+		OutTradeNO: "1415659990",
+	}, result)
+
+	// 匿名结构体指针为 nil 时跳过
+	m, err = MarshalWXML(&wxmlOrder{OutTradeNO: "1415659990"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, WXML{"out_trade_no": "1415659990"}, m)
+}